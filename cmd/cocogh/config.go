@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/github"
+	"github.com/shaharia-lab/coco-gh/v2/sink"
+)
+
+// defaultState is the name of the state file of sync next to the configuration, if none is configured.
+const defaultState = "cocogh-state.json"
+
+// loadConfig reads the configuration file, see github.LoadConfig, defaulting its state file.
+func loadConfig(path string) (*github.Config, error) {
+	cfg, err := github.LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.State == "" {
+		cfg.State = filepath.Join(filepath.Dir(path), defaultState)
+	}
+	return cfg, nil
+}
+
+// deleter is a sink that also deletes the documents of removed files.
+type deleter interface {
+	Delete(ctx context.Context, removed []document.FileChange) error
+}
+
+// newSink builds a configured sink. stdout receives JSON Lines written to the path "-"; files opened for
+// other paths are added to closers. The credentials of object stores default to the AWS environment
+// variables.
+func newSink(ctx context.Context, c github.SinkConfig, getenv func(string) string, stdout io.Writer, closers *[]io.Closer) (sink.Sink, error) {
+	switch c.Type {
+	case github.SinkTypeJSONL:
+		if c.Path == "" || c.Path == "-" {
+			return sink.WriterSink(sink.NewJSONLWriter(stdout)), nil
+		}
+		f, err := os.OpenFile(c.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open jsonl sink: %w", err)
+		}
+		*closers = append(*closers, f)
+		return sink.WriterSink(sink.NewJSONLWriter(f)), nil
+	case github.SinkTypeS3, github.SinkTypeGCS:
+		accessKeyID, secretAccessKey := c.AccessKeyID, c.SecretAccessKey
+		if accessKeyID == "" && secretAccessKey == "" {
+			accessKeyID, secretAccessKey = getenv("AWS_ACCESS_KEY_ID"), getenv("AWS_SECRET_ACCESS_KEY")
+		}
+
+		var store *sink.S3Store
+		if c.Type == github.SinkTypeGCS {
+			store = sink.NewGCSStore(c.Bucket, accessKeyID, secretAccessKey)
+		} else {
+			store = sink.NewS3Store(c.Bucket, c.Region, accessKeyID, secretAccessKey)
+		}
+		if c.Endpoint != "" {
+			store.Endpoint = c.Endpoint
+		}
+
+		objectSink := sink.NewObjectStoreSink(store)
+		objectSink.Prefix = c.Prefix
+		objectSink.KeyLayout = c.KeyLayout
+		return objectSink, nil
+	case github.SinkTypeElasticsearch:
+		indexSink := sink.NewElasticsearchSink(c.Endpoint, c.Index)
+		indexSink.Username = c.Username
+		indexSink.Password = c.Password
+		indexSink.APIKey = c.APIKey
+		indexSink.BatchSize = c.BatchSize
+		if err := indexSink.EnsureIndex(ctx); err != nil {
+			return nil, err
+		}
+		return indexSink, nil
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", c.Type)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shaharia-lab/coco-gh/v2/github"
+	"github.com/shaharia-lab/coco-gh/v2/sink"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cocogh.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("owner: testowner\nrepositories: [repo1]\n"), 0o644))
+
+	cfg, err := loadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "cocogh-state.json"), cfg.State)
+
+	require.NoError(t, os.WriteFile(path, []byte("owner: testowner\nrepositories: [repo1]\nstate: state/files.json\n"), 0o644))
+	cfg, err = loadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "state", "files.json"), cfg.State)
+}
+
+func TestNewSink(t *testing.T) {
+	env := map[string]string{"AWS_ACCESS_KEY_ID": "key", "AWS_SECRET_ACCESS_KEY": "secret"}
+	getenv := func(name string) string { return env[name] }
+	var closers []io.Closer
+
+	configured, err := newSink(context.Background(), github.SinkConfig{Type: github.SinkTypeGCS, Bucket: "docs", Prefix: "crawl"}, getenv, io.Discard, &closers)
+	require.NoError(t, err)
+	objectSink, ok := configured.(*sink.ObjectStoreSink)
+	require.True(t, ok)
+	assert.Equal(t, "crawl", objectSink.Prefix)
+
+	store, ok := objectSink.Store.(*sink.S3Store)
+	require.True(t, ok)
+	assert.Equal(t, "key", store.AccessKeyID)
+	assert.Equal(t, "secret", store.SecretAccessKey)
+
+	configured, err = newSink(context.Background(), github.SinkConfig{Type: github.SinkTypeS3, Bucket: "docs", AccessKeyID: "own", SecretAccessKey: "own-secret"}, getenv, io.Discard, &closers)
+	require.NoError(t, err)
+	assert.Equal(t, "own", configured.(*sink.ObjectStoreSink).Store.(*sink.S3Store).AccessKeyID)
+
+	path := filepath.Join(t.TempDir(), "docs.jsonl")
+	_, err = newSink(context.Background(), github.SinkConfig{Type: github.SinkTypeJSONL, Path: path}, getenv, io.Discard, &closers)
+	require.NoError(t, err)
+	assert.Len(t, closers, 1)
+	assert.FileExists(t, path)
+	for _, closer := range closers {
+		require.NoError(t, closer.Close())
+	}
+
+	_, err = newSink(context.Background(), github.SinkConfig{Type: "kafka"}, getenv, io.Discard, &closers)
+	assert.EqualError(t, err, `unknown sink type "kafka"`)
+}
@@ -0,0 +1,425 @@
+// Command cocogh collects the files of GitHub repositories from the command line.
+//
+// It reads the repositories, the filter and the sinks from a YAML configuration file, cocogh.yaml by default:
+//
+//	owner: shaharia-lab
+//	repositories: [coco-gh]
+//	filter:
+//	  include: ["**/*.md"]
+//	transformers:
+//	  normalize_line_endings: true
+//	  secrets: redact
+//	sinks:
+//	  - type: jsonl
+//	    path: docs.jsonl
+//
+// See github.Config for all fields. The GitHub token is read from GITHUB_TOKEN unless auth configures one.
+//
+// Usage:
+//
+//	cocogh list [-config cocogh.yaml] [-json]
+//	cocogh changes [-config cocogh.yaml] -since 24h
+//	cocogh sync [-config cocogh.yaml] [-dry-run]
+//	cocogh daemon [-config cocogh.yaml] [-schedule 15m] [-addr :8080] [-unhealthy-after 3]
+//
+// list prints the paths of the filtered files, or their records as JSON Lines with -json. changes prints
+// the files added, modified and removed since a duration before now, an RFC 3339 time or a date. sync
+// writes the content of the files to the configured sinks: all files on the first run, and the files
+// changed since the previous run afterwards, deleting the documents of removed files from the sinks
+// supporting it. The time of the last sync is kept in the state file of the configuration. With -dry-run
+// sync lists the files without reading them and prints the API requests a full sync takes. daemon syncs
+// at start and then on a schedule, an interval or a cron expression, until interrupted, and serves its
+// health at /healthz and its status at /status.
+//
+// The exit code is 0 on success, 1 on failure and 2 on invalid usage.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/github"
+	"github.com/shaharia-lab/coco-gh/v2/sink"
+	"github.com/shaharia-lab/coco-gh/v2/source"
+	"github.com/shaharia-lab/coco-gh/v2/watch"
+)
+
+const usage = `Usage: cocogh <command> [flags]
+
+Commands:
+  list      print the filtered files of the repositories
+  changes   print the files changed since a time
+  sync      write new and changed files to the configured sinks
+  daemon    sync on a schedule, serving health and status over HTTP
+
+Run "cocogh <command> -h" for the flags of a command.
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Getenv, os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// errUsage reports invalid usage, the flag set having printed the details.
+var errUsage = errors.New("invalid usage")
+
+// run executes the command line args and returns the exit code.
+func run(ctx context.Context, args []string, getenv func(string) string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	commands := map[string]func(context.Context, []string, func(string) string, io.Writer, io.Writer) error{
+		"list":    runList,
+		"changes": runChanges,
+		"sync":    runSync,
+		"daemon":  runDaemon,
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+
+	err := command(ctx, args[1:], getenv, stdout, stderr)
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	default:
+		fmt.Fprintf(stderr, "cocogh %s: %v\n", args[0], err)
+		return 1
+	}
+}
+
+// newFlagSet creates the flag set of a command with the flags all commands share.
+func newFlagSet(name string, stderr io.Writer) (*flag.FlagSet, *string) {
+	flags := flag.NewFlagSet("cocogh "+name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	configPath := flags.String("config", "cocogh.yaml", "path of the YAML configuration")
+	return flags, configPath
+}
+
+// parseFlags parses the args, distinguishing invalid flags from a request for help.
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintf(flags.Output(), "unexpected arguments: %v\n", flags.Args())
+		return errUsage
+	}
+	return nil
+}
+
+// runList prints the filtered files of the repositories.
+func runList(ctx context.Context, args []string, getenv func(string) string, stdout, stderr io.Writer) error {
+	flags, configPath := newFlagSet("list", stderr)
+	asJSON := flags.Bool("json", false, "print file records as JSON Lines instead of paths")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	gh, err := cfg.NewGitHub()
+	if err != nil {
+		return err
+	}
+
+	files, err := gh.ListFiles(ctx)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		records := make([]sink.Record, 0, len(files))
+		for _, file := range files {
+			records = append(records, sink.FileRecord(file, nil))
+		}
+		return sink.NewJSONLWriter(stdout).WriteRecords(ctx, records)
+	}
+	for _, file := range files {
+		fmt.Fprintf(stdout, "%s/%s\n", file.Repository, file.Path)
+	}
+	return nil
+}
+
+// runChanges prints the files changed since the time of the -since flag.
+func runChanges(ctx context.Context, args []string, getenv func(string) string, stdout, stderr io.Writer) error {
+	flags, configPath := newFlagSet("changes", stderr)
+	sinceFlag := flags.String("since", "", "duration before now, RFC 3339 time or date (2006-01-02) to list changes from")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	since, err := parseSince(*sinceFlag, time.Now())
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		flags.Usage()
+		return errUsage
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	gh, err := cfg.NewGitHub()
+	if err != nil {
+		return err
+	}
+
+	changeSet, err := gh.GetChangeSetSince(ctx, since)
+	if err != nil {
+		return err
+	}
+
+	for _, changes := range []struct {
+		status string
+		files  []document.FileChange
+	}{
+		{"added", changeSet.Added},
+		{"modified", changeSet.Modified},
+		{"removed", changeSet.Removed},
+	} {
+		for _, change := range changes.files {
+			fmt.Fprintf(stdout, "%s\t%s/%s\n", changes.status, change.Repository, change.Path)
+		}
+	}
+	return nil
+}
+
+// parseSince parses a duration before now, an RFC 3339 time or a date.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("-since is required")
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid -since %q, want a duration, an RFC 3339 time or a date", value)
+}
+
+// runSync writes the files changed since the previous sync to the configured sinks.
+func runSync(ctx context.Context, args []string, getenv func(string) string, stdout, stderr io.Writer) error {
+	flags, configPath := newFlagSet("sync", stderr)
+	dryRun := flags.Bool("dry-run", false, "print the API requests of a full sync instead of syncing")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *dryRun {
+		return estimateSync(ctx, *configPath, stdout)
+	}
+
+	job, err := newSyncJob(ctx, *configPath, getenv, stdout)
+	if err != nil {
+		return err
+	}
+	defer job.close()
+
+	return job.run(ctx, stderr)
+}
+
+// estimateSync prints the files a full sync collects and the API requests it takes.
+func estimateSync(ctx context.Context, configPath string, stdout io.Writer) error {
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	gh, err := cfg.NewGitHub()
+	if err != nil {
+		return err
+	}
+
+	estimate, err := gh.EstimateCrawl(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "%d files, %d bytes in %d repositories\n", estimate.Files, estimate.Bytes, estimate.Repositories)
+	fmt.Fprintf(stdout, "%d API requests: %d listing, %d reading content\n", estimate.Requests, estimate.ListRequests, estimate.ContentRequests)
+	fmt.Fprintf(stdout, "rate limit cost: %d REST requests, %d GraphQL points\n", estimate.RESTRequests, estimate.GraphQLCost)
+	return nil
+}
+
+// runDaemon syncs on a schedule until interrupted, serving the health and status of the syncs over HTTP.
+func runDaemon(ctx context.Context, args []string, getenv func(string) string, stdout, stderr io.Writer) error {
+	flags, configPath := newFlagSet("daemon", stderr)
+	scheduleFlag := flags.String("schedule", "15m", "interval or cron expression of the syncs")
+	addr := flags.String("addr", ":8080", "address serving /healthz and /status, empty disables it")
+	unhealthyAfter := flags.Int("unhealthy-after", 3, "consecutive failed syncs after which /healthz reports unhealthy, 0 never")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	schedule, err := watch.ParseSchedule(*scheduleFlag)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		flags.Usage()
+		return errUsage
+	}
+
+	job, err := newSyncJob(ctx, *configPath, getenv, stdout)
+	if err != nil {
+		return err
+	}
+	defer job.close()
+
+	runner := &watch.Runner{
+		Name:           "sync",
+		Schedule:       schedule,
+		RunAtStart:     true,
+		UnhealthyAfter: *unhealthyAfter,
+		Job: func(ctx context.Context) error {
+			err := job.run(ctx, stderr)
+			if err != nil && ctx.Err() == nil {
+				fmt.Fprintf(stderr, "cocogh daemon: sync failed: %v\n", err)
+			}
+			return err
+		},
+	}
+
+	if *addr != "" {
+		listener, err := net.Listen("tcp", *addr)
+		if err != nil {
+			return err
+		}
+		server := &http.Server{Handler: runner.Handler(), ReadHeaderTimeout: 10 * time.Second}
+		go server.Serve(listener)
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			server.Shutdown(shutdownCtx)
+		}()
+		fmt.Fprintf(stderr, "serving status on http://%s\n", listener.Addr())
+	}
+
+	return runner.Run(ctx)
+}
+
+// syncJob writes the files changed since the previous run to the configured sinks.
+type syncJob struct {
+	collector *watch.Collector
+	source    *removalSource
+	closers   []io.Closer
+}
+
+// newSyncJob creates the client and the sinks of the configuration.
+func newSyncJob(ctx context.Context, configPath string, getenv func(string) string, stdout io.Writer) (*syncJob, error) {
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Sinks) == 0 {
+		return nil, fmt.Errorf("config %s has no sinks", configPath)
+	}
+	gh, err := cfg.NewGitHub()
+	if err != nil {
+		return nil, err
+	}
+
+	job := &syncJob{source: &removalSource{Source: gh}}
+	sinks := &syncSink{source: job.source}
+	for _, sinkConfig := range cfg.Sinks {
+		s, err := newSink(ctx, sinkConfig, getenv, stdout, &job.closers)
+		if err != nil {
+			job.close()
+			return nil, err
+		}
+		sinks.sinks = append(sinks.sinks, s)
+	}
+
+	job.collector = &watch.Collector{
+		Name:         "files",
+		Source:       source.SourceDocuments(job.source),
+		Transformers: cfg.Transformers.Build(),
+		Sink:         sinks,
+		Checkpoints:  watch.NewFileCheckpointStore(cfg.State),
+	}
+	return job, nil
+}
+
+// run syncs once, reporting the numbers of documents to stderr.
+func (j *syncJob) run(ctx context.Context, stderr io.Writer) error {
+	j.source.removed = nil
+	result, err := j.collector.Run(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stderr, "synced %d of %d documents, %d removed\n", result.Written, result.Collected, len(j.source.removed))
+	return nil
+}
+
+// close closes the files of the sinks.
+func (j *syncJob) close() {
+	for _, closer := range j.closers {
+		closer.Close()
+	}
+}
+
+// removalSource is a Source keeping the removed files of the last change set it returned.
+type removalSource struct {
+	source.Source
+
+	removed []document.FileChange
+}
+
+// ChangesSince returns the changes of the wrapped source, keeping the removed files. The changes of the
+// repositories that succeeded are returned together with a *github.PartialError.
+func (s *removalSource) ChangesSince(ctx context.Context, since time.Time) (document.ChangeSet, error) {
+	changeSet, err := s.Source.ChangesSince(ctx, since)
+	var partial *github.PartialError
+	if err != nil && !errors.As(err, &partial) {
+		return document.ChangeSet{}, err
+	}
+	s.removed = changeSet.Removed
+	return changeSet, err
+}
+
+// syncSink writes documents to several sinks, and deletes the removed files of the source from the sinks
+// supporting it once the documents are written.
+type syncSink struct {
+	sinks  []sink.Sink
+	source *removalSource
+}
+
+// Write writes the documents to every sink, then deletes the removed files.
+func (s *syncSink) Write(ctx context.Context, docs []document.Document) error {
+	for _, dst := range s.sinks {
+		if err := dst.Write(ctx, docs); err != nil {
+			return err
+		}
+	}
+	if len(s.source.removed) == 0 {
+		return nil
+	}
+	for _, dst := range s.sinks {
+		if d, ok := dst.(deleter); ok {
+			if err := d.Delete(ctx, s.source.removed); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/cocoghtest"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/sink"
+	"github.com/shaharia-lab/coco-gh/v2/watch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setup starts a fake GitHub server with one repository and writes a configuration for it, returning the
+// server and the path of the configuration.
+func setup(t *testing.T, extra string) (*cocoghtest.Server, string) {
+	srv := cocoghtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.AddRepository(cocoghtest.Repository{
+		Owner: "testowner",
+		Name:  "repo1",
+		Files: map[string]string{
+			"README.md":     "# repo1\r\n",
+			"docs/guide.md": "# Guide",
+			"main.go":       "package main",
+		},
+	})
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cocogh.yaml")
+	config := "owner: testowner\nrepositories: [repo1]\nbase_url: " + srv.URL + "\nfilter:\n  include: [\"**/*.md\"]\n" + extra
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o644))
+	return srv, path
+}
+
+func execute(t *testing.T, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, func(string) string { return "" }, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_Usage(t *testing.T) {
+	code, _, stderr := execute(t)
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "Usage: cocogh <command>")
+
+	code, _, stderr = execute(t, "crawl")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, `unknown command "crawl"`)
+
+	code, _, _ = execute(t, "list", "-unknown")
+	assert.Equal(t, 2, code)
+
+	code, _, _ = execute(t, "list", "-h")
+	assert.Equal(t, 0, code)
+
+	code, _, stderr = execute(t, "list", "-config", filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "cocogh list: failed to read config")
+}
+
+func TestRun_List(t *testing.T) {
+	_, config := setup(t, "")
+
+	code, stdout, stderr := execute(t, "list", "-config", config)
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "repo1/README.md\nrepo1/docs/guide.md\n", stdout)
+
+	code, stdout, stderr = execute(t, "list", "-config", config, "-json")
+	require.Equal(t, 0, code, stderr)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 2)
+
+	var record sink.Record
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+	assert.Equal(t, "docs/guide.md", record.Path)
+	assert.Equal(t, "main", record.Branch)
+}
+
+func TestRun_Changes(t *testing.T) {
+	srv, config := setup(t, "")
+
+	srv.AddRepository(cocoghtest.Repository{
+		Owner: "testowner",
+		Name:  "repo1",
+		Files: map[string]string{"README.md": "# repo1", "docs/new.md": "# New"},
+		Commits: []cocoghtest.Commit{
+			{Message: "Add page", Date: time.Now().Add(-time.Hour), Files: []cocoghtest.CommitFile{
+				{Filename: "docs/new.md", Status: "added"},
+				{Filename: "docs/guide.md", Status: "removed"},
+				{Filename: "main.go", Status: "modified"},
+			}},
+			{Message: "Old", Date: time.Now().Add(-72 * time.Hour), Files: []cocoghtest.CommitFile{{Filename: "README.md", Status: "modified"}}},
+		},
+	})
+
+	code, stdout, stderr := execute(t, "changes", "-config", config, "-since", "24h")
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "added\trepo1/docs/new.md\nremoved\trepo1/docs/guide.md\n", stdout)
+
+	code, _, stderr = execute(t, "changes", "-config", config)
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "-since is required")
+}
+
+func TestRun_Sync(t *testing.T) {
+	srv, config := setup(t, "transformers:\n  normalize_line_endings: true\nsinks:\n  - type: jsonl\n    path: "+filepath.Join(t.TempDir(), "docs.jsonl")+"\n")
+
+	code, _, stderr := execute(t, "sync", "-config", config)
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "synced 2 of 2 documents, 0 removed\n", stderr)
+
+	cfg, err := loadConfig(config)
+	require.NoError(t, err)
+	records := readRecords(t, cfg.Sinks[0].Path)
+	require.Len(t, records, 2)
+	assert.Equal(t, "README.md", records[0].Path)
+	assert.Equal(t, "# repo1\n", records[0].Content)
+
+	checkpoint, err := watch.NewFileCheckpointStore(cfg.State).Load(context.Background(), "files")
+	require.NoError(t, err)
+	assert.False(t, checkpoint.IsZero())
+
+	srv.AddRepository(cocoghtest.Repository{
+		Owner: "testowner",
+		Name:  "repo1",
+		Files: map[string]string{"README.md": "# repo1", "docs/guide.md": "# Guide v2"},
+		Commits: []cocoghtest.Commit{
+			{Message: "Update guide", Date: time.Now().Add(time.Hour), Files: []cocoghtest.CommitFile{{Filename: "docs/guide.md", Status: "modified"}}},
+		},
+	})
+
+	code, _, stderr = execute(t, "sync", "-config", config)
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "synced 1 of 1 documents, 0 removed\n", stderr)
+
+	records = readRecords(t, cfg.Sinks[0].Path)
+	require.Len(t, records, 3)
+	assert.Equal(t, "# Guide v2", records[2].Content)
+}
+
+func TestRun_SyncPartial(t *testing.T) {
+	srv, config := setup(t, "continue_on_error: true\nsinks:\n  - type: jsonl\n    path: "+filepath.Join(t.TempDir(), "docs.jsonl")+"\n")
+
+	code, _, stderr := execute(t, "sync", "-config", config)
+	require.Equal(t, 0, code, stderr)
+
+	// A repository failing in an incremental sync does not hold back the changes of the others.
+	data, err := os.ReadFile(config)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(config, bytes.Replace(data, []byte("[repo1]"), []byte("[repo1, missing]"), 1), 0o644))
+	srv.AddRepository(cocoghtest.Repository{
+		Owner: "testowner",
+		Name:  "repo1",
+		Files: map[string]string{"README.md": "# repo1", "docs/guide.md": "# Guide v2"},
+		Commits: []cocoghtest.Commit{
+			{Message: "Update guide", Date: time.Now().Add(time.Hour), Files: []cocoghtest.CommitFile{{Filename: "docs/guide.md", Status: "modified"}}},
+		},
+	})
+
+	code, _, stderr = execute(t, "sync", "-config", config)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "missing")
+
+	cfg, err := loadConfig(config)
+	require.NoError(t, err)
+	records := readRecords(t, cfg.Sinks[0].Path)
+	require.Len(t, records, 3)
+	assert.Equal(t, "# Guide v2", records[2].Content)
+}
+
+func TestRun_SyncDryRun(t *testing.T) {
+	_, config := setup(t, "")
+
+	code, stdout, stderr := execute(t, "sync", "-config", config, "-dry-run")
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "2 files, 16 bytes in 1 repositories\n"+
+		"4 API requests: 2 listing, 2 reading content\n"+
+		"rate limit cost: 1 REST requests, 3 GraphQL points\n", stdout)
+
+	cfg, err := loadConfig(config)
+	require.NoError(t, err)
+	assert.NoFileExists(t, cfg.State)
+}
+
+func readRecords(t *testing.T, path string) []sink.Record {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var records []sink.Record
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var record sink.Record
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	since, err := parseSince("36h", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), since)
+
+	since, err = parseSince("2024-03-01T10:00:00+02:00", now)
+	require.NoError(t, err)
+	assert.True(t, since.Equal(time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)))
+
+	since, err = parseSince("2024-03-01", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), since)
+
+	_, err = parseSince("yesterday", now)
+	assert.EqualError(t, err, `invalid -since "yesterday", want a duration, an RFC 3339 time or a date`)
+}
+
+type deletingSink struct {
+	written int
+	removed []document.FileChange
+}
+
+func (s *deletingSink) Write(_ context.Context, docs []document.Document) error {
+	s.written += len(docs)
+	return nil
+}
+
+func (s *deletingSink) Delete(_ context.Context, removed []document.FileChange) error {
+	s.removed = append(s.removed, removed...)
+	return nil
+}
+
+func TestSyncSink(t *testing.T) {
+	source := &removalSource{removed: []document.FileChange{{Repository: "repo1", Path: "old.md"}}}
+	deleting := &deletingSink{}
+	var buf bytes.Buffer
+	syncing := &syncSink{source: source, sinks: []sink.Sink{sink.WriterSink(sink.NewJSONLWriter(&buf)), deleting}}
+
+	require.NoError(t, syncing.Write(context.Background(), []document.Document{{Repository: "repo1", Path: "new.md", Body: "New"}}))
+	assert.Equal(t, 1, deleting.written)
+	assert.Equal(t, source.removed, deleting.removed)
+	assert.Contains(t, buf.String(), `"path":"new.md"`)
+}
+
+func TestRun_Daemon(t *testing.T) {
+	_, config := setup(t, "sinks:\n  - type: jsonl\n    path: "+filepath.Join(t.TempDir(), "docs.jsonl")+"\n")
+	cfg, err := loadConfig(config)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		// Shut down once the first sync saved its checkpoint.
+		for ctx.Err() == nil {
+			if _, err := os.Stat(cfg.State); err == nil {
+				cancel()
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	var stdout, stderr bytes.Buffer
+	code := run(ctx, []string{"daemon", "-config", config, "-schedule", "@hourly", "-addr", "127.0.0.1:0"}, func(string) string { return "" }, &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+	assert.Contains(t, stderr.String(), "serving status on http://127.0.0.1:")
+	assert.Contains(t, stderr.String(), "synced 2 of 2 documents, 0 removed\n")
+	assert.Len(t, readRecords(t, cfg.Sinks[0].Path), 2)
+
+	code, _, stderr2 := execute(t, "daemon", "-config", config, "-schedule", "every day")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr2, `invalid schedule "every day"`)
+}
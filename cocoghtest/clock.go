@@ -0,0 +1,44 @@
+package cocoghtest
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a cocogh.Clock whose time only changes when the test says so.
+//
+// Usage:
+//
+//	clock := cocoghtest.NewClock(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+//	gh := srv.NewGitHub(cocogh.GitHubConfig{Owner: "octocat", Repositories: []string{"hello-world"}, Clock: clock})
+//	clock.Advance(time.Hour)
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewClock creates a Clock set to now.
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Now returns the current time of the clock.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set moves the clock to now.
+func (c *Clock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// Advance moves the clock forward by d.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
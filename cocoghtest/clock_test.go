@@ -0,0 +1,69 @@
+package cocoghtest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/cocoghtest"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shaharia-lab/coco-gh/v2/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClock(t *testing.T) {
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	clock := cocoghtest.NewClock(start)
+	assert.Equal(t, start, clock.Now())
+
+	clock.Advance(time.Hour)
+	assert.Equal(t, start.Add(time.Hour), clock.Now())
+
+	clock.Set(start)
+	assert.Equal(t, start, clock.Now())
+}
+
+func TestClock_GetChangedFilePathsWithin(t *testing.T) {
+	srv := newServer(t)
+	clock := cocoghtest.NewClock(time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC))
+	gh := srv.NewGitHub(github.GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Clock:         clock,
+	})
+
+	paths, err := gh.GetChangedFilePathsWithin(context.Background(), time.Hour)
+	assert.NoError(t, err)
+	assert.Empty(t, paths.Added)
+	assert.Empty(t, paths.Modified)
+
+	clock.Advance(-11 * time.Hour)
+	paths, err = gh.GetChangedFilePathsWithin(context.Background(), 2*time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/guides/setup.md"}, paths.Added)
+	assert.Empty(t, paths.Modified)
+}
+
+func TestClock_GetChangedFilePathsSince_Boundaries(t *testing.T) {
+	srv := newServer(t)
+	gh := srv.NewGitHub(github.GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+	})
+	renamedAt := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	paths, err := gh.GetChangedFilePathsSince(renamedAt)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/guides/setup.md"}, paths.Added, "a commit made exactly at since is included")
+
+	paths, err = gh.GetChangedFilePathsSince(renamedAt.Add(time.Second))
+	assert.NoError(t, err)
+	assert.Empty(t, paths.Added)
+
+	paths, err = gh.GetChangedFilePathsSince(renamedAt.In(time.FixedZone("UTC+2", 2*60*60)))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/guides/setup.md"}, paths.Added, "the time zone of since does not matter")
+}
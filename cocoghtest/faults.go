@@ -0,0 +1,124 @@
+package cocoghtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// secondaryRateLimitDocs is the documentation URL GitHub sends with secondary rate limit errors. go-github
+// recognizes the error by its suffix.
+const secondaryRateLimitDocs = "https://docs.github.com/rest/overview/rate-limits-for-the-rest-api#about-secondary-rate-limits"
+
+// FaultKind is the kind of failure a Fault injects.
+type FaultKind string
+
+const (
+	// FaultSecondaryRateLimit answers 403 Forbidden with a secondary rate limit error and a Retry-After
+	// header.
+	FaultSecondaryRateLimit FaultKind = "secondary_rate_limit"
+	// FaultBadGateway answers 502 Bad Gateway.
+	FaultBadGateway FaultKind = "bad_gateway"
+	// FaultTruncatedGraphQL serves the request but cuts the GraphQL response body in half, as a dropped
+	// connection would.
+	FaultTruncatedGraphQL FaultKind = "truncated_graphql"
+	// FaultSlow serves the request after a delay, or fails it if the client gives up first.
+	FaultSlow FaultKind = "slow"
+)
+
+// Fault is a failure injected into the responses of the fake server.
+//
+// Path limits the fault to requests whose path starts with it, e.g. "/graphql" or
+// "/repos/octo-org/docs/commits"; an empty Path matches every request. Times is the number of matching
+// requests the fault applies to before it is removed; zero applies it to every matching request.
+//
+// RetryAfter is the Retry-After of FaultSecondaryRateLimit, Delay the delay of FaultSlow.
+type Fault struct {
+	Kind       FaultKind
+	Path       string
+	Times      int
+	RetryAfter time.Duration
+	Delay      time.Duration
+}
+
+// InjectFault adds a fault to the server. Faults are checked in the order they were added and the first
+// matching fault applies, so retry and backoff behavior can be verified deterministically:
+//
+//	srv.InjectFault(cocoghtest.Fault{Kind: cocoghtest.FaultBadGateway, Path: "/graphql", Times: 2})
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// nextFault returns the fault applying to the request and counts it, or nil if there is none.
+func (s *Server) nextFault(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, fault := range s.faults {
+		if !strings.HasPrefix(r.URL.Path, fault.Path) {
+			continue
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+// withFaults wraps the handler of the server with the injected faults.
+func (s *Server) withFaults(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fault := s.nextFault(r)
+		if fault == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		switch fault.Kind {
+		case FaultSecondaryRateLimit:
+			retryAfter := int((fault.RetryAfter + time.Second - 1) / time.Second)
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"message":           "You have exceeded a secondary rate limit. Please wait a few minutes before you try again.",
+				"documentation_url": secondaryRateLimitDocs,
+			})
+		case FaultBadGateway:
+			writeError(w, http.StatusBadGateway, "Server Error")
+		case FaultTruncatedGraphQL:
+			rec := httptest.NewRecorder()
+			next.ServeHTTP(rec, r)
+			body := rec.Body.Bytes()
+			for name, values := range rec.Header() {
+				w.Header()[name] = values
+			}
+			w.WriteHeader(rec.Code)
+			_, _ = w.Write(body[:len(body)/2])
+		case FaultSlow:
+			select {
+			case <-time.After(fault.Delay):
+				next.ServeHTTP(w, r)
+			case <-r.Context().Done():
+			}
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
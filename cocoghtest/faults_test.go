@@ -0,0 +1,107 @@
+package cocoghtest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	gogithub "github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/cocoghtest"
+	"github.com/shaharia-lab/coco-gh/v2/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_InjectFault_SecondaryRateLimit(t *testing.T) {
+	srv := newServer(t)
+	srv.InjectFault(cocoghtest.Fault{Kind: cocoghtest.FaultSecondaryRateLimit, Path: "/repos/", Times: 1})
+
+	client := srv.GitHubClient()
+	_, _, err := client.Repositories.Get(context.Background(), "testowner", "repo1")
+
+	var rateLimitErr *gogithub.AbuseRateLimitError
+	assert.True(t, errors.As(err, &rateLimitErr))
+	assert.Equal(t, time.Duration(0), rateLimitErr.GetRetryAfter())
+
+	repo, _, err := client.Repositories.Get(context.Background(), "testowner", "repo1")
+	assert.NoError(t, err)
+	assert.Equal(t, "repo1", repo.GetName())
+}
+
+func TestServer_InjectFault_RetryAfter(t *testing.T) {
+	srv := newServer(t)
+	srv.InjectFault(cocoghtest.Fault{Kind: cocoghtest.FaultSecondaryRateLimit, RetryAfter: 1500 * time.Millisecond})
+
+	resp, err := srv.Client().Get(srv.URL + "/repos/testowner/repo1")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+}
+
+func TestServer_InjectFault_BadGateway(t *testing.T) {
+	srv := newServer(t)
+	srv.InjectFault(cocoghtest.Fault{Kind: cocoghtest.FaultBadGateway, Path: "/repos/testowner/repo1/git/trees", Times: 2})
+	gh := srv.NewGitHub(github.GitHubConfig{Owner: "testowner", Repositories: []string{"repo1"}, DefaultBranch: "main"})
+
+	for i := 0; i < 2; i++ {
+		_, err := gh.GetFilePathsFromRepositories()
+		assert.ErrorContains(t, err, "502")
+	}
+
+	files, err := gh.GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Contains(t, files, "README.md")
+}
+
+func TestServer_InjectFault_TruncatedGraphQL(t *testing.T) {
+	srv := newServer(t)
+	srv.InjectFault(cocoghtest.Fault{Kind: cocoghtest.FaultTruncatedGraphQL, Path: "/graphql", Times: 1})
+	gh := srv.NewGitHub(github.GitHubConfig{Owner: "testowner", Repositories: []string{"repo1"}, DefaultBranch: "main"})
+
+	// The files are listed through REST, their content is read through GraphQL.
+	_, err := gh.GetFileContents(context.Background())
+	assert.Error(t, err)
+
+	// REST requests are not affected.
+	_, _, err = srv.GitHubClient().Repositories.Get(context.Background(), "testowner", "repo1")
+	assert.NoError(t, err)
+}
+
+func TestServer_InjectFault_Slow(t *testing.T) {
+	srv := newServer(t)
+	srv.InjectFault(cocoghtest.Fault{Kind: cocoghtest.FaultSlow, Delay: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, _, err := srv.GitHubClient().Repositories.Get(ctx, "testowner", "repo1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	srv.ClearFaults()
+	_, _, err = srv.GitHubClient().Repositories.Get(context.Background(), "testowner", "repo1")
+	assert.NoError(t, err)
+}
+
+func TestServer_InjectFault_RateLimitTransport(t *testing.T) {
+	srv := newServer(t)
+	srv.InjectFault(cocoghtest.Fault{Kind: cocoghtest.FaultSecondaryRateLimit, Times: 2})
+
+	httpClient := &http.Client{Transport: github.NewRateLimitTransport(srv.Client().Transport, github.RateLimitOptions{})}
+	restClient := gogithub.NewClient(httpClient)
+	restClient.BaseURL = srv.GitHubClient().BaseURL
+	gh := github.NewGitHubClient(
+		&github.GitHubCommitsOpsClient{GitHubClient: restClient},
+		githubv4.NewEnterpriseClient(srv.URL+"/graphql", httpClient),
+		github.GitHubConfig{Owner: "testowner", Repositories: []string{"repo1"}, DefaultBranch: "main"},
+	)
+
+	files, err := gh.GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Contains(t, files, "README.md")
+
+	paths, err := gh.GetChangedFilePathsSince(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"README.md"}, paths.Modified)
+}
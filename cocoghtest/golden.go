@@ -0,0 +1,133 @@
+package cocoghtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// UpdateGoldenEnv is the environment variable that makes AssertGolden rewrite golden files instead of
+// comparing against them.
+const UpdateGoldenEnv = "COCOGH_UPDATE_GOLDEN"
+
+// MarshalGolden serializes a collection result deterministically for golden files.
+//
+// Values are encoded as indented JSON, which sorts map keys. The lists of Paths are sorted first, as their
+// order depends on the order GitHub returns commits in; Events by commit time, commit SHA and path. Other
+// values are encoded as they are.
+func MarshalGolden(v interface{}) ([]byte, error) {
+	switch value := v.(type) {
+	case document.Paths:
+		v = sortedPaths(value)
+	case *document.Paths:
+		v = sortedPaths(*value)
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// AssertGolden compares got, serialized with MarshalGolden, against the golden file testdata/<name>.golden
+// and fails the test with a unified diff if they differ.
+//
+// Setting the COCOGH_UPDATE_GOLDEN environment variable writes got to the golden file instead, creating
+// it if needed; review the changes before committing them.
+//
+// Usage:
+//
+//	snapshot, err := gh.GetSnapshot(ctx)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	cocoghtest.AssertGolden(t, "snapshot", snapshot)
+func AssertGolden(t testing.TB, name string, got interface{}) {
+	t.Helper()
+
+	data, err := MarshalGolden(got)
+	if err != nil {
+		t.Fatalf("failed to serialize %s: %v", name, err)
+	}
+
+	path := filepath.Join("testdata", name+".golden")
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create golden file directory: %v", err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with %s=1 to create it): %v", UpdateGoldenEnv, err)
+	}
+
+	if !bytes.Equal(want, data) {
+		diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(want)),
+			B:        difflib.SplitLines(string(data)),
+			FromFile: path,
+			ToFile:   "got",
+			Context:  3,
+		})
+		t.Errorf("%s does not match the golden file (run with %s=1 to update it):\n%s", name, UpdateGoldenEnv, diff)
+	}
+}
+
+// sortedPaths returns a copy of paths with every list sorted.
+func sortedPaths(paths document.Paths) document.Paths {
+	return document.Paths{
+		Added:    sortedCopy(paths.Added),
+		Removed:  sortedCopy(paths.Removed),
+		Modified: sortedCopy(paths.Modified),
+		Renamed:  sortedRenames(paths.Renamed),
+		Events:   sortedEvents(paths.Events),
+	}
+}
+
+func sortedRenames(renames []document.Rename) []document.Rename {
+	if len(renames) == 0 {
+		return nil
+	}
+	sorted := make([]document.Rename, len(renames))
+	copy(sorted, renames)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].To < sorted[j].To })
+	return sorted
+}
+
+func sortedEvents(events []document.ChangeEvent) []document.ChangeEvent {
+	if len(events) == 0 {
+		return nil
+	}
+	sorted := make([]document.ChangeEvent, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if !a.CommittedAt.Equal(b.CommittedAt) {
+			return a.CommittedAt.Before(b.CommittedAt)
+		}
+		if a.CommitSHA != b.CommitSHA {
+			return a.CommitSHA < b.CommitSHA
+		}
+		return a.Path < b.Path
+	})
+	return sorted
+}
+
+func sortedCopy(s []string) []string {
+	sorted := make([]string, len(s))
+	copy(sorted, s)
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,87 @@
+package cocoghtest_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/cocoghtest"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalGolden_Paths(t *testing.T) {
+	a, err := cocoghtest.MarshalGolden(document.Paths{Added: []string{"b.md", "a.md"}})
+	assert.NoError(t, err)
+	b, err := cocoghtest.MarshalGolden(&document.Paths{Added: []string{"a.md", "b.md"}})
+	assert.NoError(t, err)
+	assert.Equal(t, string(a), string(b))
+	assert.Equal(t, "{\n  \"Added\": [\n    \"a.md\",\n    \"b.md\"\n  ],\n  \"Removed\": [],\n  \"Modified\": []\n}\n", string(a))
+
+	a, err = cocoghtest.MarshalGolden(document.Paths{Renamed: []document.Rename{{From: "a.md", To: "d.md"}, {From: "b.md", To: "c.md"}}})
+	assert.NoError(t, err)
+	b, err = cocoghtest.MarshalGolden(document.Paths{Renamed: []document.Rename{{From: "b.md", To: "c.md"}, {From: "a.md", To: "d.md"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, string(a), string(b))
+
+	first := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	events := []document.ChangeEvent{
+		{CommitSHA: "sha2", CommittedAt: first.Add(time.Hour), Path: "a.md", Status: "modified"},
+		{CommitSHA: "sha1", CommittedAt: first, Path: "b.md", Status: "added"},
+		{CommitSHA: "sha1", CommittedAt: first, Path: "a.md", Status: "added"},
+	}
+	a, err = cocoghtest.MarshalGolden(document.Paths{Events: events})
+	assert.NoError(t, err)
+	b, err = cocoghtest.MarshalGolden(document.Paths{Events: []document.ChangeEvent{events[2], events[0], events[1]}})
+	assert.NoError(t, err)
+	assert.Equal(t, string(a), string(b))
+
+	var decoded document.Paths
+	assert.NoError(t, json.Unmarshal(a, &decoded))
+	assert.Equal(t, []document.ChangeEvent{events[2], events[1], events[0]}, decoded.Events)
+}
+
+func TestAssertGolden(t *testing.T) {
+	srv := newServer(t)
+	gh := srv.NewGitHub(github.GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+	})
+
+	snapshot, err := gh.GetSnapshot(context.Background())
+	assert.NoError(t, err)
+	cocoghtest.AssertGolden(t, "snapshot", snapshot)
+
+	paths, err := gh.GetChangedFilePathsSince(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	cocoghtest.AssertGolden(t, "changed_paths", paths)
+}
+
+func TestAssertGolden_Mismatch(t *testing.T) {
+	t.Setenv(cocoghtest.UpdateGoldenEnv, "")
+
+	rec := &recordingTB{TB: t}
+	cocoghtest.AssertGolden(rec, "changed_paths", document.Paths{Added: []string{"other.md"}})
+
+	assert.True(t, rec.failed)
+	assert.Contains(t, rec.message, "--- testdata/changed_paths.golden")
+	assert.Contains(t, rec.message, "+    \"other.md\"")
+}
+
+// recordingTB captures failures instead of failing the test.
+type recordingTB struct {
+	testing.TB
+	failed  bool
+	message string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.failed = true
+	r.message = fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,542 @@
+package cocoghtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Git file modes as the GraphQL API reports them.
+const (
+	modeBlob = 0100644
+	modeTree = 0040000
+)
+
+// graphQLRequest is the body of a GraphQL request.
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// graphQLError is an error of a GraphQL response.
+type graphQLError struct {
+	Message string `json:"message"`
+	Type    string `json:"type,omitempty"`
+}
+
+// handleGraphQL serves POST /graphql. Queries are parsed into their selection sets and resolved against
+// the seeded repositories, so the response holds exactly the fields the client asked for.
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+
+	var req graphQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return
+	}
+
+	selections, err := parseQuery(req.Query)
+	if err != nil {
+		writeJSON(w, map[string]interface{}{"errors": []graphQLError{{Message: err.Error()}}})
+		return
+	}
+
+	data := make(map[string]interface{})
+	if err := execute(selections, queryRoot{server: s}, req.Variables, data); err != nil {
+		gqlErr := graphQLError{Message: err.Error()}
+		if _, ok := err.(notFoundError); ok {
+			gqlErr.Type = "NOT_FOUND"
+		}
+		writeJSON(w, map[string]interface{}{"data": nil, "errors": []graphQLError{gqlErr}})
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{"data": data})
+}
+
+// selection is a field or inline fragment of a GraphQL selection set.
+type selection struct {
+	alias         string
+	name          string
+	args          string
+	typeCondition string
+	children      []selection
+}
+
+// key is the name of the selection in the response.
+func (s selection) key() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+// parseQuery parses the subset of GraphQL the client generates: a single query operation with variable
+// definitions, fields with aliases and arguments, and inline fragments.
+func parseQuery(query string) ([]selection, error) {
+	p := &parser{s: query}
+	p.skipSpace()
+	if strings.HasPrefix(p.rest(), "query") {
+		p.pos += len("query")
+		p.skipSpace()
+		p.ident()
+		p.skipSpace()
+		if p.peek() == '(' {
+			if _, err := p.balanced(); err != nil {
+				return nil, err
+			}
+		}
+		p.skipSpace()
+	}
+	return p.selectionSet()
+}
+
+type parser struct {
+	s   string
+	pos int
+}
+
+func (p *parser) rest() string {
+	return p.s[p.pos:]
+}
+
+func (p *parser) peek() byte {
+	if p.pos >= len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+// skipSpace skips white space and commas, which GraphQL treats alike.
+func (p *parser) skipSpace() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n,", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *parser) ident() string {
+	start := p.pos
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if c != '_' && (c < '0' || c > '9') && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			break
+		}
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+// balanced consumes a parenthesized, bracketed or braced group and returns its content.
+func (p *parser) balanced() (string, error) {
+	start := p.pos
+	depth := 0
+	for p.pos < len(p.s) {
+		switch c := p.s[p.pos]; c {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				p.pos++
+				return p.s[start+1 : p.pos-1], nil
+			}
+		case '"':
+			end := strings.IndexByte(p.s[p.pos+1:], '"')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated string at %d", p.pos)
+			}
+			p.pos += end + 1
+		}
+		p.pos++
+	}
+	return "", fmt.Errorf("unbalanced %q at %d", p.s[start], start)
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	if p.peek() != '{' {
+		return nil, fmt.Errorf("expected '{' at %d", p.pos)
+	}
+	p.pos++
+
+	var selections []selection
+	for {
+		p.skipSpace()
+		switch {
+		case p.pos >= len(p.s):
+			return nil, fmt.Errorf("unexpected end of query")
+		case p.peek() == '}':
+			p.pos++
+			return selections, nil
+		case strings.HasPrefix(p.rest(), "..."):
+			p.pos += len("...")
+			p.skipSpace()
+			if p.ident() != "on" {
+				return nil, fmt.Errorf("expected type condition at %d", p.pos)
+			}
+			p.skipSpace()
+			sel := selection{typeCondition: p.ident()}
+			p.skipSpace()
+			children, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			sel.children = children
+			selections = append(selections, sel)
+			continue
+		}
+
+		sel := selection{name: p.ident()}
+		if sel.name == "" {
+			return nil, fmt.Errorf("unexpected %q at %d", p.peek(), p.pos)
+		}
+		p.skipSpace()
+		if p.peek() == ':' {
+			p.pos++
+			p.skipSpace()
+			sel.alias, sel.name = sel.name, p.ident()
+			p.skipSpace()
+		}
+		if p.peek() == '(' {
+			args, err := p.balanced()
+			if err != nil {
+				return nil, err
+			}
+			sel.args = args
+			p.skipSpace()
+		}
+		if p.peek() == '{' {
+			children, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			sel.children = children
+		}
+		selections = append(selections, sel)
+	}
+}
+
+// parseArgs parses the arguments of a field, resolving variables. Object and list literals are kept as
+// their source text.
+func parseArgs(raw string, variables map[string]interface{}) map[string]interface{} {
+	args := make(map[string]interface{})
+
+	var parts []string
+	depth, start, inString := 0, 0, false
+	for i := 0; i < len(raw); i++ {
+		switch c := raw[i]; {
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, raw[start:i])
+			start = i + 1
+		}
+	}
+	parts = append(parts, raw[start:])
+
+	for _, part := range parts {
+		kv := strings.SplitN(part, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		switch {
+		case strings.HasPrefix(value, "$"):
+			args[key] = variables[value[1:]]
+		case strings.HasPrefix(value, `"`):
+			s, err := strconv.Unquote(value)
+			if err != nil {
+				s = strings.Trim(value, `"`)
+			}
+			args[key] = s
+		default:
+			if n, err := strconv.Atoi(value); err == nil {
+				args[key] = n
+			} else {
+				args[key] = value
+			}
+		}
+	}
+
+	return args
+}
+
+// object is a GraphQL object the fake resolves fields on.
+type object interface {
+	typeName() string
+	field(name string, args map[string]interface{}) (interface{}, error)
+}
+
+// notFoundError is a GraphQL error of type NOT_FOUND.
+type notFoundError string
+
+func (e notFoundError) Error() string {
+	return string(e)
+}
+
+// execute resolves the selections on obj into out, resolving argument variables from variables.
+func execute(selections []selection, obj object, variables, out map[string]interface{}) error {
+	for _, sel := range selections {
+		if sel.typeCondition != "" {
+			if sel.typeCondition == obj.typeName() {
+				if err := execute(sel.children, obj, variables, out); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if sel.name == "__typename" {
+			out[sel.key()] = obj.typeName()
+			continue
+		}
+
+		value, err := obj.field(sel.name, parseArgs(sel.args, variables))
+		if err != nil {
+			return err
+		}
+
+		resolved, err := resolve(value, sel.children, variables)
+		if err != nil {
+			return err
+		}
+		out[sel.key()] = resolved
+	}
+	return nil
+}
+
+// resolve resolves the selections on a field value.
+func resolve(value interface{}, children []selection, variables map[string]interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case object:
+		out := make(map[string]interface{})
+		if err := execute(children, v, variables, out); err != nil {
+			return nil, err
+		}
+		return out, nil
+	case []object:
+		list := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			resolved, err := resolve(item, children, variables)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, resolved)
+		}
+		return list, nil
+	default:
+		return v, nil
+	}
+}
+
+func unknownField(obj object, name string) error {
+	return fmt.Errorf("Field '%s' doesn't exist on type '%s'", name, obj.typeName())
+}
+
+// queryRoot is the Query type.
+type queryRoot struct {
+	server *Server
+}
+
+func (q queryRoot) typeName() string { return "Query" }
+
+func (q queryRoot) field(name string, args map[string]interface{}) (interface{}, error) {
+	if name != "repository" {
+		return nil, unknownField(q, name)
+	}
+
+	owner, _ := args["owner"].(string)
+	repoName, _ := args["name"].(string)
+	repo := q.server.repository(owner, repoName)
+	if repo == nil {
+		return nil, notFoundError(fmt.Sprintf("Could not resolve to a Repository with the name '%s/%s'.", owner, repoName))
+	}
+	return repositoryObject{repo: repo}, nil
+}
+
+// repositoryObject is the Repository type.
+type repositoryObject struct {
+	repo *Repository
+}
+
+func (r repositoryObject) typeName() string { return "Repository" }
+
+func (r repositoryObject) field(name string, args map[string]interface{}) (interface{}, error) {
+	switch name {
+	case "name":
+		return r.repo.Name, nil
+	case "nameWithOwner":
+		return r.repo.Owner + "/" + r.repo.Name, nil
+	case "object":
+		expression, _ := args["expression"].(string)
+		return r.object(expression), nil
+	case "defaultBranchRef":
+		return refObject{name: r.repo.DefaultBranch}, nil
+	}
+	return nil, unknownField(r, name)
+}
+
+// refObject is the Ref type.
+type refObject struct {
+	name string
+}
+
+func (r refObject) typeName() string { return "Ref" }
+
+func (r refObject) field(name string, _ map[string]interface{}) (interface{}, error) {
+	switch name {
+	case "name":
+		return r.name, nil
+	case "prefix":
+		return "refs/heads/", nil
+	}
+	return nil, unknownField(r, name)
+}
+
+// object resolves a "<ref>:<path>" expression on the default branch or a tag to a tree or blob. Other refs
+// and missing paths resolve to null.
+func (r repositoryObject) object(expression string) interface{} {
+	ref, filePath, _ := strings.Cut(expression, ":")
+	files, ok := r.repo.filesAt(ref)
+	if !ok {
+		return nil
+	}
+
+	filePath = strings.Trim(filePath, "/")
+	if content, ok := files[filePath]; ok {
+		return blobObject{content: content}
+	}
+	if filePath == "" {
+		return treeObject{repo: r.repo, files: files}
+	}
+	for p := range files {
+		if strings.HasPrefix(p, filePath+"/") {
+			return treeObject{repo: r.repo, files: files, path: filePath}
+		}
+	}
+	return nil
+}
+
+// treeObject is the Tree type. Files are the files of the ref the tree was read from.
+type treeObject struct {
+	repo  *Repository
+	files map[string]string
+	path  string
+}
+
+func (t treeObject) typeName() string { return "Tree" }
+
+func (t treeObject) field(name string, _ map[string]interface{}) (interface{}, error) {
+	switch name {
+	case "oid":
+		return hash("tree", t.repo.Owner, t.repo.Name, t.path), nil
+	case "entries":
+		return t.entries(), nil
+	}
+	return nil, unknownField(t, name)
+}
+
+// entries returns the direct children of the tree, sorted by name.
+func (t treeObject) entries() []object {
+	prefix := ""
+	if t.path != "" {
+		prefix = t.path + "/"
+	}
+
+	children := make(map[string]treeEntryObject)
+	for p, content := range t.files {
+		if !strings.HasPrefix(p, prefix) {
+			continue
+		}
+		name, _, isDir := strings.Cut(strings.TrimPrefix(p, prefix), "/")
+		if _, ok := children[name]; ok {
+			continue
+		}
+
+		entry := treeEntryObject{tree: t, name: name, path: prefix + name, typ: "blob", mode: modeBlob, oid: blobOID(content), size: len(content), content: content}
+		if isDir {
+			entry.typ, entry.mode, entry.oid, entry.content = "tree", modeTree, hash("tree", t.repo.Owner, t.repo.Name, entry.path), ""
+		}
+		children[name] = entry
+	}
+
+	names := make([]string, 0, len(children))
+	for name := range children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	entries := make([]object, 0, len(names))
+	for _, name := range names {
+		entries = append(entries, children[name])
+	}
+	return entries
+}
+
+// treeEntryObject is the TreeEntry type. Tree is the tree listing the entry, content the content of blobs.
+type treeEntryObject struct {
+	tree                          treeObject
+	name, path, typ, oid, content string
+	mode, size                    int
+}
+
+func (e treeEntryObject) typeName() string { return "TreeEntry" }
+
+func (e treeEntryObject) field(name string, _ map[string]interface{}) (interface{}, error) {
+	switch name {
+	case "name":
+		return e.name, nil
+	case "path":
+		return e.path, nil
+	case "type":
+		return e.typ, nil
+	case "mode":
+		return e.mode, nil
+	case "oid":
+		return e.oid, nil
+	case "size":
+		return e.size, nil
+	case "object":
+		if e.typ == "tree" {
+			return treeObject{repo: e.tree.repo, files: e.tree.files, path: e.path}, nil
+		}
+		return blobObject{content: e.content}, nil
+	}
+	return nil, unknownField(e, name)
+}
+
+// blobObject is the Blob type. Content holding a NUL byte is reported as binary without text, as
+// GitHub does.
+type blobObject struct {
+	content string
+}
+
+func (b blobObject) typeName() string { return "Blob" }
+
+func (b blobObject) field(name string, _ map[string]interface{}) (interface{}, error) {
+	binary := strings.IndexByte(b.content, 0) >= 0
+	switch name {
+	case "text":
+		if binary {
+			return nil, nil
+		}
+		return b.content, nil
+	case "isBinary":
+		return binary, nil
+	case "byteSize":
+		return len(b.content), nil
+	case "oid":
+		return blobOID(b.content), nil
+	}
+	return nil, unknownField(b, name)
+}
@@ -0,0 +1,245 @@
+package cocoghtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+// RecordEnv is the environment variable that switches ModeFromEnv to recording.
+const RecordEnv = "COCOGH_RECORD"
+
+// Mode selects whether a Recorder records real interactions or replays recorded ones.
+type Mode int
+
+const (
+	// ModeReplay answers requests from the fixture file without touching the network.
+	ModeReplay Mode = iota
+	// ModeRecord forwards requests to the real transport and records the interactions.
+	ModeRecord
+)
+
+// redacted replaces secrets in recorded fixtures.
+const redacted = "REDACTED"
+
+// tokenPattern matches GitHub access tokens in request and response bodies.
+var tokenPattern = regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b`)
+
+// sensitiveQueryParams are query parameters that carry credentials.
+var sensitiveQueryParams = []string{"access_token", "client_secret", "token"}
+
+// recordedHeaders are the response headers kept in fixtures. Everything else, including anything that
+// could identify the account, is dropped.
+var recordedHeaders = []string{"Content-Type", "Link", "X-Github-Media-Type"}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of a request replay matches on. Request headers are never recorded, so the
+// Authorization header cannot leak into fixtures.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded response.
+type RecordedResponse struct {
+	StatusCode int                 `json:"status_code"`
+	Header     map[string][]string `json:"header,omitempty"`
+	Body       string              `json:"body"`
+}
+
+// Recorder is an http.RoundTripper that records API interactions to a fixture file and replays them, so
+// tests can run against real response shapes without network access.
+//
+// In ModeRecord requests are sent through the wrapped transport and every interaction is kept until
+// Save writes them to the fixture file. Credentials are scrubbed before recording: request headers are
+// not recorded at all, credential query parameters and GitHub tokens found in bodies are replaced.
+//
+// In ModeReplay requests are answered from the fixture file. A request matches a recorded interaction
+// with the same method, URL and body; identical requests are answered in recording order. Requests
+// without a match fail.
+//
+// Usage:
+//
+//	rec, err := cocoghtest.NewRecorder("testdata/files.json", cocoghtest.ModeFromEnv(), http.DefaultTransport)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	defer rec.Save()
+//
+//	httpClient := &http.Client{Transport: rec}
+type Recorder struct {
+	mode      Mode
+	path      string
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// ModeFromEnv returns ModeRecord if the COCOGH_RECORD environment variable is set, ModeReplay otherwise.
+func ModeFromEnv() Mode {
+	if os.Getenv(RecordEnv) != "" {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+// NewRecorder creates a Recorder for the fixture file at path. In ModeReplay the fixture file is loaded
+// and must exist. The transport is only used in ModeRecord; nil uses http.DefaultTransport.
+func NewRecorder(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	r := &Recorder{mode: mode, path: path, transport: transport}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.interactions); err != nil {
+			return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.interactions))
+	}
+
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	recorded := RecordedRequest{Method: req.Method, URL: scrubURL(req.URL), Body: scrub(string(body))}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	forwarded := req.Clone(req.Context())
+	forwarded.Body = io.NopCloser(bytes.NewReader(body))
+	resp, err := r.transport.RoundTrip(forwarded)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	header := make(map[string][]string)
+	for _, name := range recordedHeaders {
+		if values := resp.Header.Values(name); len(values) > 0 {
+			header[name] = values
+		}
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Request:  recorded,
+		Response: RecordedResponse{StatusCode: resp.StatusCode, Header: header, Body: scrub(string(respBody))},
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the fixture file, creating its directory if needed. It does
+// nothing in ModeReplay.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// replay answers the request with the first unused interaction matching it.
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.used[i] || interaction.Request != recorded {
+			continue
+		}
+		r.used[i] = true
+
+		header := make(http.Header)
+		for name, values := range interaction.Response.Header {
+			for _, value := range values {
+				header.Add(name, value)
+			}
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader([]byte(interaction.Response.Body))),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction for %s %s in %s", recorded.Method, recorded.URL, r.path)
+}
+
+// scrubURL returns the URL with credential query parameters redacted.
+func scrubURL(u *url.URL) string {
+	scrubbed := *u
+	scrubbed.User = nil
+
+	query := scrubbed.Query()
+	for _, name := range sensitiveQueryParams {
+		if query.Has(name) {
+			query.Set(name, redacted)
+		}
+	}
+	scrubbed.RawQuery = query.Encode()
+
+	return scrub(scrubbed.String())
+}
+
+// scrub redacts GitHub access tokens.
+func scrub(s string) string {
+	return tokenPattern.ReplaceAllString(s, redacted)
+}
@@ -0,0 +1,323 @@
+package cocoghtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// defaultPerPage is the page size GitHub uses when the request does not ask for one.
+const defaultPerPage = 30
+
+// handleREST serves the /repos/{owner}/{repo}/... endpoints.
+func (s *Server) handleREST(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/repos/"), "/"), "/")
+	if len(parts) < 2 {
+		writeNotFound(w)
+		return
+	}
+
+	repo := s.repository(parts[0], parts[1])
+	if repo == nil {
+		writeNotFound(w)
+		return
+	}
+
+	switch {
+	case len(parts) == 2:
+		s.getRepository(w, repo)
+	case len(parts) == 3 && parts[2] == "commits":
+		s.listCommits(w, r, repo)
+	case len(parts) == 4 && parts[2] == "commits":
+		s.getCommit(w, repo, parts[3])
+	case len(parts) == 4 && parts[2] == "compare":
+		s.compareCommits(w, repo, parts[3])
+	case len(parts) == 5 && parts[2] == "git" && parts[3] == "trees":
+		s.getTree(w, r, repo, parts[4])
+	default:
+		writeNotFound(w)
+	}
+}
+
+// getRepository serves GET /repos/{owner}/{repo}.
+func (s *Server) getRepository(w http.ResponseWriter, repo *Repository) {
+	writeJSON(w, &github.Repository{
+		Name:          github.String(repo.Name),
+		FullName:      github.String(repo.Owner + "/" + repo.Name),
+		Owner:         &github.User{Login: github.String(repo.Owner)},
+		DefaultBranch: github.String(repo.DefaultBranch),
+		HTMLURL:       github.String(fmt.Sprintf("https://github.com/%s/%s", repo.Owner, repo.Name)),
+	})
+}
+
+// listCommits serves GET /repos/{owner}/{repo}/commits, honouring the since, until, path, page and
+// per_page parameters.
+func (s *Server) listCommits(w http.ResponseWriter, r *http.Request, repo *Repository) {
+	query := r.URL.Query()
+
+	var since, until time.Time
+	for name, t := range map[string]*time.Time{"since": &since, "until": &until} {
+		if value := query.Get(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Invalid %s: %s", name, value))
+				return
+			}
+			*t = parsed
+		}
+	}
+	filePath := strings.Trim(query.Get("path"), "/")
+
+	var commits []*github.RepositoryCommit
+	for _, commit := range repo.Commits {
+		if (!since.IsZero() && commit.Date.Before(since)) || (!until.IsZero() && commit.Date.After(until)) {
+			continue
+		}
+		if filePath != "" && !touches(commit, filePath) {
+			continue
+		}
+		commits = append(commits, repositoryCommit(repo, commit, false))
+	}
+
+	page, perPage := atoiOr(query.Get("page"), 1), atoiOr(query.Get("per_page"), defaultPerPage)
+	start, end := (page-1)*perPage, page*perPage
+	if start > len(commits) {
+		start = len(commits)
+	}
+	if end >= len(commits) {
+		end = len(commits)
+	} else {
+		next := *r.URL
+		values := next.Query()
+		values.Set("page", strconv.Itoa(page+1))
+		values.Set("per_page", strconv.Itoa(perPage))
+		next.RawQuery = values.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+	}
+
+	writeJSON(w, commits[start:end])
+}
+
+// getCommit serves GET /repos/{owner}/{repo}/commits/{sha}.
+func (s *Server) getCommit(w http.ResponseWriter, repo *Repository, sha string) {
+	for _, commit := range repo.Commits {
+		if commit.SHA == sha {
+			writeJSON(w, repositoryCommit(repo, commit, true))
+			return
+		}
+	}
+	writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("No commit found for SHA: %s", sha))
+}
+
+// compareCommits serves GET /repos/{owner}/{repo}/compare/{base}...{head}. Both refs are commit SHAs or
+// the default branch, which stands for the latest commit. The files are the net changes of the commits after
+// base up to head.
+func (s *Server) compareCommits(w http.ResponseWriter, repo *Repository, basehead string) {
+	base, head, ok := strings.Cut(basehead, "...")
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	baseIndex, headIndex := commitIndex(repo, base), commitIndex(repo, head)
+	if baseIndex < 0 || headIndex < 0 {
+		writeNotFound(w)
+		return
+	}
+
+	comparison := &github.CommitsComparison{Status: github.String("identical")}
+	if headIndex < baseIndex {
+		comparison.Status = github.String("ahead")
+		comparison.AheadBy = github.Int(baseIndex - headIndex)
+	} else if headIndex > baseIndex {
+		comparison.Status = github.String("behind")
+		comparison.BehindBy = github.Int(headIndex - baseIndex)
+	}
+
+	// Commits are ordered newest first, so the range is replayed backwards.
+	var changes []CommitFile
+	for i := baseIndex - 1; i >= headIndex; i-- {
+		comparison.Commits = append(comparison.Commits, repositoryCommit(repo, repo.Commits[i], false))
+		changes = mergeChanges(changes, repo.Commits[i].Files)
+	}
+	for _, file := range changes {
+		changed := &github.CommitFile{Filename: github.String(file.Filename), Status: github.String(file.Status)}
+		if file.PreviousFilename != "" {
+			changed.PreviousFilename = github.String(file.PreviousFilename)
+		}
+		comparison.Files = append(comparison.Files, changed)
+	}
+
+	writeJSON(w, comparison)
+}
+
+// getTree serves GET /repos/{owner}/{repo}/git/trees/{ref} for the default branch and tags, honouring the
+// recursive parameter. Trees of repositories with TruncatedTree set are reported as truncated, without any
+// entries.
+func (s *Server) getTree(w http.ResponseWriter, r *http.Request, repo *Repository, ref string) {
+	files, ok := repo.filesAt(ref)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	tree := &github.Tree{
+		SHA:       github.String(hash("tree", repo.Owner, repo.Name, "")),
+		Truncated: github.Bool(repo.TruncatedTree),
+	}
+	if !repo.TruncatedTree {
+		tree.Entries = treeEntries(treeObject{repo: repo, files: files}, r.URL.Query().Get("recursive") != "")
+	}
+	writeJSON(w, tree)
+}
+
+// treeEntries lists the entries of the tree in the REST representation, depth first if recursive is set.
+func treeEntries(tree treeObject, recursive bool) []*github.TreeEntry {
+	var entries []*github.TreeEntry
+	for _, obj := range tree.entries() {
+		entry := obj.(treeEntryObject)
+		restEntry := &github.TreeEntry{
+			SHA:  github.String(entry.oid),
+			Path: github.String(entry.path),
+			Mode: github.String(strconv.FormatInt(int64(entry.mode), 8)),
+			Type: github.String(entry.typ),
+		}
+		if entry.typ == "blob" {
+			restEntry.Size = github.Int(entry.size)
+		}
+		entries = append(entries, restEntry)
+
+		if recursive && entry.typ == "tree" {
+			entries = append(entries, treeEntries(treeObject{repo: tree.repo, files: tree.files, path: entry.path}, true)...)
+		}
+	}
+	return entries
+}
+
+// commitIndex returns the index of the commit a ref names, or -1 if there is none.
+func commitIndex(repo *Repository, ref string) int {
+	if ref == repo.DefaultBranch && len(repo.Commits) > 0 {
+		return 0
+	}
+	for i, commit := range repo.Commits {
+		if commit.SHA == ref {
+			return i
+		}
+	}
+	return -1
+}
+
+// mergeChanges applies the files changed by a later commit to the net changes so far.
+func mergeChanges(changes []CommitFile, files []CommitFile) []CommitFile {
+	for _, file := range files {
+		previous := file.Filename
+		if file.Status == "renamed" {
+			previous = file.PreviousFilename
+		}
+
+		index := -1
+		for i, change := range changes {
+			if change.Filename == previous {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			changes = append(changes, file)
+			continue
+		}
+
+		change := changes[index]
+		switch {
+		case change.Status == "added" && file.Status == "removed":
+			changes = append(changes[:index], changes[index+1:]...)
+		case change.Status == "added":
+			changes[index] = CommitFile{Filename: file.Filename, Status: "added"}
+		case change.Status == "removed" && file.Status == "added":
+			changes[index] = CommitFile{Filename: file.Filename, Status: "modified"}
+		case change.Status == "renamed" && file.Status == "removed":
+			changes[index] = CommitFile{Filename: change.PreviousFilename, Status: "removed"}
+		case change.Status == "renamed":
+			changes[index] = CommitFile{Filename: file.Filename, PreviousFilename: change.PreviousFilename, Status: "renamed"}
+		default:
+			changes[index] = file
+		}
+	}
+	return changes
+}
+
+// repositoryCommit converts a seeded commit to its REST representation. The changed files are only
+// included when a single commit is requested, as GitHub does.
+func repositoryCommit(repo *Repository, commit Commit, withFiles bool) *github.RepositoryCommit {
+	date := &github.Timestamp{Time: commit.Date}
+	result := &github.RepositoryCommit{
+		SHA: github.String(commit.SHA),
+		Commit: &github.Commit{
+			Message:   github.String(commit.Message),
+			Author:    &github.CommitAuthor{Name: github.String(commit.Author), Date: date},
+			Committer: &github.CommitAuthor{Name: github.String(commit.Author), Date: date},
+		},
+		Author:  &github.User{Login: github.String(commit.Author)},
+		HTMLURL: github.String(fmt.Sprintf("https://github.com/%s/%s/commit/%s", repo.Owner, repo.Name, commit.SHA)),
+	}
+
+	if withFiles {
+		for _, file := range commit.Files {
+			changed := &github.CommitFile{
+				Filename: github.String(file.Filename),
+				Status:   github.String(file.Status),
+			}
+			if file.PreviousFilename != "" {
+				changed.PreviousFilename = github.String(file.PreviousFilename)
+			}
+			result.Files = append(result.Files, changed)
+		}
+	}
+
+	return result
+}
+
+// touches reports whether the commit changed the file or a file inside the directory at filePath.
+func touches(commit Commit, filePath string) bool {
+	for _, file := range commit.Files {
+		for _, name := range []string{file.Filename, file.PreviousFilename} {
+			if name == filePath || strings.HasPrefix(name, filePath+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func atoiOr(s string, fallback int) int {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return fallback
+	}
+	return n
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "Not Found")
+}
@@ -0,0 +1,200 @@
+// Package cocoghtest provides a fake GitHub server for testing code built on cocogh without network access.
+//
+// The server implements the REST and GraphQL endpoints the cocogh client uses to list files, read blobs,
+// walk the commit history of a repository and compare two of its commits. It is seeded with repositories
+// holding the files of their default branch and their commits:
+//
+//	srv := cocoghtest.NewServer()
+//	defer srv.Close()
+//
+//	srv.AddRepository(cocoghtest.Repository{
+//	    Owner: "octo-org",
+//	    Name:  "docs",
+//	    Files: map[string]string{"guides/setup.md": "# Setup"},
+//	    Commits: []cocoghtest.Commit{{
+//	        Message: "Add setup guide",
+//	        Date:    time.Now(),
+//	        Files:   []cocoghtest.CommitFile{{Filename: "guides/setup.md", Status: "added"}},
+//	    }},
+//	})
+//
+//	gh := srv.NewGitHub(github.GitHubConfig{Owner: "octo-org", Repositories: []string{"docs"}})
+//
+// The endpoints are also served at the paths of GitHub Enterprise Server, so the server URL can be used
+// as the BaseURL of a github.GitHubConfig. Endpoints the fake does not implement answer 404 Not Found, GraphQL fields it does not know yield a
+// GraphQL error. Failures such as rate limits and server errors can be injected with InjectFault.
+package cocoghtest
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gogithub "github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/github"
+	"github.com/shurcooL/githubv4"
+)
+
+// DefaultBranch is the default branch of seeded repositories that do not declare one.
+const DefaultBranch = "main"
+
+// Repository is a repository served by the fake server.
+//
+// Files maps the slash separated path of every file on the default branch to its content; directories
+// are derived from the paths. Commits is the history of the default branch in any order. Commits are not
+// applied to Files, so both must be seeded consistently if a test relies on it. Tags maps tag names to the
+// files of the tagged commit, keyed like Files, so the repository can be read as of a release; a tag can
+// also be requested by its fully qualified ref "refs/tags/<name>". TruncatedTree makes the
+// Git Trees API report the tree as truncated, as GitHub does for very large repositories, and omit its
+// entries.
+type Repository struct {
+	Owner         string
+	Name          string
+	DefaultBranch string
+	Files         map[string]string
+	Tags          map[string]map[string]string
+	Commits       []Commit
+	TruncatedTree bool
+}
+
+// Commit is a commit of a seeded repository. A missing SHA is derived from the message and date.
+type Commit struct {
+	SHA     string
+	Message string
+	Author  string
+	Date    time.Time
+	Files   []CommitFile
+}
+
+// CommitFile is a file changed by a commit. Status is one of "added", "removed", "modified", "renamed",
+// "copied" or "changed", PreviousFilename is set for renamed files.
+type CommitFile struct {
+	Filename         string
+	PreviousFilename string
+	Status           string
+}
+
+// Server is a fake GitHub server. It is safe for concurrent use, repositories may be added while
+// requests are served.
+type Server struct {
+	*httptest.Server
+
+	mu     sync.RWMutex
+	repos  map[string]*Repository
+	faults []*Fault
+}
+
+// NewServer starts a fake GitHub server without repositories. The caller must call Close when done.
+func NewServer() *Server {
+	s := &Server{repos: make(map[string]*Repository)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/graphql", s.handleGraphQL)
+	mux.HandleFunc("/repos/", s.handleREST)
+	// GitHub Enterprise Server serves the same APIs below /api.
+	mux.HandleFunc("/api/graphql", s.handleGraphQL)
+	mux.Handle("/api/v3/", http.StripPrefix("/api/v3", mux))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeNotFound(w)
+	})
+
+	s.Server = httptest.NewServer(s.withFaults(mux))
+	return s
+}
+
+// AddRepository seeds a repository, replacing any repository with the same owner and name.
+func (s *Server) AddRepository(repo Repository) {
+	if repo.DefaultBranch == "" {
+		repo.DefaultBranch = DefaultBranch
+	}
+
+	commits := make([]Commit, len(repo.Commits))
+	copy(commits, repo.Commits)
+	for i := range commits {
+		if commits[i].SHA == "" {
+			commits[i].SHA = hash(commits[i].Message, commits[i].Date.UTC().Format(time.RFC3339Nano))
+		}
+	}
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].Date.After(commits[j].Date)
+	})
+	repo.Commits = commits
+
+	repo.Files = normalizeFiles(repo.Files)
+	tags := make(map[string]map[string]string, len(repo.Tags))
+	for tag, files := range repo.Tags {
+		tags[tag] = normalizeFiles(files)
+	}
+	repo.Tags = tags
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.repos[repoKey(repo.Owner, repo.Name)] = &repo
+}
+
+// normalizeFiles copies the files, trimming leading and trailing slashes from their paths.
+func normalizeFiles(files map[string]string) map[string]string {
+	normalized := make(map[string]string, len(files))
+	for p, content := range files {
+		normalized[strings.Trim(p, "/")] = content
+	}
+	return normalized
+}
+
+// filesAt returns the files the ref points to: the default branch, HEAD or a tag. The boolean is false if
+// the ref is unknown.
+func (r *Repository) filesAt(ref string) (map[string]string, bool) {
+	if ref == r.DefaultBranch || ref == "HEAD" {
+		return r.Files, true
+	}
+	files, ok := r.Tags[strings.TrimPrefix(ref, "refs/tags/")]
+	return files, ok
+}
+
+// GitHubClient returns a REST client talking to the fake server.
+func (s *Server) GitHubClient() *gogithub.Client {
+	client := gogithub.NewClient(s.Client())
+	client.BaseURL, _ = url.Parse(s.URL + "/")
+	return client
+}
+
+// GraphQLClient returns a GraphQL client talking to the fake server.
+func (s *Server) GraphQLClient() *githubv4.Client {
+	return githubv4.NewEnterpriseClient(s.URL+"/graphql", s.Client())
+}
+
+// NewGitHub creates a cocogh client using the fake server for both its REST and GraphQL calls.
+func (s *Server) NewGitHub(config github.GitHubConfig) *github.GitHub {
+	ops := &github.GitHubCommitsOpsClient{GitHubClient: s.GitHubClient()}
+	return github.NewGitHubClient(ops, s.GraphQLClient(), config)
+}
+
+// repository returns the seeded repository, or nil if there is none.
+func (s *Server) repository(owner, name string) *Repository {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.repos[repoKey(owner, name)]
+}
+
+func repoKey(owner, name string) string {
+	return strings.ToLower(owner + "/" + name)
+}
+
+// hash returns a stable fake object ID for the given parts.
+func hash(parts ...string) string {
+	sum := sha1.Sum([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// blobOID returns the git object ID of a blob with the given content.
+func blobOID(content string) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("blob %d\x00%s", len(content), content)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,398 @@
+package cocoghtest_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/cocoghtest"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shaharia-lab/coco-gh/v2/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func newServer(t *testing.T) *cocoghtest.Server {
+	srv := cocoghtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	commits := []cocoghtest.Commit{
+		{
+			SHA:     "rename",
+			Message: "Move setup guide",
+			Author:  "alice",
+			Date:    base.Add(48 * time.Hour),
+			Files:   []cocoghtest.CommitFile{{Filename: "docs/guides/setup.md", PreviousFilename: "docs/setup.md", Status: "renamed"}},
+		},
+		{
+			SHA:     "readme",
+			Message: "Update README",
+			Author:  "bob",
+			Date:    base.Add(24 * time.Hour),
+			Files:   []cocoghtest.CommitFile{{Filename: "README.md", Status: "modified"}},
+		},
+	}
+	for i := 0; i < 120; i++ {
+		commits = append(commits, cocoghtest.Commit{
+			Message: fmt.Sprintf("Old change %d", i),
+			Date:    base.Add(-time.Duration(i+1) * time.Hour),
+			Files:   []cocoghtest.CommitFile{{Filename: "docs/index.md", Status: "modified"}},
+		})
+	}
+
+	srv.AddRepository(cocoghtest.Repository{
+		Owner: "testowner",
+		Name:  "repo1",
+		Files: map[string]string{
+			"README.md":            "# repo1",
+			"CODEOWNERS":           "docs/ @docs-team\n",
+			"docs/index.md":        "# Index",
+			"docs/guides/setup.md": "# Setup",
+			"docs/logo.png":        "\x89PNG\x00",
+		},
+		Commits: commits,
+	})
+	return srv
+}
+
+func TestServer_Files(t *testing.T) {
+	srv := newServer(t)
+	gh := srv.NewGitHub(github.GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+	})
+
+	files, err := gh.GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/guides/setup.md", "docs/index.md"}, files)
+
+	roots, err := gh.GetFileTreeFromRepositories(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, roots, 1)
+	assert.Equal(t, "guides", roots[0].Children[0].Name)
+	assert.True(t, roots[0].Children[0].IsDir())
+	assert.Equal(t, document.FileModeRegular, roots[0].Children[1].Mode)
+
+	ownership, err := gh.GetOwnership(context.Background(), "repo1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"@docs-team"}, ownership.Owners("docs/index.md"))
+}
+
+func TestServer_Tags(t *testing.T) {
+	srv := newServer(t)
+	srv.AddRepository(cocoghtest.Repository{
+		Owner: "other-org",
+		Name:  "docs",
+		Files: map[string]string{"docs/index.md": "# Index", "docs/new.md": "# New"},
+		Tags:  map[string]map[string]string{"v1.0.0": {"docs/index.md": "# Index v1"}},
+	})
+	gh := srv.NewGitHub(github.GitHubConfig{
+		DefaultBranch:  "main",
+		RepositoryRefs: []github.RepositoryRef{{Owner: "other-org", Name: "docs", Ref: "v1.0.0"}},
+	})
+
+	files, err := gh.GetFiles(context.Background(), github.FileOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, "docs/index.md", files[0].Path)
+	assert.Equal(t, "v1.0.0", files[0].Branch)
+
+	contents, err := gh.GetFileContents(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, contents, 1)
+	assert.Equal(t, "# Index v1", contents[0].Text)
+}
+
+func TestServer_Commits(t *testing.T) {
+	srv := newServer(t)
+	gh := srv.NewGitHub(github.GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+	})
+
+	docs, err := gh.GetCommitDocumentsSince(context.Background(), time.Time{})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 122)
+	assert.Equal(t, "Move setup guide", docs[0].Title)
+	assert.Equal(t, "alice", docs[0].Author)
+
+	paths, err := gh.GetChangedFilePathsSince(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/guides/setup.md"}, paths.Added)
+	assert.Equal(t, []string{"docs/setup.md"}, paths.Removed)
+	assert.Equal(t, []string{"README.md"}, paths.Modified)
+}
+
+func TestServer_UnknownRepository(t *testing.T) {
+	srv := newServer(t)
+	gh := srv.NewGitHub(github.GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"missing"},
+		DefaultBranch: "main",
+	})
+
+	_, err := gh.GetFilePathsFromRepositories()
+	assert.ErrorContains(t, err, "Could not resolve to a Repository with the name 'testowner/missing'.")
+	assert.ErrorIs(t, err, github.ErrRepoNotFound)
+
+	_, err = gh.GetCommitDocumentsSince(context.Background(), time.Time{})
+	assert.ErrorContains(t, err, "404")
+	assert.ErrorIs(t, err, github.ErrRepoNotFound)
+}
+
+func TestServer_CancelledContext(t *testing.T) {
+	srv := newServer(t)
+	gh := srv.NewGitHub(github.GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := gh.GetFilePathsFromRepositoriesWithContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = gh.GetChangedFilePathsSinceWithContext(ctx, time.Time{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestServer_ChangedFilesPagination(t *testing.T) {
+	srv := newServer(t)
+	gh := srv.NewGitHub(github.GitHubConfig{
+		Owner:            "testowner",
+		Repositories:     []string{"repo1"},
+		DefaultBranch:    "main",
+		KeepChangeEvents: true,
+	})
+
+	paths, err := gh.GetChangedFilePathsSince(time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "docs/index.md"}, paths.Modified)
+	assert.Len(t, paths.Events, 122)
+	assert.Equal(t, "rename", paths.Events[len(paths.Events)-1].CommitSHA)
+}
+
+func TestServer_Enterprise(t *testing.T) {
+	srv := newServer(t)
+	gh, err := github.NewGitHubClientFromHTTPClient(srv.Client(), github.GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		BaseURL:       srv.URL,
+	})
+	assert.NoError(t, err)
+
+	files, err := gh.GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Contains(t, files, "docs/guides/setup.md")
+
+	paths, err := gh.GetChangedFilePathsSince(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"README.md"}, paths.Modified)
+}
+
+func TestServer_MultipleOwners(t *testing.T) {
+	srv := newServer(t)
+	srv.AddRepository(cocoghtest.Repository{
+		Owner:         "other-org",
+		Name:          "handbook",
+		DefaultBranch: "published",
+		Files:         map[string]string{"docs/onboarding.md": "# Onboarding"},
+		Commits: []cocoghtest.Commit{{
+			Message: "Add onboarding",
+			Date:    time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			Files:   []cocoghtest.CommitFile{{Filename: "docs/onboarding.md", Status: "added"}},
+		}},
+	})
+
+	gh := srv.NewGitHub(github.GitHubConfig{
+		Owner:          "testowner",
+		Repositories:   []string{"repo1"},
+		RepositoryRefs: []github.RepositoryRef{{Owner: "other-org", Name: "handbook", Branch: "published"}},
+		DefaultBranch:  "main",
+		Filter:         filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+	})
+
+	files, err := gh.GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/guides/setup.md", "docs/index.md", "docs/onboarding.md"}, files)
+
+	paths, err := gh.GetChangedFilePathsSince(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/guides/setup.md", "docs/onboarding.md"}, paths.Added)
+
+	content, err := gh.GetFileContent(context.Background(), "other-org/handbook", "docs/onboarding.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Onboarding", content.Text)
+}
+
+func TestServer_DetectDefaultBranch(t *testing.T) {
+	srv := newServer(t)
+	srv.AddRepository(cocoghtest.Repository{
+		Owner:         "testowner",
+		Name:          "legacy",
+		DefaultBranch: "master",
+		Files:         map[string]string{"docs/history.md": "# History"},
+	})
+
+	gh := srv.NewGitHub(github.GitHubConfig{
+		Owner:               "testowner",
+		Repositories:        []string{"repo1", "legacy"},
+		DefaultBranch:       "trunk",
+		DetectDefaultBranch: true,
+		Filter:              filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+	})
+
+	files, err := gh.GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/guides/setup.md", "docs/index.md", "docs/history.md"}, files)
+}
+
+func TestServer_GetFiles(t *testing.T) {
+	srv := newServer(t)
+	gh := srv.NewGitHub(github.GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs/guides", FileTypes: []string{".md"}},
+	})
+
+	files, err := gh.GetFiles(context.Background(), github.FileOptions{LastModified: true})
+	assert.NoError(t, err)
+	if assert.Len(t, files, 1) {
+		file := files[0]
+		assert.Equal(t, "docs/guides/setup.md", file.Path)
+		assert.Equal(t, len("# Setup"), file.Size)
+		assert.NotEmpty(t, file.SHA)
+		assert.True(t, file.Mode.IsRegular())
+		assert.Equal(t, "main", file.Branch)
+		assert.Equal(t, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), file.LastModifiedAt.UTC())
+		assert.Contains(t, file.URL, "/testowner/repo1/blob/main/docs/guides/setup.md")
+	}
+}
+
+func TestServer_CompareCommits(t *testing.T) {
+	srv := newServer(t)
+	gh := srv.NewGitHub(github.GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FileTypes: []string{".md"}},
+	})
+
+	paths, err := gh.GetChangedFilePathsBetween(context.Background(), "repo1", "readme", "main")
+	assert.NoError(t, err)
+	assert.Equal(t, document.Paths{
+		Added:   []string{"docs/guides/setup.md"},
+		Removed: []string{"docs/setup.md"},
+		Renamed: []document.Rename{{From: "docs/setup.md", To: "docs/guides/setup.md"}},
+	}, paths)
+
+	paths, err = gh.GetChangedFilePathsBetween(context.Background(), "repo1", "readme", "readme")
+	assert.NoError(t, err)
+	assert.Equal(t, document.Paths{}, paths)
+
+	_, err = gh.GetChangedFilePathsBetween(context.Background(), "repo1", "unknown", "main")
+	assert.Error(t, err)
+}
+
+func TestServer_TruncatedTree(t *testing.T) {
+	srv := newServer(t)
+	srv.AddRepository(cocoghtest.Repository{
+		Owner:         "testowner",
+		Name:          "monorepo",
+		Files:         map[string]string{"docs/index.md": "# Index", "services/api/README.md": "# API"},
+		TruncatedTree: true,
+	})
+
+	gh := srv.NewGitHub(github.GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1", "monorepo"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FileTypes: []string{".md"}},
+	})
+
+	files, err := gh.GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "docs/guides/setup.md", "docs/index.md", "docs/index.md", "services/api/README.md"}, files)
+}
+
+func TestServer_SizeAndBinaryFilters(t *testing.T) {
+	srv := newServer(t)
+	gh := srv.NewGitHub(github.GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs", MaxFileSize: 7, SkipBinary: true},
+	})
+
+	paths, err := gh.GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/guides/setup.md", "docs/index.md"}, paths)
+
+	gh.Configuration.Filter.MaxFileSize = 5
+	gh.Configuration.Filter.SkipBinary = false
+	paths, err = gh.GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/logo.png"}, paths)
+}
+
+func TestServer_TreeBatching(t *testing.T) {
+	srv := newServer(t)
+	srv.AddRepository(cocoghtest.Repository{
+		Owner: "testowner",
+		Name:  "repo2",
+		Files: map[string]string{"docs/a.md": "# A", "docs/api/b.md": "# B", "docs/api/v1/c.md": "# C", "guides/d.md": "# D"},
+	})
+	config := github.GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1", "repo2"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FileTypes: []string{".md"}, SkipBinary: true},
+	}
+
+	// Without batching every directory of every repository takes a query.
+	gh := srv.NewGitHub(config)
+	want, err := gh.GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "docs/guides/setup.md", "docs/index.md", "docs/a.md", "docs/api/b.md", "docs/api/v1/c.md", "guides/d.md"}, want)
+	estimate, err := gh.EstimateCrawl(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 8, estimate.ListRequests)
+
+	// With batching a query lists the directories of the same depth of both repositories.
+	config.TreeBatchSize = 10
+	gh = srv.NewGitHub(config)
+	paths, err := gh.GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Equal(t, want, paths)
+	estimate, err = gh.EstimateCrawl(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 4, estimate.ListRequests)
+
+	// Batches are split at the batch size and a single repository is batched across its directories.
+	config.TreeBatchSize = 2
+	gh = srv.NewGitHub(config)
+	paths, err = gh.GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Equal(t, want, paths)
+
+	config.Repositories = []string{"repo2"}
+	gh = srv.NewGitHub(config)
+	estimate, err = gh.EstimateCrawl(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 4, estimate.ListRequests)
+
+	// A missing repository fails on its own.
+	config.Repositories = []string{"repo1", "missing"}
+	gh = srv.NewGitHub(config)
+	_, err = gh.GetFilePathsFromRepositories()
+	assert.ErrorIs(t, err, github.ErrRepoNotFound)
+}
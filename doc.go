@@ -0,0 +1,21 @@
+// Package cocogh collects content from GitHub repositories for indexing and search pipelines.
+//
+// The API lives in sub-packages:
+//
+//   - github: the GitHub client, its configuration, transports and the files, changes, documents and
+//     repository metadata it collects.
+//   - filter: the repository, file and commit filters.
+//   - document: Document, File, ChangeSet, Snapshot and the Transformers applied to documents.
+//   - source: the provider independent Source and its GitLab, Bitbucket, Gitea and local implementations.
+//   - sink: the Sinks and Writers collected documents and files are written to.
+//   - watch: the Collector and Runner running collections continuously.
+//   - cocoghtest: a fake GitHub server and fixture recording for tests.
+//
+// The mocks package provides testify mocks of their interfaces, the webhook package turns push and pull
+// request webhooks into changed file paths and the cocogh command lists, diffs and syncs the repositories
+// of a YAML configuration.
+//
+// This package only keeps the names of the flat v1 API as aliases of the sub-packages, so code written
+// against it builds unchanged after updating the import path. Every alias is deprecated; new code imports
+// the sub-packages.
+package cocogh
@@ -0,0 +1,378 @@
+package cocogh
+
+import (
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// ChangeEvent is an alias of document.ChangeEvent.
+//
+// Deprecated: use document.ChangeEvent instead.
+type ChangeEvent = document.ChangeEvent
+
+// ChangeSet is an alias of document.ChangeSet.
+//
+// Deprecated: use document.ChangeSet instead.
+type ChangeSet = document.ChangeSet
+
+// Chunk is an alias of document.Chunk.
+//
+// Deprecated: use document.Chunk instead.
+type Chunk = document.Chunk
+
+// Chunker is an alias of document.Chunker.
+//
+// Deprecated: use document.Chunker instead.
+type Chunker = document.Chunker
+
+// Document is an alias of document.Document.
+//
+// Deprecated: use document.Document instead.
+type Document = document.Document
+
+// DocumentKind is an alias of document.DocumentKind.
+//
+// Deprecated: use document.DocumentKind instead.
+type DocumentKind = document.DocumentKind
+
+// File is an alias of document.File.
+//
+// Deprecated: use document.File instead.
+type File = document.File
+
+// FileChange is an alias of document.FileChange.
+//
+// Deprecated: use document.FileChange instead.
+type FileChange = document.FileChange
+
+// FileContent is an alias of document.FileContent.
+//
+// Deprecated: use document.FileContent instead.
+type FileContent = document.FileContent
+
+// FileMode is an alias of document.FileMode.
+//
+// Deprecated: use document.FileMode instead.
+type FileMode = document.FileMode
+
+// FrontMatter is an alias of document.FrontMatter.
+//
+// Deprecated: use document.FrontMatter instead.
+type FrontMatter = document.FrontMatter
+
+// PathNormalization is an alias of document.PathNormalization.
+//
+// Deprecated: use document.PathNormalization instead.
+type PathNormalization = document.PathNormalization
+
+// Paths is an alias of document.Paths.
+//
+// Deprecated: use document.Paths instead.
+type Paths = document.Paths
+
+// Rename is an alias of document.Rename.
+//
+// Deprecated: use document.Rename instead.
+type Rename = document.Rename
+
+// SecretAction is an alias of document.SecretAction.
+//
+// Deprecated: use document.SecretAction instead.
+type SecretAction = document.SecretAction
+
+// SecretFinding is an alias of document.SecretFinding.
+//
+// Deprecated: use document.SecretFinding instead.
+type SecretFinding = document.SecretFinding
+
+// SecretPattern is an alias of document.SecretPattern.
+//
+// Deprecated: use document.SecretPattern instead.
+type SecretPattern = document.SecretPattern
+
+// SecretScanner is an alias of document.SecretScanner.
+//
+// Deprecated: use document.SecretScanner instead.
+type SecretScanner = document.SecretScanner
+
+// Snapshot is an alias of document.Snapshot.
+//
+// Deprecated: use document.Snapshot instead.
+type Snapshot = document.Snapshot
+
+// Transformer is an alias of document.Transformer.
+//
+// Deprecated: use document.Transformer instead.
+type Transformer = document.Transformer
+
+// TransformerFunc is an alias of document.TransformerFunc.
+//
+// Deprecated: use document.TransformerFunc instead.
+type TransformerFunc = document.TransformerFunc
+
+// WindowsPathMapper is an alias of document.WindowsPathMapper.
+//
+// Deprecated: use document.WindowsPathMapper instead.
+type WindowsPathMapper = document.WindowsPathMapper
+
+// DefaultChunkMaxTokens is document.DefaultChunkMaxTokens.
+//
+// Deprecated: use document.DefaultChunkMaxTokens instead.
+const DefaultChunkMaxTokens = document.DefaultChunkMaxTokens
+
+// DefaultMaxPathLength is document.DefaultMaxPathLength.
+//
+// Deprecated: use document.DefaultMaxPathLength instead.
+const DefaultMaxPathLength = document.DefaultMaxPathLength
+
+// DocumentKindChangelog is document.DocumentKindChangelog.
+//
+// Deprecated: use document.DocumentKindChangelog instead.
+const DocumentKindChangelog = document.DocumentKindChangelog
+
+// DocumentKindChunk is document.DocumentKindChunk.
+//
+// Deprecated: use document.DocumentKindChunk instead.
+const DocumentKindChunk = document.DocumentKindChunk
+
+// DocumentKindCommit is document.DocumentKindCommit.
+//
+// Deprecated: use document.DocumentKindCommit instead.
+const DocumentKindCommit = document.DocumentKindCommit
+
+// DocumentKindCommunityHealth is document.DocumentKindCommunityHealth.
+//
+// Deprecated: use document.DocumentKindCommunityHealth instead.
+const DocumentKindCommunityHealth = document.DocumentKindCommunityHealth
+
+// DocumentKindDependabotAlert is document.DocumentKindDependabotAlert.
+//
+// Deprecated: use document.DocumentKindDependabotAlert instead.
+const DocumentKindDependabotAlert = document.DocumentKindDependabotAlert
+
+// DocumentKindDiscussion is document.DocumentKindDiscussion.
+//
+// Deprecated: use document.DocumentKindDiscussion instead.
+const DocumentKindDiscussion = document.DocumentKindDiscussion
+
+// DocumentKindDiscussionComment is document.DocumentKindDiscussionComment.
+//
+// Deprecated: use document.DocumentKindDiscussionComment instead.
+const DocumentKindDiscussionComment = document.DocumentKindDiscussionComment
+
+// DocumentKindFile is document.DocumentKindFile.
+//
+// Deprecated: use document.DocumentKindFile instead.
+const DocumentKindFile = document.DocumentKindFile
+
+// DocumentKindGistFile is document.DocumentKindGistFile.
+//
+// Deprecated: use document.DocumentKindGistFile instead.
+const DocumentKindGistFile = document.DocumentKindGistFile
+
+// DocumentKindIssue is document.DocumentKindIssue.
+//
+// Deprecated: use document.DocumentKindIssue instead.
+const DocumentKindIssue = document.DocumentKindIssue
+
+// DocumentKindIssueComment is document.DocumentKindIssueComment.
+//
+// Deprecated: use document.DocumentKindIssueComment instead.
+const DocumentKindIssueComment = document.DocumentKindIssueComment
+
+// DocumentKindProjectItem is document.DocumentKindProjectItem.
+//
+// Deprecated: use document.DocumentKindProjectItem instead.
+const DocumentKindProjectItem = document.DocumentKindProjectItem
+
+// DocumentKindRepositorySettings is document.DocumentKindRepositorySettings.
+//
+// Deprecated: use document.DocumentKindRepositorySettings instead.
+const DocumentKindRepositorySettings = document.DocumentKindRepositorySettings
+
+// DocumentKindReviewComment is document.DocumentKindReviewComment.
+//
+// Deprecated: use document.DocumentKindReviewComment instead.
+const DocumentKindReviewComment = document.DocumentKindReviewComment
+
+// DocumentKindSBOM is document.DocumentKindSBOM.
+//
+// Deprecated: use document.DocumentKindSBOM instead.
+const DocumentKindSBOM = document.DocumentKindSBOM
+
+// DocumentKindSecurityAdvisory is document.DocumentKindSecurityAdvisory.
+//
+// Deprecated: use document.DocumentKindSecurityAdvisory instead.
+const DocumentKindSecurityAdvisory = document.DocumentKindSecurityAdvisory
+
+// DocumentKindWorkflow is document.DocumentKindWorkflow.
+//
+// Deprecated: use document.DocumentKindWorkflow instead.
+const DocumentKindWorkflow = document.DocumentKindWorkflow
+
+// FileModeExecutable is document.FileModeExecutable.
+//
+// Deprecated: use document.FileModeExecutable instead.
+const FileModeExecutable = document.FileModeExecutable
+
+// FileModeRegular is document.FileModeRegular.
+//
+// Deprecated: use document.FileModeRegular instead.
+const FileModeRegular = document.FileModeRegular
+
+// FileModeSubmodule is document.FileModeSubmodule.
+//
+// Deprecated: use document.FileModeSubmodule instead.
+const FileModeSubmodule = document.FileModeSubmodule
+
+// FileModeSymlink is document.FileModeSymlink.
+//
+// Deprecated: use document.FileModeSymlink instead.
+const FileModeSymlink = document.FileModeSymlink
+
+// FileModeTree is document.FileModeTree.
+//
+// Deprecated: use document.FileModeTree instead.
+const FileModeTree = document.FileModeTree
+
+// NoNormalization is document.NoNormalization.
+//
+// Deprecated: use document.NoNormalization instead.
+const NoNormalization = document.NoNormalization
+
+// NormalizeNFC is document.NormalizeNFC.
+//
+// Deprecated: use document.NormalizeNFC instead.
+const NormalizeNFC = document.NormalizeNFC
+
+// NormalizeNFD is document.NormalizeNFD.
+//
+// Deprecated: use document.NormalizeNFD instead.
+const NormalizeNFD = document.NormalizeNFD
+
+// RedactedSecret is document.RedactedSecret.
+//
+// Deprecated: use document.RedactedSecret instead.
+const RedactedSecret = document.RedactedSecret
+
+// SecretDrop is document.SecretDrop.
+//
+// Deprecated: use document.SecretDrop instead.
+const SecretDrop = document.SecretDrop
+
+// SecretFlag is document.SecretFlag.
+//
+// Deprecated: use document.SecretFlag instead.
+const SecretFlag = document.SecretFlag
+
+// SecretRedact is document.SecretRedact.
+//
+// Deprecated: use document.SecretRedact instead.
+const SecretRedact = document.SecretRedact
+
+// DefaultSecretPatterns is document.DefaultSecretPatterns.
+//
+// Deprecated: use document.DefaultSecretPatterns instead.
+var DefaultSecretPatterns = document.DefaultSecretPatterns
+
+// ErrSkipDocument is document.ErrSkipDocument.
+//
+// Deprecated: use document.ErrSkipDocument instead.
+var ErrSkipDocument = document.ErrSkipDocument
+
+// ChainTransformers calls document.ChainTransformers.
+//
+// Deprecated: use document.ChainTransformers instead.
+func ChainTransformers(transformers ...document.Transformer) document.Transformer {
+	return document.ChainTransformers(transformers...)
+}
+
+// EscapePath calls document.EscapePath.
+//
+// Deprecated: use document.EscapePath instead.
+func EscapePath(p string) string {
+	return document.EscapePath(p)
+}
+
+// FrontMatterMetadata calls document.FrontMatterMetadata.
+//
+// Deprecated: use document.FrontMatterMetadata instead.
+func FrontMatterMetadata(filePath string, content []byte) map[string]string {
+	return document.FrontMatterMetadata(filePath, content)
+}
+
+// FrontMatterTransformer calls document.FrontMatterTransformer.
+//
+// Deprecated: use document.FrontMatterTransformer instead.
+func FrontMatterTransformer() document.Transformer {
+	return document.FrontMatterTransformer()
+}
+
+// NewChangeSet calls document.NewChangeSet.
+//
+// Deprecated: use document.NewChangeSet instead.
+func NewChangeSet(events []document.ChangeEvent) document.ChangeSet {
+	return document.NewChangeSet(events)
+}
+
+// NewWindowsPathMapper calls document.NewWindowsPathMapper.
+//
+// Deprecated: use document.NewWindowsPathMapper instead.
+func NewWindowsPathMapper(maxPathLength int) *document.WindowsPathMapper {
+	return document.NewWindowsPathMapper(maxPathLength)
+}
+
+// NormalizeLineEndings calls document.NormalizeLineEndings.
+//
+// Deprecated: use document.NormalizeLineEndings instead.
+func NormalizeLineEndings() document.Transformer {
+	return document.NormalizeLineEndings()
+}
+
+// NormalizePath calls document.NormalizePath.
+//
+// Deprecated: use document.NormalizePath instead.
+func NormalizePath(p string, form document.PathNormalization) string {
+	return document.NormalizePath(p, form)
+}
+
+// OriginalPath calls document.OriginalPath.
+//
+// Deprecated: use document.OriginalPath instead.
+func OriginalPath(events []document.ChangeEvent, filePath string) string {
+	return document.OriginalPath(events, filePath)
+}
+
+// ParseFrontMatter calls document.ParseFrontMatter.
+//
+// Deprecated: use document.ParseFrontMatter instead.
+func ParseFrontMatter(content []byte) (*document.FrontMatter, []byte, error) {
+	return document.ParseFrontMatter(content)
+}
+
+// ReconcileChanges calls document.ReconcileChanges.
+//
+// Deprecated: use document.ReconcileChanges instead.
+func ReconcileChanges(events []document.ChangeEvent, include func(filePath string) bool) document.Paths {
+	return document.ReconcileChanges(events, include)
+}
+
+// RedactSecrets calls document.RedactSecrets.
+//
+// Deprecated: use document.RedactSecrets instead.
+func RedactSecrets(patterns ...document.SecretPattern) document.Transformer {
+	return document.RedactSecrets(patterns...)
+}
+
+// StripHTML calls document.StripHTML.
+//
+// Deprecated: use document.StripHTML instead.
+func StripHTML() document.Transformer {
+	return document.StripHTML()
+}
+
+// UnescapePath calls document.UnescapePath.
+//
+// Deprecated: use document.UnescapePath instead.
+func UnescapePath(p string) (string, error) {
+	return document.UnescapePath(p)
+}
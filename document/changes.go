@@ -0,0 +1,235 @@
+package document
+
+import (
+	"time"
+)
+
+// ChangeEvent is the change of a single file by a single commit, as reported by GitHub.
+//
+// Status is the status GitHub reported: "added", "removed", "modified", "changed", "renamed" or "copied".
+// PreviousPath is set for renamed files. Author is the login of the commit author, or the name in the commit if
+// it is not linked to a GitHub account, Message the full commit message.
+type ChangeEvent struct {
+	Repository   string
+	CommitSHA    string
+	CommittedAt  time.Time
+	Author       string
+	Message      string
+	Path         string
+	PreviousPath string
+	Status       string
+}
+
+// Filter reports whether the change event is kept by include, the check of the files of a source. A file
+// renamed into or out of the included files is returned as added or removed.
+func (event ChangeEvent) Filter(include func(filePath string) bool) (ChangeEvent, bool) {
+	if event.Status == "renamed" {
+		from, to := include(event.PreviousPath), include(event.Path)
+		switch {
+		case from && !to:
+			event.Path, event.PreviousPath, event.Status = event.PreviousPath, "", "removed"
+		case !from && to:
+			event.PreviousPath, event.Status = "", "added"
+		}
+	}
+	return event, include(event.Path)
+}
+
+// ReconcileChanges collapses change events, ordered newest first, into the net change of every path passing
+// include, like the paths of GetChangedFilePathsSince. A file renamed into or out of the included paths is
+// only added or removed. A nil include keeps every path. It serves changes reported from elsewhere, such as
+// webhook deliveries.
+func ReconcileChanges(events []ChangeEvent, include func(filePath string) bool) Paths {
+	if include == nil {
+		return reconcileChanges(events)
+	}
+
+	var kept []ChangeEvent
+	for _, event := range events {
+		if event, ok := event.Filter(include); ok {
+			kept = append(kept, event)
+		}
+	}
+	return reconcileChanges(kept)
+}
+
+// ChangeSet is the alternative to Paths keeping the provenance of every change: the net change of every file,
+// classified like in Paths, together with the commit that changed the file last.
+type ChangeSet struct {
+	Added    []FileChange
+	Removed  []FileChange
+	Modified []FileChange
+}
+
+// FileChange is the net change of a file together with the last commit changing it. For a file removed by a
+// rename, that is the renaming commit.
+type FileChange struct {
+	Repository  string
+	Path        string
+	CommitSHA   string
+	Author      string
+	CommittedAt time.Time
+	Message     string
+}
+
+// Paths returns the paths of the change set, dropping the commit metadata.
+func (s ChangeSet) Paths() Paths {
+	paths := func(changes []FileChange) []string {
+		var result []string
+		for _, change := range changes {
+			result = append(result, change.Path)
+		}
+		return result
+	}
+	return Paths{Added: paths(s.Added), Removed: paths(s.Removed), Modified: paths(s.Modified)}
+}
+
+// NewChangeSet reconciles the change events of a repository, ordered newest first, like ReconcileChanges and
+// attributes every path to the most recent event changing it.
+func NewChangeSet(events []ChangeEvent) ChangeSet {
+	latest := make(map[string]ChangeEvent)
+	for _, event := range events {
+		for _, p := range []string{event.Path, event.PreviousPath} {
+			if _, ok := latest[p]; p != "" && !ok {
+				latest[p] = event
+			}
+		}
+	}
+
+	changes := func(paths []string) []FileChange {
+		var result []FileChange
+		for _, p := range paths {
+			event := latest[p]
+			result = append(result, FileChange{
+				Repository:  event.Repository,
+				Path:        p,
+				CommitSHA:   event.CommitSHA,
+				Author:      event.Author,
+				CommittedAt: event.CommittedAt,
+				Message:     event.Message,
+			})
+		}
+		return result
+	}
+
+	paths := reconcileChanges(events)
+	return ChangeSet{
+		Added:    changes(paths.Added),
+		Removed:  changes(paths.Removed),
+		Modified: changes(paths.Modified),
+	}
+}
+
+// Rename is a file moved from the path From to the path To.
+type Rename struct {
+	From string
+	To   string
+}
+
+// OriginalPath follows the renames among the change events of a repository, ordered newest first, back to the
+// path the file at filePath had before the first of them. It returns an empty path if the file was not renamed.
+func OriginalPath(events []ChangeEvent, filePath string) string {
+	return originalPath(renameSources(events), filePath)
+}
+
+// renameSources maps the paths of the renamed files among the change events, ordered newest first, to the
+// paths they were last renamed from.
+func renameSources(events []ChangeEvent) map[string]string {
+	renamedFrom := make(map[string]string)
+	for i := len(events) - 1; i >= 0; i-- {
+		if event := events[i]; event.Status == "renamed" && event.PreviousPath != "" {
+			renamedFrom[event.Path] = event.PreviousPath
+		}
+	}
+	return renamedFrom
+}
+
+// originalPath follows the renames of a file back to the path it had before the first of them, empty if it
+// was not renamed.
+func originalPath(renamedFrom map[string]string, filePath string) string {
+	var original string
+	seen := map[string]bool{filePath: true}
+	for previous, ok := renamedFrom[filePath]; ok && !seen[previous]; previous, ok = renamedFrom[previous] {
+		seen[previous] = true
+		original = previous
+	}
+	return original
+}
+
+// netChange tracks whether a path existed before the first and after the last of its change events.
+type netChange struct {
+	existedBefore bool
+	existsAfter   bool
+}
+
+// reconcileChanges collapses the change events of a repository, ordered newest first, into the net change of
+// every path: a file that existed before and after the events is modified, one that only exists after them
+// added and one that only existed before them removed. Files added and removed again are dropped. Renames
+// remove the previous path and add the new one, and are paired in Renamed if the first path is removed and
+// the last one added. The paths are ordered by their most recent change.
+func reconcileChanges(events []ChangeEvent) Paths {
+	var order []string
+	seen := make(map[string]bool)
+	for _, event := range events {
+		for _, p := range []string{event.Path, event.PreviousPath} {
+			if p != "" && !seen[p] {
+				seen[p] = true
+				order = append(order, p)
+			}
+		}
+	}
+
+	changes := make(map[string]*netChange)
+	apply := func(p string, existedBefore, existsAfter bool) {
+		change, ok := changes[p]
+		if !ok {
+			change = &netChange{existedBefore: existedBefore}
+			changes[p] = change
+		}
+		change.existsAfter = existsAfter
+	}
+
+	for i := len(events) - 1; i >= 0; i-- {
+		event := events[i]
+		switch event.Status {
+		case "added", "copied":
+			apply(event.Path, false, true)
+		case "removed":
+			apply(event.Path, true, false)
+		case "modified", "changed":
+			apply(event.Path, true, true)
+		case "renamed":
+			apply(event.PreviousPath, true, false)
+			apply(event.Path, false, true)
+		}
+	}
+
+	var paths Paths
+	for _, p := range order {
+		change, ok := changes[p]
+		if !ok {
+			continue
+		}
+		switch {
+		case change.existedBefore && change.existsAfter:
+			paths.Modified = append(paths.Modified, p)
+		case change.existsAfter:
+			paths.Added = append(paths.Added, p)
+		case change.existedBefore:
+			paths.Removed = append(paths.Removed, p)
+		}
+	}
+
+	removed := make(map[string]bool)
+	for _, p := range paths.Removed {
+		removed[p] = true
+	}
+	renamedFrom := renameSources(events)
+	for _, p := range paths.Added {
+		if from := originalPath(renamedFrom, p); from != "" && removed[from] {
+			paths.Renamed = append(paths.Renamed, Rename{From: from, To: p})
+		}
+	}
+
+	return paths
+}
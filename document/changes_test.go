@@ -0,0 +1,149 @@
+package document
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcileChanges(t *testing.T) {
+	// Events are ordered newest first, as the commits are listed.
+	tests := []struct {
+		name   string
+		events []ChangeEvent
+		want   Paths
+	}{
+		{
+			name: "added, modified and removed again is dropped",
+			events: []ChangeEvent{
+				{Path: "a.md", Status: "removed"},
+				{Path: "a.md", Status: "modified"},
+				{Path: "a.md", Status: "added"},
+			},
+			want: Paths{},
+		},
+		{
+			name: "added and modified is added",
+			events: []ChangeEvent{
+				{Path: "a.md", Status: "modified"},
+				{Path: "a.md", Status: "added"},
+			},
+			want: Paths{Added: []string{"a.md"}},
+		},
+		{
+			name: "modified and removed is removed",
+			events: []ChangeEvent{
+				{Path: "a.md", Status: "removed"},
+				{Path: "a.md", Status: "changed"},
+			},
+			want: Paths{Removed: []string{"a.md"}},
+		},
+		{
+			name: "removed and added again is modified",
+			events: []ChangeEvent{
+				{Path: "a.md", Status: "added"},
+				{Path: "a.md", Status: "removed"},
+			},
+			want: Paths{Modified: []string{"a.md"}},
+		},
+		{
+			name: "modified repeatedly is modified once",
+			events: []ChangeEvent{
+				{Path: "a.md", Status: "modified"},
+				{Path: "a.md", Status: "modified"},
+			},
+			want: Paths{Modified: []string{"a.md"}},
+		},
+		{
+			name: "added and renamed is added under the new name",
+			events: []ChangeEvent{
+				{Path: "b.md", PreviousPath: "a.md", Status: "renamed"},
+				{Path: "a.md", Status: "added"},
+			},
+			want: Paths{Added: []string{"b.md"}},
+		},
+		{
+			name: "renamed existing file removes the previous path",
+			events: []ChangeEvent{
+				{Path: "b.md", Status: "modified"},
+				{Path: "b.md", PreviousPath: "a.md", Status: "renamed"},
+			},
+			want: Paths{Added: []string{"b.md"}, Removed: []string{"a.md"}, Renamed: []Rename{{From: "a.md", To: "b.md"}}},
+		},
+		{
+			name: "renamed twice pairs the first and last path",
+			events: []ChangeEvent{
+				{Path: "c.md", PreviousPath: "b.md", Status: "renamed"},
+				{Path: "b.md", PreviousPath: "a.md", Status: "renamed"},
+			},
+			want: Paths{Added: []string{"c.md"}, Removed: []string{"a.md"}, Renamed: []Rename{{From: "a.md", To: "c.md"}}},
+		},
+		{
+			name: "renamed back is modified",
+			events: []ChangeEvent{
+				{Path: "a.md", PreviousPath: "b.md", Status: "renamed"},
+				{Path: "b.md", PreviousPath: "a.md", Status: "renamed"},
+			},
+			want: Paths{Modified: []string{"a.md"}},
+		},
+		{
+			name: "paths are ordered by their most recent change",
+			events: []ChangeEvent{
+				{Path: "b.md", Status: "modified"},
+				{Path: "a.md", Status: "modified"},
+				{Path: "b.md", Status: "modified"},
+			},
+			want: Paths{Modified: []string{"b.md", "a.md"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ReconcileChanges(tt.events, nil))
+		})
+	}
+}
+
+func TestReconcileChanges_Include(t *testing.T) {
+	events := []ChangeEvent{
+		{Path: "archive/faq.md", PreviousPath: "docs/faq.md", Status: "renamed"},
+		{Path: "docs/setup.md", PreviousPath: "docs-old/setup.md", Status: "renamed"},
+		{Path: "docs/guide.md", PreviousPath: "docs/intro.md", Status: "renamed"},
+		{Path: "docs-old/index.md", Status: "modified"},
+		{Path: "docs/faq.md", Status: "modified"},
+	}
+
+	inDocs := func(filePath string) bool { return strings.HasPrefix(filePath, "docs/") }
+	assert.Equal(t, Paths{
+		Added:   []string{"docs/setup.md", "docs/guide.md"},
+		Removed: []string{"docs/faq.md", "docs/intro.md"},
+		Renamed: []Rename{{From: "docs/intro.md", To: "docs/guide.md"}},
+	}, ReconcileChanges(events, inDocs))
+}
+
+func TestChangeSet(t *testing.T) {
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+
+	// Events are ordered newest first, as the commits are listed.
+	changes := NewChangeSet([]ChangeEvent{
+		{Repository: "repo1", CommitSHA: "b", CommittedAt: second, Author: "bob", Message: "Rename guide", Path: "guide.md", PreviousPath: "intro.md", Status: "renamed"},
+		{Repository: "repo1", CommitSHA: "b", CommittedAt: second, Author: "bob", Message: "Rename guide", Path: "index.md", Status: "modified"},
+		{Repository: "repo1", CommitSHA: "a", CommittedAt: first, Author: "alice", Message: "Update index", Path: "index.md", Status: "modified"},
+	})
+
+	assert.Equal(t, ChangeSet{
+		Added: []FileChange{
+			{Repository: "repo1", Path: "guide.md", CommitSHA: "b", Author: "bob", CommittedAt: second, Message: "Rename guide"},
+		},
+		Removed: []FileChange{
+			{Repository: "repo1", Path: "intro.md", CommitSHA: "b", Author: "bob", CommittedAt: second, Message: "Rename guide"},
+		},
+		Modified: []FileChange{
+			{Repository: "repo1", Path: "index.md", CommitSHA: "b", Author: "bob", CommittedAt: second, Message: "Rename guide"},
+		},
+	}, changes)
+	assert.Equal(t, Paths{Added: []string{"guide.md"}, Removed: []string{"intro.md"}, Modified: []string{"index.md"}}, changes.Paths())
+}
@@ -0,0 +1,278 @@
+package document
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// DocumentKindChunk is the kind of the documents ChunkDocuments emits for the chunks of collected documents.
+const DocumentKindChunk DocumentKind = "chunk"
+
+// DefaultChunkMaxTokens is the size of the chunks of a Chunker without any configured limit.
+const DefaultChunkMaxTokens = 512
+
+// Chunk is a part of the body of a document, sized for embedding into a vector store.
+//
+// ID is stable across runs: it is the ID of the document followed by "#" and the index of the chunk, so
+// re-chunking a document replaces its chunks in place. Hash is the SHA-256 of the text, to skip embedding
+// unchanged chunks. StartLine and EndLine are the 1-based lines of the body the chunk spans. Heading is the
+// path of the Markdown headings the chunk is below, joined by " > ".
+type Chunk struct {
+	ID         string
+	DocumentID string
+	Index      int
+	Repository string
+	Path       string
+	Heading    string
+	StartLine  int
+	EndLine    int
+	Text       string
+	Hash       string
+}
+
+// Chunker splits the bodies of documents into chunks of at most MaxTokens tokens and MaxLines lines; zero
+// disables a limit, and DefaultChunkMaxTokens applies if both are zero. Chunks end at line boundaries
+// unless a single line exceeds MaxTokens. OverlapLines repeats the last lines of a chunk at the start of the
+// next one, so context is not lost at the boundary.
+//
+// Markdown files, .md, .markdown and .mdx, are first split into sections at their headings, ignoring
+// headings in fenced code blocks, and no chunk spans two sections.
+//
+// Tokens counts the tokens of a text, e.g. with the tokenizer of the embedding model; nil counts
+// whitespace-separated words.
+type Chunker struct {
+	MaxTokens    int
+	MaxLines     int
+	OverlapLines int
+	Tokens       func(text string) int
+}
+
+// chunkSection is a run of lines below the same headings.
+type chunkSection struct {
+	heading string
+	start   int
+	lines   []string
+}
+
+// Split splits the body of the document into chunks. Blank chunks are dropped.
+func (c Chunker) Split(doc Document) []Chunk {
+	lines := strings.Split(strings.ReplaceAll(doc.Body, "\r\n", "\n"), "\n")
+
+	var sections []chunkSection
+	if isMarkdownPath(doc.Path) {
+		sections = markdownSections(lines)
+	} else {
+		sections = []chunkSection{{lines: lines}}
+	}
+
+	var chunks []Chunk
+	emit := func(section chunkSection, start, end int, text string) {
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		hash := sha256.Sum256([]byte(text))
+		chunks = append(chunks, Chunk{
+			ID:         fmt.Sprintf("%s#%d", doc.ID, len(chunks)),
+			DocumentID: doc.ID,
+			Index:      len(chunks),
+			Repository: doc.Repository,
+			Path:       doc.Path,
+			Heading:    section.heading,
+			StartLine:  section.start + start + 1,
+			EndLine:    section.start + end,
+			Text:       text,
+			Hash:       hex.EncodeToString(hash[:]),
+		})
+	}
+
+	for _, section := range sections {
+		c.splitSection(section, emit)
+	}
+	return chunks
+}
+
+// splitSection cuts the lines of a section into chunks, calling emit with the half-open range of lines of
+// every chunk.
+func (c Chunker) splitSection(section chunkSection, emit func(section chunkSection, start, end int, text string)) {
+	maxTokens, maxLines := c.MaxTokens, c.MaxLines
+	if maxTokens <= 0 && maxLines <= 0 {
+		maxTokens = DefaultChunkMaxTokens
+	}
+	fits := func(start, end int) bool {
+		return (maxLines <= 0 || end-start <= maxLines) &&
+			(maxTokens <= 0 || c.tokens(strings.Join(section.lines[start:end], "\n")) <= maxTokens)
+	}
+
+	lines := section.lines
+	start := 0
+	for start < len(lines) {
+		if !fits(start, start+1) {
+			// A single line over the limit is cut into pieces of words.
+			for _, piece := range c.splitLine(lines[start], maxTokens) {
+				emit(section, start, start+1, piece)
+			}
+			start++
+			continue
+		}
+
+		end := start + 1
+		for end < len(lines) && fits(start, end+1) {
+			end++
+		}
+		emit(section, start, end, strings.Join(lines[start:end], "\n"))
+		if end >= len(lines) {
+			break
+		}
+
+		// Overlap as many lines as fit together with the next one.
+		next := end - c.OverlapLines
+		if next <= start {
+			next = start + 1
+		}
+		for next < end && !fits(next, end+1) {
+			next++
+		}
+		start = next
+	}
+}
+
+// splitLine cuts an overlong line into pieces of at most maxTokens tokens at word boundaries.
+func (c Chunker) splitLine(line string, maxTokens int) []string {
+	var pieces []string
+	var piece []string
+	for _, word := range strings.Fields(line) {
+		if len(piece) > 0 && c.tokens(strings.Join(append(piece, word), " ")) > maxTokens {
+			pieces = append(pieces, strings.Join(piece, " "))
+			piece = nil
+		}
+		piece = append(piece, word)
+	}
+	if len(piece) > 0 {
+		pieces = append(pieces, strings.Join(piece, " "))
+	}
+	return pieces
+}
+
+// tokens counts the tokens of the text with the configured function.
+func (c Chunker) tokens(text string) int {
+	if c.Tokens != nil {
+		return c.Tokens(text)
+	}
+	return len(strings.Fields(text))
+}
+
+// markdownSections splits Markdown lines into sections starting at ATX headings outside fenced code blocks.
+func markdownSections(lines []string) []chunkSection {
+	var sections []chunkSection
+	var headings []string
+	current := chunkSection{}
+	fence := ""
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			current.lines = append(current.lines, line)
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			current.lines = append(current.lines, line)
+			continue
+		}
+
+		level, title := markdownHeading(line)
+		if level == 0 {
+			current.lines = append(current.lines, line)
+			continue
+		}
+
+		if len(current.lines) > 0 {
+			sections = append(sections, current)
+		}
+		if level > len(headings) {
+			for len(headings) < level-1 {
+				headings = append(headings, "")
+			}
+			headings = append(headings, title)
+		} else {
+			headings = append(headings[:level-1], title)
+		}
+		current = chunkSection{heading: joinHeadings(headings), start: i, lines: []string{line}}
+	}
+	if len(current.lines) > 0 {
+		sections = append(sections, current)
+	}
+	return sections
+}
+
+// markdownHeading returns the level and title of an ATX heading line, or level 0 for other lines.
+func markdownHeading(line string) (int, string) {
+	if strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t") {
+		return 0, ""
+	}
+	trimmed := strings.TrimLeft(line, " ")
+	level := 0
+	for level < len(trimmed) && trimmed[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || level < len(trimmed) && trimmed[level] != ' ' && trimmed[level] != '\t' {
+		return 0, ""
+	}
+	title := strings.TrimSpace(trimmed[level:])
+	title = strings.TrimSpace(strings.TrimRight(title, "#"))
+	return level, title
+}
+
+// joinHeadings joins the non-empty headings of a heading path.
+func joinHeadings(headings []string) string {
+	var parts []string
+	for _, heading := range headings {
+		if heading != "" {
+			parts = append(parts, heading)
+		}
+	}
+	return strings.Join(parts, " > ")
+}
+
+// isMarkdownPath reports whether the file is a Markdown file.
+func isMarkdownPath(filePath string) bool {
+	switch strings.ToLower(path.Ext(filePath)) {
+	case ".md", ".markdown", ".mdx":
+		return true
+	}
+	return false
+}
+
+// Document returns the chunk as a document of kind DocumentKindChunk, carrying the fields of the document
+// it was cut from. Its metadata holds the "document_id", "chunk_index", "start_line", "end_line", "hash"
+// and, if any, "heading" of the chunk in addition to the metadata of the document.
+func (c Chunk) Document(parent Document) Document {
+	metadata := make(map[string]string, len(parent.Metadata)+6)
+	for key, value := range parent.Metadata {
+		metadata[key] = value
+	}
+	metadata["document_id"] = c.DocumentID
+	metadata["chunk_index"] = fmt.Sprint(c.Index)
+	metadata["start_line"] = fmt.Sprint(c.StartLine)
+	metadata["end_line"] = fmt.Sprint(c.EndLine)
+	metadata["hash"] = c.Hash
+	if c.Heading != "" {
+		metadata["heading"] = c.Heading
+	}
+
+	doc := parent
+	doc.ID = c.ID
+	doc.Kind = DocumentKindChunk
+	doc.Body = c.Text
+	doc.Metadata = metadata
+	if c.Heading != "" {
+		doc.Title = parent.Title + " > " + c.Heading
+	}
+	return doc
+}
@@ -0,0 +1,83 @@
+package document
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunker_Split(t *testing.T) {
+	doc := Document{ID: "repo1/main.go", Repository: "repo1", Path: "main.go", Body: "a b\nc d\ne f\n\ng h"}
+
+	chunks := Chunker{MaxTokens: 4}.Split(doc)
+	require.Len(t, chunks, 2)
+	assert.Equal(t, Chunk{
+		ID: "repo1/main.go#0", DocumentID: "repo1/main.go", Index: 0, Repository: "repo1", Path: "main.go",
+		StartLine: 1, EndLine: 2, Text: "a b\nc d", Hash: chunks[0].Hash,
+	}, chunks[0])
+	assert.Len(t, chunks[0].Hash, 64)
+	assert.Equal(t, "e f\n\ng h", chunks[1].Text)
+	assert.Equal(t, []int{3, 5}, []int{chunks[1].StartLine, chunks[1].EndLine})
+
+	texts := func(chunks []Chunk) []string {
+		var result []string
+		for _, chunk := range chunks {
+			result = append(result, chunk.Text)
+		}
+		return result
+	}
+	assert.Equal(t, []string{"a b\nc d", "c d\ne f", "e f\n", "\ng h"}, texts(Chunker{MaxLines: 2, OverlapLines: 1}.Split(Document{Body: "a b\nc d\ne f\n\ng h"})))
+
+	// Overlapping lines are dropped when they would exceed the limit together with the next line.
+	assert.Equal(t, []string{"a b\nc d", "c d\ne f", "g h i"}, texts(Chunker{MaxTokens: 4, OverlapLines: 1}.Split(Document{Body: "a b\nc d\ne f\ng h i"})))
+
+	// Lines over the limit are cut at word boundaries.
+	long := Chunker{MaxTokens: 2}.Split(Document{Body: "one\ntwo three four five six\nseven"})
+	assert.Equal(t, []string{"one", "two three", "four five", "six", "seven"}, texts(long))
+	assert.Equal(t, []int{2, 2, 2}, []int{long[1].StartLine, long[2].EndLine, long[3].StartLine})
+
+	// A custom tokenizer counting characters.
+	assert.Equal(t, []string{"abc", "de"}, texts(Chunker{MaxTokens: 4, Tokens: func(text string) int { return len(text) }}.Split(Document{Body: "abc\nde"})))
+
+	// Without limits, the default limit applies.
+	assert.Len(t, Chunker{}.Split(Document{Body: strings.Repeat("word ", DefaultChunkMaxTokens+1)}), 2)
+}
+
+func TestChunker_Split_Markdown(t *testing.T) {
+	body := `Intro text.
+
+# Guide
+
+Read this.
+
+## Install
+
+` + "```sh\n# not a heading\ngo install\n```" + `
+
+### Linux #
+
+apt install
+
+## Usage
+Run it.`
+
+	chunks := Chunker{MaxTokens: 100}.Split(Document{ID: "repo1/docs/guide.md", Path: "docs/guide.md", Body: body})
+
+	var got [][3]interface{}
+	for _, chunk := range chunks {
+		got = append(got, [3]interface{}{chunk.Heading, chunk.StartLine, chunk.EndLine})
+	}
+	assert.Equal(t, [][3]interface{}{
+		{"", 1, 2},
+		{"Guide", 3, 6},
+		{"Guide > Install", 7, 13},
+		{"Guide > Install > Linux", 14, 17},
+		{"Guide > Usage", 18, 19},
+	}, got)
+	assert.Equal(t, "## Install\n\n```sh\n# not a heading\ngo install\n```\n", chunks[2].Text)
+
+	// Other files are not split at lines looking like headings.
+	assert.Len(t, Chunker{MaxTokens: 100}.Split(Document{Path: "main.py", Body: body}), 1)
+}
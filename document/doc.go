@@ -0,0 +1,15 @@
+// Package document holds the values coco-gh collects and the transformations applied to them.
+//
+// A Document carries the content and provenance of a collected item, a File the SHA, size, mode, URL and
+// optionally last modification time of a repository file, and FileContent its content, with SymlinkTarget
+// flagging symlinks. Paths and ChangeSet describe the files changed between two points in time;
+// Paths.Renamed pairs the old and new paths of renamed files. A Snapshot records the blob SHAs of a
+// repository to diff against the next run. PathNormalization, EscapePath and WindowsPathMapper adapt paths
+// to the stores they end up in.
+//
+// Transformers change or drop documents: ChainTransformers runs them in order, NormalizeLineEndings,
+// StripHTML and RedactSecrets clean up bodies, FrontMatterTransformer moves the YAML FrontMatter of
+// Markdown documents into their metadata and a SecretScanner redacts, flags or drops documents containing
+// secrets. Transformers drop documents by returning ErrSkipDocument. A Chunker cuts documents into Chunks
+// by tokens or lines and at Markdown headings.
+package document
@@ -0,0 +1,66 @@
+package document
+
+import (
+	"time"
+)
+
+// DocumentKind identifies what kind of GitHub content a Document was collected from.
+type DocumentKind string
+
+// The kinds of documents the client collects.
+const (
+	DocumentKindReviewComment      DocumentKind = "pull_request_review_comment"
+	DocumentKindIssue              DocumentKind = "issue"
+	DocumentKindIssueComment       DocumentKind = "issue_comment"
+	DocumentKindDiscussion         DocumentKind = "discussion"
+	DocumentKindDiscussionComment  DocumentKind = "discussion_comment"
+	DocumentKindCommit             DocumentKind = "commit"
+	DocumentKindChangelog          DocumentKind = "changelog"
+	DocumentKindProjectItem        DocumentKind = "project_item"
+	DocumentKindSecurityAdvisory   DocumentKind = "security_advisory"
+	DocumentKindDependabotAlert    DocumentKind = "dependabot_alert"
+	DocumentKindSBOM               DocumentKind = "sbom"
+	DocumentKindWorkflow           DocumentKind = "workflow"
+	DocumentKindCommunityHealth    DocumentKind = "community_health"
+	DocumentKindRepositorySettings DocumentKind = "repository_settings"
+	DocumentKindFile               DocumentKind = "file"
+	DocumentKindGistFile           DocumentKind = "gist_file"
+)
+
+// Document is a piece of collected content, such as a pull request review comment, together with its
+// provenance, ready to be indexed alongside repository files.
+//
+// ID is stable across runs and unique within the owner. Path is the repository file the document is
+// about, if any. Metadata holds kind specific attributes as plain strings.
+type Document struct {
+	ID         string
+	Kind       DocumentKind
+	Owner      string
+	Repository string
+	Path       string
+	Title      string
+	Body       string
+	URL        string
+	Author     string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Metadata   map[string]string
+}
+
+// Paths represents a collection of file paths that have been added, removed, or modified.
+//
+// Paths collected from the commit history hold the net change of every file, so a file added and modified
+// within the range is only added. Events holds the per-commit changes the paths were reconciled from, in the
+// order they happened, if GitHubConfig.KeepChangeEvents is set.
+//
+// Renamed pairs the old and new paths of renamed files, following renames of renamed files back to the first
+// path, so consumers can move their documents instead of deleting and recreating them. Both paths are also
+// listed in Removed and Added for consumers not tracking renames. A file renamed into or out of the filtered
+// paths is only added or removed.
+type Paths struct {
+	Added    []string
+	Removed  []string
+	Modified []string
+	Renamed  []Rename      `json:",omitempty"`
+	Events   []ChangeEvent `json:",omitempty"`
+}
@@ -0,0 +1,42 @@
+package document
+
+import (
+	"time"
+)
+
+// File is a file of a repository together with the attributes collectors commonly need, so they do not have
+// to look them up with further API calls.
+//
+// Repository is the repository as configured, Branch the branch, or the RepositoryRef.Ref, the file was read
+// from. SHA is the blob SHA and Size the size of the file in bytes. URL points to the file on GitHub.
+// LastModifiedAt is the time of the last commit changing the file; it is only set if
+// FileOptions.LastModified is requested. Metadata holds the fields of the front matter of Markdown files
+// read with their content if GitHubConfig.FrontMatter is set, see FrontMatter.Metadata.
+type File struct {
+	Path           string
+	SHA            string
+	Size           int
+	Mode           FileMode
+	Repository     string
+	Branch         string
+	LastModifiedAt time.Time
+	URL            string
+	Metadata       map[string]string
+}
+
+// FileContent is a file of a repository together with its content.
+//
+// Text is empty for binary files, IsBinary tells them apart from empty text files. ByteSize is the size of
+// the file in bytes. SymlinkTarget is the path of the file a symlink points at, relative to the root of the
+// repository, and empty for other files and for symlinks pointing outside the repository. The Text of
+// symlinks is their target as stored in git, unless GitHubConfig.ResolveSymlinks reads the content of
+// SymlinkTarget instead.
+type FileContent struct {
+	Repository    string
+	Path          string
+	Oid           string
+	Text          string
+	IsBinary      bool
+	ByteSize      int
+	SymlinkTarget string
+}
@@ -0,0 +1,62 @@
+package document
+
+import (
+	"os"
+	"strconv"
+)
+
+// FileMode is the git file mode of a tree entry, as reported by the GitHub API (e.g. 0100644).
+type FileMode int
+
+// The file modes git records for tree entries.
+const (
+	FileModeTree       FileMode = 0040000
+	FileModeRegular    FileMode = 0100644
+	FileModeExecutable FileMode = 0100755
+	FileModeSymlink    FileMode = 0120000
+	FileModeSubmodule  FileMode = 0160000
+)
+
+// IsRegular reports whether the mode describes a regular, non-executable file.
+func (m FileMode) IsRegular() bool {
+	return m == FileModeRegular
+}
+
+// IsExecutable reports whether the mode describes a file with the executable bit set.
+func (m FileMode) IsExecutable() bool {
+	return m == FileModeExecutable
+}
+
+// IsSymlink reports whether the mode describes a symbolic link.
+func (m FileMode) IsSymlink() bool {
+	return m == FileModeSymlink
+}
+
+// IsSubmodule reports whether the mode describes a submodule (gitlink).
+func (m FileMode) IsSubmodule() bool {
+	return m == FileModeSubmodule
+}
+
+// IsDir reports whether the mode describes a directory.
+func (m FileMode) IsDir() bool {
+	return m == FileModeTree
+}
+
+// Perm returns the permission bits to use when mirroring the entry to disk.
+func (m FileMode) Perm() os.FileMode {
+	switch m {
+	case FileModeExecutable:
+		return 0o755
+	case FileModeTree:
+		return 0o755 | os.ModeDir
+	case FileModeSymlink:
+		return 0o777 | os.ModeSymlink
+	default:
+		return 0o644
+	}
+}
+
+// String returns the mode as the octal string git uses, e.g. "100644".
+func (m FileMode) String() string {
+	return strconv.FormatInt(int64(m), 8)
+}
@@ -0,0 +1,40 @@
+package document
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileMode(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       FileMode
+		regular    bool
+		executable bool
+		symlink    bool
+		submodule  bool
+		dir        bool
+		perm       os.FileMode
+		str        string
+	}{
+		{name: "regular", mode: FileModeRegular, regular: true, perm: 0o644, str: "100644"},
+		{name: "executable", mode: FileModeExecutable, executable: true, perm: 0o755, str: "100755"},
+		{name: "symlink", mode: FileModeSymlink, symlink: true, perm: 0o777 | os.ModeSymlink, str: "120000"},
+		{name: "submodule", mode: FileModeSubmodule, submodule: true, perm: 0o644, str: "160000"},
+		{name: "tree", mode: FileModeTree, dir: true, perm: 0o755 | os.ModeDir, str: "40000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.regular, tt.mode.IsRegular())
+			assert.Equal(t, tt.executable, tt.mode.IsExecutable())
+			assert.Equal(t, tt.symlink, tt.mode.IsSymlink())
+			assert.Equal(t, tt.submodule, tt.mode.IsSubmodule())
+			assert.Equal(t, tt.dir, tt.mode.IsDir())
+			assert.Equal(t, tt.perm, tt.mode.Perm())
+			assert.Equal(t, tt.str, tt.mode.String())
+		})
+	}
+}
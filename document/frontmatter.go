@@ -0,0 +1,182 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FrontMatter is the YAML front matter of a Markdown file: the block between a "---" line at the very
+// start of the file and the next "---" or "..." line.
+//
+// Title, Tags and Date are read from the fields of the same name. Tags may be a list or a comma-separated
+// string, Date a YAML timestamp or a string in RFC 3339 or "2006-01-02" format. Fields holds all fields.
+type FrontMatter struct {
+	Title  string
+	Tags   []string
+	Date   time.Time
+	Fields map[string]interface{}
+}
+
+// frontMatterDateLayouts are the formats of dates given as strings.
+var frontMatterDateLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+// ParseFrontMatter splits the front matter off the content of a Markdown file, returning it together with
+// the rest of the content. Content without front matter yields nil and the content unchanged.
+func ParseFrontMatter(content []byte) (*FrontMatter, []byte, error) {
+	firstLine, rest, ok := bytes.Cut(bytes.TrimPrefix(content, []byte("\ufeff")), []byte("\n"))
+	if !ok || string(bytes.TrimRight(firstLine, " \r")) != "---" {
+		return nil, content, nil
+	}
+
+	var block []byte
+	for {
+		line, next, more := bytes.Cut(rest, []byte("\n"))
+		if marker := string(bytes.TrimRight(line, " \r")); marker == "---" || marker == "..." {
+			rest = next
+			break
+		}
+		if !more {
+			// Without a closing line, the content has no front matter.
+			return nil, content, nil
+		}
+		block = append(append(block, line...), '\n')
+		rest = next
+	}
+
+	fields := make(map[string]interface{})
+	if err := yaml.Unmarshal(block, &fields); err != nil {
+		return nil, content, fmt.Errorf("invalid front matter: %w", err)
+	}
+
+	frontMatter := &FrontMatter{Fields: fields}
+	if title, ok := fields["title"]; ok && title != nil {
+		frontMatter.Title = strings.TrimSpace(fmt.Sprint(title))
+	}
+	frontMatter.Tags = frontMatterList(fields["tags"])
+	frontMatter.Date = frontMatterDate(fields["date"])
+	return frontMatter, rest, nil
+}
+
+// Metadata returns the front matter as document metadata: every field with a scalar value or a list of
+// scalars, lists joined by commas. Tags are trimmed and the date is formatted in RFC 3339.
+func (f *FrontMatter) Metadata() map[string]string {
+	metadata := make(map[string]string)
+	for key, value := range f.Fields {
+		switch value := value.(type) {
+		case nil, map[string]interface{}:
+		case []interface{}:
+			if list := frontMatterList(value); len(list) == len(value) {
+				metadata[key] = strings.Join(list, ",")
+			}
+		case time.Time:
+			metadata[key] = value.UTC().Format(time.RFC3339)
+		default:
+			metadata[key] = fmt.Sprint(value)
+		}
+	}
+
+	if f.Title != "" {
+		metadata["title"] = f.Title
+	}
+	if len(f.Tags) > 0 {
+		metadata["tags"] = strings.Join(f.Tags, ",")
+	}
+	if !f.Date.IsZero() {
+		metadata["date"] = f.Date.UTC().Format(time.RFC3339)
+	}
+	return metadata
+}
+
+// frontMatterList returns the scalars of a list, or the comma-separated values of a string.
+func frontMatterList(value interface{}) []string {
+	var items []string
+	switch value := value.(type) {
+	case string:
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			switch item.(type) {
+			case nil, map[string]interface{}, []interface{}:
+				continue
+			}
+			if text := strings.TrimSpace(fmt.Sprint(item)); text != "" {
+				items = append(items, text)
+			}
+		}
+	}
+	return items
+}
+
+// frontMatterDate returns the date of a timestamp or a string in one of the supported formats.
+func frontMatterDate(value interface{}) time.Time {
+	switch value := value.(type) {
+	case time.Time:
+		return value
+	case string:
+		for _, layout := range frontMatterDateLayouts {
+			if date, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+				return date
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// FrontMatterMetadata returns the metadata of the front matter of a Markdown file, see FrontMatter.Metadata.
+// Other files, files without front matter and files with invalid front matter have none.
+func FrontMatterMetadata(filePath string, content []byte) map[string]string {
+	if !isMarkdownPath(filePath) {
+		return nil
+	}
+	frontMatter, _, err := ParseFrontMatter(content)
+	if err != nil || frontMatter == nil {
+		return nil
+	}
+	return frontMatter.Metadata()
+}
+
+// FrontMatterTransformer returns a Transformer moving the front matter of Markdown documents, .md, .markdown
+// and .mdx files, from their body into their metadata, see FrontMatter.Metadata. The title of the front
+// matter replaces the title of the document. Metadata the document already has is kept. Documents with
+// invalid front matter are left unchanged.
+//
+// Usage:
+//
+//	collector := &watch.Collector{
+//	    Name:         "docs",
+//	    Source:       source.SourceDocuments(gh),
+//	    Transformers: []Transformer{FrontMatterTransformer()},
+//	    Sink:         sink,
+//	}
+func FrontMatterTransformer() Transformer {
+	return TransformerFunc(func(_ context.Context, doc Document) (Document, error) {
+		if !isMarkdownPath(doc.Path) {
+			return doc, nil
+		}
+		frontMatter, body, err := ParseFrontMatter([]byte(doc.Body))
+		if err != nil || frontMatter == nil {
+			return doc, nil
+		}
+
+		metadata := frontMatter.Metadata()
+		for key, value := range doc.Metadata {
+			metadata[key] = value
+		}
+
+		doc.Body = string(body)
+		doc.Metadata = metadata
+		if frontMatter.Title != "" {
+			doc.Title = frontMatter.Title
+		}
+		return doc, nil
+	})
+}
@@ -0,0 +1,85 @@
+package document
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFrontMatter(t *testing.T) {
+	frontMatter, body, err := ParseFrontMatter([]byte(`---
+title: Getting started
+tags: [guide, " setup "]
+date: 2024-03-01
+draft: false
+weight: 10
+authors:
+  - alice
+  - bob
+seo:
+  description: nested
+---
+# Getting started
+`))
+	require.NoError(t, err)
+	require.NotNil(t, frontMatter)
+	assert.Equal(t, "# Getting started\n", string(body))
+	assert.Equal(t, "Getting started", frontMatter.Title)
+	assert.Equal(t, []string{"guide", "setup"}, frontMatter.Tags)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), frontMatter.Date)
+	assert.Equal(t, map[string]string{
+		"title":   "Getting started",
+		"tags":    "guide,setup",
+		"date":    "2024-03-01T00:00:00Z",
+		"draft":   "false",
+		"weight":  "10",
+		"authors": "alice,bob",
+	}, frontMatter.Metadata())
+
+	frontMatter, _, err = ParseFrontMatter([]byte("\ufeff---\r\ntags: api, reference\r\ndate: \"2024-03-01T12:30:00+01:00\"\r\n...\r\nBody"))
+	require.NoError(t, err)
+	require.NotNil(t, frontMatter)
+	assert.Equal(t, []string{"api", "reference"}, frontMatter.Tags)
+	assert.Equal(t, "2024-03-01T11:30:00Z", frontMatter.Metadata()["date"])
+
+	for _, content := range []string{"# No front matter\n", "---\ntitle: unterminated\n", "Text\n---\ntitle: late\n---\n"} {
+		frontMatter, body, err := ParseFrontMatter([]byte(content))
+		require.NoError(t, err)
+		assert.Nil(t, frontMatter, content)
+		assert.Equal(t, content, string(body))
+	}
+
+	_, body, err = ParseFrontMatter([]byte("---\ntitle: [unclosed\n---\nBody"))
+	assert.ErrorContains(t, err, "invalid front matter")
+	assert.Equal(t, "---\ntitle: [unclosed\n---\nBody", string(body))
+}
+
+func TestFrontMatterTransformer(t *testing.T) {
+	transformer := FrontMatterTransformer()
+
+	doc, err := transformer.Transform(context.Background(), Document{
+		Path:     "docs/guide.mdx",
+		Title:    "docs/guide.mdx",
+		Body:     "---\ntitle: Guide\ntags: [a]\nsha: front\n---\nBody",
+		Metadata: map[string]string{"sha": "abc"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Document{
+		Path:     "docs/guide.mdx",
+		Title:    "Guide",
+		Body:     "Body",
+		Metadata: map[string]string{"title": "Guide", "tags": "a", "sha": "abc"},
+	}, doc)
+
+	for _, doc := range []Document{
+		{Path: "config.yaml", Body: "---\ntitle: Config\n---\n"},
+		{Path: "broken.md", Body: "---\ntitle: [\n---\n"},
+	} {
+		transformed, err := transformer.Transform(context.Background(), doc)
+		require.NoError(t, err)
+		assert.Equal(t, doc, transformed)
+	}
+}
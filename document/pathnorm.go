@@ -0,0 +1,58 @@
+package document
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// PathNormalization is the Unicode normalization form applied to every path returned by the client.
+//
+// Repositories created on different operating systems may mix precomposed (NFC) and decomposed (NFD)
+// forms of the same file name, which downstream stores then treat as different keys.
+type PathNormalization string
+
+const (
+	// NoNormalization returns paths exactly as GitHub reports them.
+	NoNormalization PathNormalization = ""
+	// NormalizeNFC converts paths to the precomposed form used by most systems.
+	NormalizeNFC PathNormalization = "NFC"
+	// NormalizeNFD converts paths to the decomposed form used by macOS file systems.
+	NormalizeNFD PathNormalization = "NFD"
+)
+
+// NormalizePath converts the path to the given Unicode normalization form.
+func NormalizePath(p string, form PathNormalization) string {
+	switch form {
+	case NormalizeNFC:
+		return norm.NFC.String(p)
+	case NormalizeNFD:
+		return norm.NFD.String(p)
+	default:
+		return p
+	}
+}
+
+// EscapePath percent-encodes every segment of a slash separated path, leaving the separators intact.
+// It is meant for stores and URLs that cannot hold arbitrary Unicode or reserved characters.
+func EscapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// UnescapePath reverses EscapePath.
+func UnescapePath(p string) (string, error) {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		s, err := url.PathUnescape(segment)
+		if err != nil {
+			return "", err
+		}
+		segments[i] = s
+	}
+	return strings.Join(segments, "/"), nil
+}
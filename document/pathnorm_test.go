@@ -0,0 +1,32 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	nfcName = "caf\u00e9.md"
+	nfdName = "cafe\u0301.md"
+)
+
+func TestNormalizePath(t *testing.T) {
+	assert.Equal(t, nfcName, NormalizePath(nfdName, NormalizeNFC))
+	assert.Equal(t, nfdName, NormalizePath(nfcName, NormalizeNFD))
+	assert.Equal(t, nfdName, NormalizePath(nfdName, NoNormalization))
+}
+
+func TestEscapePath(t *testing.T) {
+	p := "docs/" + nfcName + "/100% 🎉 ready?.md"
+
+	escaped := EscapePath(p)
+	assert.Equal(t, "docs/caf%C3%A9.md/100%25%20%F0%9F%8E%89%20ready%3F.md", escaped)
+
+	unescaped, err := UnescapePath(escaped)
+	assert.NoError(t, err)
+	assert.Equal(t, p, unescaped)
+
+	_, err = UnescapePath("docs/%zz")
+	assert.Error(t, err)
+}
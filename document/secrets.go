@@ -0,0 +1,154 @@
+package document
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RedactedSecret replaces the secrets a SecretScanner redacts.
+const RedactedSecret = "[REDACTED]"
+
+// SecretPattern is a kind of secret a SecretScanner detects. Name identifies it in the metadata of flagged
+// documents.
+type SecretPattern struct {
+	Name   string
+	Regexp *regexp.Regexp
+}
+
+// DefaultSecretPatterns are the secrets a SecretScanner looks for without configured patterns.
+var DefaultSecretPatterns = []SecretPattern{
+	{Name: "github_token", Regexp: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{Name: "aws_access_key_id", Regexp: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{Name: "aws_secret_access_key", Regexp: regexp.MustCompile(`(?i)\baws_secret_access_key\b["']?\s*[:=]\s*["']?[A-Za-z0-9/+=]{40}\b`)},
+	{Name: "slack_token", Regexp: regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}\b`)},
+	{Name: "google_api_key", Regexp: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{Name: "private_key", Regexp: regexp.MustCompile(`(?s)-----BEGIN [A-Z ]*PRIVATE KEY-----.*?-----END [A-Z ]*PRIVATE KEY-----`)},
+}
+
+// SecretAction is what a SecretScanner does with documents containing secrets.
+type SecretAction int
+
+const (
+	// SecretRedact replaces the secrets with RedactedSecret and counts them in the "redactions" metadata.
+	SecretRedact SecretAction = iota
+	// SecretFlag keeps the document unchanged, listing the names of the found patterns in the "secrets"
+	// metadata.
+	SecretFlag
+	// SecretDrop drops the document with ErrSkipDocument.
+	SecretDrop
+)
+
+// SecretFinding is a secret found by a SecretScanner: the name of its pattern and the 1-based line it
+// starts on.
+type SecretFinding struct {
+	Pattern string
+	Line    int
+}
+
+// SecretScanner is a Transformer detecting secrets, such as access keys, tokens and private keys, in the
+// bodies of documents before they are shipped to external systems. Action decides whether they are
+// redacted, flagged or dropped; Patterns are the secrets it looks for, DefaultSecretPatterns if empty.
+//
+// Usage:
+//
+//	collector := &watch.Collector{
+//	    Name:         "docs",
+//	    Source:       source.SourceDocuments(gh),
+//	    Transformers: []Transformer{SecretScanner{Action: SecretDrop}},
+//	    Sink:         sink,
+//	}
+type SecretScanner struct {
+	Patterns []SecretPattern
+	Action   SecretAction
+}
+
+// RedactSecrets returns a SecretScanner redacting the secrets of the patterns, DefaultSecretPatterns if
+// none are given.
+func RedactSecrets(patterns ...SecretPattern) Transformer {
+	return SecretScanner{Patterns: patterns, Action: SecretRedact}
+}
+
+// Scan returns the secrets in the text, ordered by their position.
+func (s SecretScanner) Scan(text string) []SecretFinding {
+	type match struct {
+		offset  int
+		pattern string
+	}
+	var matches []match
+	for _, pattern := range s.patterns() {
+		for _, loc := range pattern.Regexp.FindAllStringIndex(text, -1) {
+			matches = append(matches, match{offset: loc[0], pattern: pattern.Name})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].offset < matches[j].offset })
+
+	findings := make([]SecretFinding, 0, len(matches))
+	for _, m := range matches {
+		findings = append(findings, SecretFinding{Pattern: m.pattern, Line: strings.Count(text[:m.offset], "\n") + 1})
+	}
+	return findings
+}
+
+// Transform applies the action of the scanner to a document containing secrets. Documents without secrets
+// are returned unchanged.
+func (s SecretScanner) Transform(_ context.Context, doc Document) (Document, error) {
+	switch s.Action {
+	case SecretRedact:
+		redactions := 0
+		for _, pattern := range s.patterns() {
+			doc.Body = pattern.Regexp.ReplaceAllStringFunc(doc.Body, func(string) string {
+				redactions++
+				return RedactedSecret
+			})
+		}
+		if redactions > 0 {
+			doc.Metadata = withMetadata(doc.Metadata, "redactions", fmt.Sprint(redactions))
+		}
+		return doc, nil
+	case SecretFlag:
+		findings := s.Scan(doc.Body)
+		if len(findings) == 0 {
+			return doc, nil
+		}
+		var names []string
+		seen := make(map[string]bool)
+		for _, finding := range findings {
+			if !seen[finding.Pattern] {
+				seen[finding.Pattern] = true
+				names = append(names, finding.Pattern)
+			}
+		}
+		sort.Strings(names)
+		doc.Metadata = withMetadata(doc.Metadata, "secrets", strings.Join(names, ","))
+		return doc, nil
+	case SecretDrop:
+		if len(s.Scan(doc.Body)) > 0 {
+			return Document{}, ErrSkipDocument
+		}
+		return doc, nil
+	default:
+		return Document{}, fmt.Errorf("unknown secret action %d", s.Action)
+	}
+}
+
+// patterns returns the configured patterns, falling back to the defaults.
+func (s SecretScanner) patterns() []SecretPattern {
+	if len(s.Patterns) == 0 {
+		return DefaultSecretPatterns
+	}
+	return s.Patterns
+}
+
+// withMetadata returns a copy of the metadata with the key set, leaving the metadata shared with the
+// original document untouched.
+func withMetadata(metadata map[string]string, key, value string) map[string]string {
+	result := make(map[string]string, len(metadata)+1)
+	for k, v := range metadata {
+		result[k] = v
+	}
+	result[key] = value
+	return result
+}
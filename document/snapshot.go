@@ -0,0 +1,85 @@
+package document
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+)
+
+// Snapshot holds the blob SHA of every file in scope, keyed by repository name and then by file path.
+type Snapshot map[string]map[string]string
+
+// Hash returns a deterministic SHA-256 over the (repository, path, blob SHA) triples of the snapshot.
+//
+// Two snapshots have the same hash exactly when they contain the same files with the same contents, so
+// "has anything in scope changed since the last run" is a single string comparison.
+func (s Snapshot) Hash() string {
+	repos := make([]string, 0, len(s))
+	for repo := range s {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+
+	h := sha256.New()
+	for _, repo := range repos {
+		paths := make([]string, 0, len(s[repo]))
+		for p := range s[repo] {
+			paths = append(paths, p)
+		}
+		sort.Strings(paths)
+
+		for _, p := range paths {
+			fmt.Fprintf(h, "%s\x00%s\x00%s\n", repo, p, s[repo][p])
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Diff compares the snapshot with a newer one of the same repositories: files only in next are added, files
+// only in s removed and files whose blob SHA differs modified. Paths are sorted by repository and path. The
+// comparison only depends on contents, so it is not confused by force pushes or rewritten history.
+func (s Snapshot) Diff(next Snapshot) Paths {
+	seen := make(map[string]bool, len(s)+len(next))
+	repos := make([]string, 0, len(s)+len(next))
+	for _, snapshot := range []Snapshot{s, next} {
+		for repo := range snapshot {
+			if !seen[repo] {
+				seen[repo] = true
+				repos = append(repos, repo)
+			}
+		}
+	}
+	sort.Strings(repos)
+
+	var paths Paths
+	for _, repo := range repos {
+		before, after := s[repo], next[repo]
+
+		var added, removed, modified []string
+		for p, sha := range after {
+			previous, ok := before[p]
+			switch {
+			case !ok:
+				added = append(added, p)
+			case previous != sha:
+				modified = append(modified, p)
+			}
+		}
+		for p := range before {
+			if _, ok := after[p]; !ok {
+				removed = append(removed, p)
+			}
+		}
+		sort.Strings(added)
+		sort.Strings(removed)
+		sort.Strings(modified)
+
+		paths.Added = append(paths.Added, added...)
+		paths.Removed = append(paths.Removed, removed...)
+		paths.Modified = append(paths.Modified, modified...)
+	}
+
+	return paths
+}
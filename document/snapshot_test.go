@@ -0,0 +1,48 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot_Hash(t *testing.T) {
+	a := Snapshot{
+		"repo1": {"docs/a.md": "sha-a", "docs/b.md": "sha-b"},
+		"repo2": {"docs/a.md": "sha-c"},
+	}
+	b := Snapshot{
+		"repo2": {"docs/a.md": "sha-c"},
+		"repo1": {"docs/b.md": "sha-b", "docs/a.md": "sha-a"},
+	}
+	modified := Snapshot{
+		"repo1": {"docs/a.md": "sha-a", "docs/b.md": "sha-x"},
+		"repo2": {"docs/a.md": "sha-c"},
+	}
+	moved := Snapshot{
+		"repo1": {"docs/a.md": "sha-a", "docs/b.md": "sha-b", "docs/a2.md": "sha-c"},
+	}
+
+	assert.Equal(t, a.Hash(), b.Hash())
+	assert.NotEqual(t, a.Hash(), modified.Hash())
+	assert.NotEqual(t, a.Hash(), moved.Hash())
+	assert.Len(t, Snapshot{}.Hash(), 64)
+}
+
+func TestSnapshot_Diff(t *testing.T) {
+	previous := Snapshot{
+		"repo1": {"docs/a.md": "sha-a", "docs/b.md": "sha-b", "docs/c.md": "sha-c"},
+		"repo2": {"docs/a.md": "sha-d"},
+	}
+	next := Snapshot{
+		"repo1": {"docs/a.md": "sha-a", "docs/b.md": "sha-x", "docs/d.md": "sha-c"},
+		"repo3": {"docs/a.md": "sha-e"},
+	}
+
+	assert.Equal(t, Paths{
+		Added:    []string{"docs/d.md", "docs/a.md"},
+		Removed:  []string{"docs/c.md", "docs/a.md"},
+		Modified: []string{"docs/b.md"},
+	}, previous.Diff(next))
+	assert.Equal(t, Paths{}, previous.Diff(previous))
+}
@@ -0,0 +1,67 @@
+package document
+
+import (
+	"context"
+	"errors"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// ErrSkipDocument is returned by a Transformer to drop the document instead of writing it.
+var ErrSkipDocument = errors.New("skip document")
+
+// Transformer changes a document before it is written, e.g. to enrich its metadata or clean up its body. It
+// returns ErrSkipDocument to drop the document.
+type Transformer interface {
+	Transform(ctx context.Context, doc Document) (Document, error)
+}
+
+// TransformerFunc adapts a function to a Transformer.
+type TransformerFunc func(ctx context.Context, doc Document) (Document, error)
+
+// Transform calls f(ctx, doc).
+func (f TransformerFunc) Transform(ctx context.Context, doc Document) (Document, error) {
+	return f(ctx, doc)
+}
+
+// ChainTransformers returns a Transformer applying the transformers in order, each to the result of the
+// previous one. It stops at the first failing transformer, or the first returning ErrSkipDocument.
+func ChainTransformers(transformers ...Transformer) Transformer {
+	return TransformerFunc(func(ctx context.Context, doc Document) (Document, error) {
+		for _, transformer := range transformers {
+			transformed, err := transformer.Transform(ctx, doc)
+			if err != nil {
+				return Document{}, err
+			}
+			doc = transformed
+		}
+		return doc, nil
+	})
+}
+
+// NormalizeLineEndings returns a Transformer converting the Windows and classic Mac OS line endings of
+// document bodies to "\n".
+func NormalizeLineEndings() Transformer {
+	return TransformerFunc(func(_ context.Context, doc Document) (Document, error) {
+		doc.Body = strings.ReplaceAll(strings.ReplaceAll(doc.Body, "\r\n", "\n"), "\r", "\n")
+		return doc, nil
+	})
+}
+
+var (
+	htmlBlockPattern = regexp.MustCompile(`(?is)<!--.*?-->|<script\b.*?</script\s*>|<style\b.*?</style\s*>`)
+	htmlTagPattern   = regexp.MustCompile(`</?[a-zA-Z][^<>]*>|<![a-zA-Z][^<>]*>`)
+)
+
+// StripHTML returns a Transformer reducing HTML in document bodies to its text: comments, scripts and
+// styles are removed, the remaining tags dropped and entities unescaped. It applies to every document, so
+// it is meant for sources of HTML content; "<" followed by a letter is taken for a tag, also in code.
+func StripHTML() Transformer {
+	return TransformerFunc(func(_ context.Context, doc Document) (Document, error) {
+		body := htmlBlockPattern.ReplaceAllString(doc.Body, "")
+		body = htmlTagPattern.ReplaceAllString(body, "")
+		doc.Body = html.UnescapeString(body)
+		return doc, nil
+	})
+}
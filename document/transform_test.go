@@ -0,0 +1,36 @@
+package document
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainTransformers(t *testing.T) {
+	upper := TransformerFunc(func(_ context.Context, doc Document) (Document, error) {
+		doc.Body = strings.ToUpper(doc.Body)
+		return doc, nil
+	})
+	failing := TransformerFunc(func(context.Context, Document) (Document, error) {
+		return Document{}, errors.New("boom")
+	})
+
+	doc, err := ChainTransformers(NormalizeLineEndings(), upper).Transform(context.Background(), Document{Body: "a\r\nb\rc"})
+	require.NoError(t, err)
+	assert.Equal(t, "A\nB\nC", doc.Body)
+
+	_, err = ChainTransformers(upper, failing).Transform(context.Background(), Document{Body: "a"})
+	assert.EqualError(t, err, "boom")
+}
+
+func TestStripHTML(t *testing.T) {
+	doc, err := StripHTML().Transform(context.Background(), Document{Body: `<!DOCTYPE html>
+<html><head><style>p { color: red; }</style><script type="text/javascript">if (a < b) { alert("<p>") }</script></head>
+<body><!-- draft --><h1 class="title">Fish &amp; Chips</h1><p>1 &lt; 2 and 3 < 4</p><br/></body></html>`})
+	require.NoError(t, err)
+	assert.Equal(t, "\n\nFish & Chips1 < 2 and 3 < 4", doc.Body)
+}
@@ -0,0 +1,188 @@
+package document
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// DefaultMaxPathLength is the classic Windows MAX_PATH limit used when no limit is configured.
+const DefaultMaxPathLength = 260
+
+// windowsReservedNames are the device names Windows refuses as file names, with or without extension.
+var windowsReservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// WindowsPathMapper maps repository paths to relative paths that can be written to Windows file systems.
+//
+// Characters Windows forbids (<>:"\|?* and control characters) as well as trailing dots and spaces are
+// percent-encoded, reserved device names get an underscore suffix, over-long paths are shortened with a
+// hash and paths that only differ in case get a "~N" suffix. Every path that had to be changed is
+// recorded, so the mapping can be written to a manifest and reversed later.
+//
+// A WindowsPathMapper is safe for concurrent use.
+type WindowsPathMapper struct {
+	maxPathLength int
+
+	mu       sync.Mutex
+	mapped   map[string]string
+	original map[string]string
+	folded   map[string]string
+}
+
+// NewWindowsPathMapper creates a WindowsPathMapper limiting mapped paths to maxPathLength characters.
+// A maxPathLength of zero or less uses DefaultMaxPathLength.
+func NewWindowsPathMapper(maxPathLength int) *WindowsPathMapper {
+	if maxPathLength <= 0 {
+		maxPathLength = DefaultMaxPathLength
+	}
+	return &WindowsPathMapper{
+		maxPathLength: maxPathLength,
+		mapped:        make(map[string]string),
+		original:      make(map[string]string),
+		folded:        make(map[string]string),
+	}
+}
+
+// Map returns the Windows-safe form of the slash separated repository path. Mapping the same path twice
+// returns the same result.
+func (m *WindowsPathMapper) Map(p string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if mapped, ok := m.mapped[p]; ok {
+		return mapped
+	}
+
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = sanitizeWindowsSegment(segment)
+	}
+	mapped := strings.Join(segments, "/")
+
+	if len(mapped) > m.maxPathLength {
+		mapped = shortenPath(mapped, p, m.maxPathLength)
+	}
+
+	candidate := mapped
+	for n := 1; ; n++ {
+		owner, taken := m.folded[strings.ToLower(candidate)]
+		if !taken || owner == p {
+			break
+		}
+		candidate = withSuffix(mapped, fmt.Sprintf("~%d", n))
+	}
+	mapped = candidate
+
+	m.mapped[p] = mapped
+	m.original[mapped] = p
+	m.folded[strings.ToLower(mapped)] = p
+
+	return mapped
+}
+
+// Original returns the repository path a mapped path was created from.
+func (m *WindowsPathMapper) Original(mapped string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p, ok := m.original[mapped]
+	return p, ok
+}
+
+// Manifest returns the mapped → original path of every path the mapper had to change. Paths that are
+// already Windows-safe are omitted.
+func (m *WindowsPathMapper) Manifest() map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	manifest := make(map[string]string)
+	for mapped, p := range m.original {
+		if mapped != strings.Trim(p, "/") {
+			manifest[mapped] = p
+		}
+	}
+	return manifest
+}
+
+// Restore loads a manifest written by a previous run, so paths keep the names they were given before
+// even if collisions are now encountered in a different order.
+func (m *WindowsPathMapper) Restore(manifest map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for mapped, p := range manifest {
+		m.mapped[p] = mapped
+		m.original[mapped] = p
+		m.folded[strings.ToLower(mapped)] = p
+	}
+}
+
+// sanitizeWindowsSegment makes a single path segment valid on Windows.
+func sanitizeWindowsSegment(segment string) string {
+	var b strings.Builder
+	for _, r := range segment {
+		if r < 0x20 || strings.ContainsRune(`<>:"\|?*%`, r) {
+			fmt.Fprintf(&b, "%%%02X", r)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	s := b.String()
+
+	trimmed := strings.TrimRight(s, ". ")
+	if trimmed != s {
+		var suffix strings.Builder
+		for _, r := range s[len(trimmed):] {
+			fmt.Fprintf(&suffix, "%%%02X", r)
+		}
+		s = trimmed + suffix.String()
+	}
+
+	stem := s
+	if i := strings.Index(s, "."); i >= 0 {
+		stem = s[:i]
+	}
+	if windowsReservedNames[strings.ToUpper(stem)] {
+		s = stem + "_" + s[len(stem):]
+	}
+
+	return s
+}
+
+// shortenPath fits mapped into maxLength characters by hashing the original path into the file name and,
+// if the directories alone are too long, by moving the file to a flat "_long" directory.
+func shortenPath(mapped, original string, maxLength int) string {
+	sum := sha1.Sum([]byte(original))
+	hash := hex.EncodeToString(sum[:])[:12]
+
+	dir, file := path.Split(mapped)
+	ext := path.Ext(file)
+	stem := strings.TrimSuffix(file, ext)
+
+	keep := maxLength - len(dir) - len(ext) - len(hash) - 1
+	if keep >= 0 {
+		if keep > len(stem) {
+			keep = len(stem)
+		}
+		for keep > 0 && keep < len(stem) && !utf8.RuneStart(stem[keep]) {
+			keep--
+		}
+		return dir + stem[:keep] + "~" + hash + ext
+	}
+
+	return "_long/" + hash + ext
+}
+
+// withSuffix adds suffix to the file name of p, before its extension.
+func withSuffix(p, suffix string) string {
+	ext := path.Ext(p)
+	return strings.TrimSuffix(p, ext) + suffix + ext
+}
@@ -0,0 +1,70 @@
+package document
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindowsPathMapper_Map(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "safe path", path: "docs/index.md", want: "docs/index.md"},
+		{name: "invalid characters", path: "docs/a:b?.md", want: "docs/a%3Ab%3F.md"},
+		{name: "trailing dots and spaces", path: "docs/notes. /file.", want: "docs/notes%2E%20/file%2E"},
+		{name: "reserved names", path: "con/aux.txt", want: "con_/aux_.txt"},
+		{name: "percent sign", path: "docs/100%.md", want: "docs/100%25.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewWindowsPathMapper(0).Map(tt.path))
+		})
+	}
+}
+
+func TestWindowsPathMapper_CaseCollisions(t *testing.T) {
+	m := NewWindowsPathMapper(0)
+
+	assert.Equal(t, "docs/README.md", m.Map("docs/README.md"))
+	assert.Equal(t, "docs/readme~1.md", m.Map("docs/readme.md"))
+	assert.Equal(t, "docs/Readme~2.md", m.Map("docs/Readme.md"))
+	assert.Equal(t, "docs/readme~1.md", m.Map("docs/readme.md"))
+
+	original, ok := m.Original("docs/readme~1.md")
+	assert.True(t, ok)
+	assert.Equal(t, "docs/readme.md", original)
+
+	assert.Equal(t, map[string]string{
+		"docs/readme~1.md": "docs/readme.md",
+		"docs/Readme~2.md": "docs/Readme.md",
+	}, m.Manifest())
+
+	restored := NewWindowsPathMapper(0)
+	restored.Restore(m.Manifest())
+	assert.Equal(t, "docs/Readme~2.md", restored.Map("docs/Readme.md"))
+	assert.Equal(t, "docs/README.md", restored.Map("docs/README.md"))
+}
+
+func TestWindowsPathMapper_LongPaths(t *testing.T) {
+	m := NewWindowsPathMapper(40)
+
+	long := "docs/" + strings.Repeat("a", 50) + ".md"
+	mapped := m.Map(long)
+	assert.LessOrEqual(t, len(mapped), 40)
+	assert.True(t, strings.HasPrefix(mapped, "docs/aaaa"))
+	assert.True(t, strings.HasSuffix(mapped, ".md"))
+
+	deep := strings.Repeat("d", 45) + "/file.md"
+	mapped = m.Map(deep)
+	assert.LessOrEqual(t, len(mapped), 40)
+	assert.True(t, strings.HasPrefix(mapped, "_long/"))
+
+	original, ok := m.Original(mapped)
+	assert.True(t, ok)
+	assert.Equal(t, deep, original)
+}
@@ -0,0 +1,110 @@
+package cocogh
+
+import (
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+)
+
+// CommitFilter is an alias of filter.CommitFilter.
+//
+// Deprecated: use filter.CommitFilter instead.
+type CommitFilter = filter.CommitFilter
+
+// ConventionalCommit is an alias of filter.ConventionalCommit.
+//
+// Deprecated: use filter.ConventionalCommit instead.
+type ConventionalCommit = filter.ConventionalCommit
+
+// FilterMode is an alias of filter.FilterMode.
+//
+// Deprecated: use filter.FilterMode instead.
+type FilterMode = filter.FilterMode
+
+// GitAttributes is an alias of filter.GitAttributes.
+//
+// Deprecated: use filter.GitAttributes instead.
+type GitAttributes = filter.GitAttributes
+
+// GitHubFilter is an alias of filter.GitHubFilter.
+//
+// Deprecated: use filter.GitHubFilter instead.
+type GitHubFilter = filter.GitHubFilter
+
+// IgnoreFile is an alias of filter.IgnoreFile.
+//
+// Deprecated: use filter.IgnoreFile instead.
+type IgnoreFile = filter.IgnoreFile
+
+// LinguistAttributes is an alias of filter.LinguistAttributes.
+//
+// Deprecated: use filter.LinguistAttributes instead.
+type LinguistAttributes = filter.LinguistAttributes
+
+// Ownership is an alias of filter.Ownership.
+//
+// Deprecated: use filter.Ownership instead.
+type Ownership = filter.Ownership
+
+// OwnershipRule is an alias of filter.OwnershipRule.
+//
+// Deprecated: use filter.OwnershipRule instead.
+type OwnershipRule = filter.OwnershipRule
+
+// PathFilter is an alias of filter.PathFilter.
+//
+// Deprecated: use filter.PathFilter instead.
+type PathFilter = filter.PathFilter
+
+// RepositoryFilter is an alias of filter.RepositoryFilter.
+//
+// Deprecated: use filter.RepositoryFilter instead.
+type RepositoryFilter = filter.RepositoryFilter
+
+// DefaultIgnoreFile is filter.DefaultIgnoreFile.
+//
+// Deprecated: use filter.DefaultIgnoreFile instead.
+const DefaultIgnoreFile = filter.DefaultIgnoreFile
+
+// FilterModeAll is filter.FilterModeAll.
+//
+// Deprecated: use filter.FilterModeAll instead.
+const FilterModeAll = filter.FilterModeAll
+
+// FilterModeDocumentationOnly is filter.FilterModeDocumentationOnly.
+//
+// Deprecated: use filter.FilterModeDocumentationOnly instead.
+const FilterModeDocumentationOnly = filter.FilterModeDocumentationOnly
+
+// InDirectory calls filter.InDirectory.
+//
+// Deprecated: use filter.InDirectory instead.
+func InDirectory(filePath, dir string) bool {
+	return filter.InDirectory(filePath, dir)
+}
+
+// ParseCodeowners calls filter.ParseCodeowners.
+//
+// Deprecated: use filter.ParseCodeowners instead.
+func ParseCodeowners(content string) filter.Ownership {
+	return filter.ParseCodeowners(content)
+}
+
+// ParseConventionalCommit calls filter.ParseConventionalCommit.
+//
+// Deprecated: use filter.ParseConventionalCommit instead.
+func ParseConventionalCommit(message string) (filter.ConventionalCommit, bool) {
+	return filter.ParseConventionalCommit(message)
+}
+
+// ParseGitAttributes calls filter.ParseGitAttributes.
+//
+// Deprecated: use filter.ParseGitAttributes instead.
+func ParseGitAttributes(content string) filter.GitAttributes {
+	return filter.ParseGitAttributes(content)
+}
+
+// ParseIgnoreFile calls filter.ParseIgnoreFile.
+//
+// Deprecated: use filter.ParseIgnoreFile instead.
+func ParseIgnoreFile(content string) filter.IgnoreFile {
+	return filter.ParseIgnoreFile(content)
+}
@@ -0,0 +1,71 @@
+package filter
+
+import (
+	"strings"
+)
+
+// Ownership is a parsed CODEOWNERS file.
+type Ownership struct {
+	Rules []OwnershipRule
+}
+
+// OwnershipRule is a single line of a CODEOWNERS file. A rule without owners removes the owners a
+// previous rule assigned.
+type OwnershipRule struct {
+	Pattern string
+	Owners  []string
+
+	pattern pathPattern
+}
+
+// ParseCodeowners parses the content of a CODEOWNERS file. Blank lines and comments are ignored.
+func ParseCodeowners(content string) Ownership {
+	var ownership Ownership
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		rule := OwnershipRule{Pattern: fields[0], pattern: compilePattern(fields[0])}
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			rule.Owners = append(rule.Owners, owner)
+		}
+		ownership.Rules = append(ownership.Rules, rule)
+	}
+
+	return ownership
+}
+
+// Map returns the owners of every pattern. If a pattern appears more than once, the last rule wins as
+// it does on GitHub.
+func (o Ownership) Map() map[string][]string {
+	owners := make(map[string][]string, len(o.Rules))
+	for _, rule := range o.Rules {
+		owners[rule.Pattern] = rule.Owners
+	}
+	return owners
+}
+
+// Owners returns the owners of the given file path. The last matching rule takes precedence, so the
+// result is empty if no rule matches or the matching rule has no owners.
+func (o Ownership) Owners(filePath string) []string {
+	for i := len(o.Rules) - 1; i >= 0; i-- {
+		if o.Rules[i].match(filePath) {
+			return o.Rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// match reports whether the rule applies to filePath. Unlike gitignore, a pattern ending in "/*" only
+// matches the files directly inside the directory, not those in its subdirectories.
+func (r OwnershipRule) match(filePath string) bool {
+	if strings.HasSuffix(r.Pattern, "/*") {
+		return r.pattern.match(filePath)
+	}
+	return r.pattern.matchWithParents(filePath)
+}
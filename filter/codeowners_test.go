@@ -0,0 +1,48 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCodeowners(t *testing.T) {
+	ownership := ParseCodeowners(`# default owners
+*       @octo-org/core
+
+*.js    @js-owner # frontend
+/docs/  @docs-team docs@example.com
+apps/   @apps-team
+scripts/* @ops
+/build/logs/
+*.js    @web-team
+`)
+
+	assert.Equal(t, map[string][]string{
+		"*":            {"@octo-org/core"},
+		"*.js":         {"@web-team"},
+		"/docs/":       {"@docs-team", "docs@example.com"},
+		"apps/":        {"@apps-team"},
+		"scripts/*":    {"@ops"},
+		"/build/logs/": nil,
+	}, ownership.Map())
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{path: "main.go", want: []string{"@octo-org/core"}},
+		{path: "web/app.js", want: []string{"@web-team"}},
+		{path: "docs/guides/setup.md", want: []string{"@docs-team", "docs@example.com"}},
+		{path: "services/apps/api/main.go", want: []string{"@apps-team"}},
+		{path: "scripts/deploy.sh", want: []string{"@ops"}},
+		{path: "scripts/ci/lint.sh", want: []string{"@octo-org/core"}},
+		{path: "build/logs/out.log", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, ownership.Owners(tt.path))
+		})
+	}
+}
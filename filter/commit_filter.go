@@ -0,0 +1,128 @@
+package filter
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/internal/gitutil"
+)
+
+// CommitFilter selects the commits whose changes are detected from the commit history, so commits of bots or
+// automation do not show up in change feeds. The zero CommitFilter keeps every commit.
+//
+// Authors keeps only the commits of the given logins, or author names for commits of authors without a GitHub
+// account, and ExcludeAuthors drops the commits of the given ones; logins are compared case-insensitively.
+// EmailDomains and ExcludeEmailDomains do the same for the domains of the author emails, matching subdomains,
+// e.g. "example.com" matches "dev@eu.example.com". ExcludeBots drops the commits of bot accounts such as
+// "dependabot[bot]".
+//
+// ExcludeMessages drops the commits whose messages contain any of the given markers, compared
+// case-insensitively, e.g. "[skip collect]", and ExcludeTypes the commits whose messages follow the
+// Conventional Commits specification with any of the given types, e.g. "chore" or "ci", so mechanical
+// commits do not trigger collecting unchanged documentation again.
+type CommitFilter struct {
+	Authors             []string `yaml:"authors" json:"authors"`
+	ExcludeAuthors      []string `yaml:"exclude_authors" json:"exclude_authors"`
+	EmailDomains        []string `yaml:"email_domains" json:"email_domains"`
+	ExcludeEmailDomains []string `yaml:"exclude_email_domains" json:"exclude_email_domains"`
+	ExcludeBots         bool     `yaml:"exclude_bots" json:"exclude_bots"`
+	ExcludeMessages     []string `yaml:"exclude_messages" json:"exclude_messages"`
+	ExcludeTypes        []string `yaml:"exclude_types" json:"exclude_types"`
+}
+
+// Match reports whether the changes of the commit are detected.
+func (f CommitFilter) Match(commit *github.RepositoryCommit) bool {
+	author := gitutil.CommitAuthor(commit)
+	if len(f.Authors) > 0 && !containsFold(f.Authors, author) {
+		return false
+	}
+	if containsFold(f.ExcludeAuthors, author) {
+		return false
+	}
+
+	domain := emailDomain(commit.GetCommit().GetAuthor().GetEmail())
+	if len(f.EmailDomains) > 0 && !matchDomain(f.EmailDomains, domain) {
+		return false
+	}
+	if matchDomain(f.ExcludeEmailDomains, domain) {
+		return false
+	}
+
+	if f.ExcludeBots && isBotCommit(commit) {
+		return false
+	}
+
+	message := commit.GetCommit().GetMessage()
+	lower := strings.ToLower(message)
+	for _, marker := range f.ExcludeMessages {
+		if strings.Contains(lower, strings.ToLower(marker)) {
+			return false
+		}
+	}
+	if len(f.ExcludeTypes) > 0 {
+		if conventional, ok := ParseConventionalCommit(message); ok && containsFold(f.ExcludeTypes, conventional.Type) {
+			return false
+		}
+	}
+	return true
+}
+
+// isBotCommit reports whether the commit was authored by a bot account, either of type "Bot" or, for commits
+// read without their GitHub author, with a "[bot]" name.
+func isBotCommit(commit *github.RepositoryCommit) bool {
+	return commit.GetAuthor().GetType() == "Bot" ||
+		strings.HasSuffix(commit.GetAuthor().GetLogin(), "[bot]") ||
+		strings.HasSuffix(commit.GetCommit().GetAuthor().GetName(), "[bot]")
+}
+
+// emailDomain returns the lower-cased domain of the email address, empty if it has none.
+func emailDomain(email string) string {
+	_, domain, _ := strings.Cut(email, "@")
+	return strings.ToLower(domain)
+}
+
+// matchDomain reports whether the domain is one of the domains or one of their subdomains.
+func matchDomain(domains []string, domain string) bool {
+	if domain == "" {
+		return false
+	}
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimPrefix(d, "@"))
+		if domain == d || strings.HasSuffix(domain, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// conventionalCommitPattern matches the subject line of a Conventional Commits message:
+// "type(scope)!: description".
+var conventionalCommitPattern = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// ConventionalCommit is a commit message parsed according to the Conventional Commits specification.
+type ConventionalCommit struct {
+	Type        string
+	Scope       string
+	Description string
+	Breaking    bool
+}
+
+// ParseConventionalCommit parses a commit message following the Conventional Commits specification.
+// A change is breaking if the type is followed by "!" or the body holds a "BREAKING CHANGE:" footer.
+// The boolean is false if the subject line does not follow the specification.
+func ParseConventionalCommit(message string) (ConventionalCommit, bool) {
+	subject, body := gitutil.SplitCommitMessage(message)
+
+	m := conventionalCommitPattern.FindStringSubmatch(subject)
+	if m == nil {
+		return ConventionalCommit{Description: subject}, false
+	}
+
+	return ConventionalCommit{
+		Type:        strings.ToLower(m[1]),
+		Scope:       m[2],
+		Description: m[4],
+		Breaking:    m[3] == "!" || strings.Contains(body, "BREAKING CHANGE:") || strings.Contains(body, "BREAKING-CHANGE:"),
+	}, true
+}
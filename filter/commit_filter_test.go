@@ -0,0 +1,119 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func authoredCommit(sha, login, name, email string) *github.RepositoryCommit {
+	commit := &github.RepositoryCommit{
+		SHA:    github.String(sha),
+		Commit: &github.Commit{Author: &github.CommitAuthor{Name: github.String(name), Email: github.String(email)}},
+	}
+	if login != "" {
+		commit.Author = &github.User{Login: github.String(login), Type: github.String("User")}
+	}
+	return commit
+}
+
+func TestCommitFilter_Match(t *testing.T) {
+	alice := authoredCommit("a", "alice", "Alice", "alice@eu.example.com")
+	bob := authoredCommit("b", "", "Bob", "bob@other.org")
+	dependabot := authoredCommit("c", "dependabot[bot]", "dependabot[bot]", "49699333+dependabot[bot]@users.noreply.github.com")
+	renovate := authoredCommit("d", "", "renovate[bot]", "bot@renovateapp.com")
+	app := authoredCommit("e", "docs-app", "docs-app", "app@example.com")
+	app.Author.Type = github.String("Bot")
+
+	tests := []struct {
+		name   string
+		filter CommitFilter
+		want   []bool
+	}{
+		{
+			name: "zero filter keeps every commit",
+			want: []bool{true, true, true, true, true},
+		},
+		{
+			name:   "authors by login or name",
+			filter: CommitFilter{Authors: []string{"ALICE", "Bob"}},
+			want:   []bool{true, true, false, false, false},
+		},
+		{
+			name:   "excluded authors",
+			filter: CommitFilter{ExcludeAuthors: []string{"dependabot[bot]"}},
+			want:   []bool{true, true, false, true, true},
+		},
+		{
+			name:   "email domains match subdomains",
+			filter: CommitFilter{EmailDomains: []string{"example.com"}},
+			want:   []bool{true, false, false, false, true},
+		},
+		{
+			name:   "excluded email domains",
+			filter: CommitFilter{ExcludeEmailDomains: []string{"@users.noreply.github.com", "renovateapp.com"}},
+			want:   []bool{true, true, false, false, true},
+		},
+		{
+			name:   "bots",
+			filter: CommitFilter{ExcludeBots: true},
+			want:   []bool{true, true, false, false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []bool
+			for _, commit := range []*github.RepositoryCommit{alice, bob, dependabot, renovate, app} {
+				got = append(got, tt.filter.Match(commit))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCommitFilter_Match_Messages(t *testing.T) {
+	filter := CommitFilter{ExcludeMessages: []string{"[skip collect]"}, ExcludeTypes: []string{"Chore", "ci"}}
+
+	tests := []struct {
+		message string
+		want    bool
+	}{
+		{message: "docs: explain the setup", want: true},
+		{message: "Fix typo [SKIP COLLECT]", want: false},
+		{message: "Update guide\n\n[skip collect]", want: false},
+		{message: "chore(deps): bump yaml", want: false},
+		{message: "ci!: switch runners", want: false},
+		{message: "chore without a colon", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			commit := &github.RepositoryCommit{Commit: &github.Commit{Message: github.String(tt.message)}}
+			assert.Equal(t, tt.want, filter.Match(commit))
+		})
+	}
+}
+
+func TestParseConventionalCommit(t *testing.T) {
+	tests := []struct {
+		message string
+		want    ConventionalCommit
+		ok      bool
+	}{
+		{message: "feat: add search", want: ConventionalCommit{Type: "feat", Description: "add search"}, ok: true},
+		{message: "fix(parser): handle tabs", want: ConventionalCommit{Type: "fix", Scope: "parser", Description: "handle tabs"}, ok: true},
+		{message: "feat(api)!: drop v1", want: ConventionalCommit{Type: "feat", Scope: "api", Description: "drop v1", Breaking: true}, ok: true},
+		{message: "refactor: rename config\n\nBREAKING CHANGE: Owner is now Owners", want: ConventionalCommit{Type: "refactor", Description: "rename config", Breaking: true}, ok: true},
+		{message: "Update README", want: ConventionalCommit{Description: "Update README"}, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			got, ok := ParseConventionalCommit(tt.message)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
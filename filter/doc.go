@@ -0,0 +1,10 @@
+// Package filter selects the repositories, files and commits coco-gh collects.
+//
+// GitHubFilter and FilterMode select files, below FilePath or the directories of several PathFilters,
+// GitAttributes drops generated and vendored ones, an IgnoreFile such as .cocoignore drops the ones
+// repository owners opt out of collection and GitHubFilter.MaxFileSize and SkipBinary large or binary ones.
+// A CommitFilter leaves the commits of given authors, email domains or bots, and commits with given message
+// markers or Conventional Commit types, out of the detected changes, and a RepositoryFilter skips
+// configured repositories before they are crawled. ParseCodeowners reads the Ownership rules of a
+// CODEOWNERS file.
+package filter
@@ -0,0 +1,57 @@
+package filter
+
+import "regexp"
+
+// FilterMode selects a predefined filter profile that is applied on top of the path and file type filters.
+type FilterMode string
+
+const (
+	// FilterModeAll applies no profile, every file passing the other filters is selected.
+	FilterModeAll FilterMode = ""
+	// FilterModeDocumentationOnly selects documentation files only. A file is documentation if the
+	// repository's .gitattributes marks it as linguist-documentation, or if it lives at one of the
+	// well-known documentation paths Linguist recognises (docs/, README, CHANGELOG, ...) and is not
+	// explicitly marked as linguist-documentation=false.
+	FilterModeDocumentationOnly FilterMode = "documentation-only"
+)
+
+// documentationPaths are the well-known documentation paths, following GitHub Linguist's documentation.yml.
+var documentationPaths = []*regexp.Regexp{
+	regexp.MustCompile(`^[Dd]ocs?/`),
+	regexp.MustCompile(`(^|/)[Dd]ocumentation/`),
+	regexp.MustCompile(`(^|/)[Gg]roovydoc/`),
+	regexp.MustCompile(`(^|/)[Jj]avadoc/`),
+	regexp.MustCompile(`^[Mm]an/`),
+	regexp.MustCompile(`^[Ee]xamples/`),
+	regexp.MustCompile(`^[Dd]emos?/`),
+	regexp.MustCompile(`(^|/)inst/doc/`),
+	regexp.MustCompile(`(^|/)CITATION(\.cff|(S)?(\.(bib|md))?)$`),
+	regexp.MustCompile(`(^|/)CHANGE(S|LOG)?(\.|$)`),
+	regexp.MustCompile(`(^|/)CONTRIBUTING(\.|$)`),
+	regexp.MustCompile(`(^|/)COPYING(\.|$)`),
+	regexp.MustCompile(`(^|/)INSTALL(\.|$)`),
+	regexp.MustCompile(`(^|/)LICEN[CS]E(\.|$)`),
+	regexp.MustCompile(`(^|/)[Ll]icen[cs]e(\.|$)`),
+	regexp.MustCompile(`(^|/)README(\.|$)`),
+	regexp.MustCompile(`(^|/)[Rr]eadme(\.|$)`),
+}
+
+// NeedsGitAttributes reports whether applying the filter requires the repository's .gitattributes.
+func (f GitHubFilter) NeedsGitAttributes() bool {
+	return f.ExcludeGenerated || f.ExcludeVendored || f.Mode == FilterModeDocumentationOnly
+}
+
+// isDocumentation reports whether the file is documentation according to the attributes and the
+// well-known documentation paths.
+func isDocumentation(fileName string, attributes GitAttributes) bool {
+	if value, ok := attributes.Get(fileName, attrLinguistDocumentation); ok {
+		return value == "true"
+	}
+
+	for _, re := range documentationPaths {
+		if re.MatchString(fileName) {
+			return true
+		}
+	}
+	return false
+}
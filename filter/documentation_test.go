@@ -0,0 +1,32 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDocumentation(t *testing.T) {
+	attributes := ParseGitAttributes("guides/** linguist-documentation\ndocs/generated/** -linguist-documentation\n")
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "README.md", want: true},
+		{path: "pkg/readme.txt", want: true},
+		{path: "CHANGELOG.md", want: true},
+		{path: "docs/index.md", want: true},
+		{path: "src/Documentation/api.md", want: true},
+		{path: "guides/setup.md", want: true},
+		{path: "docs/generated/api.md", want: false},
+		{path: "main.go", want: false},
+		{path: "src/docs/index.md", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, isDocumentation(tt.path, attributes))
+		})
+	}
+}
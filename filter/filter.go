@@ -0,0 +1,101 @@
+package filter
+
+import (
+	"regexp"
+	"strings"
+)
+
+// GitHubFilter represents a filter used to narrow down the file paths in a GitHub repository based on the file path and file types.
+//
+// ExcludeGenerated and ExcludeVendored drop files marked as linguist-generated or linguist-vendored in the
+// repository's .gitattributes. Mode selects a predefined filter profile, see FilterMode.
+//
+// Include and Exclude are doublestar-style globs matched against the full path of a file, e.g. "docs/**/*.md"
+// or "**/testdata/**". A file must match one of the Include globs if there are any and none of the Exclude
+// globs. IncludeRegexp and ExcludeRegexp do the same with regular expressions. These patterns apply to the
+// listed files as well as to changed files.
+//
+// MaxFileSize drops listed files larger than the given number of bytes, zero keeps files of any size.
+// SkipBinary drops listed files GitHub reports as binary; it makes the client walk trees through GraphQL,
+// which reports it, instead of fetching them with one recursive Git Trees API request, and is ignored for
+// files listed by a Fetcher. Both use the size and content of the files at the crawled ref, so they do not
+// apply to the changed file paths detected from the commit history; GetChangedFilesSince applies them to the
+// content it reads.
+//
+// IgnoreFile is the name of an ignore file at the root of every repository, e.g. DefaultIgnoreFile, whose
+// gitignore-style patterns exclude paths from listing and change detection, so repository owners can opt
+// files out of collection. Empty disables ignore files.
+//
+// Paths crawls several directories of every repository instead of FilePath and merges their files, listing
+// files below overlapping directories once. Each PathFilter may select its own files. GitHubConfig.RepositoryPaths
+// overrides Paths for individual repositories. GetFileTreeFromRepositories still builds the tree below FilePath.
+type GitHubFilter struct {
+	FilePath         string
+	Paths            []PathFilter
+	FileTypes        []string
+	ExcludeGenerated bool
+	ExcludeVendored  bool
+	Mode             FilterMode
+	Include          []string
+	Exclude          []string
+	IncludeRegexp    *regexp.Regexp
+	ExcludeRegexp    *regexp.Regexp
+	IgnoreFile       string
+	MaxFileSize      int
+	SkipBinary       bool
+}
+
+// PathFilter is a directory of a repository crawled in addition to the other directories of a
+// GitHubFilter.Paths or GitHubConfig.RepositoryPaths, e.g. "docs" or "guides".
+//
+// FileTypes, Include and Exclude replace the ones of the GitHubFilter for the files below Path if set, so
+// every directory can select its own files. The other options of the GitHubFilter apply to all of them.
+type PathFilter struct {
+	Path      string   `yaml:"path" json:"path"`
+	FileTypes []string `yaml:"file_types" json:"file_types"`
+	Include   []string `yaml:"include" json:"include"`
+	Exclude   []string `yaml:"exclude" json:"exclude"`
+}
+
+// ForPath returns the filter applying to the files below the directory of p.
+func (f GitHubFilter) ForPath(p PathFilter) GitHubFilter {
+	f.FilePath = p.Path
+	f.Paths = nil
+	if len(p.FileTypes) > 0 {
+		f.FileTypes = p.FileTypes
+	}
+	if len(p.Include) > 0 {
+		f.Include = p.Include
+	}
+	if len(p.Exclude) > 0 {
+		f.Exclude = p.Exclude
+	}
+	return f
+}
+
+// MatchRepositoryFile reports whether filePath passes FileTypes and the path patterns of the filter, is not
+// ignored by the ignore file of its repository and passes ExcludeGenerated, ExcludeVendored and Mode according
+// to the repository's .gitattributes. Unlike Match it does not check FilePath.
+func (f GitHubFilter) MatchRepositoryFile(filePath string, attributes GitAttributes, ignore IgnoreFile) bool {
+	if !f.matchFileTypes(filePath) || !f.matchPath(filePath) || ignore.Ignored(filePath) {
+		return false
+	}
+
+	if f.NeedsGitAttributes() {
+		linguist := attributes.Linguist(filePath)
+		if (f.ExcludeGenerated && linguist.Generated) || (f.ExcludeVendored && linguist.Vendored) {
+			return false
+		}
+		if f.Mode == FilterModeDocumentationOnly && !isDocumentation(filePath, attributes) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// InDirectory reports whether filePath is below dir, every path being below the root.
+func InDirectory(filePath, dir string) bool {
+	dir = strings.Trim(dir, "/")
+	return dir == "" || filePath == dir || strings.HasPrefix(filePath, dir+"/")
+}
@@ -0,0 +1,94 @@
+package filter
+
+import (
+	"strings"
+)
+
+// The Linguist attributes GitHub honours in .gitattributes.
+const (
+	attrLinguistGenerated     = "linguist-generated"
+	attrLinguistVendored      = "linguist-vendored"
+	attrLinguistDocumentation = "linguist-documentation"
+)
+
+// LinguistAttributes holds the GitHub Linguist overrides a .gitattributes file declares for a path.
+type LinguistAttributes struct {
+	Generated     bool
+	Vendored      bool
+	Documentation bool
+}
+
+// GitAttributes is a parsed .gitattributes file.
+type GitAttributes struct {
+	rules []gitAttributesRule
+}
+
+type gitAttributesRule struct {
+	pattern    pathPattern
+	attributes map[string]string
+}
+
+// ParseGitAttributes parses the content of a .gitattributes file.
+//
+// Every line holds a pattern followed by attributes in the forms "attr" (set), "-attr" (unset),
+// "!attr" (unspecified) and "attr=value". Blank lines, comments and negative patterns, which git
+// forbids in .gitattributes, are ignored.
+func ParseGitAttributes(content string) GitAttributes {
+	var attributes GitAttributes
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "!") {
+			continue
+		}
+
+		rule := gitAttributesRule{
+			pattern:    compilePattern(fields[0]),
+			attributes: make(map[string]string),
+		}
+		for _, attr := range fields[1:] {
+			switch {
+			case strings.HasPrefix(attr, "-"):
+				rule.attributes[attr[1:]] = "false"
+			case strings.HasPrefix(attr, "!"):
+				rule.attributes[attr[1:]] = ""
+			case strings.Contains(attr, "="):
+				kv := strings.SplitN(attr, "=", 2)
+				rule.attributes[kv[0]] = kv[1]
+			default:
+				rule.attributes[attr] = "true"
+			}
+		}
+		attributes.rules = append(attributes.rules, rule)
+	}
+
+	return attributes
+}
+
+// Get returns the value of the attribute for the given path. Set attributes have the value "true",
+// unset ones "false". The boolean is false if the attribute is unspecified for the path.
+func (a GitAttributes) Get(filePath, attribute string) (string, bool) {
+	var value string
+	for _, rule := range a.rules {
+		v, ok := rule.attributes[attribute]
+		if !ok || !rule.pattern.match(filePath) {
+			continue
+		}
+		value = v
+	}
+
+	return value, value != ""
+}
+
+// Linguist returns the Linguist overrides declared for the given path.
+func (a GitAttributes) Linguist(filePath string) LinguistAttributes {
+	return LinguistAttributes{
+		Generated:     a.isSet(filePath, attrLinguistGenerated),
+		Vendored:      a.isSet(filePath, attrLinguistVendored),
+		Documentation: a.isSet(filePath, attrLinguistDocumentation),
+	}
+}
+
+func (a GitAttributes) isSet(filePath, attribute string) bool {
+	value, _ := a.Get(filePath, attribute)
+	return value == "true"
+}
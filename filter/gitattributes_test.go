@@ -0,0 +1,44 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGitAttributes(t *testing.T) {
+	attributes := ParseGitAttributes(`# generated code
+*.pb.go linguist-generated
+api/*.pb.go -linguist-generated
+third_party/** linguist-vendored=true
+docs/** linguist-documentation
+docs/api/** !linguist-documentation
+!*.txt linguist-vendored
+*.png binary
+`)
+
+	tests := []struct {
+		path string
+		want LinguistAttributes
+	}{
+		{path: "internal/user.pb.go", want: LinguistAttributes{Generated: true}},
+		{path: "api/user.pb.go", want: LinguistAttributes{}},
+		{path: "third_party/lib/lib.go", want: LinguistAttributes{Vendored: true}},
+		{path: "docs/index.md", want: LinguistAttributes{Documentation: true}},
+		{path: "docs/api/index.md", want: LinguistAttributes{}},
+		{path: "notes.txt", want: LinguistAttributes{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, attributes.Linguist(tt.path))
+		})
+	}
+
+	value, ok := attributes.Get("api/user.pb.go", "linguist-generated")
+	assert.True(t, ok)
+	assert.Equal(t, "false", value)
+
+	_, ok = attributes.Get("docs/api/index.md", "linguist-documentation")
+	assert.False(t, ok)
+}
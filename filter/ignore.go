@@ -0,0 +1,60 @@
+package filter
+
+import (
+	"strings"
+)
+
+// DefaultIgnoreFile is the conventional name of the ignore file repository owners add to opt files out of
+// collection, see GitHubFilter.IgnoreFile.
+const DefaultIgnoreFile = ".cocoignore"
+
+// IgnoreFile is a parsed ignore file listing the paths excluded from collection.
+type IgnoreFile struct {
+	rules []ignoreRule
+}
+
+type ignoreRule struct {
+	pattern pathPattern
+	negate  bool
+}
+
+// ParseIgnoreFile parses the content of an ignore file, which uses the syntax of .gitignore.
+//
+// Every line holds a pattern. A pattern matching a directory ignores everything inside it, a leading "!"
+// re-includes paths ignored by an earlier pattern and the last matching pattern wins. Blank lines and
+// comments are ignored, "\#" and "\!" escape a leading "#" or "!".
+func ParseIgnoreFile(content string) IgnoreFile {
+	var ignore IgnoreFile
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var rule ignoreRule
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		line = strings.TrimPrefix(line, `\`)
+		if line == "" {
+			continue
+		}
+
+		rule.pattern = compilePattern(line)
+		ignore.rules = append(ignore.rules, rule)
+	}
+
+	return ignore
+}
+
+// Ignored reports whether the given path is excluded by the ignore file.
+func (f IgnoreFile) Ignored(filePath string) bool {
+	ignored := false
+	for _, rule := range f.rules {
+		if rule.pattern.matchWithParents(filePath) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
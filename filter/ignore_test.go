@@ -0,0 +1,37 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIgnoreFile_Ignored(t *testing.T) {
+	ignore := ParseIgnoreFile(`
+# Drafts are not published.
+drafts/
+*.tmp
+/internal/**
+!internal/handbook.md
+\#notes.md
+`)
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "README.md"},
+		{path: "drafts/plan.md", want: true},
+		{path: "docs/drafts/plan.md", want: true},
+		{path: "docs/notes.tmp", want: true},
+		{path: "internal/secrets.md", want: true},
+		{path: "internal/handbook.md"},
+		{path: "docs/internal/index.md"},
+		{path: "#notes.md", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, ignore.Ignored(tt.path))
+		})
+	}
+}
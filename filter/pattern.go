@@ -0,0 +1,142 @@
+package filter
+
+import (
+	"path"
+	"strings"
+)
+
+// pathPattern is a compiled gitignore-style path pattern as used by .gitattributes, CODEOWNERS and
+// similar files.
+//
+// A pattern without a slash matches at any depth, a pattern containing a slash is anchored at the
+// repository root, "**" matches any number of directories and a trailing slash restricts the pattern
+// to directories.
+type pathPattern struct {
+	segments []string
+	dirOnly  bool
+}
+
+// compilePattern compiles a gitignore-style pattern.
+func compilePattern(pattern string) pathPattern {
+	var p pathPattern
+	if strings.HasSuffix(pattern, "/") {
+		p.dirOnly = true
+		pattern = strings.TrimRight(pattern, "/")
+	}
+
+	if !strings.Contains(pattern, "/") {
+		pattern = "**/" + pattern
+	}
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	p.segments = strings.Split(pattern, "/")
+	return p
+}
+
+// match reports whether the pattern matches filePath itself.
+// Directory-only patterns never match a file path.
+func (p pathPattern) match(filePath string) bool {
+	if p.dirOnly {
+		return false
+	}
+	return matchSegments(p.segments, strings.Split(strings.Trim(filePath, "/"), "/"))
+}
+
+// matchWithParents reports whether the pattern matches filePath or any of the directories containing
+// it, which is how gitignore and CODEOWNERS patterns apply to whole directories.
+func (p pathPattern) matchWithParents(filePath string) bool {
+	segments := strings.Split(strings.Trim(filePath, "/"), "/")
+	if !p.dirOnly && matchSegments(p.segments, segments) {
+		return true
+	}
+	for i := len(segments) - 1; i > 0; i-- {
+		if matchSegments(p.segments, segments[:i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments matches path segments against pattern segments, where a "**" segment matches zero
+// or more path segments. A trailing "**" matches everything inside a directory but not the directory
+// itself.
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			if len(pattern) == 1 {
+				return len(segments) > 0
+			}
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], segments[0]); err != nil || !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+
+	return len(segments) == 0
+}
+
+// matchGlob reports whether a doublestar-style glob matches filePath. Unlike gitignore-style patterns the
+// glob is always anchored at the repository root, so "*.md" only matches files in the root and
+// "**/*.md" matches them at any depth.
+func matchGlob(glob, filePath string) bool {
+	return matchSegments(strings.Split(strings.Trim(glob, "/"), "/"), strings.Split(strings.Trim(filePath, "/"), "/"))
+}
+
+// matchAnyGlob reports whether any of the globs matches filePath.
+func matchAnyGlob(globs []string, filePath string) bool {
+	for _, glob := range globs {
+		if matchGlob(glob, filePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPath reports whether filePath passes the Include, Exclude, IncludeRegexp and ExcludeRegexp
+// patterns of the filter.
+func (f GitHubFilter) matchPath(filePath string) bool {
+	if len(f.Include) > 0 && !matchAnyGlob(f.Include, filePath) {
+		return false
+	}
+	if matchAnyGlob(f.Exclude, filePath) {
+		return false
+	}
+	if f.IncludeRegexp != nil && !f.IncludeRegexp.MatchString(filePath) {
+		return false
+	}
+	if f.ExcludeRegexp != nil && f.ExcludeRegexp.MatchString(filePath) {
+		return false
+	}
+	return true
+}
+
+// Match reports whether filePath is inside FilePath and passes FileTypes and the path patterns of the
+// filter. The options needing the repository, such as the .gitattributes checks, MaxFileSize and SkipBinary,
+// are not applied.
+func (f GitHubFilter) Match(filePath string) bool {
+	return InDirectory(filePath, f.FilePath) && f.matchFileTypes(filePath) && f.matchPath(filePath)
+}
+
+// matchFileTypes reports whether filePath ends with one of the FileTypes of the filter, if there are any.
+func (f GitHubFilter) matchFileTypes(filePath string) bool {
+	if len(f.FileTypes) == 0 {
+		return true
+	}
+	for _, fileType := range f.FileTypes {
+		if strings.HasSuffix(filePath, fileType) {
+			return true
+		}
+	}
+	return false
+}
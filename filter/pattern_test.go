@@ -0,0 +1,125 @@
+package filter
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathPattern_Match(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "*.md", path: "README.md", want: true},
+		{pattern: "*.md", path: "docs/guides/setup.md", want: true},
+		{pattern: "*.md", path: "docs/setup.txt", want: false},
+		{pattern: "/README.md", path: "README.md", want: true},
+		{pattern: "/README.md", path: "docs/README.md", want: false},
+		{pattern: "docs/*.md", path: "docs/index.md", want: true},
+		{pattern: "docs/*.md", path: "docs/guides/setup.md", want: false},
+		{pattern: "docs/**/*.md", path: "docs/index.md", want: true},
+		{pattern: "docs/**/*.md", path: "docs/guides/setup.md", want: true},
+		{pattern: "vendor/**", path: "vendor/a/b.go", want: true},
+		{pattern: "vendor/**", path: "vendor", want: false},
+		{pattern: "vendor/", path: "vendor/a.go", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, compilePattern(tt.pattern).match(tt.path))
+		})
+	}
+}
+
+func TestPathPattern_MatchWithParents(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "vendor/", path: "vendor/a.go", want: true},
+		{pattern: "vendor/", path: "lib/vendor/a.go", want: true},
+		{pattern: "vendor/", path: "vendor", want: false},
+		{pattern: "/docs", path: "docs/guides/setup.md", want: true},
+		{pattern: "/docs", path: "src/docs/setup.md", want: false},
+		{pattern: "*.md", path: "docs/setup.md", want: true},
+		{pattern: "docs/*", path: "docs/guides/setup.md", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, compilePattern(tt.pattern).matchWithParents(tt.path))
+		})
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		glob string
+		path string
+		want bool
+	}{
+		{glob: "*.md", path: "README.md", want: true},
+		{glob: "*.md", path: "docs/index.md", want: false},
+		{glob: "**/*.md", path: "README.md", want: true},
+		{glob: "**/*.md", path: "docs/guides/setup.md", want: true},
+		{glob: "docs/**/*.md", path: "docs/guides/setup.md", want: true},
+		{glob: "docs/**/*.md", path: "src/docs/index.md", want: false},
+		{glob: "**/testdata/**", path: "pkg/testdata/golden.json", want: true},
+		{glob: "/docs/*", path: "docs/index.md", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.glob+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchGlob(tt.glob, tt.path))
+		})
+	}
+}
+
+func TestGitHubFilter_MatchPath(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter GitHubFilter
+		path   string
+		want   bool
+	}{
+		{name: "no patterns", filter: GitHubFilter{}, path: "any/file.go", want: true},
+		{name: "included", filter: GitHubFilter{Include: []string{"docs/**"}}, path: "docs/index.md", want: true},
+		{name: "not included", filter: GitHubFilter{Include: []string{"docs/**"}}, path: "src/main.go", want: false},
+		{name: "excluded", filter: GitHubFilter{Include: []string{"docs/**"}, Exclude: []string{"**/drafts/**"}}, path: "docs/drafts/plan.md", want: false},
+		{name: "regexp included", filter: GitHubFilter{IncludeRegexp: regexp.MustCompile(`\.mdx?$`)}, path: "docs/page.mdx", want: true},
+		{name: "regexp not included", filter: GitHubFilter{IncludeRegexp: regexp.MustCompile(`\.mdx?$`)}, path: "docs/page.html", want: false},
+		{name: "regexp excluded", filter: GitHubFilter{ExcludeRegexp: regexp.MustCompile(`(^|/)CHANGELOG`)}, path: "pkg/CHANGELOG.md", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.matchPath(tt.path))
+		})
+	}
+}
+
+func TestGitHubFilter_Match(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter GitHubFilter
+		path   string
+		want   bool
+	}{
+		{name: "no file path", filter: GitHubFilter{}, path: "any/file.go", want: true},
+		{name: "inside the file path", filter: GitHubFilter{FilePath: "docs"}, path: "docs/index.md", want: true},
+		{name: "file path with slashes", filter: GitHubFilter{FilePath: "/docs/"}, path: "docs/index.md", want: true},
+		{name: "sibling sharing the prefix", filter: GitHubFilter{FilePath: "docs"}, path: "docs-old/index.md", want: false},
+		{name: "file type", filter: GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}}, path: "docs/logo.png", want: false},
+		{name: "excluded", filter: GitHubFilter{FilePath: "docs", Exclude: []string{"**/drafts/**"}}, path: "docs/drafts/plan.md", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Match(tt.path))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package filter
+
+import (
+	"strings"
+)
+
+// RepositoryFilter narrows down the repositories that are crawled, so collection from large organizations
+// can be scoped declaratively instead of by listing repositories.
+//
+// Topics only selects repositories tagged with at least one of the given topics, Languages repositories
+// whose primary language is one of the given languages, both compared case-insensitively.
+// ExcludeArchived and ExcludeForks skip archived repositories and forks.
+type RepositoryFilter struct {
+	Topics          []string
+	Languages       []string
+	ExcludeArchived bool
+	ExcludeForks    bool
+}
+
+// IsZero reports whether the filter selects every repository.
+func (f RepositoryFilter) IsZero() bool {
+	return len(f.Topics) == 0 && len(f.Languages) == 0 && !f.ExcludeArchived && !f.ExcludeForks
+}
+
+// Match reports whether a repository with the given attributes passes the filter.
+func (f RepositoryFilter) Match(topics []string, language string, archived, fork bool) bool {
+	if (archived && f.ExcludeArchived) || (fork && f.ExcludeForks) {
+		return false
+	}
+	if len(f.Languages) > 0 && !containsFold(f.Languages, language) {
+		return false
+	}
+	if len(f.Topics) == 0 {
+		return true
+	}
+	for _, topic := range topics {
+		if containsFold(f.Topics, topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold reports whether values contains s, compared case-insensitively.
+func containsFold(values []string, s string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, s) {
+			return true
+		}
+	}
+	return false
+}
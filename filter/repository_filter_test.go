@@ -0,0 +1,33 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryFilter_Match(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   RepositoryFilter
+		topics   []string
+		language string
+		archived bool
+		fork     bool
+		want     bool
+	}{
+		{name: "empty filter", want: true, archived: true, fork: true},
+		{name: "topic", filter: RepositoryFilter{Topics: []string{"docs", "Handbook"}}, topics: []string{"go", "handbook"}, want: true},
+		{name: "missing topic", filter: RepositoryFilter{Topics: []string{"docs"}}, topics: []string{"go"}},
+		{name: "language", filter: RepositoryFilter{Languages: []string{"go"}}, language: "Go", want: true},
+		{name: "other language", filter: RepositoryFilter{Languages: []string{"Go"}}, language: "Rust"},
+		{name: "archived", filter: RepositoryFilter{ExcludeArchived: true}, archived: true},
+		{name: "fork", filter: RepositoryFilter{ExcludeForks: true}, fork: true},
+		{name: "fork allowed", filter: RepositoryFilter{ExcludeArchived: true}, fork: true, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Match(tt.topics, tt.language, tt.archived, tt.fork))
+		})
+	}
+}
@@ -1,374 +1,1047 @@
-// Package cocogh to collect GitHub contents
 package cocogh
 
 import (
-	"context"
-	"fmt"
 	"net/http"
-	"strings"
-	"time"
 
-	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shaharia-lab/coco-gh/v2/github"
 	"github.com/shurcooL/githubv4"
 )
 
-// GHQueryForListFiles is a struct representing the GraphQL query for listing files in a GitHub repository.
-// It contains the information necessary to make the query, including the owner, name, expression, and path of the repository.
-type GHQueryForListFiles struct {
-	Repository struct {
-		Object struct {
-			Tree struct {
-				Entries []struct {
-					Name string
-					Path string
-					Type string
-				}
-			} `graphql:"... on Tree"`
-		} `graphql:"object(expression: $expression)"`
-	} `graphql:"repository(owner: $owner, name: $name)"`
+// AppTransport is an alias of github.AppTransport.
+//
+// Deprecated: use github.AppTransport instead.
+type AppTransport = github.AppTransport
+
+// ArchiveFile is an alias of github.ArchiveFile.
+//
+// Deprecated: use github.ArchiveFile instead.
+type ArchiveFile = github.ArchiveFile
+
+// ArchiveOpsClient is an alias of github.ArchiveOpsClient.
+//
+// Deprecated: use github.ArchiveOpsClient instead.
+type ArchiveOpsClient = github.ArchiveOpsClient
+
+// AuthConfig is an alias of github.AuthConfig.
+//
+// Deprecated: use github.AuthConfig instead.
+type AuthConfig = github.AuthConfig
+
+// BranchProtection is an alias of github.BranchProtection.
+//
+// Deprecated: use github.BranchProtection instead.
+type BranchProtection = github.BranchProtection
+
+// CacheTransport is an alias of github.CacheTransport.
+//
+// Deprecated: use github.CacheTransport instead.
+type CacheTransport = github.CacheTransport
+
+// CachedResponse is an alias of github.CachedResponse.
+//
+// Deprecated: use github.CachedResponse instead.
+type CachedResponse = github.CachedResponse
+
+// ChangedFile is an alias of github.ChangedFile.
+//
+// Deprecated: use github.ChangedFile instead.
+type ChangedFile = github.ChangedFile
+
+// ChangedFiles is an alias of github.ChangedFiles.
+//
+// Deprecated: use github.ChangedFiles instead.
+type ChangedFiles = github.ChangedFiles
+
+// Clock is an alias of github.Clock.
+//
+// Deprecated: use github.Clock instead.
+type Clock = github.Clock
+
+// Comment is an alias of github.Comment.
+//
+// Deprecated: use github.Comment instead.
+type Comment = github.Comment
+
+// CommitOpsClient is an alias of github.CommitOpsClient.
+//
+// Deprecated: use github.CommitOpsClient instead.
+type CommitOpsClient = github.CommitOpsClient
+
+// CommunityHealthBundle is an alias of github.CommunityHealthBundle.
+//
+// Deprecated: use github.CommunityHealthBundle instead.
+type CommunityHealthBundle = github.CommunityHealthBundle
+
+// CommunityHealthFile is an alias of github.CommunityHealthFile.
+//
+// Deprecated: use github.CommunityHealthFile instead.
+type CommunityHealthFile = github.CommunityHealthFile
+
+// CompareOpsClient is an alias of github.CompareOpsClient.
+//
+// Deprecated: use github.CompareOpsClient instead.
+type CompareOpsClient = github.CompareOpsClient
+
+// Config is an alias of github.Config.
+//
+// Deprecated: use github.Config instead.
+type Config = github.Config
+
+// ConfigError is an alias of github.ConfigError.
+//
+// Deprecated: use github.ConfigError instead.
+type ConfigError = github.ConfigError
+
+// ConfigErrors is an alias of github.ConfigErrors.
+//
+// Deprecated: use github.ConfigErrors instead.
+type ConfigErrors = github.ConfigErrors
+
+// CrawlEstimate is an alias of github.CrawlEstimate.
+//
+// Deprecated: use github.CrawlEstimate instead.
+type CrawlEstimate = github.CrawlEstimate
+
+// DependencyGraphOpsClient is an alias of github.DependencyGraphOpsClient.
+//
+// Deprecated: use github.DependencyGraphOpsClient instead.
+type DependencyGraphOpsClient = github.DependencyGraphOpsClient
+
+// DiscoveryOpsClient is an alias of github.DiscoveryOpsClient.
+//
+// Deprecated: use github.DiscoveryOpsClient instead.
+type DiscoveryOpsClient = github.DiscoveryOpsClient
+
+// DiscoveryOptions is an alias of github.DiscoveryOptions.
+//
+// Deprecated: use github.DiscoveryOptions instead.
+type DiscoveryOptions = github.DiscoveryOptions
+
+// Discussion is an alias of github.Discussion.
+//
+// Deprecated: use github.Discussion instead.
+type Discussion = github.Discussion
+
+// DiscussionFilter is an alias of github.DiscussionFilter.
+//
+// Deprecated: use github.DiscussionFilter instead.
+type DiscussionFilter = github.DiscussionFilter
+
+// Fetcher is an alias of github.Fetcher.
+//
+// Deprecated: use github.Fetcher instead.
+type Fetcher = github.Fetcher
+
+// FileHTTPCache is an alias of github.FileHTTPCache.
+//
+// Deprecated: use github.FileHTTPCache instead.
+type FileHTTPCache = github.FileHTTPCache
+
+// FileOptions is an alias of github.FileOptions.
+//
+// Deprecated: use github.FileOptions instead.
+type FileOptions = github.FileOptions
+
+// FileSyncStore is an alias of github.FileSyncStore.
+//
+// Deprecated: use github.FileSyncStore instead.
+type FileSyncStore = github.FileSyncStore
+
+// FilterConfig is an alias of github.FilterConfig.
+//
+// Deprecated: use github.FilterConfig instead.
+type FilterConfig = github.FilterConfig
+
+// GHBlob is an alias of github.GHBlob.
+//
+// Deprecated: use github.GHBlob instead.
+type GHBlob = github.GHBlob
+
+// GHDiscussion is an alias of github.GHDiscussion.
+//
+// Deprecated: use github.GHDiscussion instead.
+type GHDiscussion = github.GHDiscussion
+
+// GHDiscussionComment is an alias of github.GHDiscussionComment.
+//
+// Deprecated: use github.GHDiscussionComment instead.
+type GHDiscussionComment = github.GHDiscussionComment
+
+// GHDiscussionComments is an alias of github.GHDiscussionComments.
+//
+// Deprecated: use github.GHDiscussionComments instead.
+type GHDiscussionComments = github.GHDiscussionComments
+
+// GHDiscussionReplies is an alias of github.GHDiscussionReplies.
+//
+// Deprecated: use github.GHDiscussionReplies instead.
+type GHDiscussionReplies = github.GHDiscussionReplies
+
+// GHDiscussionReply is an alias of github.GHDiscussionReply.
+//
+// Deprecated: use github.GHDiscussionReply instead.
+type GHDiscussionReply = github.GHDiscussionReply
+
+// GHProjectField is an alias of github.GHProjectField.
+//
+// Deprecated: use github.GHProjectField instead.
+type GHProjectField = github.GHProjectField
+
+// GHProjectFieldValue is an alias of github.GHProjectFieldValue.
+//
+// Deprecated: use github.GHProjectFieldValue instead.
+type GHProjectFieldValue = github.GHProjectFieldValue
+
+// GHProjectItem is an alias of github.GHProjectItem.
+//
+// Deprecated: use github.GHProjectItem instead.
+type GHProjectItem = github.GHProjectItem
+
+// GHProjectItemIssue is an alias of github.GHProjectItemIssue.
+//
+// Deprecated: use github.GHProjectItemIssue instead.
+type GHProjectItemIssue = github.GHProjectItemIssue
+
+// GHQueryForBlobText is an alias of github.GHQueryForBlobText.
+//
+// Deprecated: use github.GHQueryForBlobText instead.
+type GHQueryForBlobText = github.GHQueryForBlobText
+
+// GHQueryForDefaultBranch is an alias of github.GHQueryForDefaultBranch.
+//
+// Deprecated: use github.GHQueryForDefaultBranch instead.
+type GHQueryForDefaultBranch = github.GHQueryForDefaultBranch
+
+// GHQueryForListDiscussionComments is an alias of github.GHQueryForListDiscussionComments.
+//
+// Deprecated: use github.GHQueryForListDiscussionComments instead.
+type GHQueryForListDiscussionComments = github.GHQueryForListDiscussionComments
+
+// GHQueryForListDiscussionReplies is an alias of github.GHQueryForListDiscussionReplies.
+//
+// Deprecated: use github.GHQueryForListDiscussionReplies instead.
+type GHQueryForListDiscussionReplies = github.GHQueryForListDiscussionReplies
+
+// GHQueryForListDiscussions is an alias of github.GHQueryForListDiscussions.
+//
+// Deprecated: use github.GHQueryForListDiscussions instead.
+type GHQueryForListDiscussions = github.GHQueryForListDiscussions
+
+// GHQueryForListFiles is an alias of github.GHQueryForListFiles.
+//
+// Deprecated: use github.GHQueryForListFiles instead.
+type GHQueryForListFiles = github.GHQueryForListFiles
+
+// GHQueryForListProjectItems is an alias of github.GHQueryForListProjectItems.
+//
+// Deprecated: use github.GHQueryForListProjectItems instead.
+type GHQueryForListProjectItems = github.GHQueryForListProjectItems
+
+// GHQueryForRepositoryAttributes is an alias of github.GHQueryForRepositoryAttributes.
+//
+// Deprecated: use github.GHQueryForRepositoryAttributes instead.
+type GHQueryForRepositoryAttributes = github.GHQueryForRepositoryAttributes
+
+// GHTreeEntry is an alias of github.GHTreeEntry.
+//
+// Deprecated: use github.GHTreeEntry instead.
+type GHTreeEntry = github.GHTreeEntry
+
+// GHTreeEntryObject is an alias of github.GHTreeEntryObject.
+//
+// Deprecated: use github.GHTreeEntryObject instead.
+type GHTreeEntryObject = github.GHTreeEntryObject
+
+// Gist is an alias of github.Gist.
+//
+// Deprecated: use github.Gist instead.
+type Gist = github.Gist
+
+// GistFile is an alias of github.GistFile.
+//
+// Deprecated: use github.GistFile instead.
+type GistFile = github.GistFile
+
+// GistFilter is an alias of github.GistFilter.
+//
+// Deprecated: use github.GistFilter instead.
+type GistFilter = github.GistFilter
+
+// GistOpsClient is an alias of github.GistOpsClient.
+//
+// Deprecated: use github.GistOpsClient instead.
+type GistOpsClient = github.GistOpsClient
+
+// GitFetcher is an alias of github.GitFetcher.
+//
+// Deprecated: use github.GitFetcher instead.
+type GitFetcher = github.GitFetcher
+
+// GitHub is an alias of github.GitHub.
+//
+// Deprecated: use github.GitHub instead.
+type GitHub = github.GitHub
+
+// GitHubCommitsOpsClient is an alias of github.GitHubCommitsOpsClient.
+//
+// Deprecated: use github.GitHubCommitsOpsClient instead.
+type GitHubCommitsOpsClient = github.GitHubCommitsOpsClient
+
+// GitHubConfig is an alias of github.GitHubConfig.
+//
+// Deprecated: use github.GitHubConfig instead.
+type GitHubConfig = github.GitHubConfig
+
+// GitWikiFetcher is an alias of github.GitWikiFetcher.
+//
+// Deprecated: use github.GitWikiFetcher instead.
+type GitWikiFetcher = github.GitWikiFetcher
+
+// GraphQLClient is an alias of github.GraphQLClient.
+//
+// Deprecated: use github.GraphQLClient instead.
+type GraphQLClient = github.GraphQLClient
+
+// HTTPCache is an alias of github.HTTPCache.
+//
+// Deprecated: use github.HTTPCache instead.
+type HTTPCache = github.HTTPCache
+
+// Issue is an alias of github.Issue.
+//
+// Deprecated: use github.Issue instead.
+type Issue = github.Issue
+
+// IssueFilter is an alias of github.IssueFilter.
+//
+// Deprecated: use github.IssueFilter instead.
+type IssueFilter = github.IssueFilter
+
+// IssueOpsClient is an alias of github.IssueOpsClient.
+//
+// Deprecated: use github.IssueOpsClient instead.
+type IssueOpsClient = github.IssueOpsClient
+
+// License is an alias of github.License.
+//
+// Deprecated: use github.License instead.
+type License = github.License
+
+// LicenseOpsClient is an alias of github.LicenseOpsClient.
+//
+// Deprecated: use github.LicenseOpsClient instead.
+type LicenseOpsClient = github.LicenseOpsClient
+
+// Licenses is an alias of github.Licenses.
+//
+// Deprecated: use github.Licenses instead.
+type Licenses = github.Licenses
+
+// Logger is an alias of github.Logger.
+//
+// Deprecated: use github.Logger instead.
+type Logger = github.Logger
+
+// LoggingTransport is an alias of github.LoggingTransport.
+//
+// Deprecated: use github.LoggingTransport instead.
+type LoggingTransport = github.LoggingTransport
+
+// MemoryHTTPCache is an alias of github.MemoryHTTPCache.
+//
+// Deprecated: use github.MemoryHTTPCache instead.
+type MemoryHTTPCache = github.MemoryHTTPCache
+
+// MemorySyncStore is an alias of github.MemorySyncStore.
+//
+// Deprecated: use github.MemorySyncStore instead.
+type MemorySyncStore = github.MemorySyncStore
+
+// MergeStrategy is an alias of github.MergeStrategy.
+//
+// Deprecated: use github.MergeStrategy instead.
+type MergeStrategy = github.MergeStrategy
+
+// Metrics is an alias of github.Metrics.
+//
+// Deprecated: use github.Metrics instead.
+type Metrics = github.Metrics
+
+// MetricsTransport is an alias of github.MetricsTransport.
+//
+// Deprecated: use github.MetricsTransport instead.
+type MetricsTransport = github.MetricsTransport
+
+// NopMetrics is an alias of github.NopMetrics.
+//
+// Deprecated: use github.NopMetrics instead.
+type NopMetrics = github.NopMetrics
+
+// Option is an alias of github.Option.
+//
+// Deprecated: use github.Option instead.
+type Option = github.Option
+
+// PartialError is an alias of github.PartialError.
+//
+// Deprecated: use github.PartialError instead.
+type PartialError = github.PartialError
+
+// PullRequest is an alias of github.PullRequest.
+//
+// Deprecated: use github.PullRequest instead.
+type PullRequest = github.PullRequest
+
+// PullRequestFilter is an alias of github.PullRequestFilter.
+//
+// Deprecated: use github.PullRequestFilter instead.
+type PullRequestFilter = github.PullRequestFilter
+
+// PullRequestOpsClient is an alias of github.PullRequestOpsClient.
+//
+// Deprecated: use github.PullRequestOpsClient instead.
+type PullRequestOpsClient = github.PullRequestOpsClient
+
+// RateLimitOptions is an alias of github.RateLimitOptions.
+//
+// Deprecated: use github.RateLimitOptions instead.
+type RateLimitOptions = github.RateLimitOptions
+
+// RateLimitQuota is an alias of github.RateLimitQuota.
+//
+// Deprecated: use github.RateLimitQuota instead.
+type RateLimitQuota = github.RateLimitQuota
+
+// RateLimitTransport is an alias of github.RateLimitTransport.
+//
+// Deprecated: use github.RateLimitTransport instead.
+type RateLimitTransport = github.RateLimitTransport
+
+// RateLimitedError is an alias of github.RateLimitedError.
+//
+// Deprecated: use github.RateLimitedError instead.
+type RateLimitedError = github.RateLimitedError
+
+// ReadmeOpsClient is an alias of github.ReadmeOpsClient.
+//
+// Deprecated: use github.ReadmeOpsClient instead.
+type ReadmeOpsClient = github.ReadmeOpsClient
+
+// Release is an alias of github.Release.
+//
+// Deprecated: use github.Release instead.
+type Release = github.Release
+
+// ReleaseAsset is an alias of github.ReleaseAsset.
+//
+// Deprecated: use github.ReleaseAsset instead.
+type ReleaseAsset = github.ReleaseAsset
+
+// ReleaseOpsClient is an alias of github.ReleaseOpsClient.
+//
+// Deprecated: use github.ReleaseOpsClient instead.
+type ReleaseOpsClient = github.ReleaseOpsClient
+
+// RepositoryMetadata is an alias of github.RepositoryMetadata.
+//
+// Deprecated: use github.RepositoryMetadata instead.
+type RepositoryMetadata = github.RepositoryMetadata
+
+// RepositoryOpsClient is an alias of github.RepositoryOpsClient.
+//
+// Deprecated: use github.RepositoryOpsClient instead.
+type RepositoryOpsClient = github.RepositoryOpsClient
+
+// RepositoryRef is an alias of github.RepositoryRef.
+//
+// Deprecated: use github.RepositoryRef instead.
+type RepositoryRef = github.RepositoryRef
+
+// RepositorySettings is an alias of github.RepositorySettings.
+//
+// Deprecated: use github.RepositorySettings instead.
+type RepositorySettings = github.RepositorySettings
+
+// RetryPolicy is an alias of github.RetryPolicy.
+//
+// Deprecated: use github.RetryPolicy instead.
+type RetryPolicy = github.RetryPolicy
+
+// RetryTransport is an alias of github.RetryTransport.
+//
+// Deprecated: use github.RetryTransport instead.
+type RetryTransport = github.RetryTransport
+
+// SecurityAdvisoryFilter is an alias of github.SecurityAdvisoryFilter.
+//
+// Deprecated: use github.SecurityAdvisoryFilter instead.
+type SecurityAdvisoryFilter = github.SecurityAdvisoryFilter
+
+// SecurityOpsClient is an alias of github.SecurityOpsClient.
+//
+// Deprecated: use github.SecurityOpsClient instead.
+type SecurityOpsClient = github.SecurityOpsClient
+
+// SinkConfig is an alias of github.SinkConfig.
+//
+// Deprecated: use github.SinkConfig instead.
+type SinkConfig = github.SinkConfig
+
+// Span is an alias of github.Span.
+//
+// Deprecated: use github.Span instead.
+type Span = github.Span
+
+// SpanAttribute is an alias of github.SpanAttribute.
+//
+// Deprecated: use github.SpanAttribute instead.
+type SpanAttribute = github.SpanAttribute
+
+// SubmoduleRef is an alias of github.SubmoduleRef.
+//
+// Deprecated: use github.SubmoduleRef instead.
+type SubmoduleRef = github.SubmoduleRef
+
+// SyncState is an alias of github.SyncState.
+//
+// Deprecated: use github.SyncState instead.
+type SyncState = github.SyncState
+
+// SyncStore is an alias of github.SyncStore.
+//
+// Deprecated: use github.SyncStore instead.
+type SyncStore = github.SyncStore
+
+// Tracer is an alias of github.Tracer.
+//
+// Deprecated: use github.Tracer instead.
+type Tracer = github.Tracer
+
+// TransformersConfig is an alias of github.TransformersConfig.
+//
+// Deprecated: use github.TransformersConfig instead.
+type TransformersConfig = github.TransformersConfig
+
+// TreeNode is an alias of github.TreeNode.
+//
+// Deprecated: use github.TreeNode instead.
+type TreeNode = github.TreeNode
+
+// TreeOpsClient is an alias of github.TreeOpsClient.
+//
+// Deprecated: use github.TreeOpsClient instead.
+type TreeOpsClient = github.TreeOpsClient
+
+// WarnLogger is an alias of github.WarnLogger.
+//
+// Deprecated: use github.WarnLogger instead.
+type WarnLogger = github.WarnLogger
+
+// WikiFetcher is an alias of github.WikiFetcher.
+//
+// Deprecated: use github.WikiFetcher instead.
+type WikiFetcher = github.WikiFetcher
+
+// WikiPage is an alias of github.WikiPage.
+//
+// Deprecated: use github.WikiPage instead.
+type WikiPage = github.WikiPage
+
+// Workflow is an alias of github.Workflow.
+//
+// Deprecated: use github.Workflow instead.
+type Workflow = github.Workflow
+
+// WorkflowJob is an alias of github.WorkflowJob.
+//
+// Deprecated: use github.WorkflowJob instead.
+type WorkflowJob = github.WorkflowJob
+
+// AttributeCommit is github.AttributeCommit.
+//
+// Deprecated: use github.AttributeCommit instead.
+const AttributeCommit = github.AttributeCommit
+
+// AttributeOwner is github.AttributeOwner.
+//
+// Deprecated: use github.AttributeOwner instead.
+const AttributeOwner = github.AttributeOwner
+
+// AttributePath is github.AttributePath.
+//
+// Deprecated: use github.AttributePath instead.
+const AttributePath = github.AttributePath
+
+// AttributeQuery is github.AttributeQuery.
+//
+// Deprecated: use github.AttributeQuery instead.
+const AttributeQuery = github.AttributeQuery
+
+// AttributeRef is github.AttributeRef.
+//
+// Deprecated: use github.AttributeRef instead.
+const AttributeRef = github.AttributeRef
+
+// AttributeRepository is github.AttributeRepository.
+//
+// Deprecated: use github.AttributeRepository instead.
+const AttributeRepository = github.AttributeRepository
+
+// CommunityHealthCodeOfConduct is github.CommunityHealthCodeOfConduct.
+//
+// Deprecated: use github.CommunityHealthCodeOfConduct instead.
+const CommunityHealthCodeOfConduct = github.CommunityHealthCodeOfConduct
+
+// CommunityHealthContributing is github.CommunityHealthContributing.
+//
+// Deprecated: use github.CommunityHealthContributing instead.
+const CommunityHealthContributing = github.CommunityHealthContributing
+
+// CommunityHealthReadme is github.CommunityHealthReadme.
+//
+// Deprecated: use github.CommunityHealthReadme instead.
+const CommunityHealthReadme = github.CommunityHealthReadme
+
+// CommunityHealthSecurity is github.CommunityHealthSecurity.
+//
+// Deprecated: use github.CommunityHealthSecurity instead.
+const CommunityHealthSecurity = github.CommunityHealthSecurity
+
+// CommunityHealthSupport is github.CommunityHealthSupport.
+//
+// Deprecated: use github.CommunityHealthSupport instead.
+const CommunityHealthSupport = github.CommunityHealthSupport
+
+// DefaultAPIBaseURL is github.DefaultAPIBaseURL.
+//
+// Deprecated: use github.DefaultAPIBaseURL instead.
+const DefaultAPIBaseURL = github.DefaultAPIBaseURL
+
+// DefaultRateLimitRetries is github.DefaultRateLimitRetries.
+//
+// Deprecated: use github.DefaultRateLimitRetries instead.
+const DefaultRateLimitRetries = github.DefaultRateLimitRetries
+
+// DefaultRetries is github.DefaultRetries.
+//
+// Deprecated: use github.DefaultRetries instead.
+const DefaultRetries = github.DefaultRetries
+
+// DefaultRetryBaseDelay is github.DefaultRetryBaseDelay.
+//
+// Deprecated: use github.DefaultRetryBaseDelay instead.
+const DefaultRetryBaseDelay = github.DefaultRetryBaseDelay
+
+// DefaultRetryMaxDelay is github.DefaultRetryMaxDelay.
+//
+// Deprecated: use github.DefaultRetryMaxDelay instead.
+const DefaultRetryMaxDelay = github.DefaultRetryMaxDelay
+
+// LicenseNoAssertion is github.LicenseNoAssertion.
+//
+// Deprecated: use github.LicenseNoAssertion instead.
+const LicenseNoAssertion = github.LicenseNoAssertion
+
+// MergeStrategyFirstParent is github.MergeStrategyFirstParent.
+//
+// Deprecated: use github.MergeStrategyFirstParent instead.
+const MergeStrategyFirstParent = github.MergeStrategyFirstParent
+
+// MergeStrategyInclude is github.MergeStrategyInclude.
+//
+// Deprecated: use github.MergeStrategyInclude instead.
+const MergeStrategyInclude = github.MergeStrategyInclude
+
+// MergeStrategySkip is github.MergeStrategySkip.
+//
+// Deprecated: use github.MergeStrategySkip instead.
+const MergeStrategySkip = github.MergeStrategySkip
+
+// SinkTypeElasticsearch is github.SinkTypeElasticsearch.
+//
+// Deprecated: use github.SinkTypeElasticsearch instead.
+const SinkTypeElasticsearch = github.SinkTypeElasticsearch
+
+// SinkTypeGCS is github.SinkTypeGCS.
+//
+// Deprecated: use github.SinkTypeGCS instead.
+const SinkTypeGCS = github.SinkTypeGCS
+
+// SinkTypeJSONL is github.SinkTypeJSONL.
+//
+// Deprecated: use github.SinkTypeJSONL instead.
+const SinkTypeJSONL = github.SinkTypeJSONL
+
+// SinkTypeS3 is github.SinkTypeS3.
+//
+// Deprecated: use github.SinkTypeS3 instead.
+const SinkTypeS3 = github.SinkTypeS3
+
+// SpanCrawlRepository is github.SpanCrawlRepository.
+//
+// Deprecated: use github.SpanCrawlRepository instead.
+const SpanCrawlRepository = github.SpanCrawlRepository
+
+// SpanFetchCommit is github.SpanFetchCommit.
+//
+// Deprecated: use github.SpanFetchCommit instead.
+const SpanFetchCommit = github.SpanFetchCommit
+
+// SpanGraphQLQuery is github.SpanGraphQLQuery.
+//
+// Deprecated: use github.SpanGraphQLQuery instead.
+const SpanGraphQLQuery = github.SpanGraphQLQuery
+
+// ErrForbidden is github.ErrForbidden.
+//
+// Deprecated: use github.ErrForbidden instead.
+var ErrForbidden = github.ErrForbidden
+
+// ErrInvalidConfig is github.ErrInvalidConfig.
+//
+// Deprecated: use github.ErrInvalidConfig instead.
+var ErrInvalidConfig = github.ErrInvalidConfig
+
+// ErrRefNotFound is github.ErrRefNotFound.
+//
+// Deprecated: use github.ErrRefNotFound instead.
+var ErrRefNotFound = github.ErrRefNotFound
+
+// ErrRepoNotFound is github.ErrRepoNotFound.
+//
+// Deprecated: use github.ErrRepoNotFound instead.
+var ErrRepoNotFound = github.ErrRepoNotFound
+
+// ErrUnsupportedClient is github.ErrUnsupportedClient.
+//
+// Deprecated: use github.ErrUnsupportedClient instead.
+var ErrUnsupportedClient = github.ErrUnsupportedClient
+
+// ErrWikiNotFound is github.ErrWikiNotFound.
+//
+// Deprecated: use github.ErrWikiNotFound instead.
+var ErrWikiNotFound = github.ErrWikiNotFound
+
+// BuildChangelog calls github.BuildChangelog.
+//
+// Deprecated: use github.BuildChangelog instead.
+func BuildChangelog(title string, docs []document.Document) document.Document {
+	return github.BuildChangelog(title, docs)
+}
+
+// DetectLicense calls github.DetectLicense.
+//
+// Deprecated: use github.DetectLicense instead.
+func DetectLicense(text string) string {
+	return github.DetectLicense(text)
+}
+
+// LoadConfig calls github.LoadConfig.
+//
+// Deprecated: use github.LoadConfig instead.
+func LoadConfig(path string) (*github.Config, error) {
+	return github.LoadConfig(path)
+}
+
+// NewAppTransport calls github.NewAppTransport.
+//
+// Deprecated: use github.NewAppTransport instead.
+func NewAppTransport(base http.RoundTripper, appID, installationID int64, privateKey []byte) (*github.AppTransport, error) {
+	return github.NewAppTransport(base, appID, installationID, privateKey)
+}
+
+// NewCacheTransport calls github.NewCacheTransport.
+//
+// Deprecated: use github.NewCacheTransport instead.
+func NewCacheTransport(base http.RoundTripper, cache github.HTTPCache) *github.CacheTransport {
+	return github.NewCacheTransport(base, cache)
+}
+
+// NewClientsFromToken calls github.NewClientsFromToken.
+//
+// Deprecated: use github.NewClientsFromToken instead.
+func NewClientsFromToken(token string, opts ...github.Option) (*github.GitHubCommitsOpsClient, *githubv4.Client, error) {
+	return github.NewClientsFromToken(token, opts...)
 }
 
-// Paths represents a collection of file paths that have been added, removed, or modified.
-type Paths struct {
-	Added    []string
-	Removed  []string
-	Modified []string
+// NewFileHTTPCache calls github.NewFileHTTPCache.
+//
+// Deprecated: use github.NewFileHTTPCache instead.
+func NewFileHTTPCache(dir string) *github.FileHTTPCache {
+	return github.NewFileHTTPCache(dir)
 }
 
-// GitHubFilter represents a filter used to narrow down the file paths in a GitHub repository based on the file path and file types.
-type GitHubFilter struct {
-	FilePath  string
-	FileTypes []string
-}
-
-// GitHubConfig represents the configuration for GitHub repositories.
-//
-// Owner represents the owner of the repositories.
-// Repositories represents a list of repository names.
-// DefaultBranch represents the default branch for the repositories.
-// Filter represents the filter to apply when fetching file paths from the repositories.
-type GitHubConfig struct {
-	Owner         string
-	Repositories  []string
-	DefaultBranch string
-	Filter        GitHubFilter
-}
-
-// GitHub stores CommitOpsClient, GraphQLClient and configuration.
-type GitHub struct {
-	Configuration GitHubConfig
-
-	graphQLClient   GraphQLClient
-	commitOpsClient CommitOpsClient
-}
-
-// GraphQLClient is an interface to help test the GitHub GraphQLClient.
-type GraphQLClient interface {
-	Query(ctx context.Context, q interface{}, variables map[string]interface{}) error
-}
-
-// CommitOpsClient is an interface to help test the GitHub GitHubCommitsOpsClient.
-type CommitOpsClient interface {
-	ListCommits(ctx context.Context, owner, repo string, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error)
-	GetCommit(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) (*github.RepositoryCommit, *github.Response, error)
-}
-
-// GitHubCommitsOpsClient is a type that represents an operations client for GitHub commits.
-// It contains a pointer to a GitHub client from the go-github library.
-type GitHubCommitsOpsClient struct {
-	GitHubClient *github.Client
-}
-
-// NewGitHubCommitsOpsClient creates a new GitHubCommitsOpsClient with the given http.Client.
-// It initializes the GitHubClient inside GitHubCommitsOpsClient using the provided http.Client.
-// The GitHubClient is responsible for interacting with the GitHub API.
-func NewGitHubCommitsOpsClient(httpClient *http.Client) *GitHubCommitsOpsClient {
-	gc := github.NewClient(httpClient)
-	return &GitHubCommitsOpsClient{GitHubClient: gc}
-}
-
-// ListCommits fetches the list of commits for a specific repository.
-func (gClient *GitHubCommitsOpsClient) ListCommits(ctx context.Context, owner, repo string, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
-	return gClient.GitHubClient.Repositories.ListCommits(ctx, owner, repo, opts)
-}
-
-// GetCommit retrieves a specific commit from a repository.
-//
-// Parameters:
-//   - ctx: The context.Context used for the API call. It allows you to cancel
-//     the request, set deadlines, etc.
-//   - owner: The username or organization name of the repository owner. This
-//     string identifies the owner of the repository.
-//   - repo: The name of the repository. It specifies which repository's commit
-//     is being retrieved.
-//   - sha: The SHA hash of the commit. This string uniquely identifies the commit
-//     within the repository.
-//   - opts: Optional parameters for the API call, provided as a pointer to
-//     github.ListOptions. This includes pagination options.
-//
-// Returns:
-//   - *github.RepositoryCommit: The retrieved commit information, including details
-//     like the commit message, author, etc.
-//   - *github.Response: The HTTP response from the API call. This includes
-//     information like the status code and headers.
-//   - error: An error instance if an error occurs during the API call. It will be
-//     nil if the call is successful.
-//
-// Example:
-//
-//	commit, resp, err := gClient.GetCommit(ctx, "octocat", "hello-world", "6dcb09b5b57875f334f61aebed695e2e4193db5e", nil)
-func (gClient *GitHubCommitsOpsClient) GetCommit(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) (*github.RepositoryCommit, *github.Response, error) {
-	return gClient.GitHubClient.Repositories.GetCommit(ctx, owner, repo, sha, opts)
-}
-
-// NewGitHubClient creates a new instance of the GitHub client.
-// It takes a CommitOpsClient, GraphQLClient, and GitHubConfig as parameters and returns a pointer to a GitHub struct.
-// The CommitOpsClient is responsible for making REST API calls to the GitHub API.
-// The GraphQLClient is responsible for making GraphQL API calls to the GitHub API.
-// The GitHubConfig contains the configuration parameters for the GitHub client, such as owner, repositories, default branch,
-// and filter options.
-// The new GitHub client is initialized with the provided CommitOpsClient, GraphQLClient, and GitHubConfig.
-// The GitHub client can be used to interact with the GitHub API and perform various operations, such as retrieving file paths for repositories
-// and getting changed file paths since a specified time.
-// Usage:
-//
-//	 commitOpsClient := NewGitHubCommitsOpsClient()
-//	 graphQLClient := NewGraphQLClient()
-//
-//		config := GitHubConfig{
-//		    Owner:         "testowner",
-//		    Repositories:  []string{"repo1", "repo2"},
-//		    DefaultBranch: "main",
-//		    Filter: GitHubFilter{
-//		        FilePath:  "path/to/files",
-//		        FileTypes: []string{".txt"},
-//		    },
-//		}
-//
-//	 githubClient := NewGitHubClient(commitOpsClient, graphQLClient, config)
-//	 filepaths, err := githubClient.GetFilePathsFromRepositories()
-//
-//		if err != nil {
-//		    log.Fatal(err)
-//		}
-//
-//		for _, path := range filepaths {
-//		    fmt.Println(path)
-//		}
-func NewGitHubClient(commitOpsClient CommitOpsClient, graphQLClient GraphQLClient, configuration GitHubConfig) *GitHub {
-	return &GitHub{
-		commitOpsClient: commitOpsClient,
-		graphQLClient:   graphQLClient,
-		Configuration:   configuration,
-	}
-}
-
-// GetFilePathsFromRepositories retrieves the file paths for the repositories specified in the GitHub configuration.
-// It iterates over each repository, calls the getFilePathsForRepo method to get the file paths, and appends them to the files slice.
-// If there are no file types specified in the configuration, it returns the files directly.
-// Otherwise, it filters the files based on the file types specified in the configuration and returns the filtered files.
-// If there's an error during the process, it returns nil and the error.
-//
-// Usage:
-//
-//	repos := []string{"repo1", "repo2", "repo3"}
-//	filePaths, err := GetFilePathsFromRepositories(repos)
-//	if err != nil {
-//	    log.Fatal(err)
-//	}
-//
-//	for _, paths := range filePaths {
-//	    for _, path := range paths {
-//	        fmt.Println(path)
-//	    }
-//	}
-func (c *GitHub) GetFilePathsFromRepositories() ([]string, error) {
-	var files []string
-	for _, repo := range c.Configuration.Repositories {
-		fs, err := c.getFilePathsForRepo(c.Configuration.Owner, repo, fmt.Sprintf("%s:%s", c.Configuration.DefaultBranch, c.Configuration.Filter.FilePath))
-		if err != nil {
-			return nil, err
-		}
-		files = append(files, fs...)
-	}
-
-	if len(c.Configuration.Filter.FileTypes) == 0 {
-		return files, nil
-	}
-
-	var filteredFiles []string
-	for i, file := range files {
-		if !c.hasFileType(file, c.Configuration.Filter.FileTypes) {
-			continue
-		}
-		filteredFiles = append(filteredFiles, files[i])
-	}
-
-	return filteredFiles, nil
-}
-
-// GetChangedFilePathsSince retrieves the list of file paths that have changed in the specified repositories
-// within the specified time frame. The function iterates over repositories defined in the GitHub configuration
-// and uses the GitHub commit operations client to fetch the commits and commit details for each repository.
-// It aggregates the file paths from all repositories into a single Paths object. The function filters these file
-// paths based on the directory filter and the specified time frame and file path filter defined in the GitHub
-// configuration. The Paths object is populated with lists of added, removed, and modified file paths accordingly.
-//
-// Parameters:
-//   - hoursSince: An integer representing the number of hours since the specified time. This parameter is used
-//     to determine the time frame for fetching changed file paths.
-//
-// Returns:
-//   - Paths: A struct containing lists of added, removed, and modified file paths. This struct provides an
-//     organized way to access the changed files.
-//   - error: An error instance if an error occurs during the execution of the function. It will be nil if
-//     the function executes successfully.
-//
-// Usage:
-//
-//	const sinceHours = 24
-//	changedFiles, err := c.GetChangedFilePathsSince(sinceHours)
-//	if err != nil {
-//	    log.Fatal(err)
-//	}
-//
-//	fmt.Println("Added files:", changedFiles.Added)
-//	fmt.Println("Modified files:", changedFiles.Modified)
-//	fmt.Println("Removed files:", changedFiles.Removed)
-func (c *GitHub) GetChangedFilePathsSince(since time.Time) (Paths, error) {
-	ctx := context.Background()
-
-	opt := &github.CommitsListOptions{
-		Since: since,
-		Path:  c.Configuration.Filter.FilePath,
-		ListOptions: github.ListOptions{
-			PerPage: 100,
-		},
-	}
-
-	var paths Paths
-
-	for _, repo := range c.Configuration.Repositories {
-		commitPaths, err := c.getChangedFilePathsForRepo(ctx, repo, opt)
-		if err != nil {
-			return Paths{}, err
-		}
-		paths.Added = append(paths.Added, commitPaths.Added...)
-		paths.Removed = append(paths.Removed, commitPaths.Removed...)
-		paths.Modified = append(paths.Modified, commitPaths.Modified...)
-	}
-
-	return paths, nil
-}
-
-// getFilePathsForRepo fetches the list of file paths for a specific repository, starting from the specified
-// expression. It uses the GitHub GraphQL API to retrieve the repository tree entries and their types, and
-// recursively traverses the repository tree. The function appends file paths to a slice, which is then returned.
-// If an entry is a blob, its path is added to the files slice. For tree entries, the function recurses with
-// the updated expression and appends the returned subfiles to the files slice. If any error occurs during
-// the GraphQL query or traversal, the function returns nil and the error.
-//
-// Parameters:
-//   - owner: A string representing the username of the repository owner. This parameter specifies the owner
-//     of the repository for which file paths are being fetched.
-//   - name: A string representing the name of the repository. This parameter is used to specify the repository
-//     from which the file paths are retrieved.
-//   - expression: A string specifying the starting expression for traversing the repository tree. This
-//     expression determines the starting point of the file path retrieval process.
-//
-// Returns:
-//   - files: A slice of strings, each representing a file path in the repository. This slice includes paths
-//     to all files found in the repository starting from the given expression.
-//   - error: An error instance, if any error occurred during the GraphQL query or traversal. It will be nil
-//     if the function executes successfully.
-//
-// Example usage:
-//
-//	filePaths, err := c.getFilePathsForRepo("octocat", "hello-world", "master:")
-//	if err != nil {
-//	    log.Fatal(err)
-//	}
-//	for _, path := range filePaths {
-//	    fmt.Println(path)
-//	}
-func (c *GitHub) getFilePathsForRepo(owner, name, expression string) ([]string, error) {
-	var query GHQueryForListFiles
-	variables := map[string]interface{}{
-		"owner":      githubv4.String(owner),
-		"name":       githubv4.String(name),
-		"expression": githubv4.String(expression),
-	}
-
-	err := c.graphQLClient.Query(context.Background(), &query, variables)
-	if err != nil {
-		return nil, err
-	}
-
-	var files []string
-	for _, entry := range query.Repository.Object.Tree.Entries {
-		if entry.Type == "blob" {
-			files = append(files, entry.Path)
-		} else if entry.Type == "tree" {
-			subFiles, err := c.getFilePathsForRepo(owner, name, expression+"/"+entry.Name)
-			if err != nil {
-				return nil, err
-			}
-			files = append(files, subFiles...)
-		}
-	}
-
-	return files, nil
-}
-
-// hasFileType checks if the given fileName ends with any of the fileTypes.
-func (c *GitHub) hasFileType(fileName string, fileTypes []string) bool {
-	for _, fileType := range fileTypes {
-		if strings.HasSuffix(fileName, fileType) {
-			return true
-		}
-	}
-	return false
-}
-
-// getChangedFilePathsForRepo fetches the paths of files that have been changed in a specific repository.
-// It takes the repository name, a CommitsListOptions object for filtering commits, and returns a Paths struct with added, removed, and modified files.
-// The method iterates through the commits in the repository, retrieves commit details, and checks each file in the commit against the filter path.
-// Depending on the type of change (added, removed, modified, renamed, copied), the file path is appended to the respective list in the Paths struct.
-// The method returns the Paths struct and an error, if any.
-func (c *GitHub) getChangedFilePathsForRepo(ctx context.Context, repo string, opt *github.CommitsListOptions) (Paths, error) {
-	var paths Paths
-
-	commits, _, err := c.commitOpsClient.ListCommits(ctx, c.Configuration.Owner, repo, opt)
-	if err != nil {
-		return paths, err
-	}
-
-	directory := c.Configuration.Filter.FilePath
-
-	for _, commit := range commits {
-		commitDetails, _, err := c.commitOpsClient.GetCommit(ctx, c.Configuration.Owner, repo, *commit.SHA, nil)
-		if err != nil {
-			return paths, err
-		}
-
-		for _, file := range commitDetails.Files {
-			if strings.HasPrefix(file.GetFilename(), directory) {
-				switch file.GetStatus() {
-				case "removed":
-					paths.Removed = append(paths.Removed, file.GetFilename())
-				case "added":
-					paths.Added = append(paths.Added, file.GetFilename())
-				case "modified", "changed":
-					paths.Modified = append(paths.Modified, file.GetFilename())
-				case "renamed":
-					paths.Removed = append(paths.Removed, file.GetPreviousFilename())
-					paths.Added = append(paths.Added, file.GetFilename())
-				case "copied":
-					paths.Added = append(paths.Added, file.GetFilename())
-				}
-			}
-		}
-	}
-
-	return paths, nil
+// NewFileSyncStore calls github.NewFileSyncStore.
+//
+// Deprecated: use github.NewFileSyncStore instead.
+func NewFileSyncStore(path string) *github.FileSyncStore {
+	return github.NewFileSyncStore(path)
+}
+
+// NewGitHub calls github.NewGitHub.
+//
+// Deprecated: use github.NewGitHub instead.
+func NewGitHub(token string, opts ...github.Option) (*github.GitHub, error) {
+	return github.NewGitHub(token, opts...)
+}
+
+// NewGitHubClient calls github.NewGitHubClient.
+//
+// Deprecated: use github.NewGitHubClient instead.
+func NewGitHubClient(commitOpsClient github.CommitOpsClient, graphQLClient github.GraphQLClient, configuration github.GitHubConfig) *github.GitHub {
+	return github.NewGitHubClient(commitOpsClient, graphQLClient, configuration)
+}
+
+// NewGitHubClientFromApp calls github.NewGitHubClientFromApp.
+//
+// Deprecated: use github.NewGitHubClientFromApp instead.
+func NewGitHubClientFromApp(appID, installationID int64, privateKey []byte, configuration github.GitHubConfig) (*github.GitHub, error) {
+	return github.NewGitHubClientFromApp(appID, installationID, privateKey, configuration)
+}
+
+// NewGitHubClientFromHTTPClient calls github.NewGitHubClientFromHTTPClient.
+//
+// Deprecated: use github.NewGitHubClientFromHTTPClient instead.
+func NewGitHubClientFromHTTPClient(httpClient *http.Client, configuration github.GitHubConfig) (*github.GitHub, error) {
+	return github.NewGitHubClientFromHTTPClient(httpClient, configuration)
+}
+
+// NewGitHubCommitsOpsClient calls github.NewGitHubCommitsOpsClient.
+//
+// Deprecated: use github.NewGitHubCommitsOpsClient instead.
+func NewGitHubCommitsOpsClient(httpClient *http.Client) *github.GitHubCommitsOpsClient {
+	return github.NewGitHubCommitsOpsClient(httpClient)
+}
+
+// NewLoggingTransport calls github.NewLoggingTransport.
+//
+// Deprecated: use github.NewLoggingTransport instead.
+func NewLoggingTransport(base http.RoundTripper, logger github.Logger) *github.LoggingTransport {
+	return github.NewLoggingTransport(base, logger)
+}
+
+// NewMemoryHTTPCache calls github.NewMemoryHTTPCache.
+//
+// Deprecated: use github.NewMemoryHTTPCache instead.
+func NewMemoryHTTPCache() *github.MemoryHTTPCache {
+	return github.NewMemoryHTTPCache()
+}
+
+// NewMemorySyncStore calls github.NewMemorySyncStore.
+//
+// Deprecated: use github.NewMemorySyncStore instead.
+func NewMemorySyncStore() *github.MemorySyncStore {
+	return github.NewMemorySyncStore()
+}
+
+// NewMetricsTransport calls github.NewMetricsTransport.
+//
+// Deprecated: use github.NewMetricsTransport instead.
+func NewMetricsTransport(base http.RoundTripper, metrics github.Metrics) *github.MetricsTransport {
+	return github.NewMetricsTransport(base, metrics)
+}
+
+// NewRateLimitTransport calls github.NewRateLimitTransport.
+//
+// Deprecated: use github.NewRateLimitTransport instead.
+func NewRateLimitTransport(base http.RoundTripper, options github.RateLimitOptions) *github.RateLimitTransport {
+	return github.NewRateLimitTransport(base, options)
+}
+
+// NewRetryTransport calls github.NewRetryTransport.
+//
+// Deprecated: use github.NewRetryTransport instead.
+func NewRetryTransport(base http.RoundTripper, policy github.RetryPolicy) *github.RetryTransport {
+	return github.NewRetryTransport(base, policy)
+}
+
+// ParseWorkflow calls github.ParseWorkflow.
+//
+// Deprecated: use github.ParseWorkflow instead.
+func ParseWorkflow(content string) (github.Workflow, error) {
+	return github.ParseWorkflow(content)
+}
+
+// WithBaseURL calls github.WithBaseURL.
+//
+// Deprecated: use github.WithBaseURL instead.
+func WithBaseURL(baseURL string) github.Option {
+	return github.WithBaseURL(baseURL)
+}
+
+// WithClock calls github.WithClock.
+//
+// Deprecated: use github.WithClock instead.
+func WithClock(clock github.Clock) github.Option {
+	return github.WithClock(clock)
+}
+
+// WithCommitFilter calls github.WithCommitFilter.
+//
+// Deprecated: use github.WithCommitFilter instead.
+func WithCommitFilter(f filter.CommitFilter) github.Option {
+	return github.WithCommitFilter(f)
+}
+
+// WithConcurrency calls github.WithConcurrency.
+//
+// Deprecated: use github.WithConcurrency instead.
+func WithConcurrency(n int) github.Option {
+	return github.WithConcurrency(n)
+}
+
+// WithConfig calls github.WithConfig.
+//
+// Deprecated: use github.WithConfig instead.
+func WithConfig(config github.GitHubConfig) github.Option {
+	return github.WithConfig(config)
+}
+
+// WithContinueOnError calls github.WithContinueOnError.
+//
+// Deprecated: use github.WithContinueOnError instead.
+func WithContinueOnError() github.Option {
+	return github.WithContinueOnError()
+}
+
+// WithDefaultBranch calls github.WithDefaultBranch.
+//
+// Deprecated: use github.WithDefaultBranch instead.
+func WithDefaultBranch(branch string) github.Option {
+	return github.WithDefaultBranch(branch)
+}
+
+// WithFetcher calls github.WithFetcher.
+//
+// Deprecated: use github.WithFetcher instead.
+func WithFetcher(fetcher github.Fetcher) github.Option {
+	return github.WithFetcher(fetcher)
+}
+
+// WithFilter calls github.WithFilter.
+//
+// Deprecated: use github.WithFilter instead.
+func WithFilter(f filter.GitHubFilter) github.Option {
+	return github.WithFilter(f)
+}
+
+// WithFollowSubmodules calls github.WithFollowSubmodules.
+//
+// Deprecated: use github.WithFollowSubmodules instead.
+func WithFollowSubmodules() github.Option {
+	return github.WithFollowSubmodules()
+}
+
+// WithFrontMatter calls github.WithFrontMatter.
+//
+// Deprecated: use github.WithFrontMatter instead.
+func WithFrontMatter() github.Option {
+	return github.WithFrontMatter()
+}
+
+// WithGraphQLEndpoint calls github.WithGraphQLEndpoint.
+//
+// Deprecated: use github.WithGraphQLEndpoint instead.
+func WithGraphQLEndpoint(endpoint string) github.Option {
+	return github.WithGraphQLEndpoint(endpoint)
+}
+
+// WithHTTPCache calls github.WithHTTPCache.
+//
+// Deprecated: use github.WithHTTPCache instead.
+func WithHTTPCache(cache github.HTTPCache) github.Option {
+	return github.WithHTTPCache(cache)
+}
+
+// WithHTTPClient calls github.WithHTTPClient.
+//
+// Deprecated: use github.WithHTTPClient instead.
+func WithHTTPClient(httpClient *http.Client) github.Option {
+	return github.WithHTTPClient(httpClient)
+}
+
+// WithLogger calls github.WithLogger.
+//
+// Deprecated: use github.WithLogger instead.
+func WithLogger(logger github.Logger) github.Option {
+	return github.WithLogger(logger)
+}
+
+// WithMergeStrategy calls github.WithMergeStrategy.
+//
+// Deprecated: use github.WithMergeStrategy instead.
+func WithMergeStrategy(strategy github.MergeStrategy) github.Option {
+	return github.WithMergeStrategy(strategy)
+}
+
+// WithMetrics calls github.WithMetrics.
+//
+// Deprecated: use github.WithMetrics instead.
+func WithMetrics(metrics github.Metrics) github.Option {
+	return github.WithMetrics(metrics)
+}
+
+// WithRepositories calls github.WithRepositories.
+//
+// Deprecated: use github.WithRepositories instead.
+func WithRepositories(owner string, repositories ...string) github.Option {
+	return github.WithRepositories(owner, repositories...)
+}
+
+// WithRepositoryFilter calls github.WithRepositoryFilter.
+//
+// Deprecated: use github.WithRepositoryFilter instead.
+func WithRepositoryFilter(f filter.RepositoryFilter) github.Option {
+	return github.WithRepositoryFilter(f)
+}
+
+// WithRepositoryRefs calls github.WithRepositoryRefs.
+//
+// Deprecated: use github.WithRepositoryRefs instead.
+func WithRepositoryRefs(refs ...github.RepositoryRef) github.Option {
+	return github.WithRepositoryRefs(refs...)
+}
+
+// WithResolveSymlinks calls github.WithResolveSymlinks.
+//
+// Deprecated: use github.WithResolveSymlinks instead.
+func WithResolveSymlinks() github.Option {
+	return github.WithResolveSymlinks()
+}
+
+// WithRetryPolicy calls github.WithRetryPolicy.
+//
+// Deprecated: use github.WithRetryPolicy instead.
+func WithRetryPolicy(policy github.RetryPolicy) github.Option {
+	return github.WithRetryPolicy(policy)
+}
+
+// WithTracer calls github.WithTracer.
+//
+// Deprecated: use github.WithTracer instead.
+func WithTracer(tracer github.Tracer) github.Option {
+	return github.WithTracer(tracer)
+}
+
+// WithTreeBatchSize calls github.WithTreeBatchSize.
+//
+// Deprecated: use github.WithTreeBatchSize instead.
+func WithTreeBatchSize(n int) github.Option {
+	return github.WithTreeBatchSize(n)
+}
+
+// WithWikiFetcher calls github.WithWikiFetcher.
+//
+// Deprecated: use github.WithWikiFetcher instead.
+func WithWikiFetcher(fetcher github.WikiFetcher) github.Option {
+	return github.WithWikiFetcher(fetcher)
+}
+
+// WithoutRetry calls github.WithoutRetry.
+//
+// Deprecated: use github.WithoutRetry instead.
+func WithoutRetry() github.Option {
+	return github.WithoutRetry()
 }
@@ -0,0 +1,242 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/internal/timeutil"
+)
+
+// DefaultAPIBaseURL is the base URL of the GitHub REST API.
+const DefaultAPIBaseURL = "https://api.github.com/"
+
+// appTokenRefreshMargin is how long before its expiry an installation token is replaced, so requests made
+// during a long crawl never carry an expiring token.
+const appTokenRefreshMargin = 5 * time.Minute
+
+// AppTransport is an http.RoundTripper authenticating requests as a GitHub App installation. It mints
+// installation access tokens from the private key of the app and refreshes them before they expire, so
+// crawls outlasting a token keep working.
+//
+// BaseURL is the base URL of the REST API the tokens are requested from, DefaultAPIBaseURL if empty.
+// Clock is the source of the current time; nil uses the wall clock.
+type AppTransport struct {
+	BaseURL string
+	Clock   Clock
+
+	appID          int64
+	installationID int64
+	key            *rsa.PrivateKey
+	base           http.RoundTripper
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewAppTransport creates an AppTransport for the installation of the app, signing with the PEM encoded
+// private key of the app. The base transport sends the requests, nil uses http.DefaultTransport.
+func NewAppTransport(base http.RoundTripper, appID, installationID int64, privateKey []byte) (*AppTransport, error) {
+	key, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &AppTransport{
+		appID:          appID,
+		installationID: installationID,
+		key:            key,
+		base:           base,
+	}, nil
+}
+
+// NewGitHubClientFromApp creates a GitHub client authenticated as the installation of a GitHub App, for
+// organizations that do not allow personal access tokens. Both the REST and the GraphQL client use the
+// installation token, which is refreshed automatically. The tokens are requested from the GitHub Enterprise
+// Server the configuration points at, if any.
+//
+// Usage:
+//
+//	privateKey, err := os.ReadFile("app.private-key.pem")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	client, err := NewGitHubClientFromApp(12345, 67890, privateKey, config)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func NewGitHubClientFromApp(appID, installationID int64, privateKey []byte, configuration GitHubConfig) (*GitHub, error) {
+	return newGitHubFromApp(appID, installationID, privateKey, nil, configuration)
+}
+
+// newGitHubFromApp is NewGitHubClientFromApp sending the requests through base, nil uses
+// http.DefaultTransport.
+func newGitHubFromApp(appID, installationID int64, privateKey []byte, base http.RoundTripper, configuration GitHubConfig) (*GitHub, error) {
+	transport, err := NewAppTransport(base, appID, installationID, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	transport.Clock = configuration.Clock
+
+	restClient, graphQLClient, err := newAPIClients(&http.Client{Transport: transport}, configuration)
+	if err != nil {
+		return nil, err
+	}
+	transport.BaseURL = restClient.BaseURL.String()
+
+	return NewGitHubClient(&GitHubCommitsOpsClient{GitHubClient: restClient}, graphQLClient, configuration), nil
+}
+
+// RoundTrip implements http.RoundTripper. A request rejected with 401 Unauthorized is retried once with
+// a new token, in case the token was revoked before it expired.
+func (t *AppTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.send(req, false)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || (req.Body != nil && req.GetBody == nil) {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	if req.Body != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+	return t.send(req, true)
+}
+
+// Token returns a valid installation access token, requesting a new one if there is none yet or the
+// current one is about to expire.
+func (t *AppTransport) Token(ctx context.Context) (string, error) {
+	return t.installationToken(ctx, false)
+}
+
+// send sends the request authenticated with the installation token.
+func (t *AppTransport) send(req *http.Request, refresh bool) (*http.Response, error) {
+	token, err := t.installationToken(req.Context(), refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	authenticated := req.Clone(req.Context())
+	authenticated.Header.Set("Authorization", "Bearer "+token)
+	return t.base.RoundTrip(authenticated)
+}
+
+// installationToken returns the cached installation token, requesting a new one if it is missing, about to
+// expire or refresh is set.
+func (t *AppTransport) installationToken(ctx context.Context, refresh bool) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !refresh && t.token != "" && timeutil.Now(t.Clock).Add(appTokenRefreshMargin).Before(t.expiresAt) {
+		return t.token, nil
+	}
+
+	jwt, err := t.jwt()
+	if err != nil {
+		return "", err
+	}
+
+	baseURL := t.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultAPIBaseURL
+	}
+	url := fmt.Sprintf("%s/app/installations/%d/access_tokens", strings.TrimSuffix(baseURL, "/"), t.installationID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, http.NoBody)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+jwt)
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request installation token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to request installation token: %s", resp.Status)
+	}
+
+	var body struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode installation token: %w", err)
+	}
+
+	t.token = body.Token
+	t.expiresAt = body.ExpiresAt
+	return t.token, nil
+}
+
+// jwt creates the JSON Web Token authenticating the app itself. GitHub accepts tokens valid for up to ten
+// minutes; the issue time is backdated to allow for clock drift.
+func (t *AppTransport) jwt() (string, error) {
+	now := timeutil.Now(t.Clock)
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": strconv.FormatInt(t.appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, t.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign app token: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parsePrivateKey parses a PEM encoded RSA private key in PKCS #1 or PKCS #8 form, as downloaded from the
+// settings of a GitHub App.
+func parsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to parse app private key: no PEM data found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse app private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse app private key: %T is not an RSA key", parsed)
+	}
+	return key, nil
+}
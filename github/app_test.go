@@ -0,0 +1,211 @@
+package github
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testClock is a Clock the test can move.
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// appServer is a fake GitHub API issuing installation tokens and serving a repository to holders of a valid
+// token.
+type appServer struct {
+	t     *testing.T
+	key   *rsa.PublicKey
+	clock *testClock
+
+	mu      sync.Mutex
+	issued  int
+	revoked map[string]bool
+}
+
+func (s *appServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v3/app/installations/67890/access_tokens":
+		s.verifyJWT(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+
+		s.mu.Lock()
+		s.issued++
+		token := fmt.Sprintf("ghs_token%d", s.issued)
+		s.mu.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"token": token, "expires_at": s.clock.Now().Add(time.Hour)})
+	case strings.HasPrefix(r.URL.Path, "/api/v3/repos/testowner/repo1"):
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		s.mu.Lock()
+		revoked := s.revoked[token]
+		s.mu.Unlock()
+		if !strings.HasPrefix(token, "ghs_") || revoked {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/commits") {
+			_, _ = w.Write([]byte("[]"))
+			return
+		}
+		_, _ = w.Write([]byte(token))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *appServer) verifyJWT(jwt string) {
+	parts := strings.Split(jwt, ".")
+	require.Len(s.t, parts, 3)
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(s.t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	require.NoError(s.t, rsa.VerifyPKCS1v15(s.key, crypto.SHA256, digest[:], signature))
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(s.t, err)
+	var claims struct {
+		Iss string `json:"iss"`
+		Iat int64  `json:"iat"`
+		Exp int64  `json:"exp"`
+	}
+	require.NoError(s.t, json.Unmarshal(payload, &claims))
+	assert.Equal(s.t, "12345", claims.Iss)
+	assert.Equal(s.t, s.clock.Now().Add(-time.Minute).Unix(), claims.Iat)
+	assert.Equal(s.t, s.clock.Now().Add(9*time.Minute).Unix(), claims.Exp)
+}
+
+func newAppTransport(t *testing.T, pemType string) (*AppTransport, *appServer, *httptest.Server) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	der := x509.MarshalPKCS1PrivateKey(key)
+	if pemType == "PRIVATE KEY" {
+		der, err = x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: pemType, Bytes: der})
+
+	clock := &testClock{now: time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)}
+	handler := &appServer{t: t, key: &key.PublicKey, clock: clock, revoked: make(map[string]bool)}
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	transport, err := NewAppTransport(srv.Client().Transport, 12345, 67890, privateKey)
+	require.NoError(t, err)
+	transport.BaseURL = srv.URL + "/api/v3/"
+	transport.Clock = clock
+
+	return transport, handler, srv
+}
+
+// getBody sends a GET request and returns the status code and body of the response.
+func getBody(t *testing.T, client *http.Client, url string) (int, string) {
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestAppTransport(t *testing.T) {
+	for _, pemType := range []string{"RSA PRIVATE KEY", "PRIVATE KEY"} {
+		t.Run(pemType, func(t *testing.T) {
+			transport, handler, srv := newAppTransport(t, pemType)
+			client := &http.Client{Transport: transport}
+			url := srv.URL + "/api/v3/repos/testowner/repo1"
+
+			status, token := getBody(t, client, url)
+			assert.Equal(t, http.StatusOK, status)
+			assert.Equal(t, "ghs_token1", token)
+
+			// The token is reused while it is valid.
+			handler.clock.Advance(50 * time.Minute)
+			_, token = getBody(t, client, url)
+			assert.Equal(t, "ghs_token1", token)
+
+			// Close to its expiry the token is refreshed.
+			handler.clock.Advance(6 * time.Minute)
+			_, token = getBody(t, client, url)
+			assert.Equal(t, "ghs_token2", token)
+
+			// A revoked token is replaced.
+			handler.mu.Lock()
+			handler.revoked["ghs_token2"] = true
+			handler.mu.Unlock()
+			_, token = getBody(t, client, url)
+			assert.Equal(t, "ghs_token3", token)
+		})
+	}
+}
+
+func TestNewAppTransport_InvalidKey(t *testing.T) {
+	_, err := NewAppTransport(nil, 1, 2, []byte("not a key"))
+	assert.EqualError(t, err, "failed to parse app private key: no PEM data found")
+}
+
+func TestNewGitHubClientFromApp(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	client, err := NewGitHubClientFromApp(12345, 67890, privateKey, GitHubConfig{Owner: "testowner"})
+	require.NoError(t, err)
+	assert.Equal(t, "testowner", client.Configuration.Owner)
+}
+
+func TestNewGitHubClientFromApp_Enterprise(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	clock := &testClock{now: time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)}
+	handler := &appServer{t: t, key: &key.PublicKey, clock: clock, revoked: make(map[string]bool)}
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	client, err := NewGitHubClientFromApp(12345, 67890, privateKey, GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+		Clock:        clock,
+		BaseURL:      srv.URL,
+	})
+	require.NoError(t, err)
+
+	paths, err := client.GetChangedFilePathsSince(clock.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, paths.Modified)
+	assert.Equal(t, 1, handler.issued)
+}
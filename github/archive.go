@@ -0,0 +1,201 @@
+package github
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/internal/gitutil"
+)
+
+// ArchiveOpsClient is an interface to help test downloading repository archives.
+// DownloadRepositoryArchive only asks it for the address of the tarball, which is downloaded directly.
+type ArchiveOpsClient interface {
+	GetArchiveLink(ctx context.Context, owner, repo string, archiveformat github.ArchiveFormat, opts *github.RepositoryContentGetOptions, maxRedirects int) (*url.URL, *github.Response, error)
+}
+
+// ArchiveFile is a file extracted from a repository archive together with its content.
+type ArchiveFile struct {
+	document.File
+	Content []byte
+}
+
+// GetArchiveLink returns the address to download the tarball or zipball of a repository from.
+func (gClient *GitHubCommitsOpsClient) GetArchiveLink(ctx context.Context, owner, repo string, archiveformat github.ArchiveFormat, opts *github.RepositoryContentGetOptions, maxRedirects int) (*url.URL, *github.Response, error) {
+	return gClient.GitHubClient.Repositories.GetArchiveLink(ctx, owner, repo, archiveformat, opts, maxRedirects)
+}
+
+// DownloadRepositoryArchive downloads the tarball of the repository at ref and calls fn for every file
+// passing the configured filter, as the archive is extracted. For full repository collection this takes a
+// single download instead of an API call per file. An empty ref uses the branch or ref configured for the
+// repository. The archive is downloaded with http.DefaultClient from the short-lived address GitHub
+// redirects to.
+//
+// Files carry the blob SHA computed from their content, so they compare equal to the SHAs of GetFiles.
+// MaxFileSize and SkipBinary of the filter apply to the extracted content.
+// If fn returns an error the extraction stops and DownloadRepositoryArchive returns the error, except for
+// fs.SkipAll, which stops it without an error.
+//
+// Usage:
+//
+//	err := c.DownloadRepositoryArchive(ctx, "repo1", "v1.2.0", func(file ArchiveFile) error {
+//	    return index(ctx, file.Path, file.Content)
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *GitHub) DownloadRepositoryArchive(ctx context.Context, repo, ref string, fn func(ArchiveFile) error) error {
+	client, err := c.archiveOpsClient()
+	if err != nil {
+		return err
+	}
+
+	if ref == "" {
+		if ref, err = c.branch(ctx, repo); err != nil {
+			return err
+		}
+	}
+
+	rules, err := c.getFileRules(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	link, _, err := client.GetArchiveLink(ctx, c.ownerOf(repo), c.nameOf(repo), github.Tarball, &github.RepositoryContentGetOptions{Ref: ref}, 1)
+	release()
+	if err != nil {
+		return fmt.Errorf("failed to get the archive of %s: %w", repo, c.classifyError(err))
+	}
+
+	body, err := openArchive(ctx, link)
+	if err != nil {
+		return fmt.Errorf("failed to download the archive of %s: %w", repo, err)
+	}
+	defer body.Close()
+
+	collected := 0
+	err = c.extractArchive(body, repo, ref, rules, func(file ArchiveFile) error {
+		collected++
+		return fn(file)
+	})
+	c.metrics().FilesCollected(repo, collected)
+	if errors.Is(err, fs.SkipAll) {
+		return nil
+	}
+	return err
+}
+
+// openArchive starts downloading the archive at link. The caller has to close the body.
+func openArchive(ctx context.Context, link *url.URL) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code: %s", resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// extractArchive reads a gzipped tarball as GitHub creates it and calls fn for every file passing the
+// configured filter. GitHub puts all files below a single top-level directory, which is stripped.
+func (c *GitHub) extractArchive(r io.Reader, repo, ref string, rules fileRules, fn func(ArchiveFile) error) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to read the archive of %s: %w", repo, err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read the archive of %s: %w", repo, err)
+		}
+
+		var mode document.FileMode
+		switch header.Typeflag {
+		case tar.TypeReg:
+			mode = document.FileModeRegular
+			if header.Mode&0111 != 0 {
+				mode = document.FileModeExecutable
+			}
+		case tar.TypeSymlink:
+			mode = document.FileModeSymlink
+		default:
+			// Directories and the global header holding the commit SHA.
+			continue
+		}
+
+		_, filePath, ok := strings.Cut(header.Name, "/")
+		if !ok || !c.includeChangedFile(filePath, rules) {
+			continue
+		}
+		if maxSize := c.Configuration.Filter.MaxFileSize; maxSize > 0 && header.Size > int64(maxSize) {
+			continue
+		}
+
+		content := []byte(header.Linkname)
+		if mode != document.FileModeSymlink {
+			if content, err = io.ReadAll(tr); err != nil {
+				return fmt.Errorf("failed to read %s from the archive of %s: %w", filePath, repo, err)
+			}
+		}
+		// Binary files are recognised by a NUL byte, like git and GitHub do.
+		if c.Configuration.Filter.SkipBinary && bytes.IndexByte(content, 0) >= 0 {
+			continue
+		}
+
+		file := ArchiveFile{
+			File: document.File{
+				Path:       c.normalizePath(filePath),
+				SHA:        gitutil.BlobSHA(content),
+				Size:       len(content),
+				Mode:       mode,
+				Repository: repo,
+				Branch:     ref,
+				URL:        c.fileURL(repo, ref, filePath),
+			},
+			Content: content,
+		}
+		if c.Configuration.FrontMatter && mode != document.FileModeSymlink {
+			file.Metadata = document.FrontMatterMetadata(filePath, content)
+		}
+		err = fn(file)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// archiveOpsClient returns the commit ops client as an ArchiveOpsClient.
+func (c *GitHub) archiveOpsClient() (ArchiveOpsClient, error) {
+	client, ok := c.commitOpsClient.(ArchiveOpsClient)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not implement ArchiveOpsClient", ErrUnsupportedClient, c.commitOpsClient)
+	}
+	return client, nil
+}
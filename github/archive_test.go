@@ -0,0 +1,135 @@
+package github
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shaharia-lab/coco-gh/v2/internal/gitutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// ArchiveOpsClientMock is a mock type for a CommitOpsClient that also implements ArchiveOpsClient
+type ArchiveOpsClientMock struct {
+	CommitOpsClientMock
+}
+
+// GetArchiveLink provides a mock function with given fields: ctx, owner, repo, archiveformat, opts, maxRedirects
+func (_m *ArchiveOpsClientMock) GetArchiveLink(ctx context.Context, owner, repo string, archiveformat github.ArchiveFormat, opts *github.RepositoryContentGetOptions, maxRedirects int) (*url.URL, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, archiveformat, opts, maxRedirects)
+	link, _ := ret.Get(0).(*url.URL)
+	resp, _ := ret.Get(1).(*github.Response)
+	return link, resp, ret.Error(2)
+}
+
+// tarball builds a gzipped tarball the way GitHub does, with a global header and a top-level directory.
+func tarball(t *testing.T) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	headers := []struct {
+		header  tar.Header
+		content string
+	}{
+		{header: tar.Header{Typeflag: tar.TypeXGlobalHeader, Name: "pax_global_header", PAXRecords: map[string]string{"comment": "abc123"}}},
+		{header: tar.Header{Typeflag: tar.TypeDir, Name: "testowner-repo1-abc123/", Mode: 0755}},
+		{header: tar.Header{Typeflag: tar.TypeReg, Name: "testowner-repo1-abc123/README.md", Mode: 0644}, content: "# repo1"},
+		{header: tar.Header{Typeflag: tar.TypeDir, Name: "testowner-repo1-abc123/docs/", Mode: 0755}},
+		{header: tar.Header{Typeflag: tar.TypeReg, Name: "testowner-repo1-abc123/docs/index.md", Mode: 0644}, content: "# Index"},
+		{header: tar.Header{Typeflag: tar.TypeReg, Name: "testowner-repo1-abc123/docs/build.sh", Mode: 0755}, content: "make"},
+		{header: tar.Header{Typeflag: tar.TypeSymlink, Name: "testowner-repo1-abc123/docs/latest.md", Linkname: "index.md", Mode: 0777}},
+	}
+	for _, h := range headers {
+		h.header.Size = int64(len(h.content))
+		require.NoError(t, tw.WriteHeader(&h.header))
+		_, err := tw.Write([]byte(h.content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestGitHub_DownloadRepositoryArchive(t *testing.T) {
+	archive := tarball(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/testowner/repo1/legacy.tar.gz/refs/tags/v1.2.0" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(archive)
+	}))
+	defer srv.Close()
+
+	link, err := url.Parse(srv.URL + "/testowner/repo1/legacy.tar.gz/refs/tags/v1.2.0")
+	require.NoError(t, err)
+
+	client := new(ArchiveOpsClientMock)
+	client.On("GetArchiveLink", mock.Anything, "testowner", "repo1", github.Tarball, &github.RepositoryContentGetOptions{Ref: "v1.2.0"}, 1).
+		Return(link, nil, nil)
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md", ".sh"}},
+	})
+
+	var files []ArchiveFile
+	err = gh.DownloadRepositoryArchive(context.Background(), "repo1", "v1.2.0", func(file ArchiveFile) error {
+		files = append(files, file)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+
+	assert.Equal(t, document.File{
+		Path:       "docs/index.md",
+		SHA:        gitutil.BlobSHA([]byte("# Index")),
+		Size:       7,
+		Mode:       document.FileModeRegular,
+		Repository: "repo1",
+		Branch:     "v1.2.0",
+		URL:        "https://github.com/testowner/repo1/blob/v1.2.0/docs/index.md",
+	}, files[0].File)
+	assert.Equal(t, "# Index", string(files[0].Content))
+	assert.Equal(t, document.FileModeExecutable, files[1].Mode)
+	assert.Equal(t, document.FileModeSymlink, files[2].Mode)
+	assert.Equal(t, "index.md", string(files[2].Content))
+
+	count := 0
+	err = gh.DownloadRepositoryArchive(context.Background(), "repo1", "v1.2.0", func(file ArchiveFile) error {
+		count++
+		return fs.SkipAll
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestGitHub_DownloadRepositoryArchive_Errors(t *testing.T) {
+	gh := NewGitHubClient(new(CommitOpsClientMock), new(GraphQLClientMock), GitHubConfig{Owner: "testowner", DefaultBranch: "main"})
+	err := gh.DownloadRepositoryArchive(context.Background(), "repo1", "", func(ArchiveFile) error { return nil })
+	assert.ErrorIs(t, err, ErrUnsupportedClient)
+
+	client := new(ArchiveOpsClientMock)
+	client.On("GetArchiveLink", mock.Anything, "testowner", "repo1", github.Tarball, &github.RepositoryContentGetOptions{Ref: "main"}, 1).
+		Return(nil, nil, errors.New("not found"))
+
+	gh = NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{Owner: "testowner", DefaultBranch: "main"})
+	err = gh.DownloadRepositoryArchive(context.Background(), "repo1", "", func(ArchiveFile) error { return nil })
+	assert.EqualError(t, err, "failed to get the archive of repo1: not found")
+}
@@ -0,0 +1,220 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// treeRef identifies a git tree of a repository by a "<ref>:<path>" expression.
+type treeRef struct {
+	owner, name, expression string
+}
+
+// batchTreeObject is the object a tree expression resolves to in a batched query.
+type batchTreeObject struct {
+	Tree struct {
+		Entries []GHTreeEntry
+	} `graphql:"... on Tree"`
+}
+
+// batchTreeType is the type of the objects of a batched query.
+var batchTreeType = reflect.TypeOf(batchTreeObject{})
+
+// walkTrees lists the blob entries below every root tree like getFileEntriesForRepo, walking all roots
+// breadth first so every GraphQL query lists up to GitHubConfig.TreeBatchSize trees of the same depth, of
+// several directories and repositories. The files of every root are in the order of the sequential walk.
+func (c *GitHub) walkTrees(ctx context.Context, roots []treeRef) ([][]GHTreeEntry, error) {
+	listed := make(map[treeRef][]GHTreeEntry)
+	for pending := roots; len(pending) > 0; {
+		entries, err := c.listTrees(ctx, pending)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []treeRef
+		for i, tree := range pending {
+			listed[tree] = entries[i]
+			for _, entry := range entries[i] {
+				if entry.Type == "tree" {
+					next = append(next, treeRef{owner: tree.owner, name: tree.name, expression: tree.expression + "/" + entry.Name})
+				}
+			}
+		}
+		pending = next
+	}
+
+	files := make([][]GHTreeEntry, len(roots))
+	for i, root := range roots {
+		files[i] = collectTreeFiles(listed, root)
+	}
+	return files, nil
+}
+
+// collectTreeFiles returns the blob entries below the listed tree, depth first.
+func collectTreeFiles(listed map[treeRef][]GHTreeEntry, tree treeRef) []GHTreeEntry {
+	var files []GHTreeEntry
+	for _, entry := range listed[tree] {
+		switch entry.Type {
+		case "blob":
+			files = append(files, entry)
+		case "tree":
+			files = append(files, collectTreeFiles(listed, treeRef{owner: tree.owner, name: tree.name, expression: tree.expression + "/" + entry.Name})...)
+		}
+	}
+	return files
+}
+
+// listTrees lists the direct entries of the trees, up to GitHubConfig.TreeBatchSize per query.
+func (c *GitHub) listTrees(ctx context.Context, trees []treeRef) ([][]GHTreeEntry, error) {
+	size := c.Configuration.TreeBatchSize
+	if size < 1 {
+		size = 1
+	}
+
+	entries := make([][]GHTreeEntry, 0, len(trees))
+	for start := 0; start < len(trees); start += size {
+		end := start + size
+		if end > len(trees) {
+			end = len(trees)
+		}
+		batch, err := c.listTreesBatch(ctx, trees[start:end])
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, batch...)
+	}
+	return entries, nil
+}
+
+// listTreesBatch lists the direct entries of the trees with a single GraphQL query holding an aliased
+// repository field per repository and an aliased object field per tree.
+func (c *GitHub) listTreesBatch(ctx context.Context, trees []treeRef) ([][]GHTreeEntry, error) {
+	query, variables, fields := newTreesQuery(trees)
+
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if err := c.query(ctx, query.Interface(), variables); err != nil {
+		return nil, err
+	}
+
+	entries := make([][]GHTreeEntry, len(trees))
+	for i, field := range fields {
+		object := query.Elem().Field(field[0]).Field(field[1]).Interface().(batchTreeObject)
+		entries[i] = object.Tree.Entries
+	}
+	return entries, nil
+}
+
+// newTreesQuery builds the query listing the trees: a pointer to a struct with a field per repository, each
+// with a field per tree of the repository. It returns the query, its variables and the repository and tree
+// field index of every tree.
+func newTreesQuery(trees []treeRef) (reflect.Value, map[string]interface{}, [][2]int) {
+	type repository struct {
+		owner, name string
+		objects     []reflect.StructField
+	}
+
+	var repos []*repository
+	index := make(map[[2]string]int)
+	variables := make(map[string]interface{})
+	fields := make([][2]int, len(trees))
+	for i, tree := range trees {
+		key := [2]string{tree.owner, tree.name}
+		r, ok := index[key]
+		if !ok {
+			r = len(repos)
+			index[key] = r
+			repos = append(repos, &repository{owner: tree.owner, name: tree.name})
+			variables[fmt.Sprintf("owner%d", r)] = githubv4.String(tree.owner)
+			variables[fmt.Sprintf("name%d", r)] = githubv4.String(tree.name)
+		}
+
+		repo := repos[r]
+		fields[i] = [2]int{r, len(repo.objects)}
+		repo.objects = append(repo.objects, reflect.StructField{
+			Name: fmt.Sprintf("T%d", i),
+			Type: batchTreeType,
+			Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"t%d: object(expression: $expression%d)"`, i, i)),
+		})
+		variables[fmt.Sprintf("expression%d", i)] = githubv4.String(tree.expression)
+	}
+
+	repoFields := make([]reflect.StructField, len(repos))
+	for r, repo := range repos {
+		repoFields[r] = reflect.StructField{
+			Name: fmt.Sprintf("R%d", r),
+			Type: reflect.StructOf(repo.objects),
+			Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"r%d: repository(owner: $owner%d, name: $name%d)"`, r, r, r)),
+		}
+	}
+	return reflect.New(reflect.StructOf(repoFields)), variables, fields
+}
+
+// prefetchedTreesKey is the context key of the files prefetchFileEntries listed.
+type prefetchedTreesKey struct{}
+
+// prefetchFileEntries walks the trees of all configured repositories at once if GitHubConfig.TreeBatchSize
+// is set and trees are walked through GraphQL, so queries list trees of several repositories. It returns a
+// context carrying the files for getFileEntriesForRepo. If the walk fails, e.g. because a repository does
+// not exist, the context is returned as it is and every repository is walked on its own, reporting its
+// own error.
+func (c *GitHub) prefetchFileEntries(ctx context.Context) context.Context {
+	if c.Configuration.TreeBatchSize <= 1 || !c.walksTreesWithGraphQL() || len(c.repositories()) < 2 {
+		return ctx
+	}
+
+	var roots []treeRef
+	for _, repo := range c.repositories() {
+		matched, err := c.matchRepository(ctx, repo)
+		if err != nil {
+			return ctx
+		}
+		if !matched {
+			continue
+		}
+		branch, err := c.branch(ctx, repo)
+		if err != nil {
+			return ctx
+		}
+		for _, root := range c.rootPaths(repo) {
+			expression := fmt.Sprintf("%s:%s", branch, root)
+			roots = append(roots, treeRef{owner: c.ownerOf(repo), name: c.nameOf(repo), expression: expression})
+		}
+	}
+
+	files, err := c.walkTrees(ctx, roots)
+	if err != nil {
+		c.debug("prefetching trees failed", "error", err)
+		return ctx
+	}
+
+	prefetched := make(map[treeRef][]GHTreeEntry, len(roots))
+	for i, root := range roots {
+		prefetched[root] = files[i]
+	}
+	return context.WithValue(ctx, prefetchedTreesKey{}, prefetched)
+}
+
+// prefetchedFileEntries returns the files below the tree prefetchFileEntries listed with the context.
+func prefetchedFileEntries(ctx context.Context, tree treeRef) ([]GHTreeEntry, bool) {
+	prefetched, _ := ctx.Value(prefetchedTreesKey{}).(map[treeRef][]GHTreeEntry)
+	files, ok := prefetched[tree]
+	return files, ok
+}
+
+// walksTreesWithGraphQL reports whether listFileEntries walks trees through GraphQL instead of a Fetcher or
+// the recursive Git Trees API.
+func (c *GitHub) walksTreesWithGraphQL() bool {
+	if c.Configuration.Fetcher != nil {
+		return false
+	}
+	_, ok := c.commitOpsClient.(TreeOpsClient)
+	return !ok || c.Configuration.Filter.SkipBinary
+}
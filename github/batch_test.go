@@ -0,0 +1,78 @@
+package github
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTreesQuery(t *testing.T) {
+	query, variables, fields := newTreesQuery([]treeRef{
+		{owner: "testowner", name: "repo1", expression: "main:"},
+		{owner: "testowner", name: "repo2", expression: "main:"},
+		{owner: "testowner", name: "repo1", expression: "main:docs"},
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"owner0":      githubv4.String("testowner"),
+		"name0":       githubv4.String("repo1"),
+		"owner1":      githubv4.String("testowner"),
+		"name1":       githubv4.String("repo2"),
+		"expression0": githubv4.String("main:"),
+		"expression1": githubv4.String("main:"),
+		"expression2": githubv4.String("main:docs"),
+	}, variables)
+	assert.Equal(t, [][2]int{{0, 0}, {1, 0}, {0, 1}}, fields)
+
+	typ := query.Type().Elem()
+	require.Equal(t, 2, typ.NumField())
+	assert.Equal(t, `graphql:"r0: repository(owner: $owner0, name: $name0)"`, string(typ.Field(0).Tag))
+	assert.Equal(t, `graphql:"t0: object(expression: $expression0)"`, string(typ.Field(0).Type.Field(0).Tag))
+	assert.Equal(t, `graphql:"t2: object(expression: $expression2)"`, string(typ.Field(0).Type.Field(1).Tag))
+	assert.Equal(t, `graphql:"t1: object(expression: $expression1)"`, string(typ.Field(1).Type.Field(0).Tag))
+}
+
+func TestGitHub_walkTrees(t *testing.T) {
+	trees := map[string][]GHTreeEntry{
+		"main:":          {{Name: "docs", Type: "tree"}, {Name: "README.md", Path: "README.md", Type: "blob"}},
+		"main:/docs":     {{Name: "api", Type: "tree"}, {Name: "index.md", Path: "docs/index.md", Type: "blob"}},
+		"main:/docs/api": {{Name: "b.md", Path: "docs/api/b.md", Type: "blob"}},
+	}
+
+	// The mock answers the batched queries by setting the entries of every aliased object field.
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		query := reflect.ValueOf(args.Get(1)).Elem()
+		variables := args.Get(2).(map[string]interface{})
+		for r := 0; r < query.NumField(); r++ {
+			repo := query.Field(r)
+			for o := 0; o < repo.NumField(); o++ {
+				field := repo.Type().Field(o)
+				expression := variables["expression"+field.Name[1:]].(githubv4.String)
+				object := batchTreeObject{}
+				object.Tree.Entries = trees[string(expression)]
+				repo.Field(o).Set(reflect.ValueOf(object))
+			}
+		}
+	}).Return(nil)
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{TreeBatchSize: 10})
+	files, err := gh.walkTrees(context.Background(), []treeRef{
+		{owner: "testowner", name: "repo1", expression: "main:"},
+		{owner: "testowner", name: "repo2", expression: "main:"},
+	})
+	require.NoError(t, err)
+
+	want := []GHTreeEntry{
+		{Name: "b.md", Path: "docs/api/b.md", Type: "blob"},
+		{Name: "index.md", Path: "docs/index.md", Type: "blob"},
+		{Name: "README.md", Path: "README.md", Type: "blob"},
+	}
+	assert.Equal(t, [][]GHTreeEntry{want, want}, files)
+	graphQLClient.AssertNumberOfCalls(t, "Query", 3)
+}
@@ -0,0 +1,240 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/shaharia-lab/coco-gh/v2/internal/fileutil"
+)
+
+// CachedResponse is a response stored by an HTTPCache, with the validators CacheTransport revalidates it
+// with.
+type CachedResponse struct {
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+}
+
+// HTTPCache stores the responses of a CacheTransport by key. Get reports whether a response is stored for
+// the key.
+type HTTPCache interface {
+	Get(ctx context.Context, key string) (CachedResponse, bool, error)
+	Set(ctx context.Context, key string, response CachedResponse) error
+}
+
+// MemoryHTTPCache is an HTTPCache keeping the responses in memory, for the lifetime of a process.
+type MemoryHTTPCache struct {
+	mu        sync.Mutex
+	responses map[string]CachedResponse
+}
+
+// NewMemoryHTTPCache creates an empty MemoryHTTPCache.
+func NewMemoryHTTPCache() *MemoryHTTPCache {
+	return &MemoryHTTPCache{responses: make(map[string]CachedResponse)}
+}
+
+// Get returns the response stored for the key.
+func (c *MemoryHTTPCache) Get(_ context.Context, key string) (CachedResponse, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	response, ok := c.responses[key]
+	return response, ok, nil
+}
+
+// Set stores the response for the key.
+func (c *MemoryHTTPCache) Set(_ context.Context, key string, response CachedResponse) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responses[key] = response
+	return nil
+}
+
+// FileHTTPCache is an HTTPCache keeping every response in a JSON file of the directory Dir, so repeated runs
+// of a crawl revalidate the responses of the previous one. The directory is created on the first Set.
+type FileHTTPCache struct {
+	Dir string
+}
+
+// NewFileHTTPCache creates a FileHTTPCache for the directory at dir.
+func NewFileHTTPCache(dir string) *FileHTTPCache {
+	return &FileHTTPCache{Dir: dir}
+}
+
+// Get returns the response stored for the key.
+func (c *FileHTTPCache) Get(_ context.Context, key string) (CachedResponse, bool, error) {
+	data, err := os.ReadFile(c.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return CachedResponse{}, false, nil
+	}
+	if err != nil {
+		return CachedResponse{}, false, fmt.Errorf("failed to read cached response: %w", err)
+	}
+
+	var response CachedResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return CachedResponse{}, false, fmt.Errorf("failed to read cached response %s: %w", c.path(key), err)
+	}
+	return response, true, nil
+}
+
+// Set stores the response for the key.
+func (c *FileHTTPCache) Set(_ context.Context, key string, response CachedResponse) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return fmt.Errorf("failed to write cached response: %w", err)
+	}
+	if err := fileutil.WriteAtomic(c.path(key), data); err != nil {
+		return fmt.Errorf("failed to write cached response: %w", err)
+	}
+	return nil
+}
+
+// path returns the file of the key, named after its hash as keys hold URLs.
+func (c *FileHTTPCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// headerFromCache marks the responses a CacheTransport served from its cache after revalidating them.
+const headerFromCache = "X-From-Cache"
+
+// CacheTransport is an http.RoundTripper making conditional requests: it stores the successful responses of
+// GET requests carrying an ETag or Last-Modified header in an HTTPCache and sends If-None-Match and
+// If-Modified-Since with the next request for the same URL. When GitHub answers 304 Not Modified, which does
+// not count against the rate limit, the stored response is returned with the fresh headers, such as the rate
+// limit quota, and an X-From-Cache header.
+//
+// This makes repeated crawls of unchanged repositories cheap for REST requests such as recursive tree
+// listings and commit histories. GraphQL queries are POST requests and always go through. Responses are
+// keyed by URL and credentials, so clients with different tokens do not see each other's responses. Failing
+// to read or write the cache does not fail the request.
+//
+// NewGitHub and NewClientsFromToken use a CacheTransport when a cache is passed with WithHTTPCache.
+//
+//	httpClient.Transport = github.NewCacheTransport(httpClient.Transport, github.NewFileHTTPCache(".cocogh-cache"))
+type CacheTransport struct {
+	base  http.RoundTripper
+	cache HTTPCache
+}
+
+// NewCacheTransport wraps the base transport, nil uses http.DefaultTransport.
+func NewCacheTransport(base http.RoundTripper, cache HTTPCache) *CacheTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &CacheTransport{base: base, cache: cache}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	key := cacheKey(req)
+	cached, ok, err := t.cache.Get(ctx, key)
+	if err != nil || (cached.ETag == "" && cached.LastModified == "") {
+		ok = false
+	}
+
+	if ok {
+		conditional := req.Clone(ctx)
+		if cached.ETag != "" {
+			conditional.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			conditional.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+		req = conditional
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		return cachedResponse(req, cached, resp.Header), nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	_ = t.cache.Set(ctx, key, CachedResponse{
+		StatusCode:   resp.StatusCode,
+		Header:       resp.Header.Clone(),
+		Body:         body,
+		ETag:         etag,
+		LastModified: lastModified,
+	})
+	return resp, nil
+}
+
+// cacheKey identifies the response to a request by its URL and a hash of its credentials.
+func cacheKey(req *http.Request) string {
+	authorization := req.Header.Get("Authorization")
+	if authorization == "" {
+		return req.URL.String()
+	}
+	sum := sha256.Sum256([]byte(authorization))
+	return req.URL.String() + " " + hex.EncodeToString(sum[:8])
+}
+
+// cachedResponse turns a revalidated cached response into a response to the request, with the headers of the
+// 304 Not Modified response taking precedence over the stored ones.
+func cachedResponse(req *http.Request, cached CachedResponse, fresh http.Header) *http.Response {
+	header := cached.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	for name, values := range fresh {
+		// The 304 response has no body to describe.
+		if strings.HasPrefix(name, "Content-") || name == "Transfer-Encoding" {
+			continue
+		}
+		header[name] = values
+	}
+	header.Set(headerFromCache, "1")
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", cached.StatusCode, http.StatusText(cached.StatusCode)),
+		StatusCode:    cached.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(cached.Body)),
+		ContentLength: int64(len(cached.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,154 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// etagServer serves body with its ETag, answering 304 Not Modified to requests revalidating it, and records
+// the If-None-Match headers it received.
+type etagServer struct {
+	body        string
+	etag        string
+	ifNoneMatch []string
+}
+
+func (s *etagServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.ifNoneMatch = append(s.ifNoneMatch, r.Header.Get("If-None-Match"))
+	w.Header().Set("X-RateLimit-Remaining", "4999")
+	if r.Header.Get("If-None-Match") == s.etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", s.etag)
+	w.Header().Set("X-RateLimit-Remaining", "5000")
+	_, _ = io.WriteString(w, s.body)
+}
+
+func get(t *testing.T, client *http.Client, url string) (*http.Response, string) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func TestCacheTransport(t *testing.T) {
+	server := &etagServer{body: "tree v1", etag: `"v1"`}
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewCacheTransport(nil, NewMemoryHTTPCache())}
+
+	resp, body := get(t, client, srv.URL+"/repos/o/r/git/trees/main")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "tree v1", body)
+	assert.Empty(t, resp.Header.Get("X-From-Cache"))
+
+	// The unchanged tree is revalidated and served from the cache with the fresh rate limit quota.
+	resp, body = get(t, client, srv.URL+"/repos/o/r/git/trees/main")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "tree v1", body)
+	assert.Equal(t, "1", resp.Header.Get("X-From-Cache"))
+	assert.Equal(t, "4999", resp.Header.Get("X-RateLimit-Remaining"))
+	assert.Equal(t, `"v1"`, resp.Header.Get("ETag"))
+
+	// A changed tree replaces the cached one.
+	server.body, server.etag = "tree v2", `"v2"`
+	_, body = get(t, client, srv.URL+"/repos/o/r/git/trees/main")
+	assert.Equal(t, "tree v2", body)
+	resp, body = get(t, client, srv.URL+"/repos/o/r/git/trees/main")
+	assert.Equal(t, "tree v2", body)
+	assert.Equal(t, "1", resp.Header.Get("X-From-Cache"))
+
+	assert.Equal(t, []string{"", `"v1"`, `"v1"`, `"v2"`}, server.ifNoneMatch)
+}
+
+func TestCacheTransport_NotCached(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") != "" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if r.URL.Path != "/no-validator" {
+			w.Header().Set("ETag", `"v1"`)
+		}
+		_, _ = io.WriteString(w, "body")
+	}))
+	defer srv.Close()
+
+	cache := NewMemoryHTTPCache()
+	client := &http.Client{Transport: NewCacheTransport(nil, cache)}
+
+	// Responses without validators and to other methods than GET are not stored.
+	get(t, client, srv.URL+"/no-validator")
+	resp, err := client.Post(srv.URL+"/graphql", "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, cache.responses)
+
+	// Responses to different credentials are stored separately.
+	for _, token := range []string{"token1", "token2"} {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/repos/o/r", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Empty(t, resp.Header.Get("X-From-Cache"))
+	}
+	assert.Len(t, cache.responses, 2)
+	assert.Equal(t, 4, requests)
+}
+
+func TestFileHTTPCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	cache := NewFileHTTPCache(dir)
+	ctx := context.Background()
+
+	_, ok, err := cache.Get(ctx, "https://api.github.com/repos/o/r")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	stored := CachedResponse{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Etag": []string{`"v1"`}},
+		Body:       []byte(`{"name":"r"}`),
+		ETag:       `"v1"`,
+	}
+	require.NoError(t, cache.Set(ctx, "https://api.github.com/repos/o/r", stored))
+
+	response, ok, err := NewFileHTTPCache(dir).Get(ctx, "https://api.github.com/repos/o/r")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, stored, response)
+
+	require.NoError(t, os.WriteFile(cache.path("broken"), []byte("{"), 0o644))
+	_, _, err = cache.Get(ctx, "broken")
+	assert.ErrorContains(t, err, "failed to read cached response")
+}
+
+func TestWithHTTPCache(t *testing.T) {
+	server := &etagServer{body: "repo", etag: `"v1"`}
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	client := applyOptions([]Option{WithHTTPCache(NewMemoryHTTPCache()), WithoutRetry()}).tokenHTTPClient("token")
+	get(t, client, srv.URL+"/repos/o/r")
+	resp, body := get(t, client, srv.URL+"/repos/o/r")
+	assert.Equal(t, "repo", body)
+	assert.Equal(t, "1", resp.Header.Get("X-From-Cache"))
+}
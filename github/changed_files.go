@@ -0,0 +1,162 @@
+package github
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// ChangedFiles holds the files changed since a point in time, classified like a ChangeSet, as returned by
+// GetChangedFilesSince.
+type ChangedFiles struct {
+	Added    []ChangedFile
+	Removed  []ChangedFile
+	Modified []ChangedFile
+}
+
+// ChangedFile is the net change of a file together with the last commit changing it and its new content.
+//
+// PreviousPath is the path an added file was renamed from, following renames of renamed files back to the
+// path before the first of them; it is empty for other files. Content is the file as of the branch the
+// repository is read from, the zero FileContent for removed files.
+type ChangedFile struct {
+	document.FileChange
+	PreviousPath string
+	Content      document.FileContent
+}
+
+// Document converts the changed file into a Document of kind DocumentKindFile, identified like the
+// documents of SourceDocuments, with the commit and the previous path in its metadata.
+func (f ChangedFile) Document() document.Document {
+	doc := document.Document{
+		ID:         f.Repository + "/" + f.Path,
+		Kind:       document.DocumentKindFile,
+		Repository: f.Repository,
+		Path:       f.Path,
+		Title:      f.Path,
+		Body:       f.Content.Text,
+		Author:     f.Author,
+		UpdatedAt:  f.CommittedAt,
+		Metadata:   map[string]string{"commit": f.CommitSHA},
+	}
+	if owner, name, ok := strings.Cut(f.Repository, "/"); ok {
+		doc.Owner, doc.Repository = owner, name
+	}
+	if f.Content.Oid != "" {
+		doc.Metadata["sha"] = f.Content.Oid
+	}
+	if f.PreviousPath != "" {
+		doc.Metadata["previous_path"] = f.PreviousPath
+	}
+	return doc
+}
+
+// GetChangedFilesSince is GetChangeSetSince reading the new content of the added and modified files, so
+// they can be indexed without further calls, one GraphQL query per file. Removed files have no content, the
+// documents of their paths are meant to be deleted.
+//
+// Usage:
+//
+//	changes, err := c.GetChangedFilesSince(ctx, lastRun)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, file := range append(changes.Added, changes.Modified...) {
+//	    index(file.Document())
+//	}
+//	for _, file := range changes.Removed {
+//	    remove(file.Repository, file.Path)
+//	}
+func (c *GitHub) GetChangedFilesSince(ctx context.Context, since time.Time) (ChangedFiles, error) {
+	repoChanges := make([]ChangedFiles, len(c.repositories()))
+	err := c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		opt := &github.CommitsListOptions{
+			Since:       since,
+			Path:        c.historyPath(repo),
+			ListOptions: github.ListOptions{PerPage: 100},
+		}
+
+		events, _, err := c.listChangeEvents(ctx, repo, opt, "")
+		if err != nil {
+			return err
+		}
+
+		changes, err := c.changedFiles(ctx, repo, events)
+		if err != nil {
+			return err
+		}
+		repoChanges[i] = changes
+		return nil
+	})
+	if !partialResult(err) {
+		return ChangedFiles{}, err
+	}
+
+	var changes ChangedFiles
+	for _, repoChange := range repoChanges {
+		changes.Added = append(changes.Added, repoChange.Added...)
+		changes.Removed = append(changes.Removed, repoChange.Removed...)
+		changes.Modified = append(changes.Modified, repoChange.Modified...)
+	}
+	return changes, err
+}
+
+// changedFiles reconciles the change events of a repository, ordered newest first, into its changed files
+// and reads the content of the added and modified ones. Files whose new content GitHubFilter.MaxFileSize or
+// SkipBinary drop are left out if they were added and removed if they were modified, as they are no longer
+// listed.
+func (c *GitHub) changedFiles(ctx context.Context, repo string, events []document.ChangeEvent) (ChangedFiles, error) {
+	var changes ChangedFiles
+
+	convert := func(change document.FileChange, added bool) ChangedFile {
+		file := ChangedFile{FileChange: change}
+		if added {
+			file.PreviousPath = c.normalizePath(document.OriginalPath(events, change.Path))
+		}
+		file.Path = c.normalizePath(file.Path)
+		return file
+	}
+	withContent := func(fileChanges []document.FileChange, added bool, files *[]ChangedFile) error {
+		for _, change := range fileChanges {
+			content, err := c.getFileContent(ctx, repo, change.Path)
+			if err != nil {
+				return err
+			}
+
+			file := convert(change, added)
+			switch {
+			case c.includeContent(content):
+				file.Content = content
+				*files = append(*files, file)
+			case !added:
+				changes.Removed = append(changes.Removed, file)
+			}
+		}
+		return nil
+	}
+
+	set := document.NewChangeSet(events)
+	for _, change := range set.Removed {
+		changes.Removed = append(changes.Removed, convert(change, false))
+	}
+	if err := withContent(set.Added, true, &changes.Added); err != nil {
+		return ChangedFiles{}, err
+	}
+	if err := withContent(set.Modified, false, &changes.Modified); err != nil {
+		return ChangedFiles{}, err
+	}
+	return changes, nil
+}
+
+// includeContent checks if the content of a file passes the size and binary checks of the configured filter.
+func (c *GitHub) includeContent(content document.FileContent) bool {
+	fileFilter := c.Configuration.Filter
+	if fileFilter.MaxFileSize > 0 && content.ByteSize > fileFilter.MaxFileSize {
+		return false
+	}
+	return !fileFilter.SkipBinary || !content.IsBinary
+}
@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubClient_GetChangedFilesSince(t *testing.T) {
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	commit := func(sha, message string, date time.Time) *github.RepositoryCommit {
+		return &github.RepositoryCommit{
+			SHA: github.String(sha),
+			Commit: &github.Commit{
+				Message:   github.String(message),
+				Author:    &github.CommitAuthor{Name: github.String("Jane Doe")},
+				Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: date}},
+			},
+		}
+	}
+	file := func(name, previous, status string) *github.CommitFile {
+		f := &github.CommitFile{Filename: github.String(name), Status: github.String(status)}
+		if previous != "" {
+			f.PreviousFilename = github.String(previous)
+		}
+		return f
+	}
+
+	// intro.md is renamed twice, index.md modified and old.md removed.
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.Anything).Return([]*github.RepositoryCommit{
+		commit("b", "Rename guide again", second),
+		commit("a", "Rename guide", first),
+	}, &github.Response{}, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "a", mock.Anything).
+		Return(&github.RepositoryCommit{Files: []*github.CommitFile{
+			file("docs/guide.md", "docs/intro.md", "renamed"),
+			file("docs/index.md", "", "modified"),
+		}}, &github.Response{}, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "b", mock.Anything).
+		Return(&github.RepositoryCommit{Files: []*github.CommitFile{
+			file("docs/handbook.md", "docs/guide.md", "renamed"),
+			file("docs/old.md", "", "removed"),
+		}}, &github.Response{}, nil)
+
+	blobs := map[string]GHBlob{
+		"main:docs/handbook.md": {Text: "# Handbook", ByteSize: 10},
+		"main:docs/index.md":    {Text: "# Index", ByteSize: 7},
+	}
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForBlobText"), mock.Anything).Run(func(args mock.Arguments) {
+		expression := string(args.Get(2).(map[string]interface{})["expression"].(githubv4.String))
+		args.Get(1).(*GHQueryForBlobText).Repository.Object.Blob = blobs[expression]
+	}).Return(nil)
+
+	gh := NewGitHubClient(commitOpsClient, graphQLClient, GitHubConfig{Owner: "testowner", Repositories: []string{"repo1"}, DefaultBranch: "main"})
+	changes, err := gh.GetChangedFilesSince(context.Background(), time.Time{})
+	require.NoError(t, err)
+
+	handbook := ChangedFile{
+		FileChange:   document.FileChange{Repository: "repo1", Path: "docs/handbook.md", CommitSHA: "b", Author: "Jane Doe", CommittedAt: second, Message: "Rename guide again"},
+		PreviousPath: "docs/intro.md",
+		Content:      document.FileContent{Repository: "repo1", Path: "docs/handbook.md", Text: "# Handbook", ByteSize: 10},
+	}
+	assert.Equal(t, []ChangedFile{handbook}, changes.Added)
+	assert.Equal(t, []ChangedFile{
+		{FileChange: document.FileChange{Repository: "repo1", Path: "docs/old.md", CommitSHA: "b", Author: "Jane Doe", CommittedAt: second, Message: "Rename guide again"}},
+		{FileChange: document.FileChange{Repository: "repo1", Path: "docs/intro.md", CommitSHA: "a", Author: "Jane Doe", CommittedAt: first, Message: "Rename guide"}},
+	}, changes.Removed)
+	require.Len(t, changes.Modified, 1)
+	assert.Equal(t, "# Index", changes.Modified[0].Content.Text)
+
+	assert.Equal(t, document.Document{
+		ID:         "repo1/docs/handbook.md",
+		Kind:       document.DocumentKindFile,
+		Repository: "repo1",
+		Path:       "docs/handbook.md",
+		Title:      "docs/handbook.md",
+		Body:       "# Handbook",
+		Author:     "Jane Doe",
+		UpdatedAt:  second,
+		Metadata:   map[string]string{"commit": "b", "previous_path": "docs/intro.md"},
+	}, handbook.Document())
+}
+
+func TestGitHubClient_GetChangedFilesSince_Filter(t *testing.T) {
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.Anything).
+		Return([]*github.RepositoryCommit{{SHA: github.String("a")}}, &github.Response{}, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "a", mock.Anything).
+		Return(&github.RepositoryCommit{Files: []*github.CommitFile{
+			{Filename: github.String("docs/main.go"), Status: github.String("added")},
+			{Filename: github.String("docs/new.md"), Status: github.String("added")},
+			{Filename: github.String("docs/huge.md"), Status: github.String("added")},
+			{Filename: github.String("docs/grown.md"), Status: github.String("modified")},
+		}}, &github.Response{}, nil)
+
+	var read []string
+	blobs := map[string]GHBlob{
+		"main:docs/new.md":   {Text: "# New", ByteSize: 5},
+		"main:docs/huge.md":  {ByteSize: 500},
+		"main:docs/grown.md": {ByteSize: 500},
+	}
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForBlobText"), mock.Anything).Run(func(args mock.Arguments) {
+		expression := string(args.Get(2).(map[string]interface{})["expression"].(githubv4.String))
+		read = append(read, expression)
+		args.Get(1).(*GHQueryForBlobText).Repository.Object.Blob = blobs[expression]
+	}).Return(nil)
+
+	gh := NewGitHubClient(commitOpsClient, graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}, MaxFileSize: 100},
+	})
+	changes, err := gh.GetChangedFilesSince(context.Background(), time.Time{})
+	require.NoError(t, err)
+
+	// Files of other types are not read, files grown past the size limit are no longer listed.
+	require.Len(t, changes.Added, 1)
+	assert.Equal(t, "docs/new.md", changes.Added[0].Path)
+	require.Len(t, changes.Removed, 1)
+	assert.Equal(t, "docs/grown.md", changes.Removed[0].Path)
+	assert.Equal(t, document.FileContent{}, changes.Removed[0].Content)
+	assert.Empty(t, changes.Modified)
+	assert.NotContains(t, read, "main:docs/main.go")
+}
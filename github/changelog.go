@@ -0,0 +1,157 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+)
+
+// slugPattern matches the runs of characters slugify replaces with a dash.
+var slugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// changelogSections are the changelog sections in the order they are rendered, keyed by commit type.
+var changelogSections = []struct {
+	Type  string
+	Title string
+}{
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance Improvements"},
+	{"revert", "Reverts"},
+	{"docs", "Documentation"},
+	{"refactor", "Code Refactoring"},
+	{"style", "Styles"},
+	{"test", "Tests"},
+	{"build", "Build System"},
+	{"ci", "Continuous Integration"},
+	{"chore", "Chores"},
+	{"", "Other Changes"},
+}
+
+// BuildChangelog renders the given commit or pull request documents into a Markdown changelog document.
+//
+// The title of every document is parsed as a Conventional Commits subject and the entries are grouped by
+// type, with breaking changes listed first and non-conventional entries under "Other Changes". Entries link
+// to the URL of their document.
+func BuildChangelog(title string, docs []document.Document) document.Document {
+	var breaking []string
+	entries := make(map[string][]string)
+	known := make(map[string]bool, len(changelogSections))
+	for _, section := range changelogSections {
+		known[section.Type] = true
+	}
+
+	changelog := document.Document{
+		Kind:     document.DocumentKindChangelog,
+		Title:    title,
+		Metadata: map[string]string{"entries": fmt.Sprint(len(docs))},
+	}
+
+	for _, doc := range docs {
+		commit, _ := filter.ParseConventionalCommit(doc.Title + "\n\n" + doc.Body)
+
+		entry := commit.Description
+		if commit.Scope != "" {
+			entry = fmt.Sprintf("**%s:** %s", commit.Scope, entry)
+		}
+		if doc.URL != "" {
+			entry = fmt.Sprintf("%s ([%s](%s))", entry, changelogReference(doc), doc.URL)
+		}
+
+		if commit.Breaking {
+			breaking = append(breaking, entry)
+		}
+
+		section := commit.Type
+		if !known[section] {
+			section = ""
+		}
+		entries[section] = append(entries[section], entry)
+
+		if changelog.Owner == "" {
+			changelog.Owner, changelog.Repository = doc.Owner, doc.Repository
+		} else if changelog.Owner != doc.Owner || changelog.Repository != doc.Repository {
+			changelog.Repository = ""
+		}
+		if doc.UpdatedAt.After(changelog.UpdatedAt) {
+			changelog.UpdatedAt = doc.UpdatedAt
+		}
+		if changelog.CreatedAt.IsZero() || (!doc.CreatedAt.IsZero() && doc.CreatedAt.Before(changelog.CreatedAt)) {
+			changelog.CreatedAt = doc.CreatedAt
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", title)
+	writeSection := func(heading string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", heading)
+		for _, line := range lines {
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+	}
+
+	writeSection("Breaking Changes", breaking)
+	for _, section := range changelogSections {
+		writeSection(section.Title, entries[section.Type])
+	}
+
+	changelog.Body = b.String()
+	changelog.ID = path.Join(changelog.Owner, changelog.Repository, "changelog", slugify(title))
+
+	return changelog
+}
+
+// GetChangelogSince builds a Markdown changelog document from the commits made since the given time in the
+// configured repositories, see GetCommitDocumentsSince and BuildChangelog.
+//
+// Usage:
+//
+//	changelog, err := c.GetChangelogSince(ctx, lastRelease)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	fmt.Println(changelog.Body)
+func (c *GitHub) GetChangelogSince(ctx context.Context, since time.Time) (document.Document, error) {
+	docs, err := c.GetCommitDocumentsSince(ctx, since)
+	if err != nil {
+		return document.Document{}, err
+	}
+
+	changelog := BuildChangelog(fmt.Sprintf("Changes since %s", since.Format("2006-01-02")), docs)
+	if changelog.Repository == "" {
+		changelog.Owner = c.Configuration.Owner
+	}
+	changelog.ID = path.Join(changelog.Owner, changelog.Repository, "changelog", slugify(changelog.Title))
+
+	return changelog, nil
+}
+
+// changelogReference returns the short reference of a changelog entry: the abbreviated commit SHA or the
+// pull request number.
+func changelogReference(doc document.Document) string {
+	if sha := doc.Metadata["sha"]; sha != "" {
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		return sha
+	}
+	if number := doc.Metadata["pull_request"]; number != "" {
+		return "#" + number
+	}
+	return "link"
+}
+
+// slugify turns a title into a lower case, dash separated identifier.
+func slugify(title string) string {
+	return strings.Trim(slugPattern.ReplaceAllString(strings.ToLower(title), "-"), "-")
+}
@@ -0,0 +1,47 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildChangelog(t *testing.T) {
+	day := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	docs := []document.Document{
+		{Owner: "testowner", Repository: "repo1", Title: "feat(api)!: drop v1", URL: "https://example.com/1", Metadata: map[string]string{"sha": "1111111111"}, CreatedAt: day, UpdatedAt: day},
+		{Owner: "testowner", Repository: "repo1", Title: "fix: handle tabs", URL: "https://example.com/2", Metadata: map[string]string{"pull_request": "12"}, CreatedAt: day.Add(time.Hour), UpdatedAt: day.Add(time.Hour)},
+		{Owner: "testowner", Repository: "repo1", Title: "Update README", CreatedAt: day.Add(2 * time.Hour), UpdatedAt: day.Add(2 * time.Hour)},
+		{Owner: "testowner", Repository: "repo1", Title: "feat: add search"},
+	}
+
+	changelog := BuildChangelog("Release 1.2.0", docs)
+
+	assert.Equal(t, "testowner/repo1/changelog/release-1-2-0", changelog.ID)
+	assert.Equal(t, document.DocumentKindChangelog, changelog.Kind)
+	assert.Equal(t, "testowner", changelog.Owner)
+	assert.Equal(t, "repo1", changelog.Repository)
+	assert.Equal(t, day, changelog.CreatedAt)
+	assert.Equal(t, day.Add(2*time.Hour), changelog.UpdatedAt)
+	assert.Equal(t, `# Release 1.2.0
+
+## Breaking Changes
+
+- **api:** drop v1 ([1111111](https://example.com/1))
+
+## Features
+
+- **api:** drop v1 ([1111111](https://example.com/1))
+- add search
+
+## Bug Fixes
+
+- handle tabs ([#12](https://example.com/2))
+
+## Other Changes
+
+- Update README
+`, changelog.Body)
+}
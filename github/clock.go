@@ -0,0 +1,29 @@
+package github
+
+import (
+	"context"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/internal/timeutil"
+)
+
+// Clock tells the client the current time. Methods working relative to "now" use the configured clock, so
+// tests can control the time instead of depending on the wall clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// GetChangedFilePathsWithin retrieves the file paths changed within the given window before now, as
+// reported by the configured Clock. It is GetChangedFilePathsSinceWithContext with the start time computed
+// from the clock.
+//
+// Usage:
+//
+//	changedFiles, err := c.GetChangedFilePathsWithin(ctx, 24*time.Hour)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *GitHub) GetChangedFilePathsWithin(ctx context.Context, window time.Duration) (document.Paths, error) {
+	return c.GetChangedFilePathsSinceWithContext(ctx, timeutil.Now(c.Configuration.Clock).Add(-window))
+}
@@ -0,0 +1,40 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestGitHub_GetChangedFilePathsWithin(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.CommitsListOptions) bool {
+		return opts.Since.Equal(now.Add(-24 * time.Hour))
+	})).Return([]*github.RepositoryCommit{{SHA: github.String("abc")}}, nil, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "abc", mock.Anything).Return(&github.RepositoryCommit{
+		Files: []*github.CommitFile{{Filename: github.String("docs/a.md"), Status: github.String("added")}},
+	}, nil, nil)
+
+	client := NewGitHubClient(commitOpsClient, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+		Clock:        fixedClock(now),
+	})
+
+	paths, err := client.GetChangedFilePathsWithin(context.Background(), 24*time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/a.md"}, paths.Added)
+	commitOpsClient.AssertExpectations(t)
+}
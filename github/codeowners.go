@@ -0,0 +1,42 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+)
+
+// codeownersLocations are the places GitHub looks for a CODEOWNERS file, in the order it looks.
+var codeownersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// GetOwnership reads the CODEOWNERS file of the repository from the default branch. The file is looked up
+// in the same locations GitHub uses: .github/, the repository root and docs/. A repository without a
+// CODEOWNERS file yields an empty Ownership.
+//
+// Usage:
+//
+//	ownership, err := c.GetOwnership(ctx, "repo1")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	fmt.Println(ownership.Owners("docs/index.md"))
+func (c *GitHub) GetOwnership(ctx context.Context, repo string) (filter.Ownership, error) {
+	branch, err := c.branch(ctx, repo)
+	if err != nil {
+		return filter.Ownership{}, err
+	}
+
+	for _, location := range codeownersLocations {
+		text, err := c.getBlobText(ctx, c.ownerOf(repo), c.nameOf(repo), fmt.Sprintf("%s:%s", branch, location))
+		if err != nil {
+			return filter.Ownership{}, err
+		}
+		if text != "" {
+			return filter.ParseCodeowners(text), nil
+		}
+	}
+
+	return filter.Ownership{}, nil
+}
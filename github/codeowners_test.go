@@ -0,0 +1,35 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGitHub_GetOwnership(t *testing.T) {
+	var expressions []string
+
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForBlobText"), mock.Anything).Run(func(args mock.Arguments) {
+		expression := string(args.Get(2).(map[string]interface{})["expression"].(githubv4.String))
+		expressions = append(expressions, expression)
+		if expression == "main:CODEOWNERS" {
+			args.Get(1).(*GHQueryForBlobText).Repository.Object.Blob.Text = "docs/ @docs-team\n"
+		}
+	}).Return(nil)
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+	})
+
+	ownership, err := gh.GetOwnership(context.Background(), "repo1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"main:.github/CODEOWNERS", "main:CODEOWNERS"}, expressions)
+	assert.Equal(t, []string{"@docs-team"}, ownership.Owners("docs/index.md"))
+	assert.Nil(t, ownership.Owners("README.md"))
+}
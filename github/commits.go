@@ -0,0 +1,98 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/internal/gitutil"
+)
+
+// GetCommitDocumentsSince retrieves the messages of the commits made since the given time in the configured
+// repositories as documents. Only commits touching the configured file path are included. The subject line of
+// the message becomes the title of the document and the remaining lines its body.
+//
+// Usage:
+//
+//	docs, err := c.GetCommitDocumentsSince(ctx, time.Now().Add(-7*24*time.Hour))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, doc := range docs {
+//	    fmt.Println(doc.Metadata["sha"], doc.Title)
+//	}
+func (c *GitHub) GetCommitDocumentsSince(ctx context.Context, since time.Time) ([]document.Document, error) {
+	repoDocs := make([][]document.Document, len(c.repositories()))
+	err := c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		commits, err := c.listCommitsSince(ctx, repo, since)
+		if err != nil {
+			return err
+		}
+
+		for _, commit := range commits {
+			repoDocs[i] = append(repoDocs[i], c.commitDocument(repo, commit))
+		}
+		return nil
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	return flattenDocuments(repoDocs), err
+}
+
+// listCommitsSince lists all commits of a repository made since the given time that touch the configured
+// file path.
+func (c *GitHub) listCommitsSince(ctx context.Context, repo string, since time.Time) ([]*github.RepositoryCommit, error) {
+	branch, err := c.branch(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.CommitsListOptions{
+		SHA:         branch,
+		Since:       since,
+		Path:        c.historyPath(repo),
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var commits []*github.RepositoryCommit
+	for {
+		page, resp, err := c.commitOpsClient.ListCommits(ctx, c.ownerOf(repo), c.nameOf(repo), opts)
+		if err != nil {
+			return nil, c.classifyError(err)
+		}
+		commits = append(commits, page...)
+
+		if resp == nil || resp.NextPage == 0 {
+			return commits, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// commitDocument converts a commit into a document holding its message.
+func (c *GitHub) commitDocument(repo string, commit *github.RepositoryCommit) document.Document {
+	subject, body := gitutil.SplitCommitMessage(commit.GetCommit().GetMessage())
+
+	return document.Document{
+		ID:         fmt.Sprintf("%s/%s/commit/%s", c.ownerOf(repo), c.nameOf(repo), commit.GetSHA()),
+		Kind:       document.DocumentKindCommit,
+		Owner:      c.ownerOf(repo),
+		Repository: c.nameOf(repo),
+		Title:      subject,
+		Body:       body,
+		URL:        commit.GetHTMLURL(),
+		Author:     gitutil.CommitAuthor(commit),
+		CreatedAt:  commit.GetCommit().GetAuthor().GetDate().Time,
+		UpdatedAt:  commit.GetCommit().GetCommitter().GetDate().Time,
+		Metadata: map[string]string{
+			"sha":          commit.GetSHA(),
+			"author_email": commit.GetCommit().GetAuthor().GetEmail(),
+			"committer":    commit.GetCommit().GetCommitter().GetName(),
+		},
+	}
+}
@@ -0,0 +1,72 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGitHub_GetCommitDocumentsSince(t *testing.T) {
+	since := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.CommitsListOptions) bool {
+		return opts.Since.Equal(since) && opts.Path == "docs" && opts.SHA == "main" && opts.Page == 0
+	})).Return([]*github.RepositoryCommit{{
+		SHA:     github.String("abc123"),
+		HTMLURL: github.String("https://github.com/testowner/repo1/commit/abc123"),
+		Author:  &github.User{Login: github.String("alice")},
+		Commit: &github.Commit{
+			Message:   github.String("docs: add setup guide\n\nCovers Linux and macOS."),
+			Author:    &github.CommitAuthor{Name: github.String("Alice"), Email: github.String("alice@example.com"), Date: &github.Timestamp{Time: since}},
+			Committer: &github.CommitAuthor{Name: github.String("GitHub"), Date: &github.Timestamp{Time: since.Add(time.Minute)}},
+		},
+	}}, &github.Response{NextPage: 2}, nil).Once()
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.CommitsListOptions) bool {
+		return opts.Page == 2
+	})).Return([]*github.RepositoryCommit{{
+		SHA:    github.String("def456"),
+		Commit: &github.Commit{Message: github.String("Fix link"), Author: &github.CommitAuthor{Name: github.String("Bob")}},
+	}}, nil, nil).Once()
+
+	gh := NewGitHubClient(commitOpsClient, new(GraphQLClientMock), GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs"},
+	})
+
+	docs, err := gh.GetCommitDocumentsSince(context.Background(), since)
+	assert.NoError(t, err)
+	assert.Equal(t, []document.Document{
+		{
+			ID:         "testowner/repo1/commit/abc123",
+			Kind:       document.DocumentKindCommit,
+			Owner:      "testowner",
+			Repository: "repo1",
+			Title:      "docs: add setup guide",
+			Body:       "Covers Linux and macOS.",
+			URL:        "https://github.com/testowner/repo1/commit/abc123",
+			Author:     "alice",
+			CreatedAt:  since,
+			UpdatedAt:  since.Add(time.Minute),
+			Metadata:   map[string]string{"sha": "abc123", "author_email": "alice@example.com", "committer": "GitHub"},
+		},
+		{
+			ID:         "testowner/repo1/commit/def456",
+			Kind:       document.DocumentKindCommit,
+			Owner:      "testowner",
+			Repository: "repo1",
+			Title:      "Fix link",
+			Author:     "Bob",
+			Metadata:   map[string]string{"sha": "def456", "author_email": "", "committer": ""},
+		},
+	}, docs)
+	commitOpsClient.AssertExpectations(t)
+}
@@ -0,0 +1,182 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// CommunityHealthFile is a community health file GitHub recognizes, named by its file name without
+// extension.
+type CommunityHealthFile string
+
+// The community health files collected by GetCommunityHealthFiles.
+const (
+	CommunityHealthReadme        CommunityHealthFile = "README"
+	CommunityHealthContributing  CommunityHealthFile = "CONTRIBUTING"
+	CommunityHealthCodeOfConduct CommunityHealthFile = "CODE_OF_CONDUCT"
+	CommunityHealthSecurity      CommunityHealthFile = "SECURITY"
+	CommunityHealthSupport       CommunityHealthFile = "SUPPORT"
+)
+
+// communityHealthFiles lists the collected files in the order they are looked up.
+var communityHealthFiles = []CommunityHealthFile{
+	CommunityHealthReadme,
+	CommunityHealthContributing,
+	CommunityHealthCodeOfConduct,
+	CommunityHealthSecurity,
+	CommunityHealthSupport,
+}
+
+// communityHealthDirs are the directories GitHub looks for community health files in, in order of
+// precedence.
+var communityHealthDirs = []string{".github", "", "docs"}
+
+// orgDefaultsRepository is the repository holding the default community health files of an owner.
+const orgDefaultsRepository = ".github"
+
+// CommunityHealthBundle holds the community health files that apply to a repository, keyed by file.
+// Files the repository does not have itself are taken from the owner's .github repository, except the
+// README which GitHub never inherits.
+type CommunityHealthBundle struct {
+	Repository string
+	Files      map[CommunityHealthFile]document.Document
+}
+
+// GetCommunityHealthFiles collects the README, CONTRIBUTING, CODE_OF_CONDUCT, SECURITY and SUPPORT files
+// of every configured repository as one bundle per repository.
+//
+// Every file is looked up in the .github directory, the repository root and the docs directory, in that
+// order, matching the file name case-insensitively and with any extension. Files missing from a
+// repository fall back to the default branch of the owner's .github repository. Inherited documents keep
+// the repository they apply to and record where they came from in the "source_repository" metadata.
+//
+// Usage:
+//
+//	bundles, err := c.GetCommunityHealthFiles(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, bundle := range bundles {
+//	    if doc, ok := bundle.Files[CommunityHealthSecurity]; ok {
+//	        fmt.Println(bundle.Repository, doc.Path, doc.Metadata["source_repository"])
+//	    }
+//	}
+func (c *GitHub) GetCommunityHealthFiles(ctx context.Context) ([]CommunityHealthBundle, error) {
+	// The defaults of every owner are read at most once.
+	var mu sync.Mutex
+	defaults := make(map[string]map[CommunityHealthFile]document.Document)
+
+	repoBundles := make([]*CommunityHealthBundle, len(c.repositories()))
+	err := c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		branch, err := c.branch(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		files, err := c.getCommunityHealthFiles(ctx, repo, repo, branch)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, file := range communityHealthFiles {
+			if _, ok := files[file]; ok || file == CommunityHealthReadme || c.nameOf(repo) == orgDefaultsRepository {
+				continue
+			}
+
+			owner := c.ownerOf(repo)
+			if defaults[owner] == nil {
+				source := owner + "/" + orgDefaultsRepository
+				defaults[owner], err = c.getCommunityHealthFiles(ctx, source, source, "HEAD")
+				if isRepositoryNotFound(err) {
+					defaults[owner], err = map[CommunityHealthFile]document.Document{}, nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			if doc, ok := defaults[owner][file]; ok {
+				doc.Repository = c.nameOf(repo)
+				doc.ID = path.Join(c.ownerOf(repo), c.nameOf(repo), "community", string(file))
+				files[file] = doc
+			}
+		}
+
+		repoBundles[i] = &CommunityHealthBundle{Repository: repo, Files: files}
+		return nil
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	var bundles []CommunityHealthBundle
+	for _, bundle := range repoBundles {
+		if bundle != nil {
+			bundles = append(bundles, *bundle)
+		}
+	}
+	return bundles, err
+}
+
+// getCommunityHealthFiles reads the community health files of the source repository at the given ref and
+// returns them as documents applying to repo.
+func (c *GitHub) getCommunityHealthFiles(ctx context.Context, repo, source, ref string) (map[CommunityHealthFile]document.Document, error) {
+	files := make(map[CommunityHealthFile]document.Document)
+	for _, dir := range communityHealthDirs {
+		entries, err := c.listTreeEntries(ctx, c.ownerOf(source), c.nameOf(source), fmt.Sprintf("%s:%s", ref, dir))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range communityHealthFiles {
+			if _, ok := files[file]; ok {
+				continue
+			}
+
+			for _, entry := range entries {
+				if entry.Type != "blob" || !strings.EqualFold(strings.TrimSuffix(entry.Name, path.Ext(entry.Name)), string(file)) {
+					continue
+				}
+
+				filePath := path.Join(dir, entry.Name)
+				text, err := c.getBlobText(ctx, c.ownerOf(source), c.nameOf(source), fmt.Sprintf("%s:%s", ref, filePath))
+				if err != nil {
+					return nil, err
+				}
+
+				files[file] = document.Document{
+					ID:         path.Join(c.ownerOf(repo), c.nameOf(repo), "community", string(file)),
+					Kind:       document.DocumentKindCommunityHealth,
+					Owner:      c.ownerOf(repo),
+					Repository: c.nameOf(repo),
+					Path:       c.normalizePath(filePath),
+					Title:      string(file),
+					Body:       text,
+					URL:        c.fileURL(source, ref, filePath),
+					Metadata: map[string]string{
+						"file":              string(file),
+						"source_repository": c.nameOf(source),
+					},
+				}
+				break
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// isRepositoryNotFound reports whether err is the GraphQL error GitHub returns for a repository that does
+// not exist or is not accessible.
+func isRepositoryNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "Could not resolve to a Repository")
+}
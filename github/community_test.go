@@ -0,0 +1,107 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGitHub_GetCommunityHealthFiles(t *testing.T) {
+	trees := map[string]map[string][]GHTreeEntry{
+		"repo1": {
+			"main:": {
+				{Name: "README.md", Path: "README.md", Type: "blob"},
+				{Name: "CONTRIBUTING.md", Path: "CONTRIBUTING.md", Type: "blob"},
+				{Name: "docs", Path: "docs", Type: "tree"},
+			},
+			"main:.github": {
+				{Name: "contributing.rst", Path: ".github/contributing.rst", Type: "blob"},
+			},
+			"main:docs": {
+				{Name: "SUPPORT", Path: "docs/SUPPORT", Type: "blob"},
+			},
+		},
+		".github": {
+			"HEAD:": {
+				{Name: "README.md", Path: "README.md", Type: "blob"},
+				{Name: "SECURITY.md", Path: "SECURITY.md", Type: "blob"},
+				{Name: "SUPPORT.md", Path: "SUPPORT.md", Type: "blob"},
+			},
+		},
+	}
+
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		variables := args.Get(2).(map[string]interface{})
+		repo := string(variables["name"].(githubv4.String))
+		expression := string(variables["expression"].(githubv4.String))
+		switch query := args.Get(1).(type) {
+		case *GHQueryForListFiles:
+			query.Repository.Object.Tree.Entries = trees[repo][expression]
+		case *GHQueryForBlobText:
+			query.Repository.Object.Blob.Text = repo + " " + expression
+		}
+	}).Return(nil)
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+	})
+
+	bundles, err := gh.GetCommunityHealthFiles(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, bundles, 1)
+	assert.Equal(t, "repo1", bundles[0].Repository)
+
+	files := bundles[0].Files
+	assert.Len(t, files, 4)
+	assert.Equal(t, "repo1 main:README.md", files[CommunityHealthReadme].Body)
+	assert.Equal(t, ".github/contributing.rst", files[CommunityHealthContributing].Path)
+	assert.Equal(t, "docs/SUPPORT", files[CommunityHealthSupport].Path)
+	assert.NotContains(t, files, CommunityHealthCodeOfConduct)
+
+	security := files[CommunityHealthSecurity]
+	assert.Equal(t, document.Document{
+		ID:         "testowner/repo1/community/SECURITY",
+		Kind:       document.DocumentKindCommunityHealth,
+		Owner:      "testowner",
+		Repository: "repo1",
+		Path:       "SECURITY.md",
+		Title:      "SECURITY",
+		Body:       ".github HEAD:SECURITY.md",
+		URL:        "https://github.com/testowner/.github/blob/HEAD/SECURITY.md",
+		Metadata:   map[string]string{"file": "SECURITY", "source_repository": ".github"},
+	}, security)
+
+	gh.Configuration.BaseURL = "https://github.example.com/api/v3/"
+	bundles, err = gh.GetCommunityHealthFiles(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.example.com/testowner/.github/blob/HEAD/SECURITY.md", bundles[0].Files[CommunityHealthSecurity].URL)
+}
+
+func TestGitHub_GetCommunityHealthFiles_NoOrgDefaults(t *testing.T) {
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.MatchedBy(func(variables map[string]interface{}) bool {
+		return variables["name"] == githubv4.String(".github")
+	})).Return(errors.New("Could not resolve to a Repository with the name 'testowner/.github'."))
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1", "repo2"},
+		DefaultBranch: "main",
+	})
+
+	bundles, err := gh.GetCommunityHealthFiles(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, bundles, 2)
+	assert.Empty(t, bundles[0].Files)
+	assert.Empty(t, bundles[1].Files)
+	graphQLClient.AssertNumberOfCalls(t, "Query", 7)
+}
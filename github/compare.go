@@ -0,0 +1,126 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// compareFileLimit is the number of changed files the compare API lists at most; comparisons changing more
+// files are truncated.
+const compareFileLimit = 300
+
+// CompareOpsClient is an interface to help test the GitHub commit comparison operations.
+// GetChangedFilePathsBetween compares the two refs through it.
+type CompareOpsClient interface {
+	CompareCommits(ctx context.Context, owner, repo, base, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error)
+}
+
+// CompareCommits compares two commits, branches or tags of a specific repository.
+func (gClient *GitHubCommitsOpsClient) CompareCommits(ctx context.Context, owner, repo, base, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error) {
+	return gClient.GitHubClient.Repositories.CompareCommits(ctx, owner, repo, base, head, opts)
+}
+
+// GetChangedFilePathsBetween retrieves the files that differ between two commits, branches or tags of the given
+// repository, classified into added, removed and modified paths. Only the files passing the configured filter
+// are returned.
+//
+// The paths come from a single request to the compare API, which returns the net difference of the two refs
+// instead of the changes of every commit in between. A file added and removed again in between is not
+// reported at all. GitHub lists at most 300 changed files in a comparison. For larger diffs a warning is
+// logged and the changes are detected from every commit in between instead, reconciled like
+// GetChangedFilePathsSince does, which takes one more request per commit.
+//
+// Usage:
+//
+//	paths, err := c.GetChangedFilePathsBetween(ctx, "website", lastSyncedSHA, "main")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	fmt.Println("Modified files:", paths.Modified)
+func (c *GitHub) GetChangedFilePathsBetween(ctx context.Context, repo, base, head string) (document.Paths, error) {
+	client, err := c.compareOpsClient()
+	if err != nil {
+		return document.Paths{}, err
+	}
+
+	rules, err := c.getFileRules(ctx, repo)
+	if err != nil {
+		return document.Paths{}, err
+	}
+
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return document.Paths{}, err
+	}
+	comparison, _, err := client.CompareCommits(ctx, c.ownerOf(repo), c.nameOf(repo), base, head, nil)
+	release()
+	if err != nil {
+		return document.Paths{}, fmt.Errorf("failed to compare %s...%s: %w", base, head, c.classifyError(err))
+	}
+
+	if len(comparison.Files) >= compareFileLimit {
+		c.warn("comparison truncated, detecting the changes commit by commit", "repository", c.ownerOf(repo)+"/"+c.nameOf(repo), "base", base, "head", head)
+		events, err := c.listComparisonEvents(ctx, client, repo, base, head, rules)
+		if err != nil {
+			return document.Paths{}, err
+		}
+		return c.normalizeChangedPaths(document.ReconcileChanges(events, nil)), nil
+	}
+
+	var paths document.Paths
+	for _, file := range comparison.Files {
+		addCommitFile(&paths, file, func(filePath string) bool { return c.includeChangedFile(filePath, rules) })
+	}
+
+	return c.normalizeChangedPaths(paths), nil
+}
+
+// listComparisonEvents lists the changes of the files passing the filter by every commit between base and
+// head, newest first, reading the files of each commit separately.
+func (c *GitHub) listComparisonEvents(ctx context.Context, client CompareOpsClient, repo, base, head string, rules fileRules) ([]document.ChangeEvent, error) {
+	// The compare API lists the commits oldest first.
+	var commits []*github.RepositoryCommit
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		release, err := c.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		comparison, resp, err := client.CompareCommits(ctx, c.ownerOf(repo), c.nameOf(repo), base, head, opts)
+		release()
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare %s...%s: %w", base, head, c.classifyError(err))
+		}
+		for _, commit := range comparison.Commits {
+			commits = append([]*github.RepositoryCommit{commit}, commits...)
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	var events []document.ChangeEvent
+	for _, commit := range commits {
+		files, err := c.getCommitFiles(ctx, repo, commit.GetSHA())
+		if err != nil {
+			return nil, err
+		}
+		events = c.appendChangeEvents(events, repo, commit, files, rules)
+	}
+	return events, nil
+}
+
+// compareOpsClient returns the commit ops client as a CompareOpsClient.
+func (c *GitHub) compareOpsClient() (CompareOpsClient, error) {
+	client, ok := c.commitOpsClient.(CompareOpsClient)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not implement CompareOpsClient", ErrUnsupportedClient, c.commitOpsClient)
+	}
+	return client, nil
+}
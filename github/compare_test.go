@@ -0,0 +1,204 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// CompareOpsClientMock is a mock type for a CommitOpsClient that also implements CompareOpsClient
+type CompareOpsClientMock struct {
+	CommitOpsClientMock
+}
+
+// CompareCommits provides a mock function with given fields: ctx, owner, repo, base, head, opts
+func (_m *CompareOpsClientMock) CompareCommits(ctx context.Context, owner, repo, base, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, base, head, opts)
+	comparison, _ := ret.Get(0).(*github.CommitsComparison)
+	resp, _ := ret.Get(1).(*github.Response)
+	return comparison, resp, ret.Error(2)
+}
+
+func TestGitHubClient_GetChangedFilePathsBetween(t *testing.T) {
+	client := new(CompareOpsClientMock)
+	client.On("CompareCommits", mock.Anything, "testowner", "repo1", "abc", "main", mock.Anything).Return(&github.CommitsComparison{
+		Files: []*github.CommitFile{
+			{Filename: github.String("docs/new.md"), Status: github.String("added")},
+			{Filename: github.String("docs/index.md"), Status: github.String("modified")},
+			{Filename: github.String("docs/old.md"), Status: github.String("removed")},
+			{Filename: github.String("docs/guide.md"), PreviousFilename: github.String("guide.md"), Status: github.String("renamed")},
+			{Filename: github.String("main.go"), Status: github.String("modified")},
+		},
+	}, &github.Response{}, nil)
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+	})
+
+	paths, err := gh.GetChangedFilePathsBetween(context.Background(), "repo1", "abc", "main")
+	assert.NoError(t, err)
+	assert.Equal(t, document.Paths{
+		Added:    []string{"docs/new.md", "docs/guide.md"},
+		Removed:  []string{"docs/old.md"},
+		Modified: []string{"docs/index.md"},
+	}, paths)
+}
+
+func TestGitHubClient_GetChangedFilePathsBetween_Truncated(t *testing.T) {
+	// The comparison lists as many files as the compare API returns at most, so the changes are detected
+	// from its commits.
+	files := make([]*github.CommitFile, compareFileLimit)
+	for i := range files {
+		files[i] = &github.CommitFile{Filename: github.String(fmt.Sprintf("docs/page%d.md", i)), Status: github.String("added")}
+	}
+
+	client := new(CompareOpsClientMock)
+	client.On("CompareCommits", mock.Anything, "testowner", "repo1", "abc", "main", (*github.ListOptions)(nil)).Return(&github.CommitsComparison{
+		Files: files,
+	}, &github.Response{}, nil)
+	client.On("CompareCommits", mock.Anything, "testowner", "repo1", "abc", "main", mock.MatchedBy(func(opts *github.ListOptions) bool {
+		return opts != nil && opts.Page == 0
+	})).Return(&github.CommitsComparison{
+		Commits: []*github.RepositoryCommit{{SHA: github.String("c1")}},
+	}, &github.Response{NextPage: 2}, nil)
+	client.On("CompareCommits", mock.Anything, "testowner", "repo1", "abc", "main", mock.MatchedBy(func(opts *github.ListOptions) bool {
+		return opts != nil && opts.Page == 2
+	})).Return(&github.CommitsComparison{
+		Commits: []*github.RepositoryCommit{{SHA: github.String("c2")}},
+	}, &github.Response{}, nil)
+	client.On("GetCommit", mock.Anything, "testowner", "repo1", "c1", mock.Anything).Return(&github.RepositoryCommit{
+		Files: []*github.CommitFile{
+			{Filename: github.String("docs/new.md"), Status: github.String("added")},
+			{Filename: github.String("docs/index.md"), Status: github.String("modified")},
+			{Filename: github.String("main.go"), Status: github.String("modified")},
+		},
+	}, nil, nil)
+	client.On("GetCommit", mock.Anything, "testowner", "repo1", "c2", mock.Anything).Return(&github.RepositoryCommit{
+		Files: []*github.CommitFile{
+			{Filename: github.String("docs/new.md"), Status: github.String("removed")},
+			{Filename: github.String("docs/old.md"), Status: github.String("removed")},
+		},
+	}, nil, nil)
+
+	logger := &recordingWarnLogger{}
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+		Logger:        logger,
+	})
+
+	paths, err := gh.GetChangedFilePathsBetween(context.Background(), "repo1", "abc", "main")
+	assert.NoError(t, err)
+	assert.Equal(t, document.Paths{
+		Removed:  []string{"docs/old.md"},
+		Modified: []string{"docs/index.md"},
+	}, paths)
+	assert.Equal(t, []string{"comparison truncated, detecting the changes commit by commit repository=testowner/repo1"}, logger.warnings)
+}
+
+func TestGitHubClient_GetChangedFilePathsBetween_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "missing commit", err: errorResponse(http.StatusNotFound, "No commit found for SHA: abc"), want: ErrRefNotFound},
+		{name: "missing repository", err: errorResponse(http.StatusNotFound, "Not Found"), want: ErrRepoNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(CompareOpsClientMock)
+			client.On("CompareCommits", mock.Anything, "testowner", "repo1", "abc", "main", mock.Anything).Return(nil, nil, tt.err)
+
+			gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+				Owner:         "testowner",
+				Repositories:  []string{"repo1"},
+				DefaultBranch: "main",
+			})
+
+			_, err := gh.GetChangedFilePathsBetween(context.Background(), "repo1", "abc", "main")
+			assert.ErrorIs(t, err, tt.want)
+			assert.ErrorContains(t, err, "failed to compare abc...main")
+		})
+	}
+}
+
+func TestPaths_add(t *testing.T) {
+	include := func(filePath string) bool { return strings.HasPrefix(filePath, "docs/") }
+	renamed := func(from, to string) *github.CommitFile {
+		return &github.CommitFile{Filename: github.String(to), PreviousFilename: github.String(from), Status: github.String("renamed")}
+	}
+
+	tests := []struct {
+		name string
+		file *github.CommitFile
+		want document.Paths
+	}{
+		{
+			name: "copied file is added",
+			file: &github.CommitFile{Filename: github.String("docs/copy.md"), Status: github.String("copied")},
+			want: document.Paths{Added: []string{"docs/copy.md"}},
+		},
+		{
+			name: "excluded file is skipped",
+			file: &github.CommitFile{Filename: github.String("main.go"), Status: github.String("modified")},
+			want: document.Paths{},
+		},
+		{
+			name: "renamed file is removed, added and renamed",
+			file: renamed("docs/a.md", "docs/b.md"),
+			want: document.Paths{Added: []string{"docs/b.md"}, Removed: []string{"docs/a.md"}, Renamed: []document.Rename{{From: "docs/a.md", To: "docs/b.md"}}},
+		},
+		{
+			name: "file renamed into the included paths is added",
+			file: renamed("a.md", "docs/a.md"),
+			want: document.Paths{Added: []string{"docs/a.md"}},
+		},
+		{
+			name: "file renamed out of the included paths is removed",
+			file: renamed("docs/a.md", "a.md"),
+			want: document.Paths{Removed: []string{"docs/a.md"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths document.Paths
+			addCommitFile(&paths, tt.file, include)
+			assert.Equal(t, tt.want, paths)
+		})
+	}
+}
+
+func TestGitHubClient_GetChangedFilePathsBetween_Error(t *testing.T) {
+	client := new(CompareOpsClientMock)
+	client.On("CompareCommits", mock.Anything, "testowner", "repo1", "abc", "def", mock.Anything).
+		Return(nil, nil, errors.New("not found"))
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{Owner: "testowner", Repositories: []string{"repo1"}})
+
+	_, err := gh.GetChangedFilePathsBetween(context.Background(), "repo1", "abc", "def")
+	assert.EqualError(t, err, "failed to compare abc...def: not found")
+}
+
+func TestGitHubClient_GetChangedFilePathsBetween_UnsupportedClient(t *testing.T) {
+	gh := NewGitHubClient(new(CommitOpsClientMock), new(GraphQLClientMock), GitHubConfig{Owner: "testowner", Repositories: []string{"repo1"}})
+
+	_, err := gh.GetChangedFilePathsBetween(context.Background(), "repo1", "abc", "def")
+	assert.ErrorIs(t, err, ErrUnsupportedClient)
+}
@@ -0,0 +1,119 @@
+package github
+
+import (
+	"context"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"golang.org/x/sync/errgroup"
+)
+
+// concurrent reports whether API calls may run in parallel.
+func (c *GitHub) concurrent() bool {
+	return c.Configuration.MaxConcurrency > 1
+}
+
+// acquire waits for a free API call slot and returns the function releasing it. Without concurrency it
+// returns immediately.
+func (c *GitHub) acquire(ctx context.Context) (func(), error) {
+	if !c.concurrent() {
+		return func() {}, nil
+	}
+
+	c.slotsOnce.Do(func() {
+		c.slots = make(chan struct{}, c.Configuration.MaxConcurrency)
+	})
+
+	select {
+	case c.slots <- struct{}{}:
+		return func() { <-c.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// forEachRepository calls fn for every configured repository with its index, in parallel if concurrency
+// is enabled. It returns the first error, cancelling the context passed to the remaining calls.
+//
+// With GitHubConfig.ContinueOnError a failing repository does not stop the others: forEachRepository returns
+// a *PartialError listing the failed repositories once all were crawled, or the error of the context if it
+// was cancelled.
+func (c *GitHub) forEachRepository(ctx context.Context, fn func(ctx context.Context, i int, repo string) error) error {
+	repos := c.repositories()
+	crawled := make([]bool, len(repos))
+	errs := make([]error, len(repos))
+	crawl := func(ctx context.Context, i int, repo string) error {
+		crawled[i], errs[i] = c.crawlRepository(ctx, i, repo, fn)
+		if !c.Configuration.ContinueOnError {
+			return errs[i]
+		}
+		return ctx.Err()
+	}
+
+	if err := c.crawlRepositories(ctx, repos, crawl); err != nil || !c.Configuration.ContinueOnError {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return newPartialError(repos, crawled, errs)
+}
+
+// crawlRepositories calls crawl for the repositories, in parallel if concurrency is enabled, and returns the
+// first error.
+func (c *GitHub) crawlRepositories(ctx context.Context, repos []string, crawl func(ctx context.Context, i int, repo string) error) error {
+	if !c.concurrent() {
+		for i, repo := range repos {
+			if err := crawl(ctx, i, repo); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	group, ctx := errgroup.WithContext(ctx)
+	for i, repo := range repos {
+		i, repo := i, repo
+		group.Go(func() error {
+			return crawl(ctx, i, repo)
+		})
+	}
+	return group.Wait()
+}
+
+// crawlRepository calls fn for the repository within a span, logging when it starts and finishes, and
+// reports whether it was crawled. Repositories not passing GitHubConfig.RepositoryFilter are skipped.
+func (c *GitHub) crawlRepository(ctx context.Context, i int, repo string, fn func(ctx context.Context, i int, repo string) error) (bool, error) {
+	matched, err := c.matchRepository(ctx, repo)
+	if err != nil {
+		return false, c.classifyError(err)
+	}
+	if !matched {
+		c.debug("skipping repository", "repository", repo)
+		return false, nil
+	}
+
+	c.debug("crawling repository", "repository", repo)
+	started := time.Now()
+
+	ctx, end := c.startSpan(ctx, SpanCrawlRepository, c.repositoryAttributes(repo)...)
+	err = c.classifyError(fn(ctx, i, repo))
+	end(err)
+	c.metrics().RepositoryCrawled(repo, time.Since(started), err)
+	if err != nil {
+		c.debug("crawling repository failed", "repository", repo, "duration", time.Since(started), "error", err)
+		return true, err
+	}
+
+	c.debug("crawled repository", "repository", repo, "duration", time.Since(started))
+	return true, nil
+}
+
+// flattenDocuments joins the documents collected per repository by forEachRepository in the configured order.
+func flattenDocuments(repoDocs [][]document.Document) []document.Document {
+	var docs []document.Document
+	for _, d := range repoDocs {
+		docs = append(docs, d...)
+	}
+	return docs
+}
@@ -0,0 +1,122 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// inFlight tracks the maximum number of concurrent calls.
+type inFlight struct {
+	mu      sync.Mutex
+	current int
+	max     int
+}
+
+func (f *inFlight) enter() {
+	f.mu.Lock()
+	f.current++
+	if f.current > f.max {
+		f.max = f.current
+	}
+	f.mu.Unlock()
+	time.Sleep(5 * time.Millisecond)
+}
+
+func (f *inFlight) leave() {
+	f.mu.Lock()
+	f.current--
+	f.mu.Unlock()
+}
+
+func TestGitHub_GetFilePathsFromRepositories_Concurrent(t *testing.T) {
+	var calls inFlight
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		calls.enter()
+		defer calls.leave()
+
+		variables := args.Get(2).(map[string]interface{})
+		repo := string(variables["name"].(githubv4.String))
+		expression := string(variables["expression"].(githubv4.String))
+		query := args.Get(1).(*GHQueryForListFiles)
+		if expression == "main:" {
+			for i := 0; i < 4; i++ {
+				query.Repository.Object.Tree.Entries = append(query.Repository.Object.Tree.Entries, GHTreeEntry{Name: fmt.Sprintf("dir%d", i), Type: "tree"})
+			}
+			query.Repository.Object.Tree.Entries = append(query.Repository.Object.Tree.Entries, GHTreeEntry{Name: "README.md", Path: "README.md", Type: "blob"})
+			return
+		}
+		dir := expression[len("main:/"):]
+		query.Repository.Object.Tree.Entries = []GHTreeEntry{{Name: "a.md", Path: repo + "/" + dir + "/a.md", Type: "blob"}}
+	}).Return(nil)
+
+	config := GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1", "repo2", "repo3"},
+		DefaultBranch: "main",
+	}
+	sequential, err := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, config).GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls.max)
+
+	config.MaxConcurrency = 3
+	concurrent, err := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, config).GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Equal(t, sequential, concurrent)
+	assert.Len(t, concurrent, 15)
+	assert.Equal(t, "repo1/dir0/a.md", concurrent[0])
+	assert.LessOrEqual(t, calls.max, 3)
+	assert.Greater(t, calls.max, 1)
+}
+
+func TestGitHub_GetChangedFilePathsSince_Concurrent(t *testing.T) {
+	var calls inFlight
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*github.RepositoryCommit{
+		{SHA: github.String("a")}, {SHA: github.String("b")},
+	}, nil, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		calls.enter()
+		defer calls.leave()
+	}).Return(func(_ context.Context, _, repo, sha string, _ *github.ListOptions) *github.RepositoryCommit {
+		return &github.RepositoryCommit{Files: []*github.CommitFile{{Filename: github.String(repo + "/" + sha), Status: github.String("added")}}}
+	}, nil, nil)
+
+	client := NewGitHubClient(commitOpsClient, new(GraphQLClientMock), GitHubConfig{
+		Owner:          "testowner",
+		Repositories:   []string{"repo1", "repo2", "repo3", "repo4"},
+		MaxConcurrency: 2,
+	})
+
+	paths, err := client.GetChangedFilePathsSince(time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"repo1/a", "repo1/b", "repo2/a", "repo2/b", "repo3/a", "repo3/b", "repo4/a", "repo4/b"}, paths.Added)
+	assert.LessOrEqual(t, calls.max, 2)
+}
+
+func TestGitHub_GetFilePathsFromRepositories_ConcurrentError(t *testing.T) {
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.MatchedBy(func(variables map[string]interface{}) bool {
+		return variables["name"] == githubv4.String("broken")
+	})).Return(errors.New("boom"))
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	client := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:          "testowner",
+		Repositories:   []string{"repo1", "broken", "repo3"},
+		DefaultBranch:  "main",
+		MaxConcurrency: 4,
+	})
+
+	_, err := client.GetFilePathsFromRepositories()
+	assert.EqualError(t, err, "boom")
+}
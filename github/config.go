@@ -0,0 +1,392 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the configuration of a client, its authentication, the transformers and the sinks of a
+// collection, as read from a YAML or JSON file by LoadConfig.
+//
+// Owner and Repositories select the repositories; DefaultBranch, Branches and DetectDefaultBranch their
+// branches, detected if there is no DefaultBranch. BaseURL, UploadURL and GraphQLEndpoint point at a GitHub
+// Enterprise Server. Concurrency is the GitHubConfig.MaxConcurrency, ContinueOnError the
+// GitHubConfig.ContinueOnError, TreeBatchSize the GitHubConfig.TreeBatchSize, Commits the
+// GitHubConfig.CommitFilter and MergeStrategy the GitHubConfig.MergeStrategy. State is the path of the file
+// keeping the progress of incremental runs, e.g. a FileCheckpointStore. Cache is the directory of a
+// FileHTTPCache making conditional requests, for clients authenticated with a token or as a GitHub App.
+//
+// Front matter is moved into the metadata of the collected documents by the front_matter transformer, see
+// TransformersConfig.
+type Config struct {
+	Owner               string              `yaml:"owner" json:"owner"`
+	Repositories        []string            `yaml:"repositories" json:"repositories"`
+	DefaultBranch       string              `yaml:"default_branch" json:"default_branch"`
+	Branches            map[string]string   `yaml:"branches" json:"branches"`
+	DetectDefaultBranch bool                `yaml:"detect_default_branch" json:"detect_default_branch"`
+	BaseURL             string              `yaml:"base_url" json:"base_url"`
+	UploadURL           string              `yaml:"upload_url" json:"upload_url"`
+	GraphQLEndpoint     string              `yaml:"graphql_endpoint" json:"graphql_endpoint"`
+	Concurrency         int                 `yaml:"concurrency" json:"concurrency"`
+	ContinueOnError     bool                `yaml:"continue_on_error" json:"continue_on_error"`
+	TreeBatchSize       int                 `yaml:"tree_batch_size" json:"tree_batch_size"`
+	Commits             filter.CommitFilter `yaml:"commits" json:"commits"`
+	MergeStrategy       MergeStrategy       `yaml:"merge_strategy" json:"merge_strategy"`
+	Auth                AuthConfig          `yaml:"auth" json:"auth"`
+	Filter              FilterConfig        `yaml:"filter" json:"filter"`
+	Transformers        TransformersConfig  `yaml:"transformers" json:"transformers"`
+	Sinks               []SinkConfig        `yaml:"sinks" json:"sinks"`
+	State               string              `yaml:"state" json:"state"`
+	Cache               string              `yaml:"cache" json:"cache"`
+}
+
+// AuthConfig is the authentication of a client: a token, or the installation of a GitHub App with the
+// path of its private key. Without either, the token is read from the GITHUB_TOKEN environment variable.
+type AuthConfig struct {
+	Token          string `yaml:"token" json:"token"`
+	AppID          int64  `yaml:"app_id" json:"app_id"`
+	InstallationID int64  `yaml:"installation_id" json:"installation_id"`
+	PrivateKeyFile string `yaml:"private_key_file" json:"private_key_file"`
+}
+
+// FilterConfig is the file form of a GitHubFilter, with the regular expressions as strings.
+type FilterConfig struct {
+	FilePath         string              `yaml:"file_path" json:"file_path"`
+	Paths            []filter.PathFilter `yaml:"paths" json:"paths"`
+	FileTypes        []string            `yaml:"file_types" json:"file_types"`
+	Mode             filter.FilterMode   `yaml:"mode" json:"mode"`
+	Include          []string            `yaml:"include" json:"include"`
+	Exclude          []string            `yaml:"exclude" json:"exclude"`
+	IncludeRegexp    string              `yaml:"include_regexp" json:"include_regexp"`
+	ExcludeRegexp    string              `yaml:"exclude_regexp" json:"exclude_regexp"`
+	ExcludeGenerated bool                `yaml:"exclude_generated" json:"exclude_generated"`
+	ExcludeVendored  bool                `yaml:"exclude_vendored" json:"exclude_vendored"`
+	IgnoreFile       string              `yaml:"ignore_file" json:"ignore_file"`
+	MaxFileSize      int                 `yaml:"max_file_size" json:"max_file_size"`
+	SkipBinary       bool                `yaml:"skip_binary" json:"skip_binary"`
+}
+
+// TransformersConfig selects the built-in transformers applied to collected documents, in the order of
+// the fields. FrontMatter moves the front matter of Markdown documents into their metadata with the
+// FrontMatterTransformer. Secrets is the SecretAction of a SecretScanner: "redact", "flag" or "drop".
+type TransformersConfig struct {
+	NormalizeLineEndings bool   `yaml:"normalize_line_endings" json:"normalize_line_endings"`
+	StripHTML            bool   `yaml:"strip_html" json:"strip_html"`
+	FrontMatter          bool   `yaml:"front_matter" json:"front_matter"`
+	Secrets              string `yaml:"secrets" json:"secrets"`
+}
+
+// configSecretActions are the SecretActions by their name in a TransformersConfig.
+var configSecretActions = map[string]document.SecretAction{"redact": document.SecretRedact, "flag": document.SecretFlag, "drop": document.SecretDrop}
+
+// Sink types of a SinkConfig.
+const (
+	SinkTypeJSONL         = "jsonl"
+	SinkTypeS3            = "s3"
+	SinkTypeGCS           = "gcs"
+	SinkTypeElasticsearch = "elasticsearch"
+)
+
+// SinkConfig is the configuration of a sink. Type is one of the SinkType constants.
+//
+// A jsonl sink writes JSONLWriter records to Path, "-" or empty for the standard output. s3 and gcs sinks
+// need a Bucket and use an ObjectStoreSink with Prefix and KeyLayout; Endpoint overrides the endpoint of
+// the service. elasticsearch sinks need an Endpoint and an Index.
+type SinkConfig struct {
+	Type string `yaml:"type" json:"type"`
+
+	Path string `yaml:"path" json:"path"`
+
+	Bucket          string `yaml:"bucket" json:"bucket"`
+	Region          string `yaml:"region" json:"region"`
+	Prefix          string `yaml:"prefix" json:"prefix"`
+	KeyLayout       string `yaml:"key_layout" json:"key_layout"`
+	AccessKeyID     string `yaml:"access_key_id" json:"access_key_id"`
+	SecretAccessKey string `yaml:"secret_access_key" json:"secret_access_key"`
+
+	Endpoint  string `yaml:"endpoint" json:"endpoint"`
+	Index     string `yaml:"index" json:"index"`
+	Username  string `yaml:"username" json:"username"`
+	Password  string `yaml:"password" json:"password"`
+	APIKey    string `yaml:"api_key" json:"api_key"`
+	BatchSize int    `yaml:"batch_size" json:"batch_size"`
+}
+
+// LoadConfig reads the configuration file at path, JSON for files ending in .json and YAML otherwise.
+// Unknown fields are rejected to catch typos. References to environment variables, $VAR or ${VAR}, are
+// expanded in the credentials: the token, the private key file and the secrets of the sinks, so the file
+// can be committed without them. A relative State and Cache are resolved against the directory of the file.
+// The configuration is validated before it is returned, see Config.Validate.
+//
+// Usage:
+//
+//	config, err := LoadConfig("cocogh.yaml")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	client, err := config.NewGitHub()
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	config := new(Config)
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(config)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err = decoder.Decode(config); errors.Is(err, io.EOF) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	if err := config.expandEnv(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	for _, p := range []*string{&config.State, &config.Cache} {
+		if *p != "" && !filepath.IsAbs(*p) {
+			*p = filepath.Join(filepath.Dir(path), *p)
+		}
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// expandEnv expands the environment variables referenced by the credentials, failing for unset ones.
+func (c *Config) expandEnv() error {
+	var errs ConfigErrors
+	expand := func(field string, value *string) {
+		*value = os.Expand(*value, func(name string) string {
+			env, ok := os.LookupEnv(name)
+			if !ok {
+				errs.add(field, "environment variable %s is not set", name)
+			}
+			return env
+		})
+	}
+
+	expand("auth.token", &c.Auth.Token)
+	expand("auth.private_key_file", &c.Auth.PrivateKeyFile)
+	for i := range c.Sinks {
+		sink := &c.Sinks[i]
+		expand(fmt.Sprintf("sinks[%d].access_key_id", i), &sink.AccessKeyID)
+		expand(fmt.Sprintf("sinks[%d].secret_access_key", i), &sink.SecretAccessKey)
+		expand(fmt.Sprintf("sinks[%d].password", i), &sink.Password)
+		expand(fmt.Sprintf("sinks[%d].api_key", i), &sink.APIKey)
+	}
+	return errs.err()
+}
+
+// Validate checks the configuration, see GitHubConfig.Validate, together with its authentication,
+// transformers and sinks. It returns ConfigErrors naming the fields as in the file, or nil.
+func (c *Config) Validate() error {
+	var errs ConfigErrors
+	var clientErrs ConfigErrors
+	if errors.As(c.GitHubConfig().Validate(), &clientErrs) {
+		for _, err := range clientErrs {
+			errs.add(configFieldName(err.Field), "%s", err.Reason)
+		}
+	}
+
+	if _, err := regexp.Compile(c.Filter.IncludeRegexp); err != nil {
+		errs.add("filter.include_regexp", "%v", err)
+	}
+	if _, err := regexp.Compile(c.Filter.ExcludeRegexp); err != nil {
+		errs.add("filter.exclude_regexp", "%v", err)
+	}
+
+	app := c.Auth.AppID != 0 || c.Auth.InstallationID != 0 || c.Auth.PrivateKeyFile != ""
+	switch {
+	case app && c.Auth.Token != "":
+		errs.add("auth", "token and app_id are mutually exclusive")
+	case app && (c.Auth.AppID == 0 || c.Auth.InstallationID == 0 || c.Auth.PrivateKeyFile == ""):
+		errs.add("auth", "app_id, installation_id and private_key_file are required together")
+	}
+
+	if _, ok := configSecretActions[c.Transformers.Secrets]; c.Transformers.Secrets != "" && !ok {
+		errs.add("transformers.secrets", "unknown action %q, want redact, flag or drop", c.Transformers.Secrets)
+	}
+
+	for i, sink := range c.Sinks {
+		field := fmt.Sprintf("sinks[%d]", i)
+		switch sink.Type {
+		case SinkTypeJSONL:
+		case SinkTypeS3, SinkTypeGCS:
+			if sink.Bucket == "" {
+				errs.add(field, "bucket is required for %s sinks", sink.Type)
+			}
+		case SinkTypeElasticsearch:
+			if sink.Endpoint == "" || sink.Index == "" {
+				errs.add(field, "endpoint and index are required for elasticsearch sinks")
+			}
+		default:
+			errs.add(field, "unknown type %q, want jsonl, s3, gcs or elasticsearch", sink.Type)
+		}
+	}
+	return errs.err()
+}
+
+// configFieldNames are the names of the fields of a Config by the names of the GitHubConfig fields they set.
+var configFieldNames = map[string]string{
+	"Owner":           "owner",
+	"Repositories":    "repositories",
+	"DefaultBranch":   "default_branch",
+	"Branches":        "branches",
+	"MaxConcurrency":  "concurrency",
+	"BaseURL":         "base_url",
+	"UploadURL":       "upload_url",
+	"GraphQLEndpoint": "graphql_endpoint",
+	"Filter":          "filter",
+	"FilePath":        "file_path",
+	"FileTypes":       "file_types",
+	"Mode":            "mode",
+	"Include":         "include",
+	"Exclude":         "exclude",
+	"IncludeRegexp":   "include_regexp",
+	"ExcludeRegexp":   "exclude_regexp",
+	"IgnoreFile":      "ignore_file",
+	"MaxFileSize":     "max_file_size",
+}
+
+// configFieldName converts the path of a GitHubConfig field, e.g. "Filter.Include[1]", into the path of
+// the Config field setting it, "filter.include[1]".
+func configFieldName(field string) string {
+	var b strings.Builder
+	for field != "" {
+		switch field[0] {
+		case '.':
+			b.WriteByte('.')
+			field = field[1:]
+		case '[':
+			// Indexes and map keys, which may contain dots, are kept as they are.
+			end := strings.IndexByte(field, ']') + 1
+			if end == 0 {
+				end = len(field)
+			}
+			b.WriteString(field[:end])
+			field = field[end:]
+		default:
+			end := strings.IndexAny(field, ".[")
+			if end < 0 {
+				end = len(field)
+			}
+			name := field[:end]
+			if mapped, ok := configFieldNames[name]; ok {
+				name = mapped
+			}
+			b.WriteString(name)
+			field = field[end:]
+		}
+	}
+	return b.String()
+}
+
+// GitHubConfig returns the configuration of the client.
+func (c *Config) GitHubConfig() GitHubConfig {
+	fileFilter := filter.GitHubFilter{
+		FilePath:         c.Filter.FilePath,
+		Paths:            c.Filter.Paths,
+		FileTypes:        c.Filter.FileTypes,
+		Mode:             c.Filter.Mode,
+		Include:          c.Filter.Include,
+		Exclude:          c.Filter.Exclude,
+		ExcludeGenerated: c.Filter.ExcludeGenerated,
+		ExcludeVendored:  c.Filter.ExcludeVendored,
+		IgnoreFile:       c.Filter.IgnoreFile,
+		MaxFileSize:      c.Filter.MaxFileSize,
+		SkipBinary:       c.Filter.SkipBinary,
+	}
+	// The expressions are checked by Validate; invalid ones are left out.
+	if c.Filter.IncludeRegexp != "" {
+		fileFilter.IncludeRegexp, _ = regexp.Compile(c.Filter.IncludeRegexp)
+	}
+	if c.Filter.ExcludeRegexp != "" {
+		fileFilter.ExcludeRegexp, _ = regexp.Compile(c.Filter.ExcludeRegexp)
+	}
+
+	return GitHubConfig{
+		Owner:               c.Owner,
+		Repositories:        c.Repositories,
+		DefaultBranch:       c.DefaultBranch,
+		Branches:            c.Branches,
+		DetectDefaultBranch: c.DetectDefaultBranch || c.DefaultBranch == "",
+		Filter:              fileFilter,
+		MaxConcurrency:      c.Concurrency,
+		BaseURL:             c.BaseURL,
+		UploadURL:           c.UploadURL,
+		GraphQLEndpoint:     c.GraphQLEndpoint,
+		ContinueOnError:     c.ContinueOnError,
+		TreeBatchSize:       c.TreeBatchSize,
+		CommitFilter:        c.Commits,
+		MergeStrategy:       c.MergeStrategy,
+	}
+}
+
+// NewGitHub creates a client for the configuration, authenticated as configured in Auth. The options
+// are applied on top of the configuration.
+func (c *Config) NewGitHub(opts ...Option) (*GitHub, error) {
+	options := []Option{WithConfig(c.GitHubConfig())}
+	if c.Cache != "" {
+		options = append(options, WithHTTPCache(NewFileHTTPCache(c.Cache)))
+	}
+	options = append(options, opts...)
+
+	if c.Auth.PrivateKeyFile != "" {
+		privateKey, err := os.ReadFile(c.Auth.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read private key: %w", err)
+		}
+		o := applyOptions(options)
+		var base http.RoundTripper
+		if o.httpCache != nil {
+			base = NewCacheTransport(nil, o.httpCache)
+		}
+		return newGitHubFromApp(c.Auth.AppID, c.Auth.InstallationID, privateKey, base, o.config)
+	}
+
+	token := c.Auth.Token
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	return NewGitHub(token, options...)
+}
+
+// Build returns the selected transformers.
+func (c TransformersConfig) Build() []document.Transformer {
+	var transformers []document.Transformer
+	if c.NormalizeLineEndings {
+		transformers = append(transformers, document.NormalizeLineEndings())
+	}
+	if c.StripHTML {
+		transformers = append(transformers, document.StripHTML())
+	}
+	if c.FrontMatter {
+		transformers = append(transformers, document.FrontMatterTransformer())
+	}
+	if c.Secrets != "" {
+		transformers = append(transformers, document.SecretScanner{Action: configSecretActions[c.Secrets]})
+	}
+	return transformers
+}
@@ -0,0 +1,211 @@
+package github
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("TEST_GITHUB_TOKEN", "secret-token")
+	t.Setenv("TEST_ES_PASSWORD", "es-password")
+
+	path := writeConfig(t, "cocogh.yaml", `owner: testowner
+repositories: [repo1, repo2]
+default_branch: develop
+base_url: https://github.example.com/
+concurrency: 4
+continue_on_error: true
+tree_batch_size: 25
+merge_strategy: first-parent
+commits:
+  exclude_authors: [renovate]
+  exclude_bots: true
+  exclude_types: [chore]
+auth:
+  token: ${TEST_GITHUB_TOKEN}
+filter:
+  file_types: [md]
+  paths:
+    - path: docs
+    - path: guides
+      file_types: [.md, .mdx]
+  include: ["docs/**"]
+  exclude_regexp: "^vendor/"
+  max_file_size: 1024
+  mode: documentation-only
+transformers:
+  normalize_line_endings: true
+  secrets: flag
+sinks:
+  - type: elasticsearch
+    endpoint: http://localhost:9200
+    index: docs
+    username: elastic
+    password: $TEST_ES_PASSWORD
+state: state.json
+cache: .cache
+`)
+
+	config, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-token", config.Auth.Token)
+	assert.Equal(t, "es-password", config.Sinks[0].Password)
+	assert.Equal(t, filepath.Join(filepath.Dir(path), "state.json"), config.State)
+	assert.Equal(t, filepath.Join(filepath.Dir(path), ".cache"), config.Cache)
+
+	gitHubConfig := config.GitHubConfig()
+	assert.Equal(t, "testowner", gitHubConfig.Owner)
+	assert.Equal(t, []string{"repo1", "repo2"}, gitHubConfig.Repositories)
+	assert.Equal(t, "develop", gitHubConfig.DefaultBranch)
+	assert.False(t, gitHubConfig.DetectDefaultBranch)
+	assert.Equal(t, 4, gitHubConfig.MaxConcurrency)
+	assert.True(t, gitHubConfig.ContinueOnError)
+	assert.Equal(t, 25, gitHubConfig.TreeBatchSize)
+	assert.Equal(t, MergeStrategyFirstParent, gitHubConfig.MergeStrategy)
+	assert.Equal(t, filter.CommitFilter{ExcludeAuthors: []string{"renovate"}, ExcludeBots: true, ExcludeTypes: []string{"chore"}}, gitHubConfig.CommitFilter)
+	assert.Equal(t, filter.FilterModeDocumentationOnly, gitHubConfig.Filter.Mode)
+	assert.Equal(t, []string{"docs/**"}, gitHubConfig.Filter.Include)
+	assert.Equal(t, []filter.PathFilter{{Path: "docs"}, {Path: "guides", FileTypes: []string{".md", ".mdx"}}}, gitHubConfig.Filter.Paths)
+	assert.Equal(t, 1024, gitHubConfig.Filter.MaxFileSize)
+	assert.True(t, gitHubConfig.Filter.ExcludeRegexp.MatchString("vendor/a.go"))
+	assert.Nil(t, gitHubConfig.Filter.IncludeRegexp)
+
+	transformers := config.Transformers.Build()
+	require.Len(t, transformers, 2)
+	assert.Equal(t, document.SecretScanner{Action: document.SecretFlag}, transformers[1])
+
+	gh, err := config.NewGitHub()
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.example.com/", gh.Configuration.BaseURL)
+}
+
+func TestLoadConfig_JSON(t *testing.T) {
+	path := writeConfig(t, "cocogh.json", `{"owner": "testowner", "repositories": ["repo1"], "sinks": [{"type": "jsonl", "path": "-"}]}`)
+
+	config, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, []SinkConfig{{Type: SinkTypeJSONL, Path: "-"}}, config.Sinks)
+	assert.True(t, config.GitHubConfig().DetectDefaultBranch)
+
+	_, err = LoadConfig(writeConfig(t, "cocogh.json", `{"owner": "testowner", "repos": ["repo1"]}`))
+	assert.ErrorContains(t, err, `unknown field "repos"`)
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	_, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read config")
+
+	path := writeConfig(t, "cocogh.yaml", "owner: testowner\nrepository: [repo1]\n")
+	_, err = LoadConfig(path)
+	assert.ErrorContains(t, err, "failed to parse config "+path)
+	assert.ErrorContains(t, err, "line 2: field repository not found")
+
+	path = writeConfig(t, "cocogh.yaml", "owner: testowner\nrepositories: [repo1]\nauth:\n  token: ${COCOGH_UNSET_TOKEN}\n")
+	_, err = LoadConfig(path)
+	assert.EqualError(t, err, "invalid config "+path+": auth.token: environment variable COCOGH_UNSET_TOKEN is not set")
+
+	path = writeConfig(t, "cocogh.yaml", `repositories: [testowner/repo1]
+concurrency: -1
+auth:
+  token: abc
+  app_id: 1
+filter:
+  mode: code-only
+  include_regexp: "("
+transformers:
+  secrets: hide
+sinks:
+  - type: s3
+  - type: elasticsearch
+    index: docs
+  - type: kafka
+`)
+	_, err = LoadConfig(path)
+	assert.EqualError(t, err, "invalid config "+path+`: owner: is required
+repositories[0]: "testowner/repo1" is not a repository name
+filter.mode: unknown mode "code-only", want "documentation-only"
+concurrency: must not be negative
+filter.include_regexp: error parsing regexp: missing closing ): `+"`(`"+`
+auth: token and app_id are mutually exclusive
+transformers.secrets: unknown action "hide", want redact, flag or drop
+sinks[0]: bucket is required for s3 sinks
+sinks[1]: endpoint and index are required for elasticsearch sinks
+sinks[2]: unknown type "kafka", want jsonl, s3, gcs or elasticsearch`)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	var configErr *ConfigError
+	require.ErrorAs(t, err, &configErr)
+	assert.Equal(t, &ConfigError{Field: "owner", Reason: "is required"}, configErr)
+}
+
+func TestConfig_Validate_App(t *testing.T) {
+	config := &Config{Owner: "testowner", Repositories: []string{"repo1"}, Auth: AuthConfig{AppID: 1, PrivateKeyFile: "key.pem"}}
+	assert.EqualError(t, config.Validate(), "auth: app_id, installation_id and private_key_file are required together")
+
+	config.Auth.InstallationID = 2
+	require.NoError(t, config.Validate())
+
+	_, err := config.NewGitHub()
+	assert.ErrorContains(t, err, "failed to read private key")
+}
+
+func TestConfig_NewGitHub_AppCache(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "app.pem")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0o600))
+
+	clock := &testClock{now: time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)}
+	app := &appServer{t: t, key: &key.PublicKey, clock: clock, revoked: make(map[string]bool)}
+	var revalidated int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/commits") {
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				revalidated++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+		}
+		app.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	config := &Config{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		BaseURL:       srv.URL,
+		Auth:          AuthConfig{AppID: 12345, InstallationID: 67890, PrivateKeyFile: keyFile},
+		Cache:         t.TempDir(),
+	}
+	client, err := config.NewGitHub(WithClock(clock))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetChangedFilePathsSince(clock.Now().Add(-time.Hour))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, revalidated)
+	assert.Equal(t, 1, app.issued)
+}
@@ -0,0 +1,148 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shurcooL/githubv4"
+)
+
+// GetFileContents retrieves the files passing the configured filter in all configured repositories together
+// with their content, read from the branch or ref configured for each repository. Paths are normalized like
+// the paths returned by GetFilePathsFromRepositories.
+//
+// Usage:
+//
+//	files, err := c.GetFileContents(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, file := range files {
+//	    if !file.IsBinary {
+//	        index(file.Path, file.Text)
+//	    }
+//	}
+func (c *GitHub) GetFileContents(ctx context.Context) ([]document.FileContent, error) {
+	ctx = c.prefetchFileEntries(ctx)
+	repoFiles := make([][]document.FileContent, len(c.repositories()))
+	err := c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		entries, err := c.filterFileEntries(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		var files []document.FileContent
+		for _, entry := range entries {
+			file, err := c.getFileContent(ctx, repo, entry.Path)
+			if err != nil {
+				return err
+			}
+			file.Oid = entry.Oid
+			if document.FileMode(entry.Mode).IsSymlink() {
+				file, err = c.resolveSymlink(ctx, repo, entry.Path, file)
+				if err != nil {
+					return err
+				}
+			}
+			files = append(files, file)
+		}
+		repoFiles[i] = files
+		return nil
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	var files []document.FileContent
+	for _, contents := range repoFiles {
+		files = append(files, contents...)
+	}
+
+	return files, err
+}
+
+// GetFileContent retrieves a single file of a repository with its content, read from the default branch.
+// The configured filter is not applied.
+func (c *GitHub) GetFileContent(ctx context.Context, repo, filePath string) (document.FileContent, error) {
+	return c.getFileContent(ctx, repo, filePath)
+}
+
+// getFileContent reads the blob at filePath on the default branch.
+func (c *GitHub) getFileContent(ctx context.Context, repo, filePath string) (document.FileContent, error) {
+	branch, err := c.branch(ctx, repo)
+	if err != nil {
+		return document.FileContent{}, err
+	}
+
+	expression := fmt.Sprintf("%s:%s", branch, filePath)
+	blob, err := c.getBlob(ctx, c.ownerOf(repo), c.nameOf(repo), expression)
+	if err != nil {
+		return document.FileContent{}, fmt.Errorf("failed to read %s/%s: %w", repo, filePath, err)
+	}
+
+	return document.FileContent{
+		Repository: repo,
+		Path:       c.normalizePath(filePath),
+		Text:       blob.Text,
+		IsBinary:   blob.IsBinary,
+		ByteSize:   blob.ByteSize,
+	}, nil
+}
+
+// resolveSymlink sets the SymlinkTarget of the symlink at linkPath read into file and, if
+// GitHubConfig.ResolveSymlinks is set, replaces its content with the content of the target. Targets which
+// are symlinks themselves are not followed further.
+func (c *GitHub) resolveSymlink(ctx context.Context, repo, linkPath string, file document.FileContent) (document.FileContent, error) {
+	file.SymlinkTarget = symlinkTarget(linkPath, file.Text)
+	if !c.Configuration.ResolveSymlinks || file.SymlinkTarget == "" {
+		return file, nil
+	}
+
+	target, err := c.getFileContent(ctx, repo, file.SymlinkTarget)
+	if err != nil {
+		return document.FileContent{}, err
+	}
+	file.Text, file.IsBinary, file.ByteSize = target.Text, target.IsBinary, target.ByteSize
+	return file, nil
+}
+
+// symlinkTarget resolves the target of the symlink at linkPath to a path relative to the root of the
+// repository, empty if it is absolute or leaves the repository.
+func symlinkTarget(linkPath, target string) string {
+	target = strings.TrimSpace(target)
+	if target == "" || path.IsAbs(target) {
+		return ""
+	}
+	resolved := path.Join(path.Dir(linkPath), target)
+	if resolved == ".." || strings.HasPrefix(resolved, "../") {
+		return ""
+	}
+	return resolved
+}
+
+// getBlob reads the blob identified by expression. A missing file yields an empty blob.
+func (c *GitHub) getBlob(ctx context.Context, owner, name, expression string) (GHBlob, error) {
+	var query GHQueryForBlobText
+	variables := map[string]interface{}{
+		"owner":      githubv4.String(owner),
+		"name":       githubv4.String(name),
+		"expression": githubv4.String(expression),
+	}
+
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return GHBlob{}, err
+	}
+	defer release()
+
+	err = c.query(ctx, &query, variables)
+	if err != nil {
+		return GHBlob{}, err
+	}
+
+	return query.Repository.Object.Blob, nil
+}
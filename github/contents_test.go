@@ -0,0 +1,131 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGitHub_GetFileContents(t *testing.T) {
+	decomposed := "docs/cafe\u0301.md"
+	trees := map[string][]GHTreeEntry{
+		"main:docs": {
+			{Name: "cafe\u0301.md", Path: decomposed, Type: "blob", Oid: "oid1"},
+			{Name: "logo.png", Path: "docs/logo.png", Type: "blob", Oid: "oid2"},
+			{Name: "notes.txt", Path: "docs/notes.txt", Type: "blob", Oid: "oid3"},
+		},
+	}
+	blobs := map[string]GHBlob{
+		"main:" + decomposed: {Text: "# Café", ByteSize: 7},
+		"main:docs/logo.png": {IsBinary: true, ByteSize: 2048},
+	}
+
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		expression := string(args.Get(2).(map[string]interface{})["expression"].(githubv4.String))
+		switch query := args.Get(1).(type) {
+		case *GHQueryForListFiles:
+			query.Repository.Object.Tree.Entries = trees[expression]
+		case *GHQueryForBlobText:
+			query.Repository.Object.Blob = blobs[expression]
+		}
+	}).Return(nil)
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:             "testowner",
+		Repositories:      []string{"repo1"},
+		DefaultBranch:     "main",
+		Filter:            filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md", ".png"}},
+		PathNormalization: document.NormalizeNFC,
+	})
+
+	files, err := gh.GetFileContents(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []document.FileContent{
+		{Repository: "repo1", Path: "docs/café.md", Oid: "oid1", Text: "# Café", ByteSize: 7},
+		{Repository: "repo1", Path: "docs/logo.png", Oid: "oid2", IsBinary: true, ByteSize: 2048},
+	}, files)
+}
+
+func TestGitHub_GetFileContents_Symlinks(t *testing.T) {
+	trees := map[string][]GHTreeEntry{
+		"main:docs": {
+			{Name: "guide.md", Path: "docs/guide.md", Type: "blob", Mode: int(document.FileModeSymlink), Oid: "oid1"},
+			{Name: "index.md", Path: "docs/index.md", Type: "blob", Mode: int(document.FileModeRegular), Oid: "oid2"},
+			{Name: "outside.md", Path: "docs/outside.md", Type: "blob", Mode: int(document.FileModeSymlink), Oid: "oid3"},
+		},
+	}
+	blobs := map[string]GHBlob{
+		"main:docs/guide.md":     {Text: "../handbook/guide.md", ByteSize: 20},
+		"main:docs/index.md":     {Text: "# Index", ByteSize: 7},
+		"main:docs/outside.md":   {Text: "../../outside.md", ByteSize: 16},
+		"main:handbook/guide.md": {Text: "# Guide", ByteSize: 7},
+	}
+
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		expression := string(args.Get(2).(map[string]interface{})["expression"].(githubv4.String))
+		switch query := args.Get(1).(type) {
+		case *GHQueryForListFiles:
+			query.Repository.Object.Tree.Entries = trees[expression]
+		case *GHQueryForBlobText:
+			query.Repository.Object.Blob = blobs[expression]
+		}
+	}).Return(nil)
+
+	config := GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs"},
+	}
+
+	// Symlinks are flagged with their target and keep the target path as their text.
+	files, err := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, config).GetFileContents(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []document.FileContent{
+		{Repository: "repo1", Path: "docs/guide.md", Oid: "oid1", Text: "../handbook/guide.md", ByteSize: 20, SymlinkTarget: "handbook/guide.md"},
+		{Repository: "repo1", Path: "docs/index.md", Oid: "oid2", Text: "# Index", ByteSize: 7},
+		{Repository: "repo1", Path: "docs/outside.md", Oid: "oid3", Text: "../../outside.md", ByteSize: 16},
+	}, files)
+
+	// With ResolveSymlinks the content of targets inside the repository is read instead.
+	config.ResolveSymlinks = true
+	files, err = NewGitHubClient(new(CommitOpsClientMock), graphQLClient, config).GetFileContents(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, document.FileContent{Repository: "repo1", Path: "docs/guide.md", Oid: "oid1", Text: "# Guide", ByteSize: 7, SymlinkTarget: "handbook/guide.md"}, files[0])
+	assert.Equal(t, "../../outside.md", files[2].Text)
+}
+
+func TestGitHub_GetFileContent(t *testing.T) {
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForBlobText"), map[string]interface{}{
+		"owner":      githubv4.String("testowner"),
+		"name":       githubv4.String("repo1"),
+		"expression": githubv4.String("main:README.md"),
+	}).Run(func(args mock.Arguments) {
+		args.Get(1).(*GHQueryForBlobText).Repository.Object.Blob = GHBlob{Text: "# repo1", ByteSize: 7}
+	}).Return(nil)
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{Owner: "testowner", DefaultBranch: "main"})
+
+	file, err := gh.GetFileContent(context.Background(), "repo1", "README.md")
+	assert.NoError(t, err)
+	assert.Equal(t, document.FileContent{Repository: "repo1", Path: "README.md", Text: "# repo1", ByteSize: 7}, file)
+}
+
+func TestGitHub_GetFileContent_Error(t *testing.T) {
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("boom"))
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{Owner: "testowner", DefaultBranch: "main"})
+
+	_, err := gh.GetFileContent(context.Background(), "repo1", "README.md")
+	assert.EqualError(t, err, "failed to read repo1/README.md: boom")
+}
@@ -0,0 +1,118 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+)
+
+// DiscoveryOpsClient is an interface to help test the GitHub repository discovery operations.
+// DiscoverRepositories lists the repositories of an organization through it.
+type DiscoveryOpsClient interface {
+	ListOrganizationRepositories(ctx context.Context, org string, opts *github.RepositoryListByOrgOptions) ([]*github.Repository, *github.Response, error)
+}
+
+// DiscoveryOptions selects the repositories of an organization that DiscoverRepositories returns.
+//
+// Organization is the organization to enumerate. Topics only selects repositories tagged with at least one
+// of the given topics, Languages repositories whose primary language is one of the given languages,
+// compared case-insensitively. Visibility is "public", "private" or "internal", empty selects all.
+// NameRegexp only selects repositories whose name matches. Archived repositories are skipped unless
+// IncludeArchived is set.
+type DiscoveryOptions struct {
+	Organization    string
+	Topics          []string
+	Languages       []string
+	Visibility      string
+	NameRegexp      *regexp.Regexp
+	IncludeArchived bool
+}
+
+// ListOrganizationRepositories lists the repositories of a specific organization.
+func (gClient *GitHubCommitsOpsClient) ListOrganizationRepositories(ctx context.Context, org string, opts *github.RepositoryListByOrgOptions) ([]*github.Repository, *github.Response, error) {
+	return gClient.GitHubClient.Repositories.ListByOrg(ctx, org, opts)
+}
+
+// DiscoverRepositories enumerates the repositories of an organization matching the options, ordered by
+// name, instead of listing them in the configuration. GitHubConfig.RepositoryFilter applies on top of the
+// options. The result can be added to RepositoryRefs to crawl the repositories, which are read from the
+// branch GitHubConfig selects for them.
+//
+// Usage:
+//
+//	refs, err := c.DiscoverRepositories(ctx, DiscoveryOptions{
+//	    Organization: "shaharia-lab",
+//	    Topics:       []string{"documentation"},
+//	    NameRegexp:   regexp.MustCompile(`^coco-`),
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	c.Configuration.RepositoryRefs = append(c.Configuration.RepositoryRefs, refs...)
+//	files, err := c.GetFiles(ctx)
+func (c *GitHub) DiscoverRepositories(ctx context.Context, opts DiscoveryOptions) ([]RepositoryRef, error) {
+	client, err := c.discoveryOpsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	listOpts := &github.RepositoryListByOrgOptions{
+		Sort:        "full_name",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	if opts.Visibility == "public" || opts.Visibility == "private" {
+		listOpts.Type = opts.Visibility
+	}
+
+	var refs []RepositoryRef
+	for {
+		releaseSlot, err := c.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		page, resp, err := client.ListOrganizationRepositories(ctx, opts.Organization, listOpts)
+		releaseSlot()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the repositories of %s: %w", opts.Organization, c.classifyError(err))
+		}
+		for _, repository := range page {
+			if opts.match(repository) && c.Configuration.RepositoryFilter.Match(repository.Topics, repository.GetLanguage(), repository.GetArchived(), repository.GetFork()) {
+				refs = append(refs, RepositoryRef{Owner: repository.GetOwner().GetLogin(), Name: repository.GetName()})
+			}
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			return refs, nil
+		}
+		listOpts.Page = resp.NextPage
+	}
+}
+
+// match reports whether a repository passes the options.
+func (o DiscoveryOptions) match(repository *github.Repository) bool {
+	if repository.GetArchived() && !o.IncludeArchived {
+		return false
+	}
+	if o.Visibility != "" && !strings.EqualFold(repository.GetVisibility(), o.Visibility) {
+		return false
+	}
+	if o.NameRegexp != nil && !o.NameRegexp.MatchString(repository.GetName()) {
+		return false
+	}
+	repositoryFilter := filter.RepositoryFilter{Topics: o.Topics, Languages: o.Languages}
+	return repositoryFilter.Match(repository.Topics, repository.GetLanguage(), repository.GetArchived(), repository.GetFork())
+}
+
+// discoveryOpsClient returns the commit ops client as a DiscoveryOpsClient.
+func (c *GitHub) discoveryOpsClient() (DiscoveryOpsClient, error) {
+	client, ok := c.commitOpsClient.(DiscoveryOpsClient)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not implement DiscoveryOpsClient", ErrUnsupportedClient, c.commitOpsClient)
+	}
+	return client, nil
+}
@@ -0,0 +1,105 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// DiscoveryOpsClientMock is a mock type for a CommitOpsClient that also implements DiscoveryOpsClient
+type DiscoveryOpsClientMock struct {
+	CommitOpsClientMock
+}
+
+// ListOrganizationRepositories provides a mock function with given fields: ctx, org, opts
+func (_m *DiscoveryOpsClientMock) ListOrganizationRepositories(ctx context.Context, org string, opts *github.RepositoryListByOrgOptions) ([]*github.Repository, *github.Response, error) {
+	ret := _m.Called(ctx, org, opts)
+	repositories, _ := ret.Get(0).([]*github.Repository)
+	resp, _ := ret.Get(1).(*github.Response)
+	return repositories, resp, ret.Error(2)
+}
+
+func TestGitHub_DiscoverRepositories(t *testing.T) {
+	owner := &github.User{Login: github.String("testorg")}
+	repository := func(name, language, visibility string, archived bool, topics ...string) *github.Repository {
+		return &github.Repository{
+			Name:       github.String(name),
+			Owner:      owner,
+			Language:   github.String(language),
+			Visibility: github.String(visibility),
+			Archived:   github.Bool(archived),
+			Topics:     topics,
+		}
+	}
+
+	client := new(DiscoveryOpsClientMock)
+	client.On("ListOrganizationRepositories", mock.Anything, "testorg", mock.MatchedBy(func(opts *github.RepositoryListByOrgOptions) bool {
+		return opts.Page == 0 && opts.Type == ""
+	})).Return([]*github.Repository{
+		repository("coco-docs", "Go", "public", false, "docs", "Documentation"),
+		repository("coco-legacy", "Go", "public", true, "documentation"),
+		repository("coco-web", "TypeScript", "internal", false, "documentation"),
+	}, &github.Response{NextPage: 2}, nil).Once()
+	client.On("ListOrganizationRepositories", mock.Anything, "testorg", mock.MatchedBy(func(opts *github.RepositoryListByOrgOptions) bool {
+		return opts.Page == 2
+	})).Return([]*github.Repository{
+		repository("coco-api", "go", "private", false, "documentation"),
+		repository("coco-cli", "Go", "public", false, "cli"),
+		repository("website", "Go", "public", false, "documentation"),
+	}, nil, nil).Once()
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{})
+
+	refs, err := gh.DiscoverRepositories(context.Background(), DiscoveryOptions{
+		Organization: "testorg",
+		Topics:       []string{"documentation"},
+		Languages:    []string{"Go"},
+		NameRegexp:   regexp.MustCompile(`^coco-`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []RepositoryRef{
+		{Owner: "testorg", Name: "coco-docs"},
+		{Owner: "testorg", Name: "coco-api"},
+	}, refs)
+	client.AssertExpectations(t)
+}
+
+func TestGitHub_DiscoverRepositories_Visibility(t *testing.T) {
+	client := new(DiscoveryOpsClientMock)
+	client.On("ListOrganizationRepositories", mock.Anything, "testorg", mock.MatchedBy(func(opts *github.RepositoryListByOrgOptions) bool {
+		return opts.Type == "private"
+	})).Return([]*github.Repository{
+		{Name: github.String("secret"), Owner: &github.User{Login: github.String("testorg")}, Visibility: github.String("private")},
+		{Name: github.String("archived"), Owner: &github.User{Login: github.String("testorg")}, Visibility: github.String("private"), Archived: github.Bool(true)},
+	}, nil, nil).Once()
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{})
+
+	refs, err := gh.DiscoverRepositories(context.Background(), DiscoveryOptions{Organization: "testorg", Visibility: "private", IncludeArchived: true})
+	require.NoError(t, err)
+	assert.Equal(t, []RepositoryRef{{Owner: "testorg", Name: "secret"}, {Owner: "testorg", Name: "archived"}}, refs)
+
+	// The configured repository filter applies on top of the options.
+	client.On("ListOrganizationRepositories", mock.Anything, "testorg", mock.Anything).Return([]*github.Repository{
+		{Name: github.String("fork"), Owner: &github.User{Login: github.String("testorg")}, Fork: github.Bool(true)},
+		{Name: github.String("source"), Owner: &github.User{Login: github.String("testorg")}},
+	}, nil, nil).Once()
+	filtered := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{RepositoryFilter: filter.RepositoryFilter{ExcludeForks: true}})
+	refs, err = filtered.DiscoverRepositories(context.Background(), DiscoveryOptions{Organization: "testorg"})
+	require.NoError(t, err)
+	assert.Equal(t, []RepositoryRef{{Owner: "testorg", Name: "source"}}, refs)
+
+	client.On("ListOrganizationRepositories", mock.Anything, "missing", mock.Anything).Return(nil, nil, errors.New("not found")).Once()
+	_, err = gh.DiscoverRepositories(context.Background(), DiscoveryOptions{Organization: "missing"})
+	assert.EqualError(t, err, "failed to list the repositories of missing: not found")
+
+	_, err = NewGitHubClient(new(CommitOpsClientMock), new(GraphQLClientMock), GitHubConfig{}).DiscoverRepositories(context.Background(), DiscoveryOptions{})
+	assert.ErrorIs(t, err, ErrUnsupportedClient)
+}
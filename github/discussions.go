@@ -0,0 +1,405 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shurcooL/githubv4"
+)
+
+// GHQueryForListDiscussions is a struct representing the GraphQL query for listing the discussions of a GitHub
+// repository, most recently updated first, together with the first page of their comments and replies. The
+// following pages are listed with GHQueryForListDiscussionComments and GHQueryForListDiscussionReplies.
+type GHQueryForListDiscussions struct {
+	Repository struct {
+		Discussions struct {
+			Nodes    []GHDiscussion
+			PageInfo struct {
+				HasNextPage bool
+				EndCursor   githubv4.String
+			}
+		} `graphql:"discussions(first: 50, after: $cursor, orderBy: {field: UPDATED_AT, direction: DESC})"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// GHDiscussion is a single discussion as returned by the GitHub GraphQL API.
+type GHDiscussion struct {
+	ID        string
+	Number    int
+	Title     string
+	Body      string
+	URL       string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Author    struct {
+		Login string
+	}
+	Category struct {
+		Name string
+	}
+	Labels struct {
+		Nodes []struct {
+			Name string
+		}
+	} `graphql:"labels(first: 20)"`
+	Comments GHDiscussionComments `graphql:"comments(first: 100)"`
+}
+
+// GHDiscussionComments is a page of the comments of a discussion as returned by the GitHub GraphQL API.
+type GHDiscussionComments struct {
+	Nodes    []GHDiscussionComment
+	PageInfo struct {
+		HasNextPage bool
+		EndCursor   githubv4.String
+	}
+}
+
+// GHDiscussionComment is a comment of a discussion as returned by the GitHub GraphQL API.
+type GHDiscussionComment struct {
+	GHDiscussionReply
+	Replies GHDiscussionReplies `graphql:"replies(first: 50)"`
+}
+
+// GHDiscussionReplies is a page of the replies to a discussion comment as returned by the GitHub GraphQL API.
+type GHDiscussionReplies struct {
+	Nodes    []GHDiscussionReply
+	PageInfo struct {
+		HasNextPage bool
+		EndCursor   githubv4.String
+	}
+}
+
+// GHQueryForListDiscussionComments is a struct representing the GraphQL query for listing the comments of a
+// discussion after the given cursor.
+type GHQueryForListDiscussionComments struct {
+	Node struct {
+		Discussion struct {
+			Comments GHDiscussionComments `graphql:"comments(first: 100, after: $cursor)"`
+		} `graphql:"... on Discussion"`
+	} `graphql:"node(id: $id)"`
+}
+
+// GHQueryForListDiscussionReplies is a struct representing the GraphQL query for listing the replies to a
+// discussion comment after the given cursor.
+type GHQueryForListDiscussionReplies struct {
+	Node struct {
+		DiscussionComment struct {
+			Replies GHDiscussionReplies `graphql:"replies(first: 100, after: $cursor)"`
+		} `graphql:"... on DiscussionComment"`
+	} `graphql:"node(id: $id)"`
+}
+
+// GHDiscussionReply is a reply to a discussion comment as returned by the GitHub GraphQL API.
+type GHDiscussionReply struct {
+	ID        string
+	Body      string
+	URL       string
+	IsAnswer  bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Author    struct {
+		Login string
+	}
+}
+
+// DiscussionFilter narrows down the discussions that are collected.
+//
+// Categories only selects discussions in one of the given categories, by name. Labels only selects
+// discussions carrying all the given labels and ExcludeLabels skips discussions carrying any of the given
+// labels. Since skips discussions that were last updated before the given time.
+type DiscussionFilter struct {
+	Categories    []string
+	Labels        []string
+	ExcludeLabels []string
+	Since         time.Time
+}
+
+// Discussion is a discussion together with its comments and their replies, which follow the comment they
+// reply to.
+//
+// Repository is the configured repository the discussion belongs to. Answered reports whether a comment was
+// accepted as the answer of a Q&A discussion.
+type Discussion struct {
+	Repository string
+	Number     int
+	Title      string
+	Body       string
+	Category   string
+	Author     string
+	Labels     []string
+	URL        string
+	Answered   bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Comments   []Comment
+}
+
+// GetDiscussionDocuments retrieves the discussions of all configured repositories matching the filter as
+// documents. Every discussion yields one document holding its title and body, followed by one document per
+// comment and reply. The comment marked as the answer of a Q&A discussion carries "answer": "true" in its
+// metadata.
+//
+// Usage:
+//
+//	docs, err := c.GetDiscussionDocuments(ctx, DiscussionFilter{Categories: []string{"Q&A"}})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, doc := range docs {
+//	    fmt.Println(doc.Kind, doc.Title, doc.URL)
+//	}
+func (c *GitHub) GetDiscussionDocuments(ctx context.Context, filter DiscussionFilter) ([]document.Document, error) {
+	repoDocs := make([][]document.Document, len(c.repositories()))
+	err := c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		discussions, err := c.listDiscussionsSince(ctx, repo, filter)
+		if err != nil {
+			return err
+		}
+
+		for _, discussion := range discussions {
+			repoDocs[i] = append(repoDocs[i], c.discussionDocuments(repo, discussion)...)
+		}
+		return nil
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	return flattenDocuments(repoDocs), err
+}
+
+// GetDiscussionsSince retrieves the discussions of all configured repositories matching the filter together
+// with their comments and replies, most recently updated first within each repository. Discussions with more
+// than 100 comments, or comments with more than 50 replies, take one more query per further page.
+//
+// Usage:
+//
+//	discussions, err := c.GetDiscussionsSince(ctx, DiscussionFilter{
+//	    Categories: []string{"Q&A"},
+//	    Since:      time.Now().Add(-7 * 24 * time.Hour),
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, discussion := range discussions {
+//	    fmt.Println(discussion.Number, discussion.Title, discussion.Answered)
+//	}
+func (c *GitHub) GetDiscussionsSince(ctx context.Context, filter DiscussionFilter) ([]Discussion, error) {
+	repoDiscussions := make([][]Discussion, len(c.repositories()))
+	err := c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		discussions, err := c.listDiscussionsSince(ctx, repo, filter)
+		if err != nil {
+			return err
+		}
+
+		for _, discussion := range discussions {
+			repoDiscussions[i] = append(repoDiscussions[i], newDiscussion(repo, discussion))
+		}
+		return nil
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	var result []Discussion
+	for _, discussions := range repoDiscussions {
+		result = append(result, discussions...)
+	}
+	return result, err
+}
+
+// newDiscussion converts a discussion of the given configured repository.
+func newDiscussion(repo string, discussion GHDiscussion) Discussion {
+	result := Discussion{
+		Repository: repo,
+		Number:     discussion.Number,
+		Title:      discussion.Title,
+		Body:       discussion.Body,
+		Category:   discussion.Category.Name,
+		Author:     discussion.Author.Login,
+		Labels:     discussion.labelNames(),
+		URL:        discussion.URL,
+		CreatedAt:  discussion.CreatedAt,
+		UpdatedAt:  discussion.UpdatedAt,
+	}
+
+	comment := func(reply GHDiscussionReply, parent string) Comment {
+		return Comment{
+			ID:        reply.ID,
+			Body:      reply.Body,
+			Author:    reply.Author.Login,
+			URL:       reply.URL,
+			CreatedAt: reply.CreatedAt,
+			UpdatedAt: reply.UpdatedAt,
+			IsAnswer:  reply.IsAnswer,
+			ReplyTo:   parent,
+		}
+	}
+
+	for _, node := range discussion.Comments.Nodes {
+		result.Answered = result.Answered || node.IsAnswer
+		result.Comments = append(result.Comments, comment(node.GHDiscussionReply, ""))
+		for _, reply := range node.Replies.Nodes {
+			result.Comments = append(result.Comments, comment(reply, node.ID))
+		}
+	}
+
+	return result
+}
+
+// labelNames returns the names of the labels of the discussion.
+func (d GHDiscussion) labelNames() []string {
+	names := make([]string, 0, len(d.Labels.Nodes))
+	for _, label := range d.Labels.Nodes {
+		names = append(names, label.Name)
+	}
+	return names
+}
+
+// matches checks if the discussion is in one of the categories and carries the labels of the filter.
+func (f DiscussionFilter) matches(discussion GHDiscussion) bool {
+	if len(f.Categories) > 0 {
+		found := false
+		for _, category := range f.Categories {
+			found = found || category == discussion.Category.Name
+		}
+		if !found {
+			return false
+		}
+	}
+	return matchesLabels(discussion.labelNames(), f.Labels, f.ExcludeLabels)
+}
+
+// listDiscussionsSince lists the discussions of a repository that match the filter.
+func (c *GitHub) listDiscussionsSince(ctx context.Context, repo string, filter DiscussionFilter) ([]GHDiscussion, error) {
+	variables := map[string]interface{}{
+		"owner":  githubv4.String(c.ownerOf(repo)),
+		"name":   githubv4.String(c.nameOf(repo)),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	var discussions []GHDiscussion
+	for {
+		var query GHQueryForListDiscussions
+		if err := c.query(ctx, &query, variables); err != nil {
+			return nil, err
+		}
+
+		for _, discussion := range query.Repository.Discussions.Nodes {
+			if discussion.UpdatedAt.Before(filter.Since) {
+				return discussions, nil
+			}
+			if filter.matches(discussion) {
+				if err := c.listRemainingComments(ctx, &discussion); err != nil {
+					return nil, err
+				}
+				discussions = append(discussions, discussion)
+			}
+		}
+
+		if !query.Repository.Discussions.PageInfo.HasNextPage {
+			return discussions, nil
+		}
+		variables["cursor"] = githubv4.NewString(query.Repository.Discussions.PageInfo.EndCursor)
+	}
+}
+
+// listRemainingComments adds the comments of the discussion and the replies to its comments that did not fit
+// on the first page listed with it.
+func (c *GitHub) listRemainingComments(ctx context.Context, discussion *GHDiscussion) error {
+	for page := discussion.Comments; page.PageInfo.HasNextPage; {
+		var query GHQueryForListDiscussionComments
+		variables := map[string]interface{}{
+			"id":     githubv4.ID(discussion.ID),
+			"cursor": githubv4.NewString(page.PageInfo.EndCursor),
+		}
+		if err := c.query(ctx, &query, variables); err != nil {
+			return err
+		}
+		page = query.Node.Discussion.Comments
+		discussion.Comments.Nodes = append(discussion.Comments.Nodes, page.Nodes...)
+	}
+
+	for i := range discussion.Comments.Nodes {
+		comment := &discussion.Comments.Nodes[i]
+		for page := comment.Replies; page.PageInfo.HasNextPage; {
+			var query GHQueryForListDiscussionReplies
+			variables := map[string]interface{}{
+				"id":     githubv4.ID(comment.ID),
+				"cursor": githubv4.NewString(page.PageInfo.EndCursor),
+			}
+			if err := c.query(ctx, &query, variables); err != nil {
+				return err
+			}
+			page = query.Node.DiscussionComment.Replies
+			comment.Replies.Nodes = append(comment.Replies.Nodes, page.Nodes...)
+		}
+	}
+	return nil
+}
+
+// discussionDocuments converts a discussion and its comments into documents.
+func (c *GitHub) discussionDocuments(repo string, discussion GHDiscussion) []document.Document {
+	id := fmt.Sprintf("%s/%s/discussions/%d", c.ownerOf(repo), c.nameOf(repo), discussion.Number)
+	answered := false
+	for _, comment := range discussion.Comments.Nodes {
+		answered = answered || comment.IsAnswer
+	}
+
+	docs := []document.Document{{
+		ID:         id,
+		Kind:       document.DocumentKindDiscussion,
+		Owner:      c.ownerOf(repo),
+		Repository: c.nameOf(repo),
+		Title:      discussion.Title,
+		Body:       discussion.Body,
+		URL:        discussion.URL,
+		Author:     discussion.Author.Login,
+		CreatedAt:  discussion.CreatedAt,
+		UpdatedAt:  discussion.UpdatedAt,
+		Metadata: map[string]string{
+			"discussion": strconv.Itoa(discussion.Number),
+			"category":   discussion.Category.Name,
+			"answered":   strconv.FormatBool(answered),
+		},
+	}}
+
+	commentDocument := func(reply GHDiscussionReply, parent string) document.Document {
+		metadata := map[string]string{
+			"discussion": strconv.Itoa(discussion.Number),
+			"category":   discussion.Category.Name,
+			"answer":     strconv.FormatBool(reply.IsAnswer),
+		}
+		if parent != "" {
+			metadata["reply_to"] = parent
+		}
+
+		return document.Document{
+			ID:         id + "/comments/" + reply.ID,
+			Kind:       document.DocumentKindDiscussionComment,
+			Owner:      c.ownerOf(repo),
+			Repository: c.nameOf(repo),
+			Title:      discussion.Title,
+			Body:       reply.Body,
+			URL:        reply.URL,
+			Author:     reply.Author.Login,
+			CreatedAt:  reply.CreatedAt,
+			UpdatedAt:  reply.UpdatedAt,
+			Metadata:   metadata,
+		}
+	}
+
+	for _, comment := range discussion.Comments.Nodes {
+		docs = append(docs, commentDocument(comment.GHDiscussionReply, ""))
+		for _, reply := range comment.Replies.Nodes {
+			docs = append(docs, commentDocument(reply, comment.ID))
+		}
+	}
+
+	return docs
+}
@@ -0,0 +1,163 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGitHub_GetDiscussionDocuments(t *testing.T) {
+	since := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	answer := GHDiscussionComment{GHDiscussionReply: GHDiscussionReply{ID: "C1", Body: "Use the CLI", IsAnswer: true}}
+	answer.Replies.Nodes = []GHDiscussionReply{{ID: "R1", Body: "Thanks!"}}
+
+	question := GHDiscussion{Number: 1, Title: "How to install?", Body: "Which way is best?", UpdatedAt: since.Add(2 * time.Hour)}
+	question.Category.Name = "Q&A"
+	question.Author.Login = "alice"
+	question.Comments.Nodes = []GHDiscussionComment{answer}
+
+	announcement := GHDiscussion{Number: 2, Title: "v1 released", UpdatedAt: since.Add(time.Hour)}
+	announcement.Category.Name = "Announcements"
+
+	stale := GHDiscussion{Number: 3, Title: "Old question", UpdatedAt: since.Add(-time.Hour)}
+	stale.Category.Name = "Q&A"
+
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.MatchedBy(func(v map[string]interface{}) bool {
+		return v["cursor"] == (*githubv4.String)(nil)
+	})).Run(func(args mock.Arguments) {
+		query := args.Get(1).(*GHQueryForListDiscussions)
+		query.Repository.Discussions.Nodes = []GHDiscussion{question}
+		query.Repository.Discussions.PageInfo.HasNextPage = true
+		query.Repository.Discussions.PageInfo.EndCursor = "page2"
+	}).Return(nil).Once()
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.MatchedBy(func(v map[string]interface{}) bool {
+		return v["cursor"] != (*githubv4.String)(nil)
+	})).Run(func(args mock.Arguments) {
+		query := args.Get(1).(*GHQueryForListDiscussions)
+		query.Repository.Discussions.Nodes = []GHDiscussion{announcement, stale}
+		query.Repository.Discussions.PageInfo.HasNextPage = true
+	}).Return(nil).Once()
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	docs, err := gh.GetDiscussionDocuments(context.Background(), DiscussionFilter{Categories: []string{"Q&A"}, Since: since})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 3)
+
+	assert.Equal(t, "testowner/repo1/discussions/1", docs[0].ID)
+	assert.Equal(t, document.DocumentKindDiscussion, docs[0].Kind)
+	assert.Equal(t, "How to install?", docs[0].Title)
+	assert.Equal(t, "alice", docs[0].Author)
+	assert.Equal(t, map[string]string{"discussion": "1", "category": "Q&A", "answered": "true"}, docs[0].Metadata)
+
+	assert.Equal(t, "testowner/repo1/discussions/1/comments/C1", docs[1].ID)
+	assert.Equal(t, document.DocumentKindDiscussionComment, docs[1].Kind)
+	assert.Equal(t, "true", docs[1].Metadata["answer"])
+
+	assert.Equal(t, "testowner/repo1/discussions/1/comments/R1", docs[2].ID)
+	assert.Equal(t, "C1", docs[2].Metadata["reply_to"])
+	graphQLClient.AssertExpectations(t)
+}
+
+func TestGitHub_GetDiscussionsSince(t *testing.T) {
+	answer := GHDiscussionComment{GHDiscussionReply: GHDiscussionReply{ID: "C1", Body: "Use the CLI", IsAnswer: true}}
+	answer.Author.Login = "bob"
+	answer.Replies.Nodes = []GHDiscussionReply{{ID: "R1", Body: "Thanks!"}}
+
+	question := GHDiscussion{Number: 1, Title: "How to install?", Body: "Which way is best?", URL: "https://github.com/testowner/repo1/discussions/1"}
+	question.Category.Name = "Q&A"
+	question.Author.Login = "alice"
+	question.Labels.Nodes = []struct{ Name string }{{Name: "setup"}}
+	question.Comments.Nodes = []GHDiscussionComment{answer}
+
+	unlabeled := GHDiscussion{Number: 2, Title: "Unlabeled question"}
+	unlabeled.Category.Name = "Q&A"
+
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		query := args.Get(1).(*GHQueryForListDiscussions)
+		query.Repository.Discussions.Nodes = []GHDiscussion{question, unlabeled}
+	}).Return(nil).Once()
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	discussions, err := gh.GetDiscussionsSince(context.Background(), DiscussionFilter{Labels: []string{"Setup"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []Discussion{{
+		Repository: "repo1",
+		Number:     1,
+		Title:      "How to install?",
+		Body:       "Which way is best?",
+		Category:   "Q&A",
+		Author:     "alice",
+		Labels:     []string{"setup"},
+		URL:        "https://github.com/testowner/repo1/discussions/1",
+		Answered:   true,
+		Comments: []Comment{
+			{ID: "C1", Body: "Use the CLI", Author: "bob", IsAnswer: true},
+			{ID: "R1", Body: "Thanks!", ReplyTo: "C1"},
+		},
+	}}, discussions)
+	graphQLClient.AssertExpectations(t)
+}
+
+func TestGitHub_GetDiscussionsSince_CommentPages(t *testing.T) {
+	first := GHDiscussionComment{GHDiscussionReply: GHDiscussionReply{ID: "C1", Body: "First"}}
+	first.Replies.Nodes = []GHDiscussionReply{{ID: "R1", Body: "Reply 1"}}
+	first.Replies.PageInfo.HasNextPage = true
+	first.Replies.PageInfo.EndCursor = "replies2"
+
+	question := GHDiscussion{ID: "D1", Number: 1, Title: "Long thread"}
+	question.Comments.Nodes = []GHDiscussionComment{first}
+	question.Comments.PageInfo.HasNextPage = true
+	question.Comments.PageInfo.EndCursor = "comments2"
+
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForListDiscussions"), mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*GHQueryForListDiscussions).Repository.Discussions.Nodes = []GHDiscussion{question}
+	}).Return(nil).Once()
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForListDiscussionComments"), map[string]interface{}{
+		"id":     githubv4.ID("D1"),
+		"cursor": githubv4.NewString("comments2"),
+	}).Run(func(args mock.Arguments) {
+		comments := &args.Get(1).(*GHQueryForListDiscussionComments).Node.Discussion.Comments
+		comments.Nodes = []GHDiscussionComment{{GHDiscussionReply: GHDiscussionReply{ID: "C2", Body: "Second", IsAnswer: true}}}
+	}).Return(nil).Once()
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForListDiscussionReplies"), map[string]interface{}{
+		"id":     githubv4.ID("C1"),
+		"cursor": githubv4.NewString("replies2"),
+	}).Run(func(args mock.Arguments) {
+		replies := &args.Get(1).(*GHQueryForListDiscussionReplies).Node.DiscussionComment.Replies
+		replies.Nodes = []GHDiscussionReply{{ID: "R2", Body: "Reply 2"}}
+	}).Return(nil).Once()
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	discussions, err := gh.GetDiscussionsSince(context.Background(), DiscussionFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, discussions, 1)
+	assert.True(t, discussions[0].Answered)
+	assert.Equal(t, []Comment{
+		{ID: "C1", Body: "First"},
+		{ID: "R1", Body: "Reply 1", ReplyTo: "C1"},
+		{ID: "R2", Body: "Reply 2", ReplyTo: "C1"},
+		{ID: "C2", Body: "Second", IsAnswer: true},
+	}, discussions[0].Comments)
+	graphQLClient.AssertExpectations(t)
+}
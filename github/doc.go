@@ -0,0 +1,47 @@
+// Package github is the coco-gh client collecting files, changes and documents from GitHub repositories.
+//
+// The API is grouped by area:
+//
+//   - Client: GitHub, created with NewGitHub from a token and Options, or with NewGitHubClient from a REST
+//     CommitOpsClient, a GraphQLClient and a GitHubConfig. Optional capabilities are type asserted from
+//     the CommitOpsClient and fail with ErrUnsupportedClient when missing. NewClientsFromToken and
+//     NewGitHubClientFromHTTPClient create both clients for github.com or the GitHub Enterprise Server set
+//     in GitHubConfig.BaseURL, NewGitHubClientFromApp authenticates as a GitHub App installation through
+//     an AppTransport, RateLimitTransport keeps either client within the rate limits and RetryTransport
+//     retries transient failures. A CacheTransport revalidates REST responses stored in an HTTPCache with
+//     conditional requests. A Logger, e.g. a *slog.Logger, receives debug logs of the progress, and a
+//     WarnLogger warnings about truncated trees the client walked directory by directory instead, and
+//     Metrics receive API calls, rate limit quotas, collected files and crawl durations for monitoring. A
+//     Tracer starts spans for repository crawls, GraphQL queries and commit fetches. A Fetcher, e.g. a
+//     GitFetcher reading shallow git fetches, replaces the API for file listings and commit histories.
+//     API failures match ErrRepoNotFound, ErrRefNotFound, ErrForbidden or a RateLimitedError with errors.Is.
+//   - Files: GetFilePathsFromRepositories, GetFileContents, GetFileTreeFromRepositories, GetSnapshot and the
+//     changed file paths of GetChangedFilePathsSince, GetChangedFilePathsWithin, GetChangedFilePathsBetween
+//     and GetPullRequestFiles, or as a ChangeSet with commit provenance from GetChangeSetSince, and with
+//     their new content as ChangedFiles from GetChangedFilesSince. GitHubConfig.Filter selects the files,
+//     GitHubConfig.CommitFilter leaves commits out of the changes detected from the commit history, and
+//     GitHubConfig.MergeStrategy skips merge commits or follows first parents only.
+//     GetChangedFilePathsFromSnapshot compares blob SHAs against a previous Snapshot instead. GetFiles
+//     returns the File values of every file, WalkFiles streams them to a callback,
+//     DownloadRepositoryArchive with their content from a single tarball. GetWikiPages reads wiki pages
+//     through a WikiFetcher. EstimateCrawl lists the matching files without reading them and returns a
+//     CrawlEstimate of the API requests collecting them takes. GetSubmodules returns the SubmoduleRefs file
+//     listings skip; GitHubConfig.ResolveSymlinks replaces symlinks with the content of their targets.
+//   - Documents: the Get...Documents methods return Document values with provenance for commits, issues,
+//     discussions, pull request review comments, project items, security advisories, workflows,
+//     community health files, repository settings, SBOMs and gist files. GetIssuesSince and
+//     GetDiscussionsSince return Issue and Discussion values with their Comments, ListReleases and
+//     GetReleasesSince Release values with their notes and ReleaseAssets, ListGists and
+//     ListOrganizationGists Gist values matching a GistFilter.
+//   - Repository metadata: GetRepositoryMetadata, GetOwnership, GetLicenses and GetRepositorySettings.
+//     ListPullRequestsSince returns PullRequest values with the metadata of the pull requests matching a
+//     PullRequestFilter. DiscoverRepositories enumerates the repositories of an organization matching
+//     DiscoveryOptions as RepositoryRefs to crawl. With GitHubConfig.ContinueOnError a failing repository
+//     does not stop the others; the results of the successful ones are returned together with a
+//     PartialError.
+//   - Sync: SyncChanges returns the files changed since the commit a SyncStore recorded for each
+//     repository during the previous sync.
+//
+// The cocogh command reads its YAML configuration with LoadConfig into a Config. GitHubConfig.Validate and
+// Config.Validate report invalid fields as ConfigErrors before any API call.
+package github
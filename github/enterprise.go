@@ -0,0 +1,69 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shurcooL/githubv4"
+)
+
+// NewGitHubClientFromHTTPClient creates a GitHub client whose REST and GraphQL clients send their requests
+// through httpClient, which is expected to authenticate them. The clients talk to github.com unless the
+// configuration points BaseURL at a GitHub Enterprise Server.
+//
+// Usage:
+//
+//	httpClient := oauth2.NewClient(ctx, src)
+//	client, err := NewGitHubClientFromHTTPClient(httpClient, GitHubConfig{
+//	    Owner:         "octo-org",
+//	    Repositories:  []string{"docs"},
+//	    DefaultBranch: "main",
+//	    BaseURL:       "https://github.example.com/",
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func NewGitHubClientFromHTTPClient(httpClient *http.Client, configuration GitHubConfig) (*GitHub, error) {
+	restClient, graphQLClient, err := newAPIClients(httpClient, configuration)
+	if err != nil {
+		return nil, err
+	}
+	return NewGitHubClient(&GitHubCommitsOpsClient{GitHubClient: restClient}, graphQLClient, configuration), nil
+}
+
+// newAPIClients creates the REST and GraphQL clients for the instance the configuration points at.
+func newAPIClients(httpClient *http.Client, configuration GitHubConfig) (*github.Client, *githubv4.Client, error) {
+	restClient := github.NewClient(httpClient)
+	if configuration.BaseURL == "" {
+		if configuration.GraphQLEndpoint != "" {
+			return restClient, githubv4.NewEnterpriseClient(configuration.GraphQLEndpoint, httpClient), nil
+		}
+		return restClient, githubv4.NewClient(httpClient), nil
+	}
+
+	uploadURL := configuration.UploadURL
+	if uploadURL == "" {
+		uploadURL = configuration.BaseURL
+	}
+	restClient, err := restClient.WithEnterpriseURLs(configuration.BaseURL, uploadURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid GitHub Enterprise URL: %w", err)
+	}
+
+	endpoint := configuration.GraphQLEndpoint
+	if endpoint == "" {
+		endpoint = enterpriseGraphQLEndpoint(restClient.BaseURL.String())
+	}
+	return restClient, githubv4.NewEnterpriseClient(endpoint, httpClient), nil
+}
+
+// enterpriseGraphQLEndpoint derives the GraphQL endpoint from the REST base URL of a GitHub Enterprise
+// Server, which serves REST below /api/v3/ and GraphQL at /api/graphql.
+func enterpriseGraphQLEndpoint(restBaseURL string) string {
+	if strings.HasSuffix(restBaseURL, "/api/v3/") {
+		return strings.TrimSuffix(restBaseURL, "v3/") + "graphql"
+	}
+	return strings.TrimSuffix(restBaseURL, "/") + "/graphql"
+}
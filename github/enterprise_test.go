@@ -0,0 +1,74 @@
+package github
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIClients(t *testing.T) {
+	tests := []struct {
+		name          string
+		configuration GitHubConfig
+		wantBaseURL   string
+		wantUploadURL string
+	}{
+		{
+			name:          "github.com",
+			configuration: GitHubConfig{},
+			wantBaseURL:   "https://api.github.com/",
+			wantUploadURL: "https://uploads.github.com/",
+		},
+		{
+			name:          "enterprise server",
+			configuration: GitHubConfig{BaseURL: "https://github.example.com"},
+			wantBaseURL:   "https://github.example.com/api/v3/",
+			wantUploadURL: "https://github.example.com/api/uploads/",
+		},
+		{
+			name: "enterprise server with upload URL",
+			configuration: GitHubConfig{
+				BaseURL:   "https://github.example.com/api/v3/",
+				UploadURL: "https://uploads.example.com/",
+			},
+			wantBaseURL:   "https://github.example.com/api/v3/",
+			wantUploadURL: "https://uploads.example.com/api/uploads/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restClient, graphQLClient, err := newAPIClients(http.DefaultClient, tt.configuration)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBaseURL, restClient.BaseURL.String())
+			assert.Equal(t, tt.wantUploadURL, restClient.UploadURL.String())
+			assert.NotNil(t, graphQLClient)
+		})
+	}
+}
+
+func TestNewAPIClients_InvalidURL(t *testing.T) {
+	_, _, err := newAPIClients(http.DefaultClient, GitHubConfig{BaseURL: "://github.example.com"})
+	assert.ErrorContains(t, err, "invalid GitHub Enterprise URL")
+}
+
+func TestEnterpriseGraphQLEndpoint(t *testing.T) {
+	assert.Equal(t, "https://github.example.com/api/graphql", enterpriseGraphQLEndpoint("https://github.example.com/api/v3/"))
+	assert.Equal(t, "https://api.github.example.com/graphql", enterpriseGraphQLEndpoint("https://api.github.example.com/"))
+}
+
+func TestNewGitHubClientFromHTTPClient(t *testing.T) {
+	client, err := NewGitHubClientFromHTTPClient(http.DefaultClient, GitHubConfig{
+		Owner:           "testowner",
+		GraphQLEndpoint: "https://github.example.com/custom/graphql",
+	})
+	require.NoError(t, err)
+
+	ops, ok := client.commitOpsClient.(*GitHubCommitsOpsClient)
+	require.True(t, ok)
+	assert.Equal(t, "https://api.github.com/", ops.GitHubClient.BaseURL.String())
+	assert.IsType(t, &githubv4.Client{}, client.graphQLClient)
+}
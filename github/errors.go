@@ -0,0 +1,145 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/internal/timeutil"
+)
+
+// Errors classifying failures of the GitHub API, matched with errors.Is so callers can branch on the
+// reason instead of parsing messages. The classified error keeps its message and the original error, such
+// as a *github.ErrorResponse, stays reachable with errors.As.
+var (
+	// ErrRepoNotFound matches failures for repositories that do not exist, were renamed away or cannot be
+	// seen with the credentials; GitHub answers 404 Not Found for all of them.
+	ErrRepoNotFound = errors.New("repository not found")
+	// ErrRefNotFound matches failures for branches, tags or commits that do not exist.
+	ErrRefNotFound = errors.New("ref not found")
+	// ErrForbidden matches requests the credentials are not allowed to make, other than rate limits.
+	ErrForbidden = errors.New("forbidden")
+)
+
+// RateLimitedError is a failure caused by a primary or secondary rate limit of the GitHub API. ResetAt is the
+// time the limit resets or GitHub asked to retry at, the zero time if it did not tell.
+//
+// errors.Is(err, &RateLimitedError{}) matches every rate limit failure, errors.As retrieves it.
+//
+// Usage:
+//
+//	var limited *RateLimitedError
+//	if errors.As(err, &limited) {
+//	    time.Sleep(time.Until(limited.ResetAt))
+//	}
+type RateLimitedError struct {
+	ResetAt time.Time
+	Err     error
+}
+
+// Error implements the error interface.
+func (e *RateLimitedError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	if e.ResetAt.IsZero() {
+		return "rate limited"
+	}
+	return fmt.Sprintf("rate limited until %s", e.ResetAt.Format(time.RFC3339))
+}
+
+// Unwrap returns the original error.
+func (e *RateLimitedError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is a *RateLimitedError.
+func (e *RateLimitedError) Is(target error) bool {
+	_, ok := target.(*RateLimitedError)
+	return ok
+}
+
+// apiError is a failure classified by ErrRepoNotFound, ErrRefNotFound or ErrForbidden.
+type apiError struct {
+	kind error
+	err  error
+}
+
+// Error implements the error interface, keeping the message of the original error.
+func (e *apiError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the classifying error and the original one.
+func (e *apiError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// refNotFoundMessages are the messages of REST API errors for refs and commits that do not exist.
+var refNotFoundMessages = []string{"No commit found", "Branch not found", "Not a valid ref"}
+
+// classifyError wraps a failure of the REST or GraphQL API into RateLimitedError, ErrRepoNotFound,
+// ErrRefNotFound or ErrForbidden. Errors already classified and those matching none are returned unchanged.
+func (c *GitHub) classifyError(err error) error {
+	if err == nil || classified(err) {
+		return err
+	}
+
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return &RateLimitedError{ResetAt: rateLimitErr.Rate.Reset.Time, Err: err}
+	}
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		limited := &RateLimitedError{Err: err}
+		if abuseErr.RetryAfter != nil {
+			limited.ResetAt = timeutil.Now(c.Configuration.Clock).Add(*abuseErr.RetryAfter)
+		}
+		return limited
+	}
+
+	var errResp *github.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil {
+		switch status := errResp.Response.StatusCode; {
+		case (status == http.StatusNotFound || status == http.StatusUnprocessableEntity) && refNotFound(errResp.Message):
+			return &apiError{kind: ErrRefNotFound, err: err}
+		case status == http.StatusNotFound:
+			return &apiError{kind: ErrRepoNotFound, err: err}
+		case status == http.StatusForbidden:
+			return &apiError{kind: ErrForbidden, err: err}
+		}
+		return err
+	}
+
+	// GraphQL errors only carry their message.
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "Could not resolve to a Repository"):
+		return &apiError{kind: ErrRepoNotFound, err: err}
+	case strings.Contains(strings.ToLower(message), "rate limit"):
+		return &RateLimitedError{Err: err}
+	case strings.Contains(message, "non-200 OK status code: 403"), strings.Contains(message, "Resource not accessible"):
+		return &apiError{kind: ErrForbidden, err: err}
+	}
+	return err
+}
+
+// classified reports whether err has been classified already.
+func classified(err error) bool {
+	var apiErr *apiError
+	var limited *RateLimitedError
+	return errors.As(err, &apiErr) || errors.As(err, &limited)
+}
+
+// refNotFound reports whether the message of a REST API error is about a missing ref or commit.
+func refNotFound(message string) bool {
+	for _, prefix := range refNotFoundMessages {
+		if strings.HasPrefix(message, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errorResponse returns a REST API error with the status and message.
+func errorResponse(status int, message string) *github.ErrorResponse {
+	return &github.ErrorResponse{
+		Response: &http.Response{StatusCode: status, Request: &http.Request{Method: http.MethodGet}},
+		Message:  message,
+	}
+}
+
+func TestGitHub_ClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "missing repository", err: errorResponse(http.StatusNotFound, "Not Found"), want: ErrRepoNotFound},
+		{name: "missing branch", err: errorResponse(http.StatusNotFound, "No commit found for SHA: develop"), want: ErrRefNotFound},
+		{name: "missing commit", err: errorResponse(http.StatusUnprocessableEntity, "No commit found for SHA: abc"), want: ErrRefNotFound},
+		{name: "forbidden", err: errorResponse(http.StatusForbidden, "Resource not accessible by integration"), want: ErrForbidden},
+		{name: "wrapped", err: fmt.Errorf("failed to read docs: %w", errorResponse(http.StatusNotFound, "Not Found")), want: ErrRepoNotFound},
+		{name: "GraphQL missing repository", err: errors.New("Could not resolve to a Repository with the name 'testowner/missing'."), want: ErrRepoNotFound},
+		{name: "GraphQL forbidden", err: errors.New(`non-200 OK status code: 403 Forbidden body: ""`), want: ErrForbidden},
+		{name: "GraphQL rate limit", err: errors.New("API rate limit exceeded for installation ID 1."), want: &RateLimitedError{}},
+	}
+
+	client := NewGitHubClient(nil, nil, GitHubConfig{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.classifyError(tt.err)
+			assert.ErrorIs(t, err, tt.want)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.err.Error(), err.Error())
+			assert.Same(t, err, client.classifyError(err))
+		})
+	}
+
+	var errResp *github.ErrorResponse
+	assert.ErrorAs(t, client.classifyError(errorResponse(http.StatusNotFound, "Not Found")), &errResp)
+
+	boom := errors.New("boom")
+	assert.Equal(t, boom, client.classifyError(boom))
+	assert.Equal(t, errorResponse(http.StatusInternalServerError, "Server Error"), client.classifyError(errorResponse(http.StatusInternalServerError, "Server Error")))
+	assert.NoError(t, client.classifyError(nil))
+}
+
+func TestGitHub_ClassifyError_RateLimited(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	client := NewGitHubClient(nil, nil, GitHubConfig{Clock: fixedClock(now)})
+
+	reset := now.Add(30 * time.Minute)
+	err := client.classifyError(&github.RateLimitError{
+		Rate:     github.Rate{Reset: github.Timestamp{Time: reset}},
+		Response: &http.Response{StatusCode: http.StatusForbidden, Request: &http.Request{Method: http.MethodGet}},
+		Message:  "API rate limit exceeded",
+	})
+	var limited *RateLimitedError
+	require.ErrorAs(t, err, &limited)
+	assert.Equal(t, reset, limited.ResetAt)
+
+	var rateLimitErr *github.RateLimitError
+	assert.ErrorAs(t, err, &rateLimitErr)
+
+	retryAfter := time.Minute
+	err = client.classifyError(&github.AbuseRateLimitError{
+		Response:   &http.Response{StatusCode: http.StatusForbidden, Request: &http.Request{Method: http.MethodGet}},
+		Message:    "You have exceeded a secondary rate limit",
+		RetryAfter: &retryAfter,
+	})
+	require.ErrorAs(t, err, &limited)
+	assert.Equal(t, now.Add(time.Minute), limited.ResetAt)
+	assert.ErrorIs(t, err, &RateLimitedError{})
+	assert.NotErrorIs(t, err, ErrForbidden)
+}
+
+func TestRateLimitedError_Error(t *testing.T) {
+	assert.Equal(t, "rate limited", (&RateLimitedError{}).Error())
+	assert.Equal(t, "rate limited until 2024-03-10T12:00:00Z", (&RateLimitedError{ResetAt: time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)}).Error())
+	assert.Equal(t, "boom", (&RateLimitedError{Err: errors.New("boom")}).Error())
+}
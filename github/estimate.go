@@ -0,0 +1,107 @@
+package github
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// CrawlEstimate is the approximate cost of collecting the files of the configured repositories with their
+// content, as returned by EstimateCrawl.
+//
+// Repositories is the number of repositories passing the RepositoryFilter, Files the number of files passing
+// the filter and Bytes their total size. ListRequests is the number of API requests listing the trees, which
+// EstimateCrawl made itself, ContentRequests the number of GraphQL queries reading the content of the files,
+// one per file, and Requests their sum. RESTRequests is the share of Requests counting against the REST rate
+// limit, GraphQLCost the points charged against the GraphQL rate limit, one per query.
+type CrawlEstimate struct {
+	Repositories    int
+	Files           int
+	Bytes           int64
+	ListRequests    int
+	ContentRequests int
+	Requests        int
+	RESTRequests    int
+	GraphQLCost     int
+}
+
+// EstimateCrawl lists the files of the configured repositories passing the configured filter without
+// downloading their content and estimates the API requests and rate limit points collecting them with
+// GetFileContents takes, so large crawls can be planned against the rate limits. The estimate excludes the
+// requests made by a RateLimitTransport or RetryTransport retrying failed requests.
+//
+// Usage:
+//
+//	estimate, err := c.EstimateCrawl(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	fmt.Printf("%d files, %d requests, %d GraphQL points\n", estimate.Files, estimate.Requests, estimate.GraphQLCost)
+func (c *GitHub) EstimateCrawl(ctx context.Context) (CrawlEstimate, error) {
+	counter := &requestCounter{}
+	ctx = context.WithValue(ctx, requestCounterKey{}, counter)
+	ctx = c.prefetchFileEntries(ctx)
+
+	repoEstimates := make([]CrawlEstimate, len(c.repositories()))
+	err := c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		entries, err := c.filterFileEntries(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		estimate := CrawlEstimate{Repositories: 1, Files: len(entries)}
+		for _, entry := range entries {
+			estimate.Bytes += int64(entry.Size)
+		}
+		repoEstimates[i] = estimate
+		return nil
+	})
+	if !partialResult(err) {
+		return CrawlEstimate{}, err
+	}
+
+	var estimate CrawlEstimate
+	for _, repoEstimate := range repoEstimates {
+		estimate.Repositories += repoEstimate.Repositories
+		estimate.Files += repoEstimate.Files
+		estimate.Bytes += repoEstimate.Bytes
+	}
+
+	graphQL, rest := int(counter.graphQL.Load()), int(counter.rest.Load())
+	estimate.ListRequests = graphQL + rest
+	estimate.ContentRequests = estimate.Files
+	estimate.Requests = estimate.ListRequests + estimate.ContentRequests
+	estimate.RESTRequests = rest
+	estimate.GraphQLCost = graphQL + estimate.ContentRequests
+	return estimate, err
+}
+
+// requestCounter counts the API requests made with a context carrying it, see countRequest.
+type requestCounter struct {
+	graphQL atomic.Int64
+	rest    atomic.Int64
+}
+
+// requestCounterKey is the context key of the requestCounter.
+type requestCounterKey struct{}
+
+// apiKind is the API a request is made to.
+type apiKind int
+
+const (
+	restAPI apiKind = iota
+	graphQLAPI
+)
+
+// countRequest counts a request to the API with the requestCounter of the context, if it has one.
+func countRequest(ctx context.Context, api apiKind) {
+	counter, ok := ctx.Value(requestCounterKey{}).(*requestCounter)
+	if !ok {
+		return
+	}
+	if api == graphQLAPI {
+		counter.graphQL.Add(1)
+		return
+	}
+	counter.rest.Add(1)
+}
@@ -0,0 +1,50 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHub_EstimateCrawl(t *testing.T) {
+	// repo1 is listed with one recursive tree request, the truncated tree of repo2 is walked through GraphQL.
+	client := new(TreeOpsClientMock)
+	client.On("GetTree", mock.Anything, "testowner", "repo1", "main", true).Return(newRecursiveTree(false), &github.Response{}, nil)
+	client.On("GetTree", mock.Anything, "testowner", "repo2", "main", true).Return(newRecursiveTree(true), &github.Response{}, nil)
+
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		query := args.Get(1).(*GHQueryForListFiles)
+		query.Repository.Object.Tree.Entries = []GHTreeEntry{
+			{Name: "README.md", Path: "README.md", Type: "blob", Size: 5},
+			{Name: "guide.md", Path: "guide.md", Type: "blob", Size: 6},
+			{Name: "main.go", Path: "main.go", Type: "blob", Size: 12},
+		}
+	}).Return(nil)
+
+	gh := NewGitHubClient(client, graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1", "repo2"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FileTypes: []string{".md"}},
+	})
+
+	estimate, err := gh.EstimateCrawl(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, CrawlEstimate{
+		Repositories:    2,
+		Files:           4,
+		Bytes:           26,
+		ListRequests:    3,
+		ContentRequests: 4,
+		Requests:        7,
+		RESTRequests:    2,
+		GraphQLCost:     5,
+	}, estimate)
+	graphQLClient.AssertNumberOfCalls(t, "Query", 1)
+}
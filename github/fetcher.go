@@ -0,0 +1,412 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+)
+
+// Fetcher reads the files and history of repositories from somewhere other than the GitHub API, e.g. local
+// clones, for users who run into the API rate limits. Repositories are given by their clone URL, e.g.
+// "https://github.com/owner/repo.git".
+//
+// Tree returns the recursive tree of the repository at ref, a branch, tag or commit SHA, like the Git Trees
+// API does. Commits returns the commits on opts.SHA matching opts.Since, opts.Until and opts.Path, newest
+// first, each with the files it changed compared to its first parent, like the Commits API does.
+type Fetcher interface {
+	Tree(ctx context.Context, url, ref string) (*github.Tree, error)
+	Commits(ctx context.Context, url string, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, error)
+}
+
+// gitFetchDepth is the number of commits the first fetch of a history since a given time reads. Every
+// following fetch doubles it, until the fetched history reaches back to that time.
+const gitFetchDepth = 32
+
+// gitFetchRef is the local ref fetched commits are stored under.
+const gitFetchRef = plumbing.ReferenceName("refs/cocogh/fetch")
+
+// GitFetcher is a Fetcher reading shallow clones of the repositories with go-git, so git does not have to be
+// installed. Every call fetches into memory. Trees only fetch the commit at ref. Commit histories are
+// fetched back to opts.Since by deepening the clone until the commits at its boundary are older; go-git does
+// not support partial clones, so the fetched commits come with their file contents, which the detection of
+// renames needs.
+//
+// Token authenticates the fetches, which is needed for private repositories. It is sent as the basic
+// authentication GitHub accepts for HTTPS remotes.
+type GitFetcher struct {
+	Token string
+}
+
+// Tree implements Fetcher.
+func (f GitFetcher) Tree(ctx context.Context, url, ref string) (*github.Tree, error) {
+	repo, commit, err := f.fetch(ctx, url, ref, 1)
+	if err != nil {
+		return nil, err
+	}
+	root, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &github.Tree{SHA: github.String(root.Hash.String()), Truncated: github.Bool(false)}
+	walker := object.NewTreeWalker(root, true, nil)
+	defer walker.Close()
+	for {
+		filePath, entry, err := walker.Next()
+		if errors.Is(err, io.EOF) {
+			return tree, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if entry.Mode == filemode.Dir {
+			continue
+		}
+
+		treeEntry := &github.TreeEntry{
+			Mode: github.String(fmt.Sprintf("%06o", uint32(entry.Mode))),
+			Type: github.String("blob"),
+			SHA:  github.String(entry.Hash.String()),
+			Path: github.String(filePath),
+		}
+		if entry.Mode == filemode.Submodule {
+			treeEntry.Type = github.String("commit")
+		} else {
+			blob, err := repo.BlobObject(entry.Hash)
+			if err != nil {
+				return nil, err
+			}
+			treeEntry.Size = github.Int(int(blob.Size))
+		}
+		tree.Entries = append(tree.Entries, treeEntry)
+	}
+}
+
+// Commits implements Fetcher.
+func (f GitFetcher) Commits(ctx context.Context, url string, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, error) {
+	depth := gitFetchDepth
+	if opts.Since.IsZero() {
+		depth = 0
+	}
+
+	for {
+		repo, head, err := f.fetch(ctx, url, opts.SHA, depth)
+		if err != nil {
+			return nil, err
+		}
+		complete, err := reachesBack(repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		if complete {
+			return fetchedCommits(ctx, repo, head, opts)
+		}
+		depth *= 2
+	}
+}
+
+// fetch fetches ref, the default branch if it is empty, from url into an in-memory repository, limited to
+// depth commits unless depth is 0. It returns the repository together with the fetched commit.
+func (f GitFetcher) fetch(ctx context.Context, url, ref string, depth int) (*git.Repository, *object.Commit, error) {
+	repo, err := git.Init(memory.NewStorage(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	remote, err := repo.CreateRemote(&config.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{url}})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	source, err := f.resolve(ctx, remote, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = remote.FetchContext(ctx, &git.FetchOptions{
+		RefSpecs: []config.RefSpec{config.RefSpec(source + ":" + gitFetchRef.String())},
+		Depth:    depth,
+		Auth:     f.auth(),
+		Tags:     git.NoTags,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil, nil, fmt.Errorf("failed to fetch %s: %w", ref, err)
+	}
+
+	fetched, err := repo.Reference(gitFetchRef, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	hash := fetched.Hash()
+	if tag, err := repo.TagObject(hash); err == nil {
+		hash = tag.Target
+	}
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch %s: %w", ref, err)
+	}
+	return repo, commit, nil
+}
+
+// resolve returns the name of the remote ref a branch, tag or commit SHA is fetched from, or the SHA itself.
+// An empty ref resolves to the default branch.
+func (f GitFetcher) resolve(ctx context.Context, remote *git.Remote, ref string) (string, error) {
+	refs, err := remote.ListContext(ctx, &git.ListOptions{Auth: f.auth()})
+	if err != nil {
+		return "", fmt.Errorf("failed to list the refs: %w", err)
+	}
+
+	names := make(map[plumbing.ReferenceName]*plumbing.Reference)
+	for _, r := range refs {
+		names[r.Name()] = r
+	}
+
+	if ref == "" || ref == "HEAD" {
+		head, ok := names[plumbing.HEAD]
+		if !ok {
+			return "", errors.New("failed to find the default branch: the repository is empty")
+		}
+		if head.Type() == plumbing.SymbolicReference {
+			return head.Target().String(), nil
+		}
+		return head.Hash().String(), nil
+	}
+
+	for _, name := range []plumbing.ReferenceName{plumbing.ReferenceName(ref), plumbing.NewBranchReferenceName(ref), plumbing.NewTagReferenceName(ref)} {
+		if _, ok := names[name]; ok && strings.HasPrefix(name.String(), "refs/") {
+			return name.String(), nil
+		}
+	}
+	if plumbing.IsHash(ref) {
+		return ref, nil
+	}
+	return "", fmt.Errorf("failed to find %s: %w", ref, plumbing.ErrReferenceNotFound)
+}
+
+// auth returns the authentication of the fetches, nil without a token.
+func (f GitFetcher) auth() transport.AuthMethod {
+	if f.Token == "" {
+		return nil
+	}
+	return &githttp.BasicAuth{Username: "x-access-token", Password: f.Token}
+}
+
+// reachesBack reports whether the fetched history reaches back to opts.Since: it is complete, or every
+// commit at the boundary of the shallow clone was committed before. Like git log, the commits are then
+// known together with the parents telling what they changed.
+func reachesBack(repo *git.Repository, opts *github.CommitsListOptions) (bool, error) {
+	shallow, err := repo.Storer.Shallow()
+	if err != nil {
+		return false, err
+	}
+	for _, hash := range shallow {
+		commit, err := repo.CommitObject(hash)
+		if err != nil {
+			return false, err
+		}
+		if !commit.Committer.When.Before(opts.Since) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// fetchedCommits returns the fetched commits reachable from head matching opts, newest first, with the
+// files they changed compared to their first parent. Commits at the boundary of a shallow clone are only
+// read as parents.
+func fetchedCommits(ctx context.Context, repo *git.Repository, head *object.Commit, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, error) {
+	shallow, err := repo.Storer.Shallow()
+	if err != nil {
+		return nil, err
+	}
+	boundary := make(map[plumbing.Hash]bool)
+	for _, hash := range shallow {
+		boundary[hash] = true
+	}
+
+	var commits []*object.Commit
+	seen := map[plumbing.Hash]bool{head.Hash: true}
+	for queue := []*object.Commit{head}; len(queue) > 0; queue = queue[1:] {
+		commit := queue[0]
+		commits = append(commits, commit)
+		if boundary[commit.Hash] {
+			continue
+		}
+		for _, hash := range commit.ParentHashes {
+			if seen[hash] {
+				continue
+			}
+			seen[hash] = true
+			parent, err := repo.CommitObject(hash)
+			if err != nil {
+				return nil, err
+			}
+			queue = append(queue, parent)
+		}
+	}
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].Committer.When.After(commits[j].Committer.When)
+	})
+
+	var result []*github.RepositoryCommit
+	for _, commit := range commits {
+		when := commit.Committer.When
+		if boundary[commit.Hash] || (!opts.Since.IsZero() && when.Before(opts.Since)) || (!opts.Until.IsZero() && when.After(opts.Until)) {
+			continue
+		}
+
+		files, err := commitFiles(ctx, commit, opts.Path)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Path != "" && len(files) == 0 {
+			continue
+		}
+		result = append(result, repositoryCommit(commit, files))
+	}
+	return result, nil
+}
+
+// commitFiles returns the files a commit changed compared to its first parent, with the statuses the Commits
+// API reports, ordered by path. With a path only the files inside it are returned, renames if either of their
+// paths is.
+func commitFiles(ctx context.Context, commit *object.Commit, dir string) ([]*github.CommitFile, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	var parentTree *object.Tree
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return nil, err
+		}
+	}
+
+	changes, err := object.DiffTreeWithOptions(ctx, parentTree, tree, object.DefaultDiffTreeOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*github.CommitFile
+	for _, change := range changes {
+		from, to := change.From.Name, change.To.Name
+		if dir != "" && !filter.InDirectory(from, dir) && !filter.InDirectory(to, dir) {
+			continue
+		}
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+
+		file := &github.CommitFile{Filename: github.String(to)}
+		switch {
+		case action == merkletrie.Insert:
+			file.Status = github.String("added")
+		case action == merkletrie.Delete:
+			file.Filename, file.Status = github.String(from), github.String("removed")
+		case from != to:
+			file.PreviousFilename, file.Status = github.String(from), github.String("renamed")
+		case isSymlink(change.From.TreeEntry.Mode) != isSymlink(change.To.TreeEntry.Mode):
+			file.Status = github.String("changed")
+		default:
+			file.Status = github.String("modified")
+		}
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].GetFilename() < files[j].GetFilename()
+	})
+	return files, nil
+}
+
+// isSymlink reports whether a tree entry mode is the one of a symbolic link.
+func isSymlink(mode filemode.FileMode) bool {
+	return mode == filemode.Symlink
+}
+
+// repositoryCommit converts a commit into the commit the Commits API reports.
+func repositoryCommit(commit *object.Commit, files []*github.CommitFile) *github.RepositoryCommit {
+	result := &github.RepositoryCommit{
+		SHA: github.String(commit.Hash.String()),
+		Commit: &github.Commit{
+			Author:    &github.CommitAuthor{Name: github.String(commit.Author.Name), Email: github.String(commit.Author.Email), Date: &github.Timestamp{Time: commit.Author.When}},
+			Committer: &github.CommitAuthor{Name: github.String(commit.Committer.Name), Email: github.String(commit.Committer.Email), Date: &github.Timestamp{Time: commit.Committer.When}},
+			Message:   github.String(strings.TrimSpace(commit.Message)),
+		},
+		Files: files,
+	}
+	for _, parent := range commit.ParentHashes {
+		result.Parents = append(result.Parents, &github.Commit{SHA: github.String(parent.String())})
+	}
+	return result
+}
+
+// fetchFileEntries lists the blob entries below the tree identified by expression with the configured
+// Fetcher.
+func (c *GitHub) fetchFileEntries(ctx context.Context, owner, name, expression string) ([]GHTreeEntry, error) {
+	ref, dir, _ := strings.Cut(expression, ":")
+
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := c.Configuration.Fetcher.Tree(ctx, c.cloneURL(owner, name), ref)
+	release()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the tree of %s/%s: %w", owner, name, err)
+	}
+
+	return recursiveTreeFiles(tree, dir), nil
+}
+
+// fetchChangeEvents is listChangeEvents reading the commit history with the configured Fetcher.
+func (c *GitHub) fetchChangeEvents(ctx context.Context, repo string, opt *github.CommitsListOptions, until string, rules fileRules, merges *mergeFilter) ([]document.ChangeEvent, string, error) {
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	commits, err := c.Configuration.Fetcher.Commits(ctx, c.cloneURL(c.ownerOf(repo), c.nameOf(repo)), opt)
+	release()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch the history of %s: %w", repo, err)
+	}
+
+	var events []document.ChangeEvent
+	var head string
+	for _, commit := range commits {
+		if until != "" && commit.GetSHA() == until {
+			return events, head, nil
+		}
+		if head == "" {
+			head = commit.GetSHA()
+		}
+		if !merges.keep(commit) || !c.Configuration.CommitFilter.Match(commit) {
+			continue
+		}
+		events = c.appendChangeEvents(events, repo, commit, commit.Files, rules)
+	}
+	return events, head, nil
+}
+
+// cloneURL returns the address the repository is fetched from with git.
+func (c *GitHub) cloneURL(owner, name string) string {
+	return fmt.Sprintf("%s/%s/%s.git", c.webBaseURL(), owner, name)
+}
@@ -0,0 +1,245 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shaharia-lab/coco-gh/v2/internal/gitutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticFetcher is a Fetcher serving a fixed tree and history.
+type staticFetcher struct {
+	tree    *github.Tree
+	commits []*github.RepositoryCommit
+
+	urls []string
+	opts []github.CommitsListOptions
+}
+
+func (f *staticFetcher) Tree(_ context.Context, url, ref string) (*github.Tree, error) {
+	f.urls = append(f.urls, url+"@"+ref)
+	return f.tree, nil
+}
+
+func (f *staticFetcher) Commits(_ context.Context, url string, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, error) {
+	f.urls = append(f.urls, url+"@"+opts.SHA)
+	f.opts = append(f.opts, *opts)
+	return f.commits, nil
+}
+
+func TestGitHub_Fetcher(t *testing.T) {
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	fetcher := &staticFetcher{
+		tree: &github.Tree{Entries: []*github.TreeEntry{
+			{Path: github.String("README.md"), Type: github.String("blob"), Mode: github.String("100644"), SHA: github.String("a1")},
+			{Path: github.String("docs"), Type: github.String("tree"), Mode: github.String("040000"), SHA: github.String("t1")},
+			{Path: github.String("docs/index.md"), Type: github.String("blob"), Mode: github.String("100644"), SHA: github.String("b1"), Size: github.Int(7)},
+			{Path: github.String("docs/logo.png"), Type: github.String("blob"), Mode: github.String("100644"), SHA: github.String("c1")},
+		}},
+		commits: []*github.RepositoryCommit{
+			{
+				SHA:    github.String("second"),
+				Commit: &github.Commit{Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: since.Add(2 * time.Hour)}}},
+				Files: []*github.CommitFile{
+					{Filename: github.String("docs/guide.md"), PreviousFilename: github.String("docs/setup.md"), Status: github.String("renamed")},
+				},
+			},
+			{
+				SHA:    github.String("first"),
+				Commit: &github.Commit{Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: since.Add(time.Hour)}}},
+				Files: []*github.CommitFile{
+					{Filename: github.String("docs/index.md"), Status: github.String("modified")},
+					{Filename: github.String("README.md"), Status: github.String("modified")},
+				},
+			},
+		},
+	}
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), new(GraphQLClientMock), GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+		Fetcher:       fetcher,
+	})
+
+	files, err := gh.GetFilePathsFromRepositories()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/index.md"}, files)
+
+	paths, err := gh.GetChangedFilePathsSince(since)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/guide.md"}, paths.Added)
+	assert.Equal(t, []string{"docs/setup.md"}, paths.Removed)
+	assert.Equal(t, []string{"docs/index.md"}, paths.Modified)
+
+	assert.Equal(t, []string{
+		"https://github.com/testowner/repo1.git@main",
+		"https://github.com/testowner/repo1.git@main",
+	}, fetcher.urls)
+	assert.Equal(t, since, fetcher.opts[0].Since)
+	assert.Equal(t, "docs", fetcher.opts[0].Path)
+}
+
+// serveGit serves the git repository in dir over the smart HTTP protocol with git http-backend, checking the
+// token of the fetches. The bodies of the fetch requests are sent to requests.
+func serveGit(t *testing.T, dir, token string, requests chan<- string) string {
+	gitPath, err := exec.LookPath("git")
+	require.NoError(t, err)
+
+	backend := &cgi.Handler{
+		Path: gitPath,
+		Args: []string{"http-backend"},
+		Env:  []string{"GIT_PROJECT_ROOT=" + filepath.Dir(dir), "GIT_HTTP_EXPORT_ALL=1"},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		assert.Equal(t, "x-access-token", user)
+		assert.Equal(t, token, password)
+
+		if strings.HasSuffix(r.URL.Path, "/git-upload-pack") {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			requests <- string(body)
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		backend.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL + "/" + filepath.Base(dir)
+}
+
+// fetchRequests returns the bodies of the fetch requests sent so far.
+func fetchRequests(requests chan string) []string {
+	var bodies []string
+	for {
+		select {
+		case body := <-requests:
+			bodies = append(bodies, body)
+		default:
+			return bodies
+		}
+	}
+}
+
+func TestGitFetcher(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := filepath.Join(t.TempDir(), "repo1")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	commit := func(message string, date time.Time, files map[string]string, args ...string) {
+		writeFiles(t, dir, files)
+		if len(args) > 0 {
+			runGit(t, dir, date, args...)
+		}
+		runGit(t, dir, date, "add", "--all")
+		runGit(t, dir, date, "commit", "--quiet", "--allow-empty", "--message", message)
+	}
+
+	// More commits than the first shallow fetch reads, most of them changing nothing.
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	runGit(t, dir, base, "init", "--quiet", "--initial-branch", "main")
+	runGit(t, dir, base, "config", "uploadpack.allowAnySHA1InWant", "true")
+	commit("Initial commit", base, map[string]string{"README.md": "# repo1", "docs/setup.md": "# Setup"})
+	for i := 1; i <= gitFetchDepth+8; i++ {
+		commit(fmt.Sprintf("Change nothing %d", i), base.Add(time.Duration(i)*time.Minute), nil)
+	}
+	commit("Add index", base.Add(24*time.Hour), map[string]string{"docs/index.md": "# Index"})
+	commit("Move setup guide\n\nIt is a guide now.", base.Add(48*time.Hour), nil, "mv", "docs/setup.md", "docs/guide.md")
+	runGit(t, dir, base, "tag", "v1.0.0", "HEAD~1")
+
+	requests := make(chan string, 100)
+	url := serveGit(t, dir, "secret", requests)
+	fetcher := GitFetcher{Token: "secret"}
+
+	tree, err := fetcher.Tree(context.Background(), url, "v1.0.0")
+	require.NoError(t, err)
+	var paths []string
+	for _, entry := range tree.Entries {
+		paths = append(paths, entry.GetPath())
+	}
+	assert.Equal(t, []string{"README.md", "docs/index.md", "docs/setup.md"}, paths)
+	assert.Equal(t, "100644", tree.Entries[0].GetMode())
+	assert.Equal(t, "blob", tree.Entries[0].GetType())
+	assert.Equal(t, gitutil.BlobSHA([]byte("# repo1")), tree.Entries[0].GetSHA())
+	assert.Equal(t, 7, tree.Entries[0].GetSize())
+	bodies := fetchRequests(requests)
+	require.Len(t, bodies, 1)
+	assert.Contains(t, bodies[0], "deepen 1")
+
+	// The history since a day ago is within the first shallow fetch.
+	commits, err := fetcher.Commits(context.Background(), url, &github.CommitsListOptions{SHA: "main", Since: base.Add(12 * time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, "Move setup guide\n\nIt is a guide now.", commits[0].GetCommit().GetMessage())
+	assert.Equal(t, "alice", commits[0].GetCommit().GetAuthor().GetName())
+	assert.Equal(t, base.Add(48*time.Hour), commits[0].GetCommit().GetCommitter().GetDate().UTC())
+	require.Len(t, commits[0].Files, 1)
+	assert.Equal(t, "renamed", commits[0].Files[0].GetStatus())
+	assert.Equal(t, "docs/guide.md", commits[0].Files[0].GetFilename())
+	assert.Equal(t, "docs/setup.md", commits[0].Files[0].GetPreviousFilename())
+	require.Len(t, commits[0].Parents, 1)
+	assert.Equal(t, commits[1].GetSHA(), commits[0].Parents[0].GetSHA())
+	require.Len(t, commits[1].Files, 1)
+	assert.Equal(t, "added", commits[1].Files[0].GetStatus())
+	bodies = fetchRequests(requests)
+	require.Len(t, bodies, 1)
+	assert.Contains(t, bodies[0], fmt.Sprintf("deepen %d", gitFetchDepth))
+
+	// Reaching back to the first commits deepens the clone.
+	commits, err = fetcher.Commits(context.Background(), url, &github.CommitsListOptions{SHA: "main", Since: base.Add(30 * time.Second)})
+	require.NoError(t, err)
+	require.Len(t, commits, gitFetchDepth+10)
+	assert.Empty(t, commits[len(commits)-1].Files)
+	require.Len(t, commits[len(commits)-1].Parents, 1)
+	bodies = fetchRequests(requests)
+	require.Len(t, bodies, 2)
+	assert.Contains(t, bodies[0], fmt.Sprintf("deepen %d", gitFetchDepth))
+	assert.Contains(t, bodies[1], fmt.Sprintf("deepen %d", 2*gitFetchDepth))
+
+	commits, err = fetcher.Commits(context.Background(), url, &github.CommitsListOptions{SHA: "main", Since: base.Add(72 * time.Hour)})
+	assert.NoError(t, err)
+	assert.Empty(t, commits)
+	assert.Len(t, fetchRequests(requests), 1)
+
+	// Without a start the whole history is fetched. Commits can be fetched by SHA as well.
+	head, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	require.NoError(t, err)
+	commits, err = fetcher.Commits(context.Background(), url, &github.CommitsListOptions{SHA: strings.TrimSpace(string(head)), Path: "README.md"})
+	assert.NoError(t, err)
+	require.Len(t, commits, 1)
+	assert.Equal(t, "Initial commit", commits[0].GetCommit().GetMessage())
+	assert.Equal(t, "added", commits[0].Files[0].GetStatus())
+	assert.NotContains(t, fetchRequests(requests)[0], "deepen")
+}
+
+// runGit runs git in dir with the author and committer date set to date.
+func runGit(t *testing.T, dir string, date time.Time, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=alice", "GIT_AUTHOR_EMAIL=alice@example.com", "GIT_AUTHOR_DATE="+date.Format(time.RFC3339),
+		"GIT_COMMITTER_NAME=alice", "GIT_COMMITTER_EMAIL=alice@example.com", "GIT_COMMITTER_DATE="+date.Format(time.RFC3339),
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
@@ -0,0 +1,229 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// FileOptions selects the optional attributes GetFiles fills in.
+//
+// LastModified looks up the last commit changing each file, which takes one API call per file.
+type FileOptions struct {
+	LastModified bool
+}
+
+// GetFiles retrieves the files passing the configured filter in all configured repositories with their
+// metadata. Paths are normalized like the paths returned by GetFilePathsFromRepositories.
+//
+// Usage:
+//
+//	files, err := c.GetFiles(ctx, FileOptions{LastModified: true})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, file := range files {
+//	    fmt.Println(file.Repository, file.Path, file.Size, file.LastModifiedAt)
+//	}
+func (c *GitHub) GetFiles(ctx context.Context, options FileOptions) ([]document.File, error) {
+	ctx = c.prefetchFileEntries(ctx)
+	repoFiles := make([][]document.File, len(c.repositories()))
+	err := c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		branch, err := c.branch(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		entries, err := c.filterFileEntries(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		var files []document.File
+		for _, entry := range entries {
+			file, err := c.newFile(ctx, repo, branch, entry, options)
+			if err != nil {
+				return err
+			}
+			files = append(files, file)
+		}
+		repoFiles[i] = files
+		return nil
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	var files []document.File
+	for _, f := range repoFiles {
+		files = append(files, f...)
+	}
+
+	return files, err
+}
+
+// WalkFiles calls fn for every file passing the configured filter in all configured repositories, as the
+// trees are listed, instead of collecting all files first like GetFiles. Repositories and directories are
+// walked one after another in the order they are configured and listed, regardless of MaxConcurrency.
+//
+// If fn returns an error the walk stops and WalkFiles returns the error, except for fs.SkipAll, which stops
+// the walk without an error.
+//
+// Usage:
+//
+//	err := c.WalkFiles(ctx, FileOptions{}, func(file document.File) error {
+//	    if file.Size > maxSize {
+//	        return nil
+//	    }
+//	    return index(ctx, file)
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *GitHub) WalkFiles(ctx context.Context, options FileOptions, fn func(document.File) error) error {
+	for _, repo := range c.repositories() {
+		matched, err := c.matchRepository(ctx, repo)
+		if err != nil {
+			return c.classifyError(err)
+		}
+		if !matched {
+			c.debug("skipping repository", "repository", repo)
+			continue
+		}
+
+		branch, err := c.branch(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		rules, err := c.getFileRules(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		collected := 0
+		listed := make(map[string]bool)
+		for _, root := range c.rootPaths(repo) {
+			expression := fmt.Sprintf("%s:%s", branch, root)
+			err = c.walkTree(ctx, repo, expression, func(entry GHTreeEntry) error {
+				if listed[entry.Path] || !c.includeEntry(entry, rules) {
+					return nil
+				}
+				listed[entry.Path] = true
+
+				file, err := c.newFile(ctx, repo, branch, entry, options)
+				if err != nil {
+					return err
+				}
+				collected++
+				return fn(file)
+			})
+			if err != nil {
+				break
+			}
+		}
+		c.metrics().FilesCollected(repo, collected)
+		if errors.Is(err, fs.SkipAll) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walkTree calls fn for every blob below the tree identified by expression, depth first in listing order.
+func (c *GitHub) walkTree(ctx context.Context, repo, expression string, fn func(GHTreeEntry) error) error {
+	entries, err := c.listTreeEntries(ctx, c.ownerOf(repo), c.nameOf(repo), expression)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		switch entry.Type {
+		case "blob":
+			err = fn(entry)
+		case "tree":
+			err = c.walkTree(ctx, repo, expression+"/"+entry.Name, fn)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newFile converts a tree entry read from the branch into a File.
+func (c *GitHub) newFile(ctx context.Context, repo, branch string, entry GHTreeEntry, options FileOptions) (document.File, error) {
+	file := document.File{
+		Path:       c.normalizePath(entry.Path),
+		SHA:        entry.Oid,
+		Size:       entry.Size,
+		Mode:       document.FileMode(entry.Mode),
+		Repository: repo,
+		Branch:     branch,
+		URL:        c.fileURL(repo, branch, entry.Path),
+	}
+
+	if options.LastModified {
+		var err error
+		file.LastModifiedAt, err = c.lastModified(ctx, repo, branch, entry.Path)
+		if err != nil {
+			return document.File{}, err
+		}
+	}
+	return file, nil
+}
+
+// lastModified returns the committer date of the last commit on the branch changing filePath.
+func (c *GitHub) lastModified(ctx context.Context, repo, branch, filePath string) (time.Time, error) {
+	opts := &github.CommitsListOptions{
+		SHA:         branch,
+		Path:        filePath,
+		ListOptions: github.ListOptions{PerPage: 1},
+	}
+
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	commits, _, err := c.commitOpsClient.ListCommits(ctx, c.ownerOf(repo), c.nameOf(repo), opts)
+	release()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read the history of %s: %w", filePath, c.classifyError(err))
+	}
+
+	if len(commits) == 0 {
+		return time.Time{}, nil
+	}
+	return commits[0].GetCommit().GetCommitter().GetDate().Time, nil
+}
+
+// fileURL returns the address of a file on the web interface of GitHub or the configured GitHub Enterprise
+// Server.
+func (c *GitHub) fileURL(repo, branch, filePath string) string {
+	return fmt.Sprintf("%s/%s/%s/blob/%s/%s", c.webBaseURL(), c.ownerOf(repo), c.nameOf(repo), branch, filePath)
+}
+
+// webBaseURL returns the base URL of the web interface: github.com or the host of the configured BaseURL.
+func (c *GitHub) webBaseURL() string {
+	if c.Configuration.BaseURL == "" {
+		return "https://github.com"
+	}
+
+	u, err := url.Parse(c.Configuration.BaseURL)
+	if err != nil || u.Host == "" {
+		return "https://github.com"
+	}
+	return u.Scheme + "://" + strings.TrimPrefix(u.Host, "api.")
+}
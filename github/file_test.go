@@ -0,0 +1,155 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubClient_GetFiles(t *testing.T) {
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForListFiles"), mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*GHQueryForListFiles).Repository.Object.Tree.Entries = []GHTreeEntry{
+			{Name: "README.md", Path: "README.md", Type: "blob", Mode: 0o100644, Oid: "abc", Size: 12},
+		}
+	}).Return(nil)
+
+	modified := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.CommitsListOptions) bool {
+		return opts.SHA == "main" && opts.Path == "README.md" && opts.PerPage == 1
+	})).Return([]*github.RepositoryCommit{{Commit: &github.Commit{
+		Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: modified}},
+	}}}, &github.Response{}, nil)
+
+	client := NewGitHubClient(commitOpsClient, graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+	})
+
+	want := document.File{
+		Path:       "README.md",
+		SHA:        "abc",
+		Size:       12,
+		Mode:       document.FileMode(0o100644),
+		Repository: "repo1",
+		Branch:     "main",
+		URL:        "https://github.com/testowner/repo1/blob/main/README.md",
+	}
+
+	files, err := client.GetFiles(context.Background(), FileOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []document.File{want}, files)
+	commitOpsClient.AssertNotCalled(t, "ListCommits", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	files, err = client.GetFiles(context.Background(), FileOptions{LastModified: true})
+	require.NoError(t, err)
+	want.LastModifiedAt = modified
+	assert.Equal(t, []document.File{want}, files)
+}
+
+func TestGitHub_WebBaseURL(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		want    string
+	}{
+		{"", "https://github.com"},
+		{"https://ghe.example.com/api/v3/", "https://ghe.example.com"},
+		{"https://api.octocorp.ghe.com/", "https://octocorp.ghe.com"},
+	}
+
+	for _, tt := range tests {
+		c := &GitHub{Configuration: GitHubConfig{BaseURL: tt.baseURL}}
+		assert.Equal(t, tt.want, c.webBaseURL(), tt.baseURL)
+	}
+}
+
+func newWalkTestGraphQLClient(queried *[]string) *GraphQLClientMock {
+	trees := map[string][]GHTreeEntry{
+		"main:": {
+			{Name: "README.md", Path: "README.md", Type: "blob"},
+			{Name: "docs", Path: "docs", Type: "tree"},
+			{Name: "main.go", Path: "main.go", Type: "blob"},
+			{Name: "site", Path: "site", Type: "tree"},
+		},
+		"main:/docs": {
+			{Name: "index.md", Path: "docs/index.md", Type: "blob"},
+		},
+		"main:/site": {
+			{Name: "about.md", Path: "site/about.md", Type: "blob"},
+		},
+	}
+
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForListFiles"), mock.Anything).Run(func(args mock.Arguments) {
+		expression := string(args.Get(2).(map[string]interface{})["expression"].(githubv4.String))
+		*queried = append(*queried, expression)
+		args.Get(1).(*GHQueryForListFiles).Repository.Object.Tree.Entries = trees[expression]
+	}).Return(nil)
+	return graphQLClient
+}
+
+func TestGitHubClient_WalkFiles(t *testing.T) {
+	var queried []string
+	client := NewGitHubClient(new(CommitOpsClientMock), newWalkTestGraphQLClient(&queried), GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FileTypes: []string{".md"}},
+	})
+
+	var paths []string
+	err := client.WalkFiles(context.Background(), FileOptions{}, func(file document.File) error {
+		paths = append(paths, file.Path)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "docs/index.md", "site/about.md"}, paths)
+}
+
+func TestGitHubClient_WalkFiles_Stop(t *testing.T) {
+	failed := errors.New("index unavailable")
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{name: "SkipAll stops without an error", err: fs.SkipAll},
+		{name: "error stops with the error", err: failed, wantErr: failed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queried []string
+			client := NewGitHubClient(new(CommitOpsClientMock), newWalkTestGraphQLClient(&queried), GitHubConfig{
+				Owner:         "testowner",
+				Repositories:  []string{"repo1", "repo2"},
+				DefaultBranch: "main",
+				Filter:        filter.GitHubFilter{FileTypes: []string{".md"}},
+			})
+
+			var paths []string
+			err := client.WalkFiles(context.Background(), FileOptions{}, func(file document.File) error {
+				paths = append(paths, file.Path)
+				if file.Path == "docs/index.md" {
+					return tt.err
+				}
+				return nil
+			})
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, []string{"README.md", "docs/index.md"}, paths)
+			assert.Equal(t, []string{"main:", "main:/docs"}, queried, "the remaining trees and repositories are not listed")
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// GistOpsClient is an interface to help test the GitHub gist operations.
+// ListGists, ListOrganizationGists and GetGist need the CommitOpsClient to implement it.
+type GistOpsClient interface {
+	ListGists(ctx context.Context, user string, opts *github.GistListOptions) ([]*github.Gist, *github.Response, error)
+	GetGist(ctx context.Context, id string) (*github.Gist, *github.Response, error)
+	ListOrganizationMembers(ctx context.Context, org string, opts *github.ListMembersOptions) ([]*github.User, *github.Response, error)
+}
+
+// GistFilter narrows down the gists that are collected.
+//
+// FileTypes only keeps the files ending with one of the given suffixes, e.g. ".md"; gists without such a
+// file are skipped. Since skips gists that were last updated before the given time and is evaluated by
+// GitHub.
+type GistFilter struct {
+	FileTypes []string
+	Since     time.Time
+}
+
+// Gist is a gist together with its files passing the filter, ordered by name.
+//
+// Owner is the login of the user owning the gist. Public is false for secret gists, which are only listed
+// for the authenticated user.
+type Gist struct {
+	ID          string
+	Owner       string
+	Description string
+	Public      bool
+	URL         string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Files       []GistFile
+}
+
+// GistFile is a file of a gist. Content is only set by GetGist and GetGistDocuments, GitHub truncates it
+// for files larger than one megabyte, which have to be downloaded from RawURL.
+type GistFile struct {
+	Name     string
+	Language string
+	Type     string
+	Size     int
+	RawURL   string
+	Content  string
+}
+
+// ListGists lists the gists of a specific user, or of the authenticated user if user is empty.
+func (gClient *GitHubCommitsOpsClient) ListGists(ctx context.Context, user string, opts *github.GistListOptions) ([]*github.Gist, *github.Response, error) {
+	return gClient.GitHubClient.Gists.List(ctx, user, opts)
+}
+
+// GetGist retrieves a specific gist with the content of its files.
+func (gClient *GitHubCommitsOpsClient) GetGist(ctx context.Context, id string) (*github.Gist, *github.Response, error) {
+	return gClient.GitHubClient.Gists.Get(ctx, id)
+}
+
+// ListOrganizationMembers lists the members of a specific organization.
+func (gClient *GitHubCommitsOpsClient) ListOrganizationMembers(ctx context.Context, org string, opts *github.ListMembersOptions) ([]*github.User, *github.Response, error) {
+	return gClient.GitHubClient.Organizations.ListMembers(ctx, org, opts)
+}
+
+// ListGists lists the gists of a user matching the filter, most recently updated first, without the
+// content of their files. An empty user lists the gists of the authenticated user, including the secret
+// ones, other users only expose their public gists.
+//
+// Usage:
+//
+//	gists, err := c.ListGists(ctx, "octocat", GistFilter{FileTypes: []string{".md", ".go"}})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, gist := range gists {
+//	    fmt.Println(gist.ID, gist.Description, len(gist.Files))
+//	}
+func (c *GitHub) ListGists(ctx context.Context, user string, filter GistFilter) ([]Gist, error) {
+	client, err := c.gistOpsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	gists, err := c.listGists(ctx, client, user, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the gists of %s: %w", gistUser(user), err)
+	}
+	return gists, nil
+}
+
+// ListOrganizationGists lists the public gists of all members of an organization matching the filter,
+// grouped by member in the order GitHub lists the members. Members are only listed if they are visible to
+// the authenticated user.
+//
+// Usage:
+//
+//	gists, err := c.ListOrganizationGists(ctx, "shaharia-lab", GistFilter{Since: time.Now().Add(-30 * 24 * time.Hour)})
+func (c *GitHub) ListOrganizationGists(ctx context.Context, org string, filter GistFilter) ([]Gist, error) {
+	client, err := c.gistOpsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := c.listOrganizationMembers(ctx, client, org)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the members of %s: %w", org, err)
+	}
+
+	var gists []Gist
+	for _, member := range members {
+		page, err := c.listGists(ctx, client, member.GetLogin(), filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the gists of %s: %w", member.GetLogin(), err)
+		}
+		gists = append(gists, page...)
+	}
+	return gists, nil
+}
+
+// GetGist retrieves a gist with the content of its files passing the filter. Since is ignored.
+func (c *GitHub) GetGist(ctx context.Context, id string, filter GistFilter) (Gist, error) {
+	client, err := c.gistOpsClient()
+	if err != nil {
+		return Gist{}, err
+	}
+
+	releaseSlot, err := c.acquire(ctx)
+	if err != nil {
+		return Gist{}, err
+	}
+	gist, _, err := client.GetGist(ctx, id)
+	releaseSlot()
+	if err != nil {
+		return Gist{}, fmt.Errorf("failed to get gist %s: %w", id, c.classifyError(err))
+	}
+	return c.newGist(gist, filter.FileTypes), nil
+}
+
+// GetGistDocuments retrieves the content of the given gists, as listed by ListGists or
+// ListOrganizationGists, and converts every listed file into a document. Files are retrieved again, so
+// files that were removed since the gists were listed are skipped.
+//
+// Usage:
+//
+//	gists, err := c.ListGists(ctx, "octocat", GistFilter{FileTypes: []string{".md"}})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	docs, err := c.GetGistDocuments(ctx, gists)
+func (c *GitHub) GetGistDocuments(ctx context.Context, gists []Gist) ([]document.Document, error) {
+	var docs []document.Document
+	for _, listed := range gists {
+		listedFiles := make(map[string]bool, len(listed.Files))
+		for _, file := range listed.Files {
+			listedFiles[file.Name] = true
+		}
+
+		gist, err := c.GetGist(ctx, listed.ID, GistFilter{})
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range gist.Files {
+			if listedFiles[file.Name] {
+				docs = append(docs, gistFileDocument(gist, file))
+			}
+		}
+	}
+	return docs, nil
+}
+
+// listGists lists all gists of a user matching the filter.
+func (c *GitHub) listGists(ctx context.Context, client GistOpsClient, user string, filter GistFilter) ([]Gist, error) {
+	opts := &github.GistListOptions{Since: filter.Since, ListOptions: github.ListOptions{PerPage: 100}}
+
+	var gists []Gist
+	for {
+		releaseSlot, err := c.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		page, resp, err := client.ListGists(ctx, user, opts)
+		releaseSlot()
+		if err != nil {
+			return nil, c.classifyError(err)
+		}
+		for _, gist := range page {
+			if result := c.newGist(gist, filter.FileTypes); len(result.Files) > 0 {
+				gists = append(gists, result)
+			}
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			return gists, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// listOrganizationMembers lists all members of an organization.
+func (c *GitHub) listOrganizationMembers(ctx context.Context, client GistOpsClient, org string) ([]*github.User, error) {
+	opts := &github.ListMembersOptions{ListOptions: github.ListOptions{PerPage: 100}}
+
+	var members []*github.User
+	for {
+		releaseSlot, err := c.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		page, resp, err := client.ListOrganizationMembers(ctx, org, opts)
+		releaseSlot()
+		if err != nil {
+			return nil, c.classifyError(err)
+		}
+		members = append(members, page...)
+
+		if resp == nil || resp.NextPage == 0 {
+			return members, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// newGist converts a gist, keeping the files ending with one of the file types, all if there are none.
+func (c *GitHub) newGist(gist *github.Gist, fileTypes []string) Gist {
+	result := Gist{
+		ID:          gist.GetID(),
+		Owner:       gist.GetOwner().GetLogin(),
+		Description: gist.GetDescription(),
+		Public:      gist.GetPublic(),
+		URL:         gist.GetHTMLURL(),
+		CreatedAt:   gist.GetCreatedAt().Time,
+		UpdatedAt:   gist.GetUpdatedAt().Time,
+	}
+
+	for name, file := range gist.Files {
+		if len(fileTypes) > 0 && !c.hasFileType(string(name), fileTypes) {
+			continue
+		}
+		result.Files = append(result.Files, GistFile{
+			Name:     string(name),
+			Language: file.GetLanguage(),
+			Type:     file.GetType(),
+			Size:     file.GetSize(),
+			RawURL:   file.GetRawURL(),
+			Content:  file.GetContent(),
+		})
+	}
+	sort.Slice(result.Files, func(i, j int) bool {
+		return result.Files[i].Name < result.Files[j].Name
+	})
+
+	return result
+}
+
+// gistFileDocument converts a file of a gist into a document. Gists do not belong to a repository, the
+// document is owned by the owner of the gist.
+func gistFileDocument(gist Gist, file GistFile) document.Document {
+	title := gist.Description
+	if title == "" {
+		title = file.Name
+	}
+
+	return document.Document{
+		ID:        fmt.Sprintf("gists/%s/%s", gist.ID, file.Name),
+		Kind:      document.DocumentKindGistFile,
+		Owner:     gist.Owner,
+		Path:      file.Name,
+		Title:     title,
+		Body:      file.Content,
+		URL:       gist.URL,
+		Author:    gist.Owner,
+		CreatedAt: gist.CreatedAt,
+		UpdatedAt: gist.UpdatedAt,
+		Metadata: map[string]string{
+			"gist":     gist.ID,
+			"language": file.Language,
+			"public":   strconv.FormatBool(gist.Public),
+		},
+	}
+}
+
+// gistUser describes the user whose gists are listed in errors.
+func gistUser(user string) string {
+	if user == "" {
+		return "the authenticated user"
+	}
+	return user
+}
+
+// gistOpsClient returns the commit ops client as a GistOpsClient.
+func (c *GitHub) gistOpsClient() (GistOpsClient, error) {
+	client, ok := c.commitOpsClient.(GistOpsClient)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not implement GistOpsClient", ErrUnsupportedClient, c.commitOpsClient)
+	}
+	return client, nil
+}
@@ -0,0 +1,168 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// GistOpsClientMock is a mock type for a CommitOpsClient that also implements GistOpsClient
+type GistOpsClientMock struct {
+	CommitOpsClientMock
+}
+
+// ListGists provides a mock function with given fields: ctx, user, opts
+func (_m *GistOpsClientMock) ListGists(ctx context.Context, user string, opts *github.GistListOptions) ([]*github.Gist, *github.Response, error) {
+	ret := _m.Called(ctx, user, opts)
+	gists, _ := ret.Get(0).([]*github.Gist)
+	resp, _ := ret.Get(1).(*github.Response)
+	return gists, resp, ret.Error(2)
+}
+
+// GetGist provides a mock function with given fields: ctx, id
+func (_m *GistOpsClientMock) GetGist(ctx context.Context, id string) (*github.Gist, *github.Response, error) {
+	ret := _m.Called(ctx, id)
+	gist, _ := ret.Get(0).(*github.Gist)
+	resp, _ := ret.Get(1).(*github.Response)
+	return gist, resp, ret.Error(2)
+}
+
+// ListOrganizationMembers provides a mock function with given fields: ctx, org, opts
+func (_m *GistOpsClientMock) ListOrganizationMembers(ctx context.Context, org string, opts *github.ListMembersOptions) ([]*github.User, *github.Response, error) {
+	ret := _m.Called(ctx, org, opts)
+	members, _ := ret.Get(0).([]*github.User)
+	resp, _ := ret.Get(1).(*github.Response)
+	return members, resp, ret.Error(2)
+}
+
+// gistFiles builds the files of a gist, with the content if it is not empty.
+func gistFiles(files map[string]string) map[github.GistFilename]github.GistFile {
+	result := make(map[github.GistFilename]github.GistFile)
+	for name, content := range files {
+		file := github.GistFile{Filename: github.String(name), Size: github.Int(len(content)), RawURL: github.String("https://gist.githubusercontent.com/raw/" + name)}
+		if content != "" {
+			file.Content = github.String(content)
+		}
+		result[github.GistFilename(name)] = file
+	}
+	return result
+}
+
+func TestGitHub_ListGists(t *testing.T) {
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	client := new(GistOpsClientMock)
+	client.On("ListGists", mock.Anything, "octocat", mock.MatchedBy(func(opts *github.GistListOptions) bool {
+		return opts.Since.Equal(since) && opts.Page == 0
+	})).Return([]*github.Gist{
+		{
+			ID:          github.String("g1"),
+			Description: github.String("Deployment notes"),
+			Public:      github.Bool(true),
+			Owner:       &github.User{Login: github.String("octocat")},
+			HTMLURL:     github.String("https://gist.github.com/octocat/g1"),
+			UpdatedAt:   &github.Timestamp{Time: since.Add(time.Hour)},
+			Files:       gistFiles(map[string]string{"deploy.sh": "", "README.md": "", "notes.md": ""}),
+		},
+	}, &github.Response{NextPage: 2}, nil).Once()
+	client.On("ListGists", mock.Anything, "octocat", mock.MatchedBy(func(opts *github.GistListOptions) bool {
+		return opts.Page == 2
+	})).Return([]*github.Gist{
+		{ID: github.String("g2"), Files: gistFiles(map[string]string{"main.go": ""})},
+	}, nil, nil).Once()
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{})
+
+	gists, err := gh.ListGists(context.Background(), "octocat", GistFilter{FileTypes: []string{".md"}, Since: since})
+	require.NoError(t, err)
+	require.Len(t, gists, 1)
+	assert.Equal(t, Gist{
+		ID:          "g1",
+		Owner:       "octocat",
+		Description: "Deployment notes",
+		Public:      true,
+		URL:         "https://gist.github.com/octocat/g1",
+		UpdatedAt:   since.Add(time.Hour),
+		Files: []GistFile{
+			{Name: "README.md", RawURL: "https://gist.githubusercontent.com/raw/README.md"},
+			{Name: "notes.md", RawURL: "https://gist.githubusercontent.com/raw/notes.md"},
+		},
+	}, gists[0])
+	client.AssertExpectations(t)
+
+	client.On("ListGists", mock.Anything, "", mock.Anything).Return(nil, nil, errors.New("unauthorized")).Once()
+	_, err = gh.ListGists(context.Background(), "", GistFilter{})
+	assert.EqualError(t, err, "failed to list the gists of the authenticated user: unauthorized")
+}
+
+func TestGitHub_ListOrganizationGists(t *testing.T) {
+	client := new(GistOpsClientMock)
+	client.On("ListOrganizationMembers", mock.Anything, "testowner", mock.Anything).Return([]*github.User{
+		{Login: github.String("alice")},
+		{Login: github.String("bob")},
+	}, nil, nil).Once()
+	client.On("ListGists", mock.Anything, "alice", mock.Anything).Return([]*github.Gist{
+		{ID: github.String("a1"), Files: gistFiles(map[string]string{"a.md": ""})},
+	}, nil, nil).Once()
+	client.On("ListGists", mock.Anything, "bob", mock.Anything).Return([]*github.Gist{
+		{ID: github.String("b1"), Files: gistFiles(map[string]string{"b.md": ""})},
+	}, nil, nil).Once()
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{})
+
+	gists, err := gh.ListOrganizationGists(context.Background(), "testowner", GistFilter{})
+	require.NoError(t, err)
+	require.Len(t, gists, 2)
+	assert.Equal(t, "a1", gists[0].ID)
+	assert.Equal(t, "b1", gists[1].ID)
+	client.AssertExpectations(t)
+}
+
+func TestGitHub_GetGistDocuments(t *testing.T) {
+	created := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	client := new(GistOpsClientMock)
+	client.On("GetGist", mock.Anything, "g1").Return(&github.Gist{
+		ID:          github.String("g1"),
+		Description: github.String("Deployment notes"),
+		Public:      github.Bool(false),
+		Owner:       &github.User{Login: github.String("octocat")},
+		HTMLURL:     github.String("https://gist.github.com/octocat/g1"),
+		CreatedAt:   &github.Timestamp{Time: created},
+		Files:       gistFiles(map[string]string{"notes.md": "# Notes", "deploy.sh": "make deploy"}),
+	}, nil, nil).Once()
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{})
+
+	docs, err := gh.GetGistDocuments(context.Background(), []Gist{
+		{ID: "g1", Files: []GistFile{{Name: "notes.md"}, {Name: "removed.md"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []document.Document{{
+		ID:        "gists/g1/notes.md",
+		Kind:      document.DocumentKindGistFile,
+		Owner:     "octocat",
+		Path:      "notes.md",
+		Title:     "Deployment notes",
+		Body:      "# Notes",
+		URL:       "https://gist.github.com/octocat/g1",
+		Author:    "octocat",
+		CreatedAt: created,
+		Metadata:  map[string]string{"gist": "g1", "language": "", "public": "false"},
+	}}, docs)
+	client.AssertExpectations(t)
+}
+
+func TestGitHub_GetGist_UnsupportedClient(t *testing.T) {
+	gh := NewGitHubClient(new(CommitOpsClientMock), new(GraphQLClientMock), GitHubConfig{})
+
+	_, err := gh.GetGist(context.Background(), "g1", GistFilter{})
+	assert.ErrorIs(t, err, ErrUnsupportedClient)
+}
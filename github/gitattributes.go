@@ -0,0 +1,24 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+)
+
+// getGitAttributes fetches and parses the .gitattributes file at the root of the repository.
+// Repositories without a .gitattributes file yield empty attributes.
+func (c *GitHub) getGitAttributes(ctx context.Context, repo string) (filter.GitAttributes, error) {
+	branch, err := c.branch(ctx, repo)
+	if err != nil {
+		return filter.GitAttributes{}, err
+	}
+
+	text, err := c.getBlobText(ctx, c.ownerOf(repo), c.nameOf(repo), fmt.Sprintf("%s:.gitattributes", branch))
+	if err != nil {
+		return filter.GitAttributes{}, err
+	}
+
+	return filter.ParseGitAttributes(text), nil
+}
@@ -0,0 +1,40 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGitHub_GetFilePathsFromRepositories_ExcludeGeneratedAndVendored(t *testing.T) {
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		switch query := args.Get(1).(type) {
+		case *GHQueryForListFiles:
+			query.Repository.Object.Tree.Entries = []GHTreeEntry{
+				{Name: "main.go", Path: "main.go", Type: "blob"},
+				{Name: "main.pb.go", Path: "main.pb.go", Type: "blob"},
+				{Name: "lib.go", Path: "lib.go", Type: "blob"},
+			}
+		case *GHQueryForBlobText:
+			query.Repository.Object.Blob.Text = "*.pb.go linguist-generated\nlib.go linguist-vendored\n"
+		}
+	}).Return(nil)
+
+	client := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter: filter.GitHubFilter{
+			FileTypes:        []string{".go"},
+			ExcludeGenerated: true,
+			ExcludeVendored:  true,
+		},
+	})
+
+	files, err := client.GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"main.go"}, files)
+}
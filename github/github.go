@@ -0,0 +1,762 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shaharia-lab/coco-gh/v2/internal/gitutil"
+	"github.com/shurcooL/githubv4"
+	"golang.org/x/sync/errgroup"
+)
+
+// GHQueryForListFiles is a struct representing the GraphQL query for listing files in a GitHub repository.
+// It contains the information necessary to make the query, including the owner, name, expression, and path of the repository.
+type GHQueryForListFiles struct {
+	Repository struct {
+		Object struct {
+			Tree struct {
+				Entries []GHTreeEntry
+			} `graphql:"... on Tree"`
+		} `graphql:"object(expression: $expression)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// GHQueryForBlobText is a struct representing the GraphQL query for reading the text of a single file in a
+// GitHub repository. The expression has the form "<ref>:<path>".
+type GHQueryForBlobText struct {
+	Repository struct {
+		Object struct {
+			Blob GHBlob `graphql:"... on Blob"`
+		} `graphql:"object(expression: $expression)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// GHBlob is a git blob as returned by the GitHub GraphQL API. Text is empty for binary blobs.
+type GHBlob struct {
+	Text     string
+	IsBinary bool
+	ByteSize int
+}
+
+// GHTreeEntry is a single entry of a git tree as returned by the GitHub GraphQL API.
+type GHTreeEntry struct {
+	Name   string
+	Path   string
+	Type   string
+	Mode   int
+	Oid    string
+	Size   int
+	Object GHTreeEntryObject
+}
+
+// GHTreeEntryObject is the git object a tree entry points to, of which only whether a blob is binary is
+// queried.
+type GHTreeEntryObject struct {
+	Blob struct {
+		IsBinary bool
+	} `graphql:"... on Blob"`
+}
+
+// GitHubConfig represents the configuration for GitHub repositories.
+//
+// Owner represents the owner of the repositories.
+// Repositories represents a list of repository names.
+// RepositoryRefs represents repositories of other owners, collected together with Repositories.
+// RepositoryFilter represents the filter selecting which of the configured repositories are crawled and which
+// repositories DiscoverRepositories returns.
+// DefaultBranch represents the default branch for the repositories.
+// Branches represents the branches of individual repositories, keyed by repository name or full name, overriding
+// DefaultBranch.
+// DetectDefaultBranch represents whether the default branch of repositories without a configured branch is looked
+// up through the API instead of using DefaultBranch.
+// Filter represents the filter to apply when fetching file paths from the repositories.
+// PathNormalization represents the Unicode normalization form applied to all returned paths.
+// Clock represents the source of the current time for methods working relative to now; nil uses the wall clock.
+// MaxConcurrency represents the maximum number of API calls made in parallel when collecting file paths; zero or
+// one fetches repositories and sub-trees sequentially.
+// BaseURL represents the base URL of the REST API of a GitHub Enterprise Server, e.g. "https://github.example.com/";
+// empty uses github.com.
+// UploadURL represents the upload URL of a GitHub Enterprise Server; empty uses BaseURL.
+// GraphQLEndpoint represents the GraphQL endpoint of a GitHub Enterprise Server; empty derives it from BaseURL.
+// KeepChangeEvents represents whether Paths collected from the commit history keep the per-commit changes in Events.
+// Logger represents the receiver of debug logs about crawled repositories; nil disables logging.
+// Metrics represents the receiver of measurements about crawled repositories and collected files; nil disables them.
+// Tracer represents the starter of spans for repository crawls, GraphQL queries and commit fetches; nil disables tracing.
+// WikiFetcher represents how the wikis of repositories are fetched; nil clones them with git, see GitWikiFetcher.
+// Fetcher represents an alternative to the API for listing the files and commit history of repositories, see
+// Fetcher; nil uses the API.
+// FrontMatter represents whether the front matter of Markdown files read with their content, from archives and
+// wikis, is parsed into File.Metadata.
+// ContinueOnError represents whether methods crawling all repositories keep going when a repository fails and
+// return the results of the others together with a *PartialError.
+// TreeBatchSize represents the maximum number of trees listed per GraphQL query when trees are walked through
+// GraphQL, across directories and repositories; zero or one lists one tree per query.
+// RepositoryPaths represents the directories crawled in individual repositories, keyed by repository name or full
+// name, overriding Filter.Paths.
+// FollowSubmodules represents whether GetSubmodules also lists the submodules of submodules hosted on GitHub.
+// ResolveSymlinks represents whether GetFileContents reads the content of the files symlinks point at instead
+// of the link targets.
+// CommitFilter represents the filter selecting the commits whose changes are detected from the commit history.
+// MergeStrategy represents how merge commits take part in detecting changes from the commit history.
+type GitHubConfig struct {
+	Owner               string
+	Repositories        []string
+	RepositoryRefs      []RepositoryRef
+	RepositoryFilter    filter.RepositoryFilter
+	DefaultBranch       string
+	Branches            map[string]string
+	DetectDefaultBranch bool
+	Filter              filter.GitHubFilter
+	PathNormalization   document.PathNormalization
+	Clock               Clock
+	MaxConcurrency      int
+	BaseURL             string
+	UploadURL           string
+	GraphQLEndpoint     string
+	KeepChangeEvents    bool
+	Logger              Logger
+	Metrics             Metrics
+	Tracer              Tracer
+	WikiFetcher         WikiFetcher
+	Fetcher             Fetcher
+	FrontMatter         bool
+	ContinueOnError     bool
+	TreeBatchSize       int
+	RepositoryPaths     map[string][]filter.PathFilter
+	FollowSubmodules    bool
+	ResolveSymlinks     bool
+	CommitFilter        filter.CommitFilter
+	MergeStrategy       MergeStrategy
+}
+
+// GitHub stores CommitOpsClient, GraphQLClient and configuration.
+type GitHub struct {
+	Configuration GitHubConfig
+
+	graphQLClient   GraphQLClient
+	commitOpsClient CommitOpsClient
+
+	slotsOnce sync.Once
+	slots     chan struct{}
+
+	branchesMu       sync.Mutex
+	detectedBranches map[string]string
+
+	matchedMu           sync.Mutex
+	matchedRepositories map[string]bool
+}
+
+// GraphQLClient is an interface to help test the GitHub GraphQLClient.
+type GraphQLClient interface {
+	Query(ctx context.Context, q interface{}, variables map[string]interface{}) error
+}
+
+// CommitOpsClient is an interface to help test the GitHub GitHubCommitsOpsClient.
+type CommitOpsClient interface {
+	ListCommits(ctx context.Context, owner, repo string, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error)
+	GetCommit(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) (*github.RepositoryCommit, *github.Response, error)
+}
+
+// ErrUnsupportedClient is returned when the CommitOpsClient the GitHub client was created with does not
+// implement the operations a method needs, e.g. a minimal test double used with a pull request method.
+var ErrUnsupportedClient = errors.New("commit ops client does not support the operation")
+
+// GitHubCommitsOpsClient is a type that represents an operations client for GitHub commits.
+// It contains a pointer to a GitHub client from the go-github library.
+type GitHubCommitsOpsClient struct {
+	GitHubClient *github.Client
+}
+
+// NewGitHubCommitsOpsClient creates a new GitHubCommitsOpsClient with the given http.Client.
+// It initializes the GitHubClient inside GitHubCommitsOpsClient using the provided http.Client.
+// The GitHubClient is responsible for interacting with the GitHub API.
+func NewGitHubCommitsOpsClient(httpClient *http.Client) *GitHubCommitsOpsClient {
+	gc := github.NewClient(httpClient)
+	return &GitHubCommitsOpsClient{GitHubClient: gc}
+}
+
+// ListCommits fetches the list of commits for a specific repository.
+func (gClient *GitHubCommitsOpsClient) ListCommits(ctx context.Context, owner, repo string, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+	return gClient.GitHubClient.Repositories.ListCommits(ctx, owner, repo, opts)
+}
+
+// GetCommit retrieves a specific commit from a repository.
+//
+// Parameters:
+//   - ctx: The context.Context used for the API call. It allows you to cancel
+//     the request, set deadlines, etc.
+//   - owner: The username or organization name of the repository owner. This
+//     string identifies the owner of the repository.
+//   - repo: The name of the repository. It specifies which repository's commit
+//     is being retrieved.
+//   - sha: The SHA hash of the commit. This string uniquely identifies the commit
+//     within the repository.
+//   - opts: Optional parameters for the API call, provided as a pointer to
+//     github.ListOptions. This includes pagination options.
+//
+// Returns:
+//   - *github.RepositoryCommit: The retrieved commit information, including details
+//     like the commit message, author, etc.
+//   - *github.Response: The HTTP response from the API call. This includes
+//     information like the status code and headers.
+//   - error: An error instance if an error occurs during the API call. It will be
+//     nil if the call is successful.
+//
+// Example:
+//
+//	commit, resp, err := gClient.GetCommit(ctx, "octocat", "hello-world", "6dcb09b5b57875f334f61aebed695e2e4193db5e", nil)
+func (gClient *GitHubCommitsOpsClient) GetCommit(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) (*github.RepositoryCommit, *github.Response, error) {
+	return gClient.GitHubClient.Repositories.GetCommit(ctx, owner, repo, sha, opts)
+}
+
+// NewGitHubClient creates a new instance of the GitHub client.
+// It takes a CommitOpsClient, GraphQLClient, and GitHubConfig as parameters and returns a pointer to a GitHub struct.
+// The CommitOpsClient is responsible for making REST API calls to the GitHub API.
+// The GraphQLClient is responsible for making GraphQL API calls to the GitHub API.
+// The GitHubConfig contains the configuration parameters for the GitHub client, such as owner, repositories, default branch,
+// and filter options.
+// The new GitHub client is initialized with the provided CommitOpsClient, GraphQLClient, and GitHubConfig.
+// The GitHub client can be used to interact with the GitHub API and perform various operations, such as retrieving file paths for repositories
+// and getting changed file paths since a specified time.
+// Usage:
+//
+//	 commitOpsClient := NewGitHubCommitsOpsClient()
+//	 graphQLClient := NewGraphQLClient()
+//
+//		config := GitHubConfig{
+//		    Owner:         "testowner",
+//		    Repositories:  []string{"repo1", "repo2"},
+//		    DefaultBranch: "main",
+//		    Filter: filter.GitHubFilter{
+//		        FilePath:  "path/to/files",
+//		        FileTypes: []string{".txt"},
+//		    },
+//		}
+//
+//	 githubClient := NewGitHubClient(commitOpsClient, graphQLClient, config)
+//	 filepaths, err := githubClient.GetFilePathsFromRepositories()
+//
+//		if err != nil {
+//		    log.Fatal(err)
+//		}
+//
+//		for _, path := range filepaths {
+//		    fmt.Println(path)
+//		}
+func NewGitHubClient(commitOpsClient CommitOpsClient, graphQLClient GraphQLClient, configuration GitHubConfig) *GitHub {
+	return &GitHub{
+		commitOpsClient: commitOpsClient,
+		graphQLClient:   graphQLClient,
+		Configuration:   configuration,
+	}
+}
+
+// GetFilePathsFromRepositories retrieves the file paths for the repositories specified in the GitHub configuration.
+// It iterates over each repository, calls the getFilteredFileEntries method to get the files passing the configured
+// filter, and appends their paths to the files slice.
+// If there's an error during the process, it returns nil and the error.
+//
+// Usage:
+//
+//	repos := []string{"repo1", "repo2", "repo3"}
+//	filePaths, err := GetFilePathsFromRepositories(repos)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, paths := range filePaths {
+//	    for _, path := range paths {
+//	        fmt.Println(path)
+//	    }
+//	}
+func (c *GitHub) GetFilePathsFromRepositories() ([]string, error) {
+	return c.GetFilePathsFromRepositoriesWithContext(context.Background())
+}
+
+// GetFilePathsFromRepositoriesWithContext is GetFilePathsFromRepositories with a context, which is passed to
+// every API call so long crawls can be cancelled or bounded by a deadline.
+func (c *GitHub) GetFilePathsFromRepositoriesWithContext(ctx context.Context) ([]string, error) {
+	ctx = c.prefetchFileEntries(ctx)
+	repoFiles := make([][]string, len(c.repositories()))
+	err := c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		entries, err := c.getFilteredFileEntries(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			repoFiles[i] = append(repoFiles[i], entry.Path)
+		}
+		return nil
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	var files []string
+	for _, paths := range repoFiles {
+		files = append(files, paths...)
+	}
+
+	return files, err
+}
+
+// GetChangedFilePathsSince retrieves the file paths changed by the commits made in the configured repositories
+// since the given time, classified into added, removed and modified paths. Only the files passing the
+// configured filter are returned, the paths of all repositories are aggregated into a single Paths object.
+//
+// since is an absolute point in time and is passed to GitHub unchanged. Commits whose date equals since are
+// included, the zero time selects the whole history. To look back a relative window, such as the last 24
+// hours, use GetChangedFilePathsWithin, which computes the start time from the configured Clock.
+//
+// Usage:
+//
+//	changedFiles, err := c.GetChangedFilePathsSince(time.Now().Add(-24 * time.Hour))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	fmt.Println("Added files:", changedFiles.Added)
+//	fmt.Println("Modified files:", changedFiles.Modified)
+//	fmt.Println("Removed files:", changedFiles.Removed)
+func (c *GitHub) GetChangedFilePathsSince(since time.Time) (document.Paths, error) {
+	return c.GetChangedFilePathsSinceWithContext(context.Background(), since)
+}
+
+// GetChangedFilePathsSinceWithContext is GetChangedFilePathsSince with a context, which is passed to every
+// API call so long crawls can be cancelled or bounded by a deadline.
+func (c *GitHub) GetChangedFilePathsSinceWithContext(ctx context.Context, since time.Time) (document.Paths, error) {
+	repoPaths := make([]document.Paths, len(c.repositories()))
+	err := c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		opt := &github.CommitsListOptions{
+			Since: since,
+			Path:  c.historyPath(repo),
+			ListOptions: github.ListOptions{
+				PerPage: 100,
+			},
+		}
+
+		paths, _, err := c.getChangedFilePathsForRepo(ctx, repo, opt, "")
+		if err != nil {
+			return err
+		}
+		repoPaths[i] = paths
+		return nil
+	})
+	if !partialResult(err) {
+		return document.Paths{}, err
+	}
+
+	var paths document.Paths
+	for _, commitPaths := range repoPaths {
+		paths.Added = append(paths.Added, commitPaths.Added...)
+		paths.Removed = append(paths.Removed, commitPaths.Removed...)
+		paths.Modified = append(paths.Modified, commitPaths.Modified...)
+		paths.Renamed = append(paths.Renamed, commitPaths.Renamed...)
+		paths.Events = append(paths.Events, commitPaths.Events...)
+	}
+
+	return c.normalizeChangedPaths(paths), err
+}
+
+// GetChangeSetSince is GetChangedFilePathsSinceWithContext keeping the provenance of the changes: every
+// changed file comes with the SHA, author, date and message of the last commit changing it.
+//
+// Usage:
+//
+//	changes, err := c.GetChangeSetSince(ctx, time.Now().Add(-24*time.Hour))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, change := range changes.Modified {
+//	    fmt.Println(change.Path, change.CommitSHA, change.Author, change.Message)
+//	}
+func (c *GitHub) GetChangeSetSince(ctx context.Context, since time.Time) (document.ChangeSet, error) {
+	repoChanges := make([]document.ChangeSet, len(c.repositories()))
+	err := c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		opt := &github.CommitsListOptions{
+			Since:       since,
+			Path:        c.historyPath(repo),
+			ListOptions: github.ListOptions{PerPage: 100},
+		}
+
+		events, _, err := c.listChangeEvents(ctx, repo, opt, "")
+		if err != nil {
+			return err
+		}
+		repoChanges[i] = document.NewChangeSet(events)
+		return nil
+	})
+	if !partialResult(err) {
+		return document.ChangeSet{}, err
+	}
+
+	var changes document.ChangeSet
+	for _, repoChange := range repoChanges {
+		changes.Added = append(changes.Added, repoChange.Added...)
+		changes.Removed = append(changes.Removed, repoChange.Removed...)
+		changes.Modified = append(changes.Modified, repoChange.Modified...)
+	}
+
+	for _, list := range [][]document.FileChange{changes.Added, changes.Removed, changes.Modified} {
+		for i := range list {
+			list[i].Path = c.normalizePath(list[i].Path)
+		}
+	}
+	return changes, err
+}
+
+// getFileEntriesForRepo fetches the list of file entries for a specific repository, starting from the specified
+// expression. It uses the GitHub GraphQL API to retrieve the repository tree entries and their types, and
+// recursively traverses the repository tree. The function appends file entries to a slice, which is then returned.
+// If an entry is a blob, it is added to the files slice. For tree entries, the function recurses with
+// the updated expression and appends the returned subfiles to the files slice. If any error occurs during
+// the GraphQL query or traversal, the function returns nil and the error.
+//
+// Parameters:
+//   - ctx: The context.Context used for the GraphQL queries.
+//   - owner: A string representing the username of the repository owner. This parameter specifies the owner
+//     of the repository for which file paths are being fetched.
+//   - name: A string representing the name of the repository. This parameter is used to specify the repository
+//     from which the file paths are retrieved.
+//   - expression: A string specifying the starting expression for traversing the repository tree. This
+//     expression determines the starting point of the file path retrieval process.
+//
+// Returns:
+//   - files: A slice of tree entries, each representing a file in the repository. This slice includes
+//     all files found in the repository starting from the given expression.
+//   - error: An error instance, if any error occurred during the GraphQL query or traversal. It will be nil
+//     if the function executes successfully.
+//
+// Example usage:
+//
+//	entries, err := c.getFileEntriesForRepo(ctx, "octocat", "hello-world", "master:")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, entry := range entries {
+//	    fmt.Println(entry.Path)
+//	}
+func (c *GitHub) getFileEntriesForRepo(ctx context.Context, owner, name, expression string) ([]GHTreeEntry, error) {
+	root := treeRef{owner: owner, name: name, expression: expression}
+	if files, ok := prefetchedFileEntries(ctx, root); ok {
+		return files, nil
+	}
+	if c.Configuration.TreeBatchSize > 1 {
+		files, err := c.walkTrees(ctx, []treeRef{root})
+		if err != nil {
+			return nil, err
+		}
+		return files[0], nil
+	}
+
+	entries, err := c.listTreeEntries(ctx, owner, name, expression)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.concurrent() {
+		return c.getFileEntriesConcurrently(ctx, owner, name, expression, entries)
+	}
+
+	var files []GHTreeEntry
+	for _, entry := range entries {
+		if entry.Type == "blob" {
+			files = append(files, entry)
+		} else if entry.Type == "tree" {
+			subFiles, err := c.getFileEntriesForRepo(ctx, owner, name, expression+"/"+entry.Name)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, subFiles...)
+		}
+	}
+
+	return files, nil
+}
+
+// getFileEntriesConcurrently is the concurrent traversal of getFileEntriesForRepo: the sub-trees of the
+// entries are fetched in parallel and their files merged in the order of the entries.
+func (c *GitHub) getFileEntriesConcurrently(ctx context.Context, owner, name, expression string, entries []GHTreeEntry) ([]GHTreeEntry, error) {
+	subFiles := make([][]GHTreeEntry, len(entries))
+	group, ctx := errgroup.WithContext(ctx)
+	for i, entry := range entries {
+		i, entry := i, entry
+		switch entry.Type {
+		case "blob":
+			subFiles[i] = []GHTreeEntry{entry}
+		case "tree":
+			group.Go(func() error {
+				var err error
+				subFiles[i], err = c.getFileEntriesForRepo(ctx, owner, name, expression+"/"+entry.Name)
+				return err
+			})
+		}
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	var files []GHTreeEntry
+	for _, entries := range subFiles {
+		files = append(files, entries...)
+	}
+
+	return files, nil
+}
+
+// getFilteredFileEntries fetches the blob entries of a configured repository that pass the configured
+// filter, with their names and paths normalized.
+func (c *GitHub) getFilteredFileEntries(ctx context.Context, repo string) ([]GHTreeEntry, error) {
+	entries, err := c.filterFileEntries(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		entries[i].Name = c.normalizePath(entries[i].Name)
+		entries[i].Path = c.normalizePath(entries[i].Path)
+	}
+
+	return entries, nil
+}
+
+// filterFileEntries fetches the blob entries of a configured repository that pass the configured filter,
+// with their names and paths as stored in the repository.
+func (c *GitHub) filterFileEntries(ctx context.Context, repo string) ([]GHTreeEntry, error) {
+	branch, err := c.branch(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := c.getFileRules(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []GHTreeEntry
+	listed := make(map[string]bool)
+	for _, root := range c.rootPaths(repo) {
+		expression := fmt.Sprintf("%s:%s", branch, root)
+		entries, err := c.listFileEntries(ctx, c.ownerOf(repo), c.nameOf(repo), expression)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if listed[entry.Path] || !c.includeEntry(entry, rules) {
+				continue
+			}
+			listed[entry.Path] = true
+			files = append(files, entry)
+		}
+	}
+
+	c.metrics().FilesCollected(repo, len(files))
+	return files, nil
+}
+
+// listTreeEntries queries the direct entries of the git tree identified by expression.
+func (c *GitHub) listTreeEntries(ctx context.Context, owner, name, expression string) ([]GHTreeEntry, error) {
+	var query GHQueryForListFiles
+	variables := map[string]interface{}{
+		"owner":      githubv4.String(owner),
+		"name":       githubv4.String(name),
+		"expression": githubv4.String(expression),
+	}
+
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	err = c.query(ctx, &query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	return query.Repository.Object.Tree.Entries, nil
+}
+
+// getBlobText reads the text of the file identified by expression. A missing file yields an empty string.
+func (c *GitHub) getBlobText(ctx context.Context, owner, name, expression string) (string, error) {
+	blob, err := c.getBlob(ctx, owner, name, expression)
+	if err != nil {
+		return "", err
+	}
+
+	return blob.Text, nil
+}
+
+// getChangedFilePathsForRepo fetches the paths of files that have been changed in a specific repository.
+// It takes the repository name, a CommitsListOptions object for filtering commits, and returns a Paths struct with added, removed, and modified files.
+// The method iterates through all pages of commits in the repository, retrieves the files of each commit, and checks each file against the filter path.
+// The changes of all commits are reconciled into the net change of every file, see reconcileChanges, and kept in Events if configured.
+// If until is set, the commits from the commit with that SHA on are skipped. Besides the paths, the method returns the SHA of the
+// newest commit listed, empty if there was none, and an error, if any.
+func (c *GitHub) getChangedFilePathsForRepo(ctx context.Context, repo string, opt *github.CommitsListOptions, until string) (document.Paths, string, error) {
+	events, head, err := c.listChangeEvents(ctx, repo, opt, until)
+	if err != nil {
+		return document.Paths{}, head, err
+	}
+
+	paths := document.ReconcileChanges(events, nil)
+	if c.Configuration.KeepChangeEvents {
+		paths.Events = chronological(events)
+	}
+	return paths, head, nil
+}
+
+// listChangeEvents lists the changes of the files passing the filter by the commits matching opt, the commit
+// filter and the merge strategy, newest first. It stops at the commit with the SHA until, if set, and also returns the SHA of the newest commit listed.
+func (c *GitHub) listChangeEvents(ctx context.Context, repo string, opt *github.CommitsListOptions, until string) ([]document.ChangeEvent, string, error) {
+	var events []document.ChangeEvent
+	var head string
+
+	branch, err := c.branch(ctx, repo)
+	if err != nil {
+		return events, head, err
+	}
+	opt.SHA = branch
+	if c.Configuration.MergeStrategy == MergeStrategyFirstParent {
+		opt.Path = ""
+	}
+
+	rules, err := c.getFileRules(ctx, repo)
+	if err != nil {
+		return events, head, err
+	}
+
+	merges := &mergeFilter{strategy: c.Configuration.MergeStrategy}
+	if c.Configuration.Fetcher != nil {
+		return c.fetchChangeEvents(ctx, repo, opt, until, rules, merges)
+	}
+
+	for {
+		release, err := c.acquire(ctx)
+		if err != nil {
+			return events, head, err
+		}
+		commits, resp, err := c.commitOpsClient.ListCommits(ctx, c.ownerOf(repo), c.nameOf(repo), opt)
+		release()
+		if err != nil {
+			return events, head, c.classifyError(err)
+		}
+
+		for _, commit := range commits {
+			if until != "" && commit.GetSHA() == until {
+				return events, head, nil
+			}
+			if head == "" {
+				head = commit.GetSHA()
+			}
+			if !merges.keep(commit) || !c.Configuration.CommitFilter.Match(commit) {
+				continue
+			}
+
+			files, err := c.getCommitFiles(ctx, repo, commit.GetSHA())
+			if err != nil {
+				return events, head, err
+			}
+			events = c.appendChangeEvents(events, repo, commit, files, rules)
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			return events, head, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+// appendChangeEvents appends the changes of the files of a commit that are inside the configured file path or
+// a crawled directory and pass the filter like listed files, see includeChangedFile. A file renamed into or
+// out of them is appended as added or removed.
+func (c *GitHub) appendChangeEvents(events []document.ChangeEvent, repo string, commit *github.RepositoryCommit, files []*github.CommitFile, rules fileRules) []document.ChangeEvent {
+	included := func(fileName string) bool {
+		return c.includeChangedFile(fileName, rules)
+	}
+
+	for _, file := range files {
+		if event, ok := gitutil.ChangeEvent(repo, commit, file).Filter(included); ok {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// getCommitFiles fetches the files changed by a commit. GitHub pages the files of large commits, all pages are
+// fetched.
+func (c *GitHub) getCommitFiles(ctx context.Context, repo, sha string) (files []*github.CommitFile, err error) {
+	ctx, end := c.startSpan(ctx, SpanFetchCommit, c.repositoryAttributes(repo, SpanAttribute{Key: AttributeCommit, Value: sha})...)
+	defer func() { end(err) }()
+
+	opts := &github.ListOptions{PerPage: 300}
+
+	for {
+		release, err := c.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		commit, resp, err := c.commitOpsClient.GetCommit(ctx, c.ownerOf(repo), c.nameOf(repo), sha, opts)
+		release()
+		if err != nil {
+			return nil, c.classifyError(err)
+		}
+		files = append(files, commit.Files...)
+
+		if resp == nil || resp.NextPage == 0 {
+			return files, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// addCommitFile appends the changed file to the list of p matching its change status if it passes include. A
+// file renamed into or out of the included paths is only added or removed.
+func addCommitFile(p *document.Paths, file *github.CommitFile, include func(filePath string) bool) {
+	name := file.GetFilename()
+	switch file.GetStatus() {
+	case "removed":
+		if include(name) {
+			p.Removed = append(p.Removed, name)
+		}
+	case "added", "copied":
+		if include(name) {
+			p.Added = append(p.Added, name)
+		}
+	case "modified", "changed":
+		if include(name) {
+			p.Modified = append(p.Modified, name)
+		}
+	case "renamed":
+		previous := file.GetPreviousFilename()
+		from, to := include(previous), include(name)
+		if from {
+			p.Removed = append(p.Removed, previous)
+		}
+		if to {
+			p.Added = append(p.Added, name)
+		}
+		if from && to {
+			p.Renamed = append(p.Renamed, document.Rename{From: previous, To: name})
+		}
+	}
+}
+
+// chronological returns the events, ordered newest first, in the order they happened.
+func chronological(events []document.ChangeEvent) []document.ChangeEvent {
+	ordered := make([]document.ChangeEvent, len(events))
+	for i, event := range events {
+		ordered[len(events)-1-i] = event
+	}
+	return ordered
+}
@@ -0,0 +1,643 @@
+package github
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// GraphQLClient is an autogenerated mock type for the GraphQLClient type
+type GraphQLClientMock struct {
+	mock.Mock
+}
+
+// Query provides a mock function with given fields: ctx, q, variables
+func (_m *GraphQLClientMock) Query(ctx context.Context, q interface{}, variables map[string]interface{}) error {
+	ret := _m.Called(ctx, q, variables)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, map[string]interface{}) error); ok {
+		r0 = rf(ctx, q, variables)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GraphQLClient is an autogenerated mock type for the GraphQLClient type
+type CommitOpsClientMock struct {
+	mock.Mock
+}
+
+// GetCommit provides a mock function with given fields: ctx, owner, repo, sha, opts
+func (_m *CommitOpsClientMock) GetCommit(ctx context.Context, owner string, repo string, sha string, opts *github.ListOptions) (*github.RepositoryCommit, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, sha, opts)
+
+	var r0 *github.RepositoryCommit
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *github.ListOptions) (*github.RepositoryCommit, *github.Response, error)); ok {
+		return rf(ctx, owner, repo, sha, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *github.ListOptions) *github.RepositoryCommit); ok {
+		r0 = rf(ctx, owner, repo, sha, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.RepositoryCommit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *github.ListOptions) *github.Response); ok {
+		r1 = rf(ctx, owner, repo, sha, opts)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string, *github.ListOptions) error); ok {
+		r2 = rf(ctx, owner, repo, sha, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListCommits provides a mock function with given fields: ctx, owner, repo, opts
+func (_m *CommitOpsClientMock) ListCommits(ctx context.Context, owner string, repo string, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, opts)
+
+	var r0 []*github.RepositoryCommit
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error)); ok {
+		return rf(ctx, owner, repo, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *github.CommitsListOptions) []*github.RepositoryCommit); ok {
+		r0 = rf(ctx, owner, repo, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*github.RepositoryCommit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *github.CommitsListOptions) *github.Response); ok {
+		r1 = rf(ctx, owner, repo, opts)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, *github.CommitsListOptions) error); ok {
+		r2 = rf(ctx, owner, repo, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+func TestGitHubClient_GetFilePathsFromRepositories(t *testing.T) {
+	type entry = GHTreeEntry
+
+	tests := []struct {
+		name          string
+		config        GitHubConfig
+		entries       []entry
+		expectedFiles []string
+	}{
+		// Here we specify different test cases. For example:
+		{
+			name: "test 1",
+			config: GitHubConfig{
+				Owner:         "testowner1",
+				Repositories:  []string{"repo1", "repo2"},
+				DefaultBranch: "main1",
+				Filter: filter.GitHubFilter{
+					FilePath:  "path/to/files",
+					FileTypes: []string{".txt"},
+				},
+			},
+			entries: []entry{
+				{Name: "File1", Path: "path/to/files/file1.txt", Type: "blob"},
+				{Name: "File2", Path: "path/to/files/file2.txt", Type: "blob"},
+			},
+			expectedFiles: []string{
+				"path/to/files/file1.txt",
+				"path/to/files/file2.txt",
+				"path/to/files/file1.txt",
+				"path/to/files/file2.txt",
+			},
+		},
+		// ...more test cases...
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Initialize a mock GitHub client
+			graphQLClient := new(GraphQLClientMock)
+			commitOpsClient := new(CommitOpsClientMock)
+
+			// Create a GitHub client with the mock client and configuration
+			client := NewGitHubClient(commitOpsClient, graphQLClient, tt.config)
+
+			// Set up the mock to return the query object and populate Entries
+			graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				arg := args.Get(1).(*GHQueryForListFiles)
+
+				// Populate Entries here as needed
+				for _, entry := range tt.entries {
+					arg.Repository.Object.Tree.Entries = append(arg.Repository.Object.Tree.Entries, entry)
+				}
+			}).Return(nil)
+
+			// Call the method to get file paths for repositories
+			files, err := client.GetFilePathsFromRepositories()
+
+			// Verify the results
+			if err != nil {
+				t.Errorf("Error occurred: %v", err)
+			}
+
+			for _, expectedFile := range tt.expectedFiles {
+				if !contains(files, expectedFile) {
+					t.Errorf("Expected file not found: %s", expectedFile)
+				}
+			}
+		})
+	}
+}
+
+func TestGitHubClient_GetChangedFilePathsSince(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          GitHubConfig
+		detectedChanges []*github.CommitFile
+		want            document.Paths
+	}{
+		{
+			name: "Check for changed file paths",
+			config: GitHubConfig{
+				Owner:         "testowner",
+				Repositories:  []string{"repo1", "repo2"},
+				DefaultBranch: "main",
+				Filter: filter.GitHubFilter{
+					FilePath:  "path/to/files",
+					FileTypes: []string{".txt"},
+				},
+			},
+			detectedChanges: []*github.CommitFile{
+				{
+					Filename: github.String("path/to/files/file1.txt"),
+					Status:   github.String("added"),
+				},
+				{
+					Filename: github.String("path/to/files/file2.txt"),
+					Status:   github.String("modified"),
+				},
+			},
+			want: document.Paths{
+				Added:    []string{"path/to/files/file1.txt", "path/to/files/file1.txt"},
+				Removed:  []string{},
+				Modified: []string{"path/to/files/file2.txt", "path/to/files/file2.txt"},
+			},
+		},
+		{
+			name: "change detected some files with different statuses",
+			config: GitHubConfig{
+				Owner:         "testowner",
+				Repositories:  []string{"repo1"},
+				DefaultBranch: "main",
+				Filter: filter.GitHubFilter{
+					FilePath:  "path/to/files",
+					FileTypes: []string{".txt"},
+				},
+			},
+			detectedChanges: []*github.CommitFile{
+				{
+					Filename: github.String("path/to/files/file_removed.txt"),
+					Status:   github.String("removed"),
+				},
+				{
+					Filename: github.String("path/to/files/file_modified.txt"),
+					Status:   github.String("modified"),
+				},
+				{
+					Filename: github.String("path/to/files/file_added.txt"),
+					Status:   github.String("added"),
+				},
+				{
+					Filename: github.String("path/to/files/file_changed.txt"),
+					Status:   github.String("changed"),
+				},
+				{
+					Filename:         github.String("path/to/files/file_renamed.txt"),
+					PreviousFilename: github.String("path/to/files/file_previous.txt"),
+					Status:           github.String("renamed"),
+				},
+				{
+					Filename: github.String("path/to/files/file_copied.txt"),
+					Status:   github.String("copied"),
+				},
+			},
+			want: document.Paths{
+				Added:    []string{"path/to/files/file_added.txt", "path/to/files/file_renamed.txt", "path/to/files/file_copied.txt"},
+				Removed:  []string{"path/to/files/file_removed.txt", "path/to/files/file_previous.txt"},
+				Modified: []string{"path/to/files/file_modified.txt", "path/to/files/file_changed.txt"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ghClient := new(GraphQLClientMock)
+			commitOpsClient := new(CommitOpsClientMock)
+			commitOpsClient.On("ListCommits", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*github.RepositoryCommit{{
+				SHA: github.String("1234567890"),
+			}}, nil, nil)
+			commitOpsClient.On("GetCommit", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&github.RepositoryCommit{Files: tt.detectedChanges}, nil, nil)
+
+			client := NewGitHubClient(commitOpsClient, ghClient, tt.config)
+
+			paths, err := client.GetChangedFilePathsSince(time.Now())
+
+			if err != nil {
+				t.Errorf("Error occurred: %v", err)
+			}
+
+			if !comparePaths(paths, tt.want) {
+				t.Errorf("Expected paths do not match the result: %v", paths)
+			}
+		})
+	}
+}
+
+func TestGitHubClient_GetChangedFilePathsSince_Renames(t *testing.T) {
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*github.RepositoryCommit{{
+		SHA: github.String("1234567890"),
+	}}, nil, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&github.RepositoryCommit{Files: []*github.CommitFile{
+		{Filename: github.String("docs/moved.md"), PreviousFilename: github.String("docs/old.md"), Status: github.String("renamed")},
+		{Filename: github.String("docs/imported.md"), PreviousFilename: github.String("drafts/imported.md"), Status: github.String("renamed")},
+		{Filename: github.String("drafts/retired.md"), PreviousFilename: github.String("docs/retired.md"), Status: github.String("renamed")},
+	}}, nil, nil)
+
+	client := NewGitHubClient(commitOpsClient, new(GraphQLClientMock), GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+	})
+
+	paths, err := client.GetChangedFilePathsSince(time.Now())
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"docs/moved.md", "docs/imported.md"}, paths.Added)
+	assert.ElementsMatch(t, []string{"docs/old.md", "docs/retired.md"}, paths.Removed)
+	assert.Empty(t, paths.Modified)
+	assert.Equal(t, []document.Rename{{From: "docs/old.md", To: "docs/moved.md"}}, paths.Renamed)
+}
+
+// Helper function to check if a string is in a slice
+func contains(slice []string, str string) bool {
+	for _, s := range slice {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+// Helper function to compare Paths
+func comparePaths(paths1, paths2 document.Paths) bool {
+	return compareStringSlices(paths1.Added, paths2.Added) &&
+		compareStringSlices(paths1.Removed, paths2.Removed) &&
+		compareStringSlices(paths1.Modified, paths2.Modified)
+}
+
+// Helper function to compare string slices
+func compareStringSlices(slice1, slice2 []string) bool {
+	if len(slice1) != len(slice2) {
+		return false
+	}
+	for i := range slice1 {
+		if slice1[i] != slice2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+type ctxKey struct{}
+
+func TestGitHubClient_WithContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, "crawl")
+	withCtx := mock.MatchedBy(func(c context.Context) bool { return c.Value(ctxKey{}) == "crawl" })
+
+	ghClient := new(GraphQLClientMock)
+	ghClient.On("Query", withCtx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		query := args.Get(1).(*GHQueryForListFiles)
+		query.Repository.Object.Tree.Entries = []GHTreeEntry{{Name: "a.md", Path: "a.md", Type: "blob"}}
+	}).Return(nil)
+
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", withCtx, "testowner", "repo1", mock.Anything).Return([]*github.RepositoryCommit{{SHA: github.String("abc")}}, nil, nil)
+	commitOpsClient.On("GetCommit", withCtx, "testowner", "repo1", "abc", mock.Anything).Return(&github.RepositoryCommit{
+		Files: []*github.CommitFile{{Filename: github.String("a.md"), Status: github.String("modified")}},
+	}, nil, nil)
+
+	client := NewGitHubClient(commitOpsClient, ghClient, GitHubConfig{Owner: "testowner", Repositories: []string{"repo1"}, DefaultBranch: "main"})
+
+	files, err := client.GetFilePathsFromRepositoriesWithContext(ctx)
+	if err != nil {
+		t.Fatalf("Error occurred: %v", err)
+	}
+	if !contains(files, "a.md") {
+		t.Errorf("Expected file not found: a.md")
+	}
+
+	paths, err := client.GetChangedFilePathsSinceWithContext(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("Error occurred: %v", err)
+	}
+	if !contains(paths.Modified, "a.md") {
+		t.Errorf("Expected modified file not found: a.md")
+	}
+
+	ghClient.AssertExpectations(t)
+	commitOpsClient.AssertExpectations(t)
+}
+
+func TestGitHubClient_GetChangedFilePathsSince_Pagination(t *testing.T) {
+	onPage := func(page int) interface{} {
+		return mock.MatchedBy(func(opts *github.CommitsListOptions) bool { return opts.Page == page })
+	}
+	onFilePage := func(page int) interface{} {
+		return mock.MatchedBy(func(opts *github.ListOptions) bool { return opts.Page == page })
+	}
+
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", onPage(0)).
+		Return([]*github.RepositoryCommit{{SHA: github.String("a")}}, &github.Response{NextPage: 2}, nil)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", onPage(2)).
+		Return([]*github.RepositoryCommit{{SHA: github.String("b")}}, &github.Response{}, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "a", onFilePage(0)).
+		Return(&github.RepositoryCommit{Files: []*github.CommitFile{{Filename: github.String("a1.md"), Status: github.String("added")}}}, &github.Response{NextPage: 2}, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "a", onFilePage(2)).
+		Return(&github.RepositoryCommit{Files: []*github.CommitFile{{Filename: github.String("a2.md"), Status: github.String("added")}}}, &github.Response{}, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "b", onFilePage(0)).
+		Return(&github.RepositoryCommit{Files: []*github.CommitFile{{Filename: github.String("b.md"), Status: github.String("modified")}}}, &github.Response{}, nil)
+
+	client := NewGitHubClient(commitOpsClient, new(GraphQLClientMock), GitHubConfig{Owner: "testowner", Repositories: []string{"repo1"}})
+
+	paths, err := client.GetChangedFilePathsSince(time.Time{})
+	if err != nil {
+		t.Fatalf("Error occurred: %v", err)
+	}
+	if !comparePaths(paths, document.Paths{Added: []string{"a1.md", "a2.md"}, Modified: []string{"b.md"}}) {
+		t.Errorf("Expected paths do not match the result: %v", paths)
+	}
+	commitOpsClient.AssertExpectations(t)
+}
+
+func TestGitHubClient_IncludeExcludePatterns(t *testing.T) {
+	fileFilter := filter.GitHubFilter{
+		Include:       []string{"docs/**", "*.md"},
+		Exclude:       []string{"**/drafts/**"},
+		ExcludeRegexp: regexp.MustCompile(`\.tmp$`),
+	}
+
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForListFiles"), mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*GHQueryForListFiles).Repository.Object.Tree.Entries = []GHTreeEntry{
+			{Name: "README.md", Path: "README.md", Type: "blob"},
+			{Name: "main.go", Path: "main.go", Type: "blob"},
+			{Name: "index.md", Path: "docs/index.md", Type: "blob"},
+			{Name: "plan.md", Path: "docs/drafts/plan.md", Type: "blob"},
+			{Name: "notes.tmp", Path: "docs/notes.tmp", Type: "blob"},
+		}
+	}).Return(nil)
+
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.Anything).
+		Return([]*github.RepositoryCommit{{SHA: github.String("a")}}, &github.Response{}, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "a", mock.Anything).
+		Return(&github.RepositoryCommit{Files: []*github.CommitFile{
+			{Filename: github.String("docs/index.md"), Status: github.String("modified")},
+			{Filename: github.String("docs/drafts/plan.md"), Status: github.String("added")},
+			{Filename: github.String("main.go"), Status: github.String("modified")},
+			{Filename: github.String("CHANGES.md"), Status: github.String("added")},
+		}}, &github.Response{}, nil)
+
+	client := NewGitHubClient(commitOpsClient, graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        fileFilter,
+	})
+
+	files, err := client.GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "docs/index.md"}, files)
+
+	paths, err := client.GetChangedFilePathsSince(time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, document.Paths{Added: []string{"CHANGES.md"}, Modified: []string{"docs/index.md"}}, paths)
+}
+
+func TestGitHubClient_GetChangedFilePathsSince_Time(t *testing.T) {
+	since := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name  string
+		call  func(c *GitHub) (document.Paths, error)
+		since time.Time
+	}{
+		{
+			name:  "absolute time is passed unchanged",
+			call:  func(c *GitHub) (document.Paths, error) { return c.GetChangedFilePathsSince(since) },
+			since: since,
+		},
+		{
+			name:  "zero time selects the whole history",
+			call:  func(c *GitHub) (document.Paths, error) { return c.GetChangedFilePathsSince(time.Time{}) },
+			since: time.Time{},
+		},
+		{
+			name: "window is counted in its own unit",
+			call: func(c *GitHub) (document.Paths, error) {
+				return c.GetChangedFilePathsWithin(context.Background(), 24*time.Hour)
+			},
+			since: since.Add(-24 * time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commitOpsClient := new(CommitOpsClientMock)
+			commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.CommitsListOptions) bool {
+				return opts.Since.Equal(tt.since)
+			})).Return([]*github.RepositoryCommit{}, &github.Response{}, nil)
+
+			client := NewGitHubClient(commitOpsClient, new(GraphQLClientMock), GitHubConfig{
+				Owner:         "testowner",
+				Repositories:  []string{"repo1"},
+				DefaultBranch: "main",
+				Clock:         fixedClock(since),
+			})
+
+			_, err := tt.call(client)
+			assert.NoError(t, err)
+			commitOpsClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGitHubClient_KeepChangeEvents(t *testing.T) {
+	added := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	modified := added.Add(time.Hour)
+	commit := func(sha string, date time.Time) *github.RepositoryCommit {
+		return &github.RepositoryCommit{
+			SHA:    github.String(sha),
+			Commit: &github.Commit{Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: date}}},
+		}
+	}
+
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.Anything).
+		Return([]*github.RepositoryCommit{commit("b", modified), commit("a", added)}, &github.Response{}, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "a", mock.Anything).
+		Return(&github.RepositoryCommit{Files: []*github.CommitFile{{Filename: github.String("doc.md"), Status: github.String("added")}}}, &github.Response{}, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "b", mock.Anything).
+		Return(&github.RepositoryCommit{Files: []*github.CommitFile{{Filename: github.String("doc.md"), Status: github.String("modified")}}}, &github.Response{}, nil)
+
+	config := GitHubConfig{Owner: "testowner", Repositories: []string{"repo1"}, DefaultBranch: "main"}
+
+	paths, err := NewGitHubClient(commitOpsClient, new(GraphQLClientMock), config).GetChangedFilePathsSince(time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, document.Paths{Added: []string{"doc.md"}}, paths)
+
+	config.KeepChangeEvents = true
+	paths, err = NewGitHubClient(commitOpsClient, new(GraphQLClientMock), config).GetChangedFilePathsSince(time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"doc.md"}, paths.Added)
+	assert.Equal(t, []document.ChangeEvent{
+		{Repository: "repo1", CommitSHA: "a", CommittedAt: added, Path: "doc.md", Status: "added"},
+		{Repository: "repo1", CommitSHA: "b", CommittedAt: modified, Path: "doc.md", Status: "modified"},
+	}, paths.Events)
+}
+
+func TestGitHubClient_GetChangeSetSince(t *testing.T) {
+	added := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	modified := added.Add(time.Hour)
+	commit := func(sha, message string, date time.Time, author *github.User) *github.RepositoryCommit {
+		return &github.RepositoryCommit{
+			SHA:    github.String(sha),
+			Author: author,
+			Commit: &github.Commit{
+				Message:   github.String(message),
+				Author:    &github.CommitAuthor{Name: github.String("Jane Doe")},
+				Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: date}},
+			},
+		}
+	}
+
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.Anything).Return([]*github.RepositoryCommit{
+		commit("b", "Fix typo", modified, nil),
+		commit("a", "Add docs", added, &github.User{Login: github.String("octocat")}),
+	}, &github.Response{}, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "a", mock.Anything).
+		Return(&github.RepositoryCommit{Files: []*github.CommitFile{
+			{Filename: github.String("docs/a.md"), Status: github.String("added")},
+			{Filename: github.String("docs/b.md"), Status: github.String("modified")},
+		}}, &github.Response{}, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "b", mock.Anything).
+		Return(&github.RepositoryCommit{Files: []*github.CommitFile{
+			{Filename: github.String("docs/b.md"), Status: github.String("modified")},
+		}}, &github.Response{}, nil)
+
+	config := GitHubConfig{Owner: "testowner", Repositories: []string{"repo1"}, DefaultBranch: "main"}
+
+	changes, err := NewGitHubClient(commitOpsClient, new(GraphQLClientMock), config).GetChangeSetSince(context.Background(), time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, document.ChangeSet{
+		Added: []document.FileChange{
+			{Repository: "repo1", Path: "docs/a.md", CommitSHA: "a", Author: "octocat", CommittedAt: added, Message: "Add docs"},
+		},
+		Modified: []document.FileChange{
+			{Repository: "repo1", Path: "docs/b.md", CommitSHA: "b", Author: "Jane Doe", CommittedAt: modified, Message: "Fix typo"},
+		},
+	}, changes)
+}
+
+func authoredCommit(sha, login, name, email string) *github.RepositoryCommit {
+	commit := &github.RepositoryCommit{
+		SHA:    github.String(sha),
+		Commit: &github.Commit{Author: &github.CommitAuthor{Name: github.String(name), Email: github.String(email)}},
+	}
+	if login != "" {
+		commit.Author = &github.User{Login: github.String(login), Type: github.String("User")}
+	}
+	return commit
+}
+
+func TestGitHubClient_GetChangedFilePathsSince_CommitFilter(t *testing.T) {
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.Anything).Return([]*github.RepositoryCommit{
+		authoredCommit("bump", "dependabot[bot]", "dependabot[bot]", "bot@users.noreply.github.com"),
+		authoredCommit("docs", "alice", "Alice", "alice@example.com"),
+	}, nil, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "docs", mock.Anything).Return(&github.RepositoryCommit{
+		Files: []*github.CommitFile{{Filename: github.String("docs/index.md"), Status: github.String("modified")}},
+	}, nil, nil)
+
+	client := NewGitHubClient(commitOpsClient, new(GraphQLClientMock), GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+		CommitFilter:  filter.CommitFilter{ExcludeBots: true},
+	})
+
+	paths, err := client.GetChangedFilePathsSince(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/index.md"}, paths.Modified)
+	commitOpsClient.AssertNotCalled(t, "GetCommit", mock.Anything, "testowner", "repo1", "bump", mock.Anything)
+}
+
+func TestGitHub_GetFilePathsFromRepositories_DocumentationOnly(t *testing.T) {
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		switch query := args.Get(1).(type) {
+		case *GHQueryForListFiles:
+			query.Repository.Object.Tree.Entries = []GHTreeEntry{
+				{Name: "README.md", Path: "README.md", Type: "blob"},
+				{Name: "main.go", Path: "main.go", Type: "blob"},
+				{Name: "notes.md", Path: "notes.md", Type: "blob"},
+			}
+		case *GHQueryForBlobText:
+			query.Repository.Object.Blob.Text = "notes.md linguist-documentation\n"
+		}
+	}).Return(nil)
+
+	client := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{Mode: filter.FilterModeDocumentationOnly},
+	})
+
+	files, err := client.GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "notes.md"}, files)
+}
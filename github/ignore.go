@@ -0,0 +1,58 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+)
+
+// fileRules holds the files at the root of a repository the configured filter consults: the .gitattributes
+// and the ignore file, together with the filters of the directories crawled in it, nil if it is crawled
+// below GitHubFilter.FilePath.
+type fileRules struct {
+	attributes filter.GitAttributes
+	ignore     filter.IgnoreFile
+	filters    []filter.GitHubFilter
+}
+
+// getIgnoreFile fetches and parses the configured ignore file at the root of the repository. Repositories
+// without the file, and clients without a configured ignore file, yield an empty ignore file.
+func (c *GitHub) getIgnoreFile(ctx context.Context, repo string) (filter.IgnoreFile, error) {
+	name := c.Configuration.Filter.IgnoreFile
+	if name == "" {
+		return filter.IgnoreFile{}, nil
+	}
+
+	branch, err := c.branch(ctx, repo)
+	if err != nil {
+		return filter.IgnoreFile{}, err
+	}
+
+	text, err := c.getBlobText(ctx, c.ownerOf(repo), c.nameOf(repo), fmt.Sprintf("%s:%s", branch, name))
+	if err != nil {
+		return filter.IgnoreFile{}, fmt.Errorf("failed to read %s of %s: %w", name, repo, err)
+	}
+
+	return filter.ParseIgnoreFile(text), nil
+}
+
+// getFileRules fetches the files the configured filter consults for the repository, skipping those it does
+// not need.
+func (c *GitHub) getFileRules(ctx context.Context, repo string) (fileRules, error) {
+	rules := fileRules{filters: c.pathFilters(repo)}
+	if c.Configuration.Filter.NeedsGitAttributes() {
+		attributes, err := c.getGitAttributes(ctx, repo)
+		if err != nil {
+			return fileRules{}, err
+		}
+		rules.attributes = attributes
+	}
+
+	ignore, err := c.getIgnoreFile(ctx, repo)
+	if err != nil {
+		return fileRules{}, err
+	}
+	rules.ignore = ignore
+	return rules, nil
+}
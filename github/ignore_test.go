@@ -0,0 +1,55 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGitHubClient_IgnoreFile(t *testing.T) {
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForListFiles"), mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*GHQueryForListFiles).Repository.Object.Tree.Entries = []GHTreeEntry{
+			{Name: ".cocoignore", Path: ".cocoignore", Type: "blob"},
+			{Name: "README.md", Path: "README.md", Type: "blob"},
+			{Name: "plan.md", Path: "drafts/plan.md", Type: "blob"},
+		}
+	}).Return(nil)
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForBlobText"), map[string]interface{}{
+		"owner":      githubv4.String("testowner"),
+		"name":       githubv4.String("repo1"),
+		"expression": githubv4.String("main:.cocoignore"),
+	}).Run(func(args mock.Arguments) {
+		args.Get(1).(*GHQueryForBlobText).Repository.Object.Blob.Text = ".cocoignore\ndrafts/\n"
+	}).Return(nil)
+
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.Anything).
+		Return([]*github.RepositoryCommit{{SHA: github.String("a")}}, &github.Response{}, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "a", mock.Anything).
+		Return(&github.RepositoryCommit{Files: []*github.CommitFile{
+			{Filename: github.String("README.md"), Status: github.String("modified")},
+			{Filename: github.String("drafts/plan.md"), Status: github.String("added")},
+		}}, &github.Response{}, nil)
+
+	client := NewGitHubClient(commitOpsClient, graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{IgnoreFile: filter.DefaultIgnoreFile},
+	})
+
+	files, err := client.GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"README.md"}, files)
+
+	paths, err := client.GetChangedFilePathsSince(time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, document.Paths{Modified: []string{"README.md"}}, paths)
+}
@@ -0,0 +1,353 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// IssueOpsClient is an interface to help test the GitHub issue operations.
+// GetIssuesSince and GetIssueDocuments list the issues and their comments through it.
+type IssueOpsClient interface {
+	ListIssues(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error)
+	ListIssueComments(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error)
+}
+
+// IssueFilter narrows down the issues that are collected.
+//
+// Labels only selects issues carrying all the given labels and is evaluated by GitHub. ExcludeLabels skips
+// issues carrying any of the given labels. State is the issue state to collect: "open", "closed" or "all"
+// (the default). Since skips issues that were last updated before the given time.
+type IssueFilter struct {
+	Labels        []string
+	ExcludeLabels []string
+	State         string
+	Since         time.Time
+}
+
+// Issue is an issue together with its comments.
+//
+// Repository is the configured repository the issue belongs to.
+type Issue struct {
+	Repository string
+	Number     int
+	Title      string
+	Body       string
+	State      string
+	Author     string
+	Labels     []string
+	URL        string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	ClosedAt   time.Time
+	Comments   []Comment
+}
+
+// Comment is a comment of an issue or discussion.
+//
+// IsAnswer marks the comment accepted as the answer of a Q&A discussion. ReplyTo is the ID of the discussion
+// comment a reply belongs to, empty for top level comments.
+type Comment struct {
+	ID        string
+	Body      string
+	Author    string
+	URL       string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	IsAnswer  bool
+	ReplyTo   string
+}
+
+// ListIssues lists the issues of a specific repository. GitHub includes pull requests in the result.
+func (gClient *GitHubCommitsOpsClient) ListIssues(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+	return gClient.GitHubClient.Issues.ListByRepo(ctx, owner, repo, opts)
+}
+
+// ListIssueComments lists the comments of a specific issue.
+func (gClient *GitHubCommitsOpsClient) ListIssueComments(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+	return gClient.GitHubClient.Issues.ListComments(ctx, owner, repo, number, opts)
+}
+
+// GetIssueDocuments retrieves the issues of all configured repositories matching the filter as documents.
+// Every issue yields one document holding its title and body, followed by one document per comment.
+// Pull requests, which GitHub also reports as issues, are skipped.
+//
+// Usage:
+//
+//	docs, err := c.GetIssueDocuments(ctx, IssueFilter{
+//	    Labels: []string{"documentation"},
+//	    Since:  time.Now().Add(-7 * 24 * time.Hour),
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, doc := range docs {
+//	    fmt.Println(doc.Kind, doc.Title, doc.URL)
+//	}
+func (c *GitHub) GetIssueDocuments(ctx context.Context, filter IssueFilter) ([]document.Document, error) {
+	client, err := c.issueOpsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	repoDocs := make([][]document.Document, len(c.repositories()))
+	err = c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		issues, err := c.listIssues(ctx, client, repo, filter)
+		if err != nil {
+			return err
+		}
+
+		var docs []document.Document
+		for _, issue := range issues {
+			if issue.IsPullRequest() {
+				continue
+			}
+
+			docs = append(docs, c.issueDocument(repo, issue))
+			if issue.GetComments() == 0 {
+				continue
+			}
+
+			comments, err := c.listIssueComments(ctx, client, repo, issue.GetNumber())
+			if err != nil {
+				return err
+			}
+			for _, comment := range comments {
+				docs = append(docs, c.issueCommentDocument(repo, issue, comment))
+			}
+		}
+		repoDocs[i] = docs
+		return nil
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	return flattenDocuments(repoDocs), err
+}
+
+// GetIssuesSince retrieves the issues of all configured repositories matching the filter together with their
+// comments, most recently updated first within each repository. Pull requests, which GitHub also reports as
+// issues, are skipped.
+//
+// Usage:
+//
+//	issues, err := c.GetIssuesSince(ctx, IssueFilter{
+//	    Labels: []string{"documentation"},
+//	    Since:  time.Now().Add(-7 * 24 * time.Hour),
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, issue := range issues {
+//	    fmt.Println(issue.Number, issue.Title, len(issue.Comments))
+//	}
+func (c *GitHub) GetIssuesSince(ctx context.Context, filter IssueFilter) ([]Issue, error) {
+	client, err := c.issueOpsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	repoIssues := make([][]Issue, len(c.repositories()))
+	err = c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		issues, err := c.listIssues(ctx, client, repo, filter)
+		if err != nil {
+			return err
+		}
+
+		for _, issue := range issues {
+			if issue.IsPullRequest() {
+				continue
+			}
+
+			var comments []*github.IssueComment
+			if issue.GetComments() > 0 {
+				comments, err = c.listIssueComments(ctx, client, repo, issue.GetNumber())
+				if err != nil {
+					return err
+				}
+			}
+			repoIssues[i] = append(repoIssues[i], newIssue(repo, issue, comments))
+		}
+		return nil
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	var result []Issue
+	for _, issues := range repoIssues {
+		result = append(result, issues...)
+	}
+	return result, err
+}
+
+// newIssue converts an issue of the given configured repository and its comments.
+func newIssue(repo string, issue *github.Issue, comments []*github.IssueComment) Issue {
+	result := Issue{
+		Repository: repo,
+		Number:     issue.GetNumber(),
+		Title:      issue.GetTitle(),
+		Body:       issue.GetBody(),
+		State:      issue.GetState(),
+		Author:     issue.GetUser().GetLogin(),
+		Labels:     labelNames(issue.Labels),
+		URL:        issue.GetHTMLURL(),
+		CreatedAt:  issue.GetCreatedAt().Time,
+		UpdatedAt:  issue.GetUpdatedAt().Time,
+		ClosedAt:   issue.GetClosedAt().Time,
+	}
+
+	for _, comment := range comments {
+		result.Comments = append(result.Comments, Comment{
+			ID:        strconv.FormatInt(comment.GetID(), 10),
+			Body:      comment.GetBody(),
+			Author:    comment.GetUser().GetLogin(),
+			URL:       comment.GetHTMLURL(),
+			CreatedAt: comment.GetCreatedAt().Time,
+			UpdatedAt: comment.GetUpdatedAt().Time,
+		})
+	}
+
+	return result
+}
+
+// listIssues lists all issues of a repository matching the filter.
+func (c *GitHub) listIssues(ctx context.Context, client IssueOpsClient, repo string, filter IssueFilter) ([]*github.Issue, error) {
+	state := filter.State
+	if state == "" {
+		state = "all"
+	}
+
+	opts := &github.IssueListByRepoOptions{
+		State:       state,
+		Labels:      filter.Labels,
+		Since:       filter.Since,
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var issues []*github.Issue
+	for {
+		page, resp, err := client.ListIssues(ctx, c.ownerOf(repo), c.nameOf(repo), opts)
+		if err != nil {
+			return nil, c.classifyError(err)
+		}
+		for _, issue := range page {
+			if matchesLabels(labelNames(issue.Labels), nil, filter.ExcludeLabels) {
+				issues = append(issues, issue)
+			}
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			return issues, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// listIssueComments lists all comments of an issue.
+func (c *GitHub) listIssueComments(ctx context.Context, client IssueOpsClient, repo string, number int) ([]*github.IssueComment, error) {
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+
+	var comments []*github.IssueComment
+	for {
+		page, resp, err := client.ListIssueComments(ctx, c.ownerOf(repo), c.nameOf(repo), number, opts)
+		if err != nil {
+			return nil, c.classifyError(err)
+		}
+		comments = append(comments, page...)
+
+		if resp == nil || resp.NextPage == 0 {
+			return comments, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// issueDocument converts an issue into a document.
+func (c *GitHub) issueDocument(repo string, issue *github.Issue) document.Document {
+	return document.Document{
+		ID:         fmt.Sprintf("%s/%s/issues/%d", c.ownerOf(repo), c.nameOf(repo), issue.GetNumber()),
+		Kind:       document.DocumentKindIssue,
+		Owner:      c.ownerOf(repo),
+		Repository: c.nameOf(repo),
+		Title:      issue.GetTitle(),
+		Body:       issue.GetBody(),
+		URL:        issue.GetHTMLURL(),
+		Author:     issue.GetUser().GetLogin(),
+		CreatedAt:  issue.GetCreatedAt().Time,
+		UpdatedAt:  issue.GetUpdatedAt().Time,
+		Metadata: map[string]string{
+			"issue":  strconv.Itoa(issue.GetNumber()),
+			"state":  issue.GetState(),
+			"labels": strings.Join(labelNames(issue.Labels), ","),
+		},
+	}
+}
+
+// issueCommentDocument converts a comment of an issue into a document.
+func (c *GitHub) issueCommentDocument(repo string, issue *github.Issue, comment *github.IssueComment) document.Document {
+	return document.Document{
+		ID:         fmt.Sprintf("%s/%s/issues/%d/comments/%d", c.ownerOf(repo), c.nameOf(repo), issue.GetNumber(), comment.GetID()),
+		Kind:       document.DocumentKindIssueComment,
+		Owner:      c.ownerOf(repo),
+		Repository: c.nameOf(repo),
+		Title:      issue.GetTitle(),
+		Body:       comment.GetBody(),
+		URL:        comment.GetHTMLURL(),
+		Author:     comment.GetUser().GetLogin(),
+		CreatedAt:  comment.GetCreatedAt().Time,
+		UpdatedAt:  comment.GetUpdatedAt().Time,
+		Metadata: map[string]string{
+			"issue": strconv.Itoa(issue.GetNumber()),
+		},
+	}
+}
+
+// labelNames returns the names of the given labels.
+func labelNames(labels []*github.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.GetName())
+	}
+	return names
+}
+
+// matchesLabels checks if the labels contain all the include labels and none of the exclude labels.
+// Label names are compared case-insensitively, as GitHub does.
+func matchesLabels(labels, include, exclude []string) bool {
+	has := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		has[strings.ToLower(label)] = true
+	}
+
+	for _, label := range include {
+		if !has[strings.ToLower(label)] {
+			return false
+		}
+	}
+	for _, label := range exclude {
+		if has[strings.ToLower(label)] {
+			return false
+		}
+	}
+	return true
+}
+
+// issueOpsClient returns the commit ops client as an IssueOpsClient.
+func (c *GitHub) issueOpsClient() (IssueOpsClient, error) {
+	client, ok := c.commitOpsClient.(IssueOpsClient)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not implement IssueOpsClient", ErrUnsupportedClient, c.commitOpsClient)
+	}
+	return client, nil
+}
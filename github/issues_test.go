@@ -0,0 +1,190 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// IssueOpsClientMock is a mock type for a CommitOpsClient that also implements IssueOpsClient
+type IssueOpsClientMock struct {
+	CommitOpsClientMock
+}
+
+// ListIssues provides a mock function with given fields: ctx, owner, repo, opts
+func (_m *IssueOpsClientMock) ListIssues(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, opts)
+	issues, _ := ret.Get(0).([]*github.Issue)
+	resp, _ := ret.Get(1).(*github.Response)
+	return issues, resp, ret.Error(2)
+}
+
+// ListIssueComments provides a mock function with given fields: ctx, owner, repo, number, opts
+func (_m *IssueOpsClientMock) ListIssueComments(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, number, opts)
+	comments, _ := ret.Get(0).([]*github.IssueComment)
+	resp, _ := ret.Get(1).(*github.Response)
+	return comments, resp, ret.Error(2)
+}
+
+func TestGitHub_GetIssueDocuments(t *testing.T) {
+	since := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	client := new(IssueOpsClientMock)
+	client.On("ListIssues", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.IssueListByRepoOptions) bool {
+		return opts.State == "all" && opts.Since.Equal(since) && assert.ObjectsAreEqual([]string{"documentation"}, opts.Labels)
+	})).Return([]*github.Issue{
+		{
+			Number:   github.Int(1),
+			Title:    github.String("Document the setup"),
+			Body:     github.String("We need setup docs"),
+			State:    github.String("open"),
+			HTMLURL:  github.String("https://github.com/testowner/repo1/issues/1"),
+			User:     &github.User{Login: github.String("alice")},
+			Labels:   []*github.Label{{Name: github.String("documentation")}, {Name: github.String("good first issue")}},
+			Comments: github.Int(1),
+		},
+		{
+			Number:           github.Int(2),
+			Title:            github.String("A pull request"),
+			PullRequestLinks: &github.PullRequestLinks{URL: github.String("https://api.github.com/repos/testowner/repo1/pulls/2")},
+		},
+	}, nil, nil)
+	client.On("ListIssueComments", mock.Anything, "testowner", "repo1", 1, mock.Anything).
+		Return([]*github.IssueComment{
+			{ID: github.Int64(10), Body: github.String("On it"), User: &github.User{Login: github.String("bob")}},
+		}, nil, nil)
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	docs, err := gh.GetIssueDocuments(context.Background(), IssueFilter{Labels: []string{"documentation"}, Since: since})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 2)
+
+	assert.Equal(t, "testowner/repo1/issues/1", docs[0].ID)
+	assert.Equal(t, document.DocumentKindIssue, docs[0].Kind)
+	assert.Equal(t, "Document the setup", docs[0].Title)
+	assert.Equal(t, "We need setup docs", docs[0].Body)
+	assert.Equal(t, "alice", docs[0].Author)
+	assert.Equal(t, map[string]string{"issue": "1", "state": "open", "labels": "documentation,good first issue"}, docs[0].Metadata)
+
+	assert.Equal(t, "testowner/repo1/issues/1/comments/10", docs[1].ID)
+	assert.Equal(t, document.DocumentKindIssueComment, docs[1].Kind)
+	assert.Equal(t, "On it", docs[1].Body)
+	assert.Equal(t, "bob", docs[1].Author)
+	client.AssertExpectations(t)
+}
+
+func TestMatchesLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  []string
+		include []string
+		exclude []string
+		want    bool
+	}{
+		{name: "no filter", labels: []string{"bug"}, want: true},
+		{name: "all included", labels: []string{"documentation", "help wanted"}, include: []string{"Documentation", "help wanted"}, want: true},
+		{name: "missing include", labels: []string{"documentation"}, include: []string{"documentation", "help wanted"}, want: false},
+		{name: "excluded", labels: []string{"documentation", "wontfix"}, include: []string{"documentation"}, exclude: []string{"WontFix"}, want: false},
+		{name: "exclude absent", labels: []string{"documentation"}, exclude: []string{"wontfix"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchesLabels(tt.labels, tt.include, tt.exclude))
+		})
+	}
+}
+
+func TestGitHub_GetIssueDocuments_ExcludeLabels(t *testing.T) {
+	client := new(IssueOpsClientMock)
+	client.On("ListIssues", mock.Anything, "testowner", "repo1", mock.Anything).Return([]*github.Issue{
+		{Number: github.Int(1), Title: github.String("Keep"), Labels: []*github.Label{{Name: github.String("documentation")}}},
+		{Number: github.Int(2), Title: github.String("Skip"), Labels: []*github.Label{{Name: github.String("wontfix")}}},
+	}, nil, nil)
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	docs, err := gh.GetIssueDocuments(context.Background(), IssueFilter{ExcludeLabels: []string{"wontfix"}})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 1)
+	assert.Equal(t, "Keep", docs[0].Title)
+}
+
+func TestGitHub_GetIssuesSince(t *testing.T) {
+	created := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	client := new(IssueOpsClientMock)
+	client.On("ListIssues", mock.Anything, "testowner", "repo1", mock.Anything).Return([]*github.Issue{
+		{
+			Number:    github.Int(1),
+			Title:     github.String("Document the setup"),
+			Body:      github.String("We need setup docs"),
+			State:     github.String("open"),
+			HTMLURL:   github.String("https://github.com/testowner/repo1/issues/1"),
+			User:      &github.User{Login: github.String("alice")},
+			Labels:    []*github.Label{{Name: github.String("documentation")}},
+			Comments:  github.Int(1),
+			CreatedAt: &github.Timestamp{Time: created},
+			UpdatedAt: &github.Timestamp{Time: created.Add(time.Hour)},
+		},
+		{
+			Number:           github.Int(2),
+			PullRequestLinks: &github.PullRequestLinks{URL: github.String("https://api.github.com/repos/testowner/repo1/pulls/2")},
+		},
+		{Number: github.Int(3), Title: github.String("No comments")},
+	}, nil, nil)
+	client.On("ListIssueComments", mock.Anything, "testowner", "repo1", 1, mock.Anything).
+		Return([]*github.IssueComment{
+			{
+				ID:        github.Int64(10),
+				Body:      github.String("On it"),
+				User:      &github.User{Login: github.String("bob")},
+				HTMLURL:   github.String("https://github.com/testowner/repo1/issues/1#issuecomment-10"),
+				CreatedAt: &github.Timestamp{Time: created.Add(time.Hour)},
+			},
+		}, nil, nil).Once()
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	issues, err := gh.GetIssuesSince(context.Background(), IssueFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []Issue{
+		{
+			Repository: "repo1",
+			Number:     1,
+			Title:      "Document the setup",
+			Body:       "We need setup docs",
+			State:      "open",
+			Author:     "alice",
+			Labels:     []string{"documentation"},
+			URL:        "https://github.com/testowner/repo1/issues/1",
+			CreatedAt:  created,
+			UpdatedAt:  created.Add(time.Hour),
+			Comments: []Comment{{
+				ID:        "10",
+				Body:      "On it",
+				Author:    "bob",
+				URL:       "https://github.com/testowner/repo1/issues/1#issuecomment-10",
+				CreatedAt: created.Add(time.Hour),
+			}},
+		},
+		{Repository: "repo1", Number: 3, Title: "No comments", Labels: []string{}},
+	}, issues)
+	client.AssertExpectations(t)
+}
@@ -0,0 +1,227 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// LicenseNoAssertion is the SPDX identifier used when a license file exists but its license could not be
+// identified.
+const LicenseNoAssertion = "NOASSERTION"
+
+// licenseFilePattern matches the file names conventionally holding a license.
+var licenseFilePattern = regexp.MustCompile(`(?i)^((un)?licen[cs]e(-[a-z0-9.]+)?|copying(\.lesser)?)(\.(md|markdown|txt|rst))?$`)
+
+// licenseTitleWindow is the number of characters at the start of a normalized license text the title of a
+// license is looked for in, leaving room for a project name or blank lines before it.
+const licenseTitleWindow = 200
+
+// licenseSignatures identify well known licenses by the title at the start of their text or, for licenses
+// without a fixed title, by phrases anywhere in it. Titles are not looked for in the whole text, as licenses
+// refer to each other: GPLv3 mentions the GNU Affero and Lesser General Public Licenses. The first matching
+// signature wins, so more specific licenses come before the ones they contain.
+var licenseSignatures = []struct {
+	spdxID  string
+	title   string
+	phrases []string
+}{
+	{spdxID: "AGPL-3.0", title: "gnu affero general public license version 3, 19 november 2007"},
+	{spdxID: "LGPL-3.0", title: "gnu lesser general public license version 3, 29 june 2007"},
+	{spdxID: "LGPL-2.1", title: "gnu lesser general public license version 2.1, february 1999"},
+	{spdxID: "GPL-3.0", title: "gnu general public license version 3, 29 june 2007"},
+	{spdxID: "GPL-2.0", title: "gnu general public license version 2, june 1991"},
+	{spdxID: "Apache-2.0", title: "apache license version 2.0, january 2004"},
+	{spdxID: "MPL-2.0", title: "mozilla public license version 2.0"},
+	{spdxID: "Unlicense", phrases: []string{"free and unencumbered software released into the public domain"}},
+	{spdxID: "ISC", phrases: []string{"permission to use, copy, modify, and/or distribute this software for any purpose"}},
+	{spdxID: "MIT", phrases: []string{"permission is hereby granted, free of charge"}},
+	{spdxID: "BSD-3-Clause", phrases: []string{"redistribution and use in source and binary forms", "neither the name"}},
+	{spdxID: "BSD-2-Clause", phrases: []string{"redistribution and use in source and binary forms"}},
+}
+
+// LicenseOpsClient is an interface to help test the GitHub repository license operations.
+// GetLicenses reads the repository license GitHub detected through it.
+type LicenseOpsClient interface {
+	GetLicense(ctx context.Context, owner, repo string) (*github.RepositoryLicense, *github.Response, error)
+}
+
+// License is a license found in a repository, identified by its SPDX identifier, and the file declaring it.
+type License struct {
+	SPDXID string
+	Path   string
+}
+
+// Licenses holds the license of a repository and the licenses declared by LICENSE files in its
+// subdirectories, keyed by directory.
+type Licenses struct {
+	Repository  License
+	Directories map[string]License
+}
+
+// For returns the license applying to the given file path: the license of the closest directory
+// containing it, or the repository license.
+func (l Licenses) For(filePath string) License {
+	for dir := path.Dir(strings.Trim(filePath, "/")); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if license, ok := l.Directories[dir]; ok {
+			return license
+		}
+	}
+	return l.Repository
+}
+
+// DetectLicense identifies the license of a license file by its text and returns its SPDX identifier.
+// Text that does not match any known license yields LicenseNoAssertion.
+func DetectLicense(text string) string {
+	normalized := strings.ToLower(strings.Join(strings.Fields(text), " "))
+	head := normalized
+	if len(head) > licenseTitleWindow {
+		head = head[:licenseTitleWindow]
+	}
+
+	for _, signature := range licenseSignatures {
+		if signature.title != "" {
+			if strings.Contains(head, signature.title) {
+				return signature.spdxID
+			}
+			continue
+		}
+
+		matched := true
+		for _, phrase := range signature.phrases {
+			if !strings.Contains(normalized, phrase) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return signature.spdxID
+		}
+	}
+	return LicenseNoAssertion
+}
+
+// GetLicense retrieves the license GitHub detected for a specific repository.
+func (gClient *GitHubCommitsOpsClient) GetLicense(ctx context.Context, owner, repo string) (*github.RepositoryLicense, *github.Response, error) {
+	return gClient.GitHubClient.Repositories.License(ctx, owner, repo)
+}
+
+// GetLicenses detects the licenses of a configured repository. The repository license is the one GitHub
+// detected, falling back to the license file at the root if GitHub could not detect one. Every directory
+// holding its own LICENSE or COPYING file overrides the license for the files inside it.
+//
+// The whole default branch is scanned for license files, regardless of the configured filter.
+//
+// Usage:
+//
+//	licenses, err := c.GetLicenses(ctx, "repo1")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	fmt.Println(licenses.For("third_party/lib/lib.go").SPDXID)
+func (c *GitHub) GetLicenses(ctx context.Context, repo string) (Licenses, error) {
+	client, err := c.licenseOpsClient()
+	if err != nil {
+		return Licenses{}, err
+	}
+
+	licenses := Licenses{Directories: make(map[string]License)}
+
+	repoLicense, _, err := client.GetLicense(ctx, c.ownerOf(repo), c.nameOf(repo))
+	if err != nil && !isNotFound(err) {
+		return Licenses{}, c.classifyError(err)
+	}
+	if err == nil {
+		licenses.Repository = License{SPDXID: repoLicense.GetLicense().GetSPDXID(), Path: repoLicense.GetPath()}
+	}
+
+	branch, err := c.branch(ctx, repo)
+	if err != nil {
+		return Licenses{}, err
+	}
+
+	entries, err := c.listFileEntries(ctx, c.ownerOf(repo), c.nameOf(repo), fmt.Sprintf("%s:", branch))
+	if err != nil {
+		return Licenses{}, err
+	}
+
+	for _, entry := range entries {
+		if !licenseFilePattern.MatchString(entry.Name) {
+			continue
+		}
+
+		dir := path.Dir(entry.Path)
+		if dir == "." && licenses.Repository.SPDXID != "" && licenses.Repository.SPDXID != LicenseNoAssertion {
+			continue
+		}
+		if _, ok := licenses.Directories[dir]; ok {
+			continue
+		}
+
+		text, err := c.getBlobText(ctx, c.ownerOf(repo), c.nameOf(repo), fmt.Sprintf("%s:%s", branch, entry.Path))
+		if err != nil {
+			return Licenses{}, err
+		}
+
+		license := License{SPDXID: DetectLicense(text), Path: entry.Path}
+		if dir == "." {
+			licenses.Repository = license
+			continue
+		}
+		licenses.Directories[dir] = license
+	}
+
+	return licenses, nil
+}
+
+// AttachLicenses records the license applying to every document in its "license" metadata, and the file
+// declaring it in "license_path". Licenses are detected once per repository.
+func (c *GitHub) AttachLicenses(ctx context.Context, docs []document.Document) error {
+	licenses := make(map[string]Licenses)
+	for i := range docs {
+		repoLicenses, ok := licenses[docs[i].Repository]
+		if !ok {
+			var err error
+			repoLicenses, err = c.GetLicenses(ctx, docs[i].Repository)
+			if err != nil {
+				return err
+			}
+			licenses[docs[i].Repository] = repoLicenses
+		}
+
+		license := repoLicenses.For(docs[i].Path)
+		if license.SPDXID == "" {
+			continue
+		}
+		if docs[i].Metadata == nil {
+			docs[i].Metadata = make(map[string]string)
+		}
+		docs[i].Metadata["license"] = license.SPDXID
+		docs[i].Metadata["license_path"] = license.Path
+	}
+
+	return nil
+}
+
+// isNotFound reports whether err is a GitHub API error for a missing resource.
+func isNotFound(err error) bool {
+	var errResp *github.ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound
+}
+
+// licenseOpsClient returns the commit ops client as a LicenseOpsClient.
+func (c *GitHub) licenseOpsClient() (LicenseOpsClient, error) {
+	client, ok := c.commitOpsClient.(LicenseOpsClient)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not implement LicenseOpsClient", ErrUnsupportedClient, c.commitOpsClient)
+	}
+	return client, nil
+}
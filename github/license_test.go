@@ -0,0 +1,178 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// LicenseOpsClientMock is a mock type for a CommitOpsClient that also implements LicenseOpsClient
+type LicenseOpsClientMock struct {
+	CommitOpsClientMock
+}
+
+// GetLicense provides a mock function with given fields: ctx, owner, repo
+func (_m *LicenseOpsClientMock) GetLicense(ctx context.Context, owner, repo string) (*github.RepositoryLicense, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo)
+	license, _ := ret.Get(0).(*github.RepositoryLicense)
+	resp, _ := ret.Get(1).(*github.Response)
+	return license, resp, ret.Error(2)
+}
+
+// gplv3Text holds the title, preamble and the sections of GPLv3 referring to the GNU Affero and Lesser
+// General Public Licenses.
+const gplv3Text = `                    GNU GENERAL PUBLIC LICENSE
+                       Version 3, 29 June 2007
+
+ Copyright (C) 2007 Free Software Foundation, Inc. <https://fsf.org/>
+ Everyone is permitted to copy and distribute verbatim copies
+ of this license document, but changing it is not allowed.
+
+                            Preamble
+
+  The GNU General Public License is a free, copyleft license for
+software and other kinds of works.
+
+  13. Use with the GNU Affero General Public License.
+
+  Notwithstanding any other provision of this License, you have
+permission to link or combine any covered work with a work licensed
+under version 3 of the GNU Affero General Public License into a single
+combined work, and to convey the resulting work.
+
+  The GNU General Public License does not permit incorporating your program
+into proprietary programs.  If your program is a subroutine library, you
+may consider it more useful to permit linking proprietary applications with
+the library.  If this is what you want to do, use the GNU Lesser General
+Public License instead of this License.  But first, please read
+<https://www.gnu.org/licenses/why-not-lgpl.html>.
+`
+
+func TestDetectLicense(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "MIT", text: "MIT License\n\nPermission is hereby granted,\nfree of charge, to any person", want: "MIT"},
+		{name: "Apache", text: "Apache License\n  Version 2.0, January 2004", want: "Apache-2.0"},
+		{name: "GPL-3.0", text: "GNU GENERAL PUBLIC LICENSE\nVersion 3, 29 June 2007", want: "GPL-3.0"},
+		{name: "GPL-3.0 full text", text: gplv3Text, want: "GPL-3.0"},
+		{name: "GPL-2.0", text: "GNU GENERAL PUBLIC LICENSE\nVersion 2, June 1991", want: "GPL-2.0"},
+		{name: "AGPL-3.0", text: "GNU AFFERO GENERAL PUBLIC LICENSE\nVersion 3, 19 November 2007\n... GNU General Public License", want: "AGPL-3.0"},
+		{name: "LGPL-3.0", text: "GNU LESSER GENERAL PUBLIC LICENSE\nVersion 3, 29 June 2007\n\nThis version of the GNU Lesser General Public License incorporates\nthe terms and conditions of version 3 of the GNU General Public\nLicense", want: "LGPL-3.0"},
+		{name: "title after a project name", text: "mylib\n\nGNU LESSER GENERAL PUBLIC LICENSE\nVersion 3, 29 June 2007", want: "LGPL-3.0"},
+		{name: "title mentioned in a notice", text: "This program is free software: you can redistribute it and/or modify it under the terms of the GNU Affero General Public License as published by the Free Software Foundation, either version 3 of the License, or (at your option) any later version. You should have received a copy of the GNU Affero General Public License Version 3, 19 November 2007 along with this program.", want: LicenseNoAssertion},
+		{name: "LGPL-2.1", text: "GNU LESSER GENERAL PUBLIC LICENSE\nVersion 2.1, February 1999\n... GNU General Public License", want: "LGPL-2.1"},
+		{name: "BSD-3-Clause", text: "Redistribution and use in source and binary forms ... Neither the name of the copyright holder", want: "BSD-3-Clause"},
+		{name: "BSD-2-Clause", text: "Redistribution and use in source and binary forms, with or without modification", want: "BSD-2-Clause"},
+		{name: "unknown", text: "All rights reserved.", want: LicenseNoAssertion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectLicense(tt.text))
+		})
+	}
+}
+
+func TestLicensePattern(t *testing.T) {
+	for _, name := range []string{"LICENSE", "LICENSE.md", "License.txt", "LICENCE", "LICENSE-APACHE", "COPYING", "COPYING.LESSER", "UNLICENSE"} {
+		assert.True(t, licenseFilePattern.MatchString(name), name)
+	}
+	for _, name := range []string{"license.go", "licenses.md", "LICENSE_HEADER.java", "copyright.txt"} {
+		assert.False(t, licenseFilePattern.MatchString(name), name)
+	}
+}
+
+func newLicenseTestGraphQLClient() *GraphQLClientMock {
+	trees := map[string][]GHTreeEntry{
+		"main:": {
+			{Name: "LICENSE", Path: "LICENSE", Type: "blob"},
+			{Name: "main.go", Path: "main.go", Type: "blob"},
+			{Name: "third_party", Path: "third_party", Type: "tree"},
+		},
+		"main:/third_party": {
+			{Name: "lib", Path: "third_party/lib", Type: "tree"},
+		},
+		"main:/third_party/lib": {
+			{Name: "COPYING", Path: "third_party/lib/COPYING", Type: "blob"},
+			{Name: "lib.go", Path: "third_party/lib/lib.go", Type: "blob"},
+		},
+	}
+	blobs := map[string]string{
+		"main:LICENSE":                 "Permission is hereby granted, free of charge",
+		"main:third_party/lib/COPYING": "GNU GENERAL PUBLIC LICENSE Version 2, June 1991",
+	}
+
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		expression := string(args.Get(2).(map[string]interface{})["expression"].(githubv4.String))
+		switch query := args.Get(1).(type) {
+		case *GHQueryForListFiles:
+			query.Repository.Object.Tree.Entries = trees[expression]
+		case *GHQueryForBlobText:
+			query.Repository.Object.Blob.Text = blobs[expression]
+		}
+	}).Return(nil)
+	return graphQLClient
+}
+
+func TestGitHub_GetLicenses(t *testing.T) {
+	client := new(LicenseOpsClientMock)
+	client.On("GetLicense", mock.Anything, "testowner", "repo1").Return(&github.RepositoryLicense{
+		Path:    github.String("LICENSE"),
+		License: &github.License{SPDXID: github.String("Apache-2.0")},
+	}, nil, nil)
+
+	graphQLClient := newLicenseTestGraphQLClient()
+	gh := NewGitHubClient(client, graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+	})
+
+	licenses, err := gh.GetLicenses(context.Background(), "repo1")
+	assert.NoError(t, err)
+	assert.Equal(t, Licenses{
+		Repository: License{SPDXID: "Apache-2.0", Path: "LICENSE"},
+		Directories: map[string]License{
+			"third_party/lib": {SPDXID: "GPL-2.0", Path: "third_party/lib/COPYING"},
+		},
+	}, licenses)
+
+	assert.Equal(t, "GPL-2.0", licenses.For("third_party/lib/lib.go").SPDXID)
+	assert.Equal(t, "Apache-2.0", licenses.For("main.go").SPDXID)
+	graphQLClient.AssertNotCalled(t, "Query", mock.Anything, mock.Anything, map[string]interface{}{
+		"owner": githubv4.String("testowner"), "name": githubv4.String("repo1"), "expression": githubv4.String("main:LICENSE"),
+	})
+}
+
+func TestGitHub_AttachLicenses(t *testing.T) {
+	client := new(LicenseOpsClientMock)
+	client.On("GetLicense", mock.Anything, "testowner", "repo1").Return(nil, nil, &github.ErrorResponse{
+		Response: &http.Response{StatusCode: http.StatusNotFound},
+	}).Once()
+
+	gh := NewGitHubClient(client, newLicenseTestGraphQLClient(), GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+	})
+
+	docs := []document.Document{
+		{Repository: "repo1", Path: "third_party/lib/lib.go"},
+		{Repository: "repo1", Metadata: map[string]string{"issue": "1"}},
+	}
+	assert.NoError(t, gh.AttachLicenses(context.Background(), docs))
+
+	assert.Equal(t, map[string]string{"license": "GPL-2.0", "license_path": "third_party/lib/COPYING"}, docs[0].Metadata)
+	assert.Equal(t, map[string]string{"issue": "1", "license": "MIT", "license_path": "LICENSE"}, docs[1].Metadata)
+	client.AssertExpectations(t)
+}
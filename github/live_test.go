@@ -0,0 +1,97 @@
+//go:build live
+
+package github_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The live tests run a minimal end-to-end collection against the real GitHub API to catch API drift the
+// mocks and the fake server cannot. They are built with the live tag and skipped unless a token is set:
+//
+//	COCOGH_LIVE_TOKEN=... go test -tags live -run Live ./...
+const (
+	liveTokenEnv = "COCOGH_LIVE_TOKEN"
+	// liveRepoEnv overrides the public repository the live tests read, as "owner/name".
+	liveRepoEnv     = "COCOGH_LIVE_REPO"
+	defaultLiveRepo = "octocat/Hello-World"
+)
+
+// bearerTransport authenticates requests with a personal access token.
+type bearerTransport struct {
+	token string
+}
+
+func (t bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newLiveGitHub(t *testing.T) *github.GitHub {
+	token := os.Getenv(liveTokenEnv)
+	if token == "" {
+		t.Skipf("%s is not set", liveTokenEnv)
+	}
+
+	fullName := os.Getenv(liveRepoEnv)
+	if fullName == "" {
+		fullName = defaultLiveRepo
+	}
+	owner, name, ok := strings.Cut(fullName, "/")
+	require.True(t, ok, "%s must be owner/name", liveRepoEnv)
+
+	httpClient := &http.Client{Transport: bearerTransport{token: token}, Timeout: time.Minute}
+	ops := github.NewGitHubCommitsOpsClient(httpClient)
+
+	repo, _, err := ops.GitHubClient.Repositories.Get(context.Background(), owner, name)
+	require.NoError(t, err)
+
+	return github.NewGitHubClient(ops, githubv4.NewClient(httpClient), github.GitHubConfig{
+		Owner:         owner,
+		Repositories:  []string{name},
+		DefaultBranch: repo.GetDefaultBranch(),
+	})
+}
+
+func TestLive_GetFilePathsFromRepositories(t *testing.T) {
+	gh := newLiveGitHub(t)
+
+	files, err := gh.GetFilePathsFromRepositories()
+	require.NoError(t, err)
+	assert.NotEmpty(t, files)
+}
+
+func TestLive_GetSnapshot(t *testing.T) {
+	gh := newLiveGitHub(t)
+
+	snapshot, err := gh.GetSnapshot(context.Background())
+	require.NoError(t, err)
+	for repo, files := range snapshot {
+		assert.NotEmpty(t, files, repo)
+		for path, oid := range files {
+			assert.Len(t, oid, 40, path)
+		}
+	}
+}
+
+func TestLive_GetCommitDocumentsSince(t *testing.T) {
+	gh := newLiveGitHub(t)
+
+	docs, err := gh.GetCommitDocumentsSince(context.Background(), time.Time{})
+	require.NoError(t, err)
+	require.NotEmpty(t, docs)
+	assert.NotEmpty(t, docs[0].ID)
+	assert.NotEmpty(t, docs[0].Title)
+	assert.False(t, docs[0].CreatedAt.IsZero())
+}
@@ -0,0 +1,70 @@
+package github
+
+import (
+	"net/http"
+	"time"
+)
+
+// Logger receives debug logs about the progress of a client: API calls, crawled repositories, retries and
+// rate limit pauses. keysAndValues alternate between keys and values, e.g. "repository", "docs". A
+// *slog.Logger satisfies it.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+}
+
+// WarnLogger is a Logger also receiving warnings about results GitHub cut short, such as truncated trees,
+// and how the client worked around them. Loggers without Warn receive warnings as debug logs. A *slog.Logger
+// satisfies it.
+type WarnLogger interface {
+	Logger
+	Warn(msg string, keysAndValues ...interface{})
+}
+
+// debug logs to the configured Logger, if any.
+func (c *GitHub) debug(msg string, keysAndValues ...interface{}) {
+	logDebug(c.Configuration.Logger, msg, keysAndValues...)
+}
+
+// warn logs a warning to the configured Logger, if any.
+func (c *GitHub) warn(msg string, keysAndValues ...interface{}) {
+	if logger, ok := c.Configuration.Logger.(WarnLogger); ok {
+		logger.Warn(msg, keysAndValues...)
+		return
+	}
+	c.debug(msg, keysAndValues...)
+}
+
+// LoggingTransport is an http.RoundTripper logging every request with its status and duration. NewGitHub
+// and NewClientsFromToken use one when a Logger is passed with WithLogger.
+type LoggingTransport struct {
+	base   http.RoundTripper
+	logger Logger
+}
+
+// NewLoggingTransport wraps the base transport, nil uses http.DefaultTransport.
+func NewLoggingTransport(base http.RoundTripper, logger Logger) *LoggingTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &LoggingTransport{base: base, logger: logger}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	started := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.logger.Debug("API call failed", "method", req.Method, "url", req.URL.String(), "duration", time.Since(started), "error", err)
+		return resp, err
+	}
+
+	t.logger.Debug("API call", "method", req.Method, "url", req.URL.String(), "status", resp.StatusCode, "duration", time.Since(started))
+	return resp, nil
+}
+
+// logDebug logs to the logger if it is set.
+func logDebug(logger Logger, msg string, keysAndValues ...interface{}) {
+	if logger != nil {
+		logger.Debug(msg, keysAndValues...)
+	}
+}
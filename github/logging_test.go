@@ -0,0 +1,129 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingLogger records the messages and their first key-value pair.
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(keysAndValues) >= 2 {
+		msg = fmt.Sprintf("%s %s=%v", msg, keysAndValues[0], keysAndValues[1])
+	}
+	l.messages = append(l.messages, msg)
+}
+
+// recordingWarnLogger is a recordingLogger also recording warnings.
+type recordingWarnLogger struct {
+	recordingLogger
+	warnings []string
+}
+
+func (l *recordingWarnLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(keysAndValues) >= 2 {
+		msg = fmt.Sprintf("%s %s=%v", msg, keysAndValues[0], keysAndValues[1])
+	}
+	l.warnings = append(l.warnings, msg)
+}
+
+func TestGitHubClient_LogsCrawledRepositories(t *testing.T) {
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.Anything).Return([]*github.RepositoryCommit{}, &github.Response{}, nil)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo2", mock.Anything).Return(nil, nil, errors.New("boom"))
+
+	logger := &recordingLogger{}
+	client := NewGitHubClient(commitOpsClient, new(GraphQLClientMock), GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1", "repo2"},
+		DefaultBranch: "main",
+		Logger:        logger,
+	})
+
+	_, err := client.GetChangedFilePathsSince(time.Time{})
+	assert.EqualError(t, err, "boom")
+	assert.Equal(t, []string{
+		"crawling repository repository=repo1",
+		"crawled repository repository=repo1",
+		"crawling repository repository=repo2",
+		"crawling repository failed repository=repo2",
+	}, logger.messages)
+}
+
+func TestNewGitHub_WithLogger(t *testing.T) {
+	failures := 1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+
+	logger := &recordingLogger{}
+	client, err := NewGitHub("token",
+		WithBaseURL(srv.URL),
+		WithRepositories("testowner", "repo1"),
+		WithDefaultBranch("main"),
+		WithRetryPolicy(RetryPolicy{BaseDelay: time.Millisecond}),
+		WithLogger(logger),
+	)
+	require.NoError(t, err)
+
+	_, err = client.GetChangedFilePathsSince(time.Time{})
+	require.NoError(t, err)
+
+	commits := srv.URL + "/api/v3/repos/testowner/repo1/commits?per_page=100&sha=main"
+	assert.Equal(t, []string{
+		"crawling repository repository=repo1",
+		"API call method=GET",
+		"retrying request url=" + commits,
+		"API call method=GET",
+		"crawled repository repository=repo1",
+	}, logger.messages)
+}
+
+func TestRateLimitTransport_Logger(t *testing.T) {
+	server := &rateLimitServer{responses: []func(w http.ResponseWriter){status(http.StatusForbidden, "Retry-After", "1")}}
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	logger := &recordingLogger{}
+	transport, _ := newTestRateLimitTransport(RateLimitOptions{Logger: logger})
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL, nil)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, []string{"rate limited, retrying resource=core"}, logger.messages)
+}
+
+func TestGitHubClient_WarnWithoutWarnLogger(t *testing.T) {
+	logger := &recordingLogger{}
+	gh := NewGitHubClient(new(CommitOpsClientMock), new(GraphQLClientMock), GitHubConfig{Logger: logger})
+
+	gh.warn("tree truncated, listing it directory by directory", "repository", "testowner/repo1")
+	assert.Equal(t, []string{"tree truncated, listing it directory by directory repository=testowner/repo1"}, logger.messages)
+}
@@ -0,0 +1,47 @@
+package github
+
+import "github.com/google/go-github/v57/github"
+
+// MergeStrategy selects how merge commits take part in detecting changes from the commit history. A merge
+// commit lists every file the merged branch changed, so with both the merge and the commits of the branch in
+// the range, these files are seen twice.
+type MergeStrategy string
+
+const (
+	// MergeStrategyInclude detects the changes of every commit, merge commits with the files changed against
+	// their first parent.
+	MergeStrategyInclude MergeStrategy = ""
+	// MergeStrategySkip skips merge commits, so the changes of merged branches are detected from their own
+	// commits only.
+	MergeStrategySkip MergeStrategy = "skip-merges"
+	// MergeStrategyFirstParent follows the first parents from the newest commit, like git log --first-parent,
+	// so the changes of merged branches are detected from their merge commits only. The history is listed
+	// without narrowing it to the crawled directory, as narrowed histories miss commits of the chain.
+	MergeStrategyFirstParent MergeStrategy = "first-parent"
+)
+
+// mergeFilter selects the commits of a history listed newest first according to a MergeStrategy.
+type mergeFilter struct {
+	strategy MergeStrategy
+	started  bool
+	next     string
+}
+
+// keep reports whether the changes of the commit are detected. Commits must be passed in the order they are
+// listed.
+func (f *mergeFilter) keep(commit *github.RepositoryCommit) bool {
+	switch f.strategy {
+	case MergeStrategySkip:
+		return len(commit.Parents) < 2
+	case MergeStrategyFirstParent:
+		if f.started && commit.GetSHA() != f.next {
+			return false
+		}
+		f.started = true
+		f.next = ""
+		if len(commit.Parents) > 0 {
+			f.next = commit.Parents[0].GetSHA()
+		}
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func commitWithParents(sha string, parents ...string) *github.RepositoryCommit {
+	commit := &github.RepositoryCommit{SHA: github.String(sha)}
+	for _, parent := range parents {
+		commit.Parents = append(commit.Parents, &github.Commit{SHA: github.String(parent)})
+	}
+	return commit
+}
+
+// mergeHistory is a history listed newest first, with the branch of feature1 and feature2 merged into main.
+var mergeHistory = []*github.RepositoryCommit{
+	commitWithParents("merge", "main2", "feature2"),
+	commitWithParents("feature2", "feature1"),
+	commitWithParents("main2", "main1"),
+	commitWithParents("feature1", "main1"),
+	commitWithParents("main1", "root"),
+	commitWithParents("root"),
+}
+
+func TestMergeFilter_keep(t *testing.T) {
+	tests := []struct {
+		strategy MergeStrategy
+		want     []string
+	}{
+		{strategy: MergeStrategyInclude, want: []string{"merge", "feature2", "main2", "feature1", "main1", "root"}},
+		{strategy: MergeStrategySkip, want: []string{"feature2", "main2", "feature1", "main1", "root"}},
+		{strategy: MergeStrategyFirstParent, want: []string{"merge", "main2", "main1", "root"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			merges := &mergeFilter{strategy: tt.strategy}
+			var kept []string
+			for _, commit := range mergeHistory {
+				if merges.keep(commit) {
+					kept = append(kept, commit.GetSHA())
+				}
+			}
+			assert.Equal(t, tt.want, kept)
+		})
+	}
+}
+
+func TestGitHubClient_GetChangedFilePathsSince_MergeStrategy(t *testing.T) {
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.CommitsListOptions) bool {
+		return opts.Path == ""
+	})).Return(mergeHistory[:4], nil, nil)
+	for _, sha := range []string{"merge", "main2"} {
+		commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", sha, mock.Anything).Return(&github.RepositoryCommit{
+			Files: []*github.CommitFile{{Filename: github.String("docs/" + sha + ".md"), Status: github.String("added")}},
+		}, nil, nil)
+	}
+
+	client := NewGitHubClient(commitOpsClient, new(GraphQLClientMock), GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+		MergeStrategy: MergeStrategyFirstParent,
+	})
+
+	paths, err := client.GetChangedFilePathsSince(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/merge.md", "docs/main2.md"}, paths.Added)
+	commitOpsClient.AssertNumberOfCalls(t, "GetCommit", 2)
+}
@@ -0,0 +1,127 @@
+package github
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Metrics receives measurements of a client, so they can be exported to Prometheus, OpenTelemetry or any
+// other monitoring system. Embed NopMetrics to implement only some of the methods.
+//
+// APICall is called for every HTTP request with its endpoint, e.g. "GET /repos/{owner}/{repo}/commits",
+// the status code, zero if the request failed, and the duration. RateLimit is called with the quota
+// reported by every response. FilesCollected is called with the number of files collected from a
+// repository, RepositoryCrawled after a repository has been crawled, with the error if the crawl failed.
+//
+// Methods may be called concurrently.
+type Metrics interface {
+	APICall(endpoint string, status int, duration time.Duration, err error)
+	RateLimit(quota RateLimitQuota)
+	FilesCollected(repository string, count int)
+	RepositoryCrawled(repository string, duration time.Duration, err error)
+}
+
+// NopMetrics is a Metrics ignoring all measurements.
+type NopMetrics struct{}
+
+// APICall implements Metrics.
+func (NopMetrics) APICall(string, int, time.Duration, error) {}
+
+// RateLimit implements Metrics.
+func (NopMetrics) RateLimit(RateLimitQuota) {}
+
+// FilesCollected implements Metrics.
+func (NopMetrics) FilesCollected(string, int) {}
+
+// RepositoryCrawled implements Metrics.
+func (NopMetrics) RepositoryCrawled(string, time.Duration, error) {}
+
+// metrics returns the configured Metrics, NopMetrics if there is none.
+func (c *GitHub) metrics() Metrics {
+	if c.Configuration.Metrics == nil {
+		return NopMetrics{}
+	}
+	return c.Configuration.Metrics
+}
+
+// MetricsTransport is an http.RoundTripper reporting every request and the rate limit quota of every
+// response to a Metrics. NewGitHub and NewClientsFromToken use one when Metrics are passed with WithMetrics.
+type MetricsTransport struct {
+	base    http.RoundTripper
+	metrics Metrics
+}
+
+// NewMetricsTransport wraps the base transport, nil uses http.DefaultTransport.
+func NewMetricsTransport(base http.RoundTripper, metrics Metrics) *MetricsTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &MetricsTransport{base: base, metrics: metrics}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *MetricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	started := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.metrics.APICall(endpointOf(req), 0, time.Since(started), err)
+		return resp, err
+	}
+
+	t.metrics.APICall(endpointOf(req), resp.StatusCode, time.Since(started), nil)
+	if quota, ok := responseQuota(resp); ok {
+		t.metrics.RateLimit(quota)
+	}
+	return resp, nil
+}
+
+// endpointOf returns the method and path of the request with owners, repositories, refs and numbers replaced
+// by placeholders, so endpoints can be used as metric labels without exploding their cardinality.
+func endpointOf(req *http.Request) string {
+	path := strings.TrimPrefix(req.URL.Path, "/api/v3")
+	path = strings.TrimPrefix(path, "/api")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, segment := range segments {
+		switch {
+		case i == 1 && (segments[0] == "repos" || segments[0] == "orgs" || segments[0] == "users"):
+			segments[i] = "{owner}"
+		case i == 2 && segments[0] == "repos":
+			segments[i] = "{repo}"
+		case i > 3 && segments[0] == "repos" && refParents[segments[i-1]]:
+			// Refs and paths may contain slashes, so everything after them is one placeholder.
+			segments = append(segments[:i], "{ref}")
+			return req.Method + " /" + strings.Join(segments, "/")
+		case isNumber(segment):
+			segments[i] = "{id}"
+		}
+	}
+
+	return req.Method + " /" + strings.Join(segments, "/")
+}
+
+// refParents are the path segments of the REST API followed by a ref, SHA or file path.
+var refParents = map[string]bool{
+	"commits":  true,
+	"compare":  true,
+	"contents": true,
+	"trees":    true,
+	"blobs":    true,
+	"ref":      true,
+	"refs":     true,
+	"branches": true,
+}
+
+// isNumber checks if a path segment is a decimal number, e.g. the number of a pull request.
+func isNumber(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	for _, r := range segment {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,189 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingMetrics records the measurements without their durations.
+type recordingMetrics struct {
+	mu     sync.Mutex
+	calls  []string
+	quotas []RateLimitQuota
+	files  map[string]int
+	crawls []string
+}
+
+func (m *recordingMetrics) APICall(endpoint string, status int, _ time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, fmt.Sprintf("%s %d %v", endpoint, status, err != nil))
+}
+
+func (m *recordingMetrics) RateLimit(quota RateLimitQuota) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.quotas = append(m.quotas, quota)
+}
+
+func (m *recordingMetrics) FilesCollected(repository string, count int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.files == nil {
+		m.files = make(map[string]int)
+	}
+	m.files[repository] += count
+}
+
+func (m *recordingMetrics) RepositoryCrawled(repository string, _ time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.crawls = append(m.crawls, fmt.Sprintf("%s %v", repository, err))
+}
+
+func TestMetricsTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "5000")
+		w.Header().Set(headerRateRemaining, "4999")
+		w.Header().Set(headerRateUsed, "1")
+		w.Header().Set(headerRateReset, "1700000000")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	metrics := &recordingMetrics{}
+	transport := NewMetricsTransport(nil, metrics)
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/repos/o/r/pulls/42/files", nil)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, []string{"GET /repos/{owner}/{repo}/pulls/{id}/files 404 false"}, metrics.calls)
+	assert.Equal(t, []RateLimitQuota{{
+		Resource:  "core",
+		Limit:     5000,
+		Remaining: 4999,
+		Used:      1,
+		Reset:     time.Unix(1700000000, 0),
+	}}, metrics.quotas)
+}
+
+func TestMetricsTransport_Error(t *testing.T) {
+	metrics := &recordingMetrics{}
+	transport := NewMetricsTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}), metrics)
+
+	req, _ := http.NewRequest(http.MethodPost, "https://api.github.com/graphql", nil)
+	_, err := transport.RoundTrip(req)
+	assert.EqualError(t, err, "connection refused")
+	assert.Equal(t, []string{"POST /graphql 0 true"}, metrics.calls)
+	assert.Empty(t, metrics.quotas)
+}
+
+func TestEndpointOf(t *testing.T) {
+	tests := []struct {
+		method string
+		url    string
+		want   string
+	}{
+		{http.MethodPost, "https://api.github.com/graphql", "POST /graphql"},
+		{http.MethodPost, "https://github.example.com/api/graphql", "POST /graphql"},
+		{http.MethodGet, "https://api.github.com/repos/o/r/commits", "GET /repos/{owner}/{repo}/commits"},
+		{http.MethodGet, "https://api.github.com/repos/o/r/commits/abc123", "GET /repos/{owner}/{repo}/commits/{ref}"},
+		{http.MethodGet, "https://api.github.com/repos/o/r/compare/main...feature/x", "GET /repos/{owner}/{repo}/compare/{ref}"},
+		{http.MethodGet, "https://api.github.com/repos/o/r/git/trees/main", "GET /repos/{owner}/{repo}/git/trees/{ref}"},
+		{http.MethodGet, "https://api.github.com/repos/o/r/contents/docs/index.md", "GET /repos/{owner}/{repo}/contents/{ref}"},
+		{http.MethodGet, "https://github.example.com/api/v3/repos/o/r/issues/7/comments", "GET /repos/{owner}/{repo}/issues/{id}/comments"},
+		{http.MethodGet, "https://api.github.com/orgs/o/repos", "GET /orgs/{owner}/repos"},
+		{http.MethodGet, "https://api.github.com/app/installations/12/access_tokens", "GET /app/installations/{id}/access_tokens"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, tt.url, nil)
+			assert.Equal(t, tt.want, endpointOf(req))
+		})
+	}
+}
+
+func TestGitHubClient_Metrics(t *testing.T) {
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.Anything).Return([]*github.RepositoryCommit{}, &github.Response{}, nil)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo2", mock.Anything).Return(nil, nil, errors.New("boom"))
+
+	metrics := &recordingMetrics{}
+	client := NewGitHubClient(commitOpsClient, new(GraphQLClientMock), GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1", "repo2"},
+		DefaultBranch: "main",
+		Metrics:       metrics,
+	})
+
+	_, err := client.GetChangedFilePathsSince(time.Time{})
+	assert.EqualError(t, err, "boom")
+	assert.Equal(t, []string{"repo1 <nil>", "repo2 boom"}, metrics.crawls)
+}
+
+func TestGitHubClient_Metrics_FilesCollected(t *testing.T) {
+	var queried []string
+	metrics := &recordingMetrics{}
+	client := NewGitHubClient(new(CommitOpsClientMock), newWalkTestGraphQLClient(&queried), GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FileTypes: []string{".md"}},
+		Metrics:       metrics,
+	})
+
+	err := client.WalkFiles(context.Background(), FileOptions{}, func(file document.File) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"repo1": 3}, metrics.files)
+}
+
+func TestNewGitHub_WithMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+
+	metrics := &recordingMetrics{}
+	client, err := NewGitHub("token",
+		WithBaseURL(srv.URL),
+		WithRepositories("testowner", "repo1"),
+		WithDefaultBranch("main"),
+		WithMetrics(metrics),
+	)
+	require.NoError(t, err)
+
+	_, err = client.GetChangedFilePathsSince(time.Time{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"GET /repos/{owner}/{repo}/commits 200 false"}, metrics.calls)
+	assert.Equal(t, []string{"repo1 <nil>"}, metrics.crawls)
+}
+
+func TestNopMetrics(t *testing.T) {
+	var metrics Metrics = NopMetrics{}
+	metrics.APICall("GET /", http.StatusOK, time.Second, nil)
+	metrics.RateLimit(RateLimitQuota{})
+	metrics.FilesCollected("repo1", 1)
+	metrics.RepositoryCrawled("repo1", time.Second, nil)
+}
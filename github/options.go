@@ -0,0 +1,327 @@
+package github
+
+import (
+	"net/http"
+
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shurcooL/githubv4"
+)
+
+// Option configures a GitHub client created with NewGitHub.
+type Option func(*clientOptions)
+
+// clientOptions collects the settings of the Options passed to NewGitHub.
+type clientOptions struct {
+	httpClient  *http.Client
+	config      GitHubConfig
+	retryPolicy RetryPolicy
+	noRetry     bool
+	httpCache   HTTPCache
+}
+
+// WithHTTPClient sends the requests through httpClient, e.g. to set timeouts or wrap the transport in a
+// RateLimitTransport. The token is added to its requests. Without it http.DefaultTransport is used.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithLogger sends debug logs about API calls, crawled repositories and retries to the logger.
+func WithLogger(logger Logger) Option {
+	return func(o *clientOptions) {
+		o.config.Logger = logger
+	}
+}
+
+// WithMetrics reports API calls, rate limit quotas, collected files and crawl durations to the metrics.
+func WithMetrics(metrics Metrics) Option {
+	return func(o *clientOptions) {
+		o.config.Metrics = metrics
+	}
+}
+
+// WithTracer starts spans for repository crawls, GraphQL queries and commit fetches with the tracer.
+func WithTracer(tracer Tracer) Option {
+	return func(o *clientOptions) {
+		o.config.Tracer = tracer
+	}
+}
+
+// WithWikiFetcher sets how the wikis of repositories are fetched, see GitHubConfig.WikiFetcher.
+func WithWikiFetcher(fetcher WikiFetcher) Option {
+	return func(o *clientOptions) {
+		o.config.WikiFetcher = fetcher
+	}
+}
+
+// WithFrontMatter parses the front matter of Markdown files read with their content into File.Metadata, see
+// GitHubConfig.FrontMatter.
+func WithFrontMatter() Option {
+	return func(o *clientOptions) {
+		o.config.FrontMatter = true
+	}
+}
+
+// WithContinueOnError keeps collecting from the other repositories when one fails, see
+// GitHubConfig.ContinueOnError and PartialError.
+func WithContinueOnError() Option {
+	return func(o *clientOptions) {
+		o.config.ContinueOnError = true
+	}
+}
+
+// WithTreeBatchSize lists up to n trees per GraphQL query when walking trees through GraphQL, see
+// GitHubConfig.TreeBatchSize.
+func WithTreeBatchSize(n int) Option {
+	return func(o *clientOptions) {
+		o.config.TreeBatchSize = n
+	}
+}
+
+// WithFollowSubmodules lists the submodules of submodules with GetSubmodules, see
+// GitHubConfig.FollowSubmodules.
+func WithFollowSubmodules() Option {
+	return func(o *clientOptions) {
+		o.config.FollowSubmodules = true
+	}
+}
+
+// WithResolveSymlinks reads the content of the files symlinks point at with GetFileContents, see
+// GitHubConfig.ResolveSymlinks.
+func WithResolveSymlinks() Option {
+	return func(o *clientOptions) {
+		o.config.ResolveSymlinks = true
+	}
+}
+
+// WithCommitFilter sets the filter selecting the commits whose changes are detected, e.g. to skip the commits
+// of bots, see CommitFilter.
+func WithCommitFilter(f filter.CommitFilter) Option {
+	return func(o *clientOptions) {
+		o.config.CommitFilter = f
+	}
+}
+
+// WithMergeStrategy sets how merge commits take part in detecting changes, see MergeStrategy.
+func WithMergeStrategy(strategy MergeStrategy) Option {
+	return func(o *clientOptions) {
+		o.config.MergeStrategy = strategy
+	}
+}
+
+// WithFetcher lists the files and commit history of repositories with the fetcher instead of the API, see
+// GitHubConfig.Fetcher.
+func WithFetcher(fetcher Fetcher) Option {
+	return func(o *clientOptions) {
+		o.config.Fetcher = fetcher
+	}
+}
+
+// WithRetryPolicy sets the policy of the RetryTransport retrying transient failures.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retryPolicy = policy
+		o.noRetry = false
+	}
+}
+
+// WithoutRetry disables retrying transient failures, so they are returned to the caller right away.
+func WithoutRetry() Option {
+	return func(o *clientOptions) {
+		o.noRetry = true
+	}
+}
+
+// WithHTTPCache makes conditional requests with the responses stored in the cache, so unchanged resources
+// cost almost no rate limit quota on repeated runs, see CacheTransport.
+func WithHTTPCache(cache HTTPCache) Option {
+	return func(o *clientOptions) {
+		o.httpCache = cache
+	}
+}
+
+// WithConfig starts from an existing configuration. Options passed after it override its fields.
+func WithConfig(config GitHubConfig) Option {
+	return func(o *clientOptions) {
+		o.config = config
+	}
+}
+
+// WithRepositories collects the repositories of the owner.
+func WithRepositories(owner string, repositories ...string) Option {
+	return func(o *clientOptions) {
+		o.config.Owner = owner
+		o.config.Repositories = append(o.config.Repositories, repositories...)
+	}
+}
+
+// WithRepositoryRefs collects repositories of any owner, see RepositoryRef.
+func WithRepositoryRefs(refs ...RepositoryRef) Option {
+	return func(o *clientOptions) {
+		o.config.RepositoryRefs = append(o.config.RepositoryRefs, refs...)
+	}
+}
+
+// WithRepositoryFilter sets the filter selecting the crawled repositories, see RepositoryFilter.
+func WithRepositoryFilter(f filter.RepositoryFilter) Option {
+	return func(o *clientOptions) {
+		o.config.RepositoryFilter = f
+	}
+}
+
+// WithDefaultBranch sets the branch read from repositories without a configured branch.
+func WithDefaultBranch(branch string) Option {
+	return func(o *clientOptions) {
+		o.config.DefaultBranch = branch
+	}
+}
+
+// WithBaseURL points the client at the REST API of a GitHub Enterprise Server, e.g.
+// "https://github.example.com/". The GraphQL endpoint is derived from it.
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		o.config.BaseURL = baseURL
+	}
+}
+
+// WithGraphQLEndpoint sets the GraphQL endpoint if it cannot be derived from the base URL.
+func WithGraphQLEndpoint(endpoint string) Option {
+	return func(o *clientOptions) {
+		o.config.GraphQLEndpoint = endpoint
+	}
+}
+
+// WithConcurrency sets the maximum number of API calls made in parallel, see GitHubConfig.MaxConcurrency.
+func WithConcurrency(n int) Option {
+	return func(o *clientOptions) {
+		o.config.MaxConcurrency = n
+	}
+}
+
+// WithFilter sets the filter selecting the collected files.
+func WithFilter(f filter.GitHubFilter) Option {
+	return func(o *clientOptions) {
+		o.config.Filter = f
+	}
+}
+
+// WithClock sets the source of the current time, see GitHubConfig.Clock.
+func WithClock(clock Clock) Option {
+	return func(o *clientOptions) {
+		o.config.Clock = clock
+	}
+}
+
+// NewGitHub creates a GitHub client authenticating with a personal access token or any other token GitHub
+// accepts as a bearer token. It creates the REST and GraphQL clients itself, so neither go-github nor
+// githubv4 have to be wired up by hand. An empty token sends unauthenticated requests, which only work for
+// public repositories and the REST API. Transient failures are retried, see RetryTransport and
+// WithRetryPolicy.
+//
+// NewGitHubClient remains available for passing in clients directly, e.g. test doubles.
+//
+// Usage:
+//
+//	client, err := NewGitHub(os.Getenv("GITHUB_TOKEN"),
+//	    WithRepositories("kubernetes", "website"),
+//	    WithDefaultBranch("main"),
+//	    WithFilter(filter.GitHubFilter{FilePath: "content/en/blog/_posts", FileTypes: []string{".md"}}),
+//	    WithConcurrency(8),
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func NewGitHub(token string, opts ...Option) (*GitHub, error) {
+	o := applyOptions(opts)
+	if o.config.WikiFetcher == nil && token != "" {
+		o.config.WikiFetcher = GitWikiFetcher{Token: token}
+	}
+	restClient, graphQLClient, err := newAPIClients(o.tokenHTTPClient(token), o.config)
+	if err != nil {
+		return nil, err
+	}
+	return NewGitHubClient(&GitHubCommitsOpsClient{GitHubClient: restClient}, graphQLClient, o.config), nil
+}
+
+// NewClientsFromToken creates the REST and GraphQL clients NewGitHubClient takes, both authenticating with
+// the token. They talk to github.com, or to the GitHub Enterprise Server set with WithBaseURL and
+// WithGraphQLEndpoint, and retry transient failures like the clients of NewGitHub. Options not concerning
+// the connection are ignored. The REST client implements all optional ops client interfaces.
+//
+// Usage:
+//
+//	ops, graphQLClient, err := NewClientsFromToken(os.Getenv("GITHUB_TOKEN"), WithBaseURL("https://github.example.com/"))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	client := NewGitHubClient(ops, graphQLClient, config)
+func NewClientsFromToken(token string, opts ...Option) (*GitHubCommitsOpsClient, *githubv4.Client, error) {
+	o := applyOptions(opts)
+	restClient, graphQLClient, err := newAPIClients(o.tokenHTTPClient(token), o.config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &GitHubCommitsOpsClient{GitHubClient: restClient}, graphQLClient, nil
+}
+
+// applyOptions applies the options in order.
+func applyOptions(opts []Option) clientOptions {
+	var o clientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// tokenHTTPClient returns a copy of the configured HTTP client, or a new one, authenticating its requests
+// with the token, revalidating cached responses if an HTTPCache is configured, logging them if a Logger is
+// configured, measuring them if Metrics are configured and retrying transient failures unless disabled. An
+// empty token leaves the requests unauthenticated.
+func (o clientOptions) tokenHTTPClient(token string) *http.Client {
+	var httpClient http.Client
+	if o.httpClient != nil {
+		httpClient = *o.httpClient
+	}
+
+	// The cache sits below the token, so it keys responses by credentials.
+	if o.httpCache != nil {
+		httpClient.Transport = NewCacheTransport(httpClient.Transport, o.httpCache)
+	}
+	if token != "" {
+		httpClient.Transport = &tokenTransport{token: token, base: httpClient.Transport}
+	}
+	if o.config.Metrics != nil {
+		httpClient.Transport = NewMetricsTransport(httpClient.Transport, o.config.Metrics)
+	}
+	if o.config.Logger != nil {
+		httpClient.Transport = NewLoggingTransport(httpClient.Transport, o.config.Logger)
+	}
+	if !o.noRetry {
+		policy := o.retryPolicy
+		if policy.Logger == nil {
+			policy.Logger = o.config.Logger
+		}
+		httpClient.Transport = NewRetryTransport(httpClient.Transport, policy)
+	}
+	return &httpClient
+}
+
+// tokenTransport is an http.RoundTripper authenticating requests with a bearer token.
+type tokenTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	authenticated := req.Clone(req.Context())
+	authenticated.Header.Set("Authorization", "Bearer "+t.token)
+	return base.RoundTrip(authenticated)
+}
@@ -0,0 +1,139 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGitHub_Options(t *testing.T) {
+	fileFilter := filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}}
+	clock := fixedClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	client, err := NewGitHub("token",
+		WithConfig(GitHubConfig{DefaultBranch: "develop", PathNormalization: document.NormalizeNFC}),
+		WithRepositories("octo-org", "docs", "website"),
+		WithRepositoryRefs(RepositoryRef{Owner: "other-org", Name: "handbook"}),
+		WithRepositoryFilter(filter.RepositoryFilter{Topics: []string{"docs"}, ExcludeForks: true}),
+		WithDefaultBranch("main"),
+		WithBaseURL("https://github.example.com/"),
+		WithGraphQLEndpoint("https://github.example.com/custom/graphql"),
+		WithConcurrency(4),
+		WithFilter(fileFilter),
+		WithClock(clock),
+		WithFrontMatter(),
+		WithContinueOnError(),
+		WithTreeBatchSize(50),
+		WithFollowSubmodules(),
+		WithResolveSymlinks(),
+		WithCommitFilter(filter.CommitFilter{ExcludeBots: true}),
+		WithMergeStrategy(MergeStrategyFirstParent),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, GitHubConfig{
+		Owner:             "octo-org",
+		Repositories:      []string{"docs", "website"},
+		RepositoryRefs:    []RepositoryRef{{Owner: "other-org", Name: "handbook"}},
+		RepositoryFilter:  filter.RepositoryFilter{Topics: []string{"docs"}, ExcludeForks: true},
+		DefaultBranch:     "main",
+		Filter:            fileFilter,
+		PathNormalization: document.NormalizeNFC,
+		Clock:             clock,
+		MaxConcurrency:    4,
+		BaseURL:           "https://github.example.com/",
+		GraphQLEndpoint:   "https://github.example.com/custom/graphql",
+		WikiFetcher:       GitWikiFetcher{Token: "token"},
+		FrontMatter:       true,
+		ContinueOnError:   true,
+		TreeBatchSize:     50,
+		FollowSubmodules:  true,
+		ResolveSymlinks:   true,
+		CommitFilter:      filter.CommitFilter{ExcludeBots: true},
+		MergeStrategy:     MergeStrategyFirstParent,
+	}, client.Configuration)
+
+	ops, ok := client.commitOpsClient.(*GitHubCommitsOpsClient)
+	require.True(t, ok)
+	assert.Equal(t, "https://github.example.com/api/v3/", ops.GitHubClient.BaseURL.String())
+}
+
+func TestNewGitHub_Token(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{name: "token", token: "secret", want: "Bearer secret"},
+		{name: "anonymous", token: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var authorization string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorization = r.Header.Get("Authorization")
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("[]"))
+			}))
+			defer srv.Close()
+
+			client, err := NewGitHub(tt.token,
+				WithHTTPClient(srv.Client()),
+				WithBaseURL(srv.URL),
+				WithRepositories("testowner", "repo1"),
+				WithDefaultBranch("main"),
+			)
+			require.NoError(t, err)
+
+			_, err = client.GetChangedFilePathsSince(time.Time{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, authorization)
+		})
+	}
+}
+
+func TestNewClientsFromToken(t *testing.T) {
+	requests := make(map[string]string)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path] = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/graphql" {
+			_, _ = w.Write([]byte(`{"data":{"repository":{"defaultBranchRef":{"name":"main"}}}}`))
+			return
+		}
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+
+	ops, graphQLClient, err := NewClientsFromToken("secret", WithHTTPClient(srv.Client()), WithBaseURL(srv.URL))
+	require.NoError(t, err)
+
+	_, _, err = ops.ListCommits(context.Background(), "testowner", "repo1", nil)
+	require.NoError(t, err)
+
+	var query GHQueryForDefaultBranch
+	err = graphQLClient.Query(context.Background(), &query, map[string]interface{}{
+		"owner": githubv4.String("testowner"),
+		"name":  githubv4.String("repo1"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"/api/v3/repos/testowner/repo1/commits": "Bearer secret",
+		"/api/graphql":                          "Bearer secret",
+	}, requests)
+}
+
+func TestNewClientsFromToken_InvalidBaseURL(t *testing.T) {
+	_, _, err := NewClientsFromToken("secret", WithBaseURL("://invalid"))
+	assert.ErrorContains(t, err, "invalid GitHub Enterprise URL")
+}
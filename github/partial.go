@@ -0,0 +1,85 @@
+package github
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PartialError is returned together with the results of the successful repositories when
+// GitHubConfig.ContinueOnError is set and some repositories failed, e.g. because they were archived, renamed
+// or deleted, so one broken repository does not block the whole collection.
+//
+// Succeeded lists the crawled repositories in the configured order, Failed maps every failed repository to
+// its error. Repositories skipped by GitHubConfig.RepositoryFilter are in neither. errors.Is and errors.As
+// look into the errors of the failed repositories.
+//
+// Usage:
+//
+//	files, err := c.GetFiles(ctx, FileOptions{})
+//	var partial *PartialError
+//	if errors.As(err, &partial) {
+//	    for repo, err := range partial.Failed {
+//	        log.Printf("skipped %s: %v", repo, err)
+//	    }
+//	} else if err != nil {
+//	    log.Fatal(err)
+//	}
+type PartialError struct {
+	Succeeded []string
+	Failed    map[string]error
+}
+
+// newPartialError returns a *PartialError for the outcome of crawling the repositories, or nil if none failed.
+func newPartialError(repos []string, crawled []bool, errs []error) error {
+	partial := &PartialError{Failed: make(map[string]error)}
+	for i, repo := range repos {
+		switch {
+		case errs[i] != nil:
+			partial.Failed[repo] = errs[i]
+		case crawled[i]:
+			partial.Succeeded = append(partial.Succeeded, repo)
+		}
+	}
+	if len(partial.Failed) == 0 {
+		return nil
+	}
+	return partial
+}
+
+// Error implements the error interface, listing the failed repositories by name.
+func (e *PartialError) Error() string {
+	repos := e.failedRepositories()
+	messages := make([]string, len(repos))
+	for i, repo := range repos {
+		messages[i] = repo + ": " + e.Failed[repo].Error()
+	}
+	return fmt.Sprintf("%d of %d repositories failed: %s", len(repos), len(repos)+len(e.Succeeded), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of the failed repositories, ordered by repository name.
+func (e *PartialError) Unwrap() []error {
+	repos := e.failedRepositories()
+	errs := make([]error, len(repos))
+	for i, repo := range repos {
+		errs[i] = e.Failed[repo]
+	}
+	return errs
+}
+
+// failedRepositories returns the names of the failed repositories in order.
+func (e *PartialError) failedRepositories() []string {
+	repos := make([]string, 0, len(e.Failed))
+	for repo := range e.Failed {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	return repos
+}
+
+// partialResult reports whether err leaves results to return: there is no error or only some repositories
+// failed.
+func partialResult(err error) bool {
+	_, partial := err.(*PartialError)
+	return err == nil || partial
+}
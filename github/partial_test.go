@@ -0,0 +1,90 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newPartialTestGitHub returns a client for three repositories of which "archived" fails with errArchived.
+func newPartialTestGitHub(errArchived error, concurrency int) *GitHub {
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "archived", mock.Anything).Return(nil, nil, errArchived)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", mock.Anything, mock.Anything).
+		Return([]*github.RepositoryCommit{{SHA: github.String("c1")}}, &github.Response{}, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", mock.Anything, "c1", mock.Anything).
+		Return(func(_ context.Context, _, repo, _ string, _ *github.ListOptions) *github.RepositoryCommit {
+			return &github.RepositoryCommit{Files: []*github.CommitFile{{Filename: github.String(repo + ".md"), Status: github.String("added")}}}
+		}, &github.Response{}, nil)
+
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	return NewGitHubClient(commitOpsClient, graphQLClient, GitHubConfig{
+		Owner:           "testowner",
+		Repositories:    []string{"repo1", "archived", "repo3"},
+		DefaultBranch:   "main",
+		MaxConcurrency:  concurrency,
+		ContinueOnError: true,
+	})
+}
+
+func TestGitHub_ContinueOnError(t *testing.T) {
+	errArchived := errors.New("repository archived")
+
+	for _, concurrency := range []int{0, 3} {
+		client := newPartialTestGitHub(errArchived, concurrency)
+
+		paths, err := client.GetChangedFilePathsSince(time.Time{})
+		assert.Equal(t, []string{"repo1.md", "repo3.md"}, paths.Added)
+		assert.EqualError(t, err, "1 of 3 repositories failed: archived: repository archived")
+		assert.ErrorIs(t, err, errArchived)
+
+		var partial *PartialError
+		require.ErrorAs(t, err, &partial)
+		assert.Equal(t, []string{"repo1", "repo3"}, partial.Succeeded)
+		assert.Equal(t, map[string]error{"archived": errArchived}, partial.Failed)
+
+		client.Configuration.ContinueOnError = false
+		paths, err = client.GetChangedFilePathsSince(time.Time{})
+		assert.Equal(t, errArchived, err)
+		assert.Equal(t, document.Paths{}, paths)
+	}
+}
+
+func TestGitHub_ContinueOnError_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := newPartialTestGitHub(errors.New("repository archived"), 0).GetChangedFilePathsSinceWithContext(ctx, time.Time{})
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestGitHub_SyncChanges_ContinueOnError(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	store := NewMemorySyncStore()
+
+	client := newPartialTestGitHub(errors.New("repository archived"), 0)
+	client.Configuration.Clock = fixedClock(now)
+
+	_, err := client.SyncChanges(context.Background(), store)
+	var partial *PartialError
+	require.ErrorAs(t, err, &partial)
+
+	// The synced repositories move on, the failed one is synced from scratch next time.
+	state, ok, err := store.Load(context.Background(), "testowner/repo3")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, SyncState{Repository: "testowner/repo3", CommitSHA: "c1", SyncedAt: now}, state)
+
+	_, ok, err = store.Load(context.Background(), "testowner/archived")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
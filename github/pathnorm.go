@@ -0,0 +1,21 @@
+package github
+
+import (
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// normalizePath applies the configured normalization form to the path.
+func (c *GitHub) normalizePath(p string) string {
+	return document.NormalizePath(p, c.Configuration.PathNormalization)
+}
+
+// normalizePaths applies the configured normalization form to every path of the slice in place.
+func (c *GitHub) normalizePaths(paths []string) []string {
+	if c.Configuration.PathNormalization == document.NoNormalization {
+		return paths
+	}
+	for i := range paths {
+		paths[i] = c.normalizePath(paths[i])
+	}
+	return paths
+}
@@ -0,0 +1,50 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	nfcName = "caf\u00e9.md"
+	nfdName = "cafe\u0301.md"
+)
+
+func TestGitHub_PathNormalization(t *testing.T) {
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		if query, ok := args.Get(1).(*GHQueryForListFiles); ok {
+			query.Repository.Object.Tree.Entries = []GHTreeEntry{{Name: nfdName, Path: "docs/" + nfdName, Type: "blob"}}
+		}
+	}).Return(nil)
+
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]*github.RepositoryCommit{{SHA: github.String("1234567890")}}, nil, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&github.RepositoryCommit{Files: []*github.CommitFile{
+			{Filename: github.String("docs/" + nfdName), Status: github.String("modified")},
+		}}, nil, nil)
+
+	client := NewGitHubClient(commitOpsClient, graphQLClient, GitHubConfig{
+		Owner:             "testowner",
+		Repositories:      []string{"repo1"},
+		DefaultBranch:     "main",
+		Filter:            filter.GitHubFilter{FilePath: "docs"},
+		PathNormalization: document.NormalizeNFC,
+	})
+
+	files, err := client.GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/" + nfcName}, files)
+
+	paths, err := client.GetChangedFilePathsSince(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/" + nfcName}, paths.Modified)
+}
@@ -0,0 +1,95 @@
+package github
+
+import (
+	"strings"
+
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+)
+
+// pathFilters returns the filters of the directories crawled in the repository: the RepositoryPaths configured
+// for it or the Paths of the filter. It returns nil if neither is set and the repository is crawled below
+// GitHubFilter.FilePath.
+func (c *GitHub) pathFilters(repo string) []filter.GitHubFilter {
+	paths, ok := c.Configuration.RepositoryPaths[repo]
+	if !ok {
+		paths = c.Configuration.Filter.Paths
+	}
+
+	var filters []filter.GitHubFilter
+	for _, p := range paths {
+		filters = append(filters, c.Configuration.Filter.ForPath(p))
+	}
+	return filters
+}
+
+// rootPaths returns the directories crawled in the repository.
+func (c *GitHub) rootPaths(repo string) []string {
+	filters := c.pathFilters(repo)
+	if filters == nil {
+		return []string{c.Configuration.Filter.FilePath}
+	}
+
+	roots := make([]string, len(filters))
+	for i, pathFilter := range filters {
+		roots[i] = pathFilter.FilePath
+	}
+	return roots
+}
+
+// historyPath returns the path commit histories of the repository are narrowed to: the only crawled
+// directory, or empty if several are crawled and the changed files are filtered client-side.
+func (c *GitHub) historyPath(repo string) string {
+	roots := c.rootPaths(repo)
+	if len(roots) != 1 {
+		return ""
+	}
+	return roots[0]
+}
+
+// includeFile checks if the given file passes the configured filter, or the filter of a crawled directory
+// containing it. The rules hold the parsed .gitattributes and ignore file of the repository the file belongs
+// to.
+func (c *GitHub) includeFile(fileName string, rules fileRules) bool {
+	if rules.filters == nil {
+		return c.Configuration.Filter.MatchRepositoryFile(fileName, rules.attributes, rules.ignore)
+	}
+
+	for _, pathFilter := range rules.filters {
+		if filter.InDirectory(fileName, pathFilter.FilePath) && pathFilter.MatchRepositoryFile(fileName, rules.attributes, rules.ignore) {
+			return true
+		}
+	}
+	return false
+}
+
+// includeChangedFile checks if a changed file is inside the configured file path, or a crawled directory, and
+// passes the configured filter.
+func (c *GitHub) includeChangedFile(fileName string, rules fileRules) bool {
+	if rules.filters != nil {
+		return c.includeFile(fileName, rules)
+	}
+	return filter.InDirectory(fileName, c.Configuration.Filter.FilePath) && c.includeFile(fileName, rules)
+}
+
+// includeEntry checks if the file of a tree entry passes the configured filter, including the size and
+// binary checks that need the entry.
+func (c *GitHub) includeEntry(entry GHTreeEntry, rules fileRules) bool {
+	fileFilter := c.Configuration.Filter
+	if fileFilter.MaxFileSize > 0 && entry.Size > fileFilter.MaxFileSize {
+		return false
+	}
+	if fileFilter.SkipBinary && entry.Object.Blob.IsBinary {
+		return false
+	}
+	return c.includeFile(entry.Path, rules)
+}
+
+// hasFileType checks if the given fileName ends with any of the fileTypes.
+func (c *GitHub) hasFileType(fileName string, fileTypes []string) bool {
+	for _, fileType := range fileTypes {
+		if strings.HasSuffix(fileName, fileType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHub_Paths(t *testing.T) {
+	trees := map[string][]GHTreeEntry{
+		"main:docs": {
+			{Name: "api", Type: "tree"},
+			{Name: "index.md", Path: "docs/index.md", Type: "blob"},
+			{Name: "logo.png", Path: "docs/logo.png", Type: "blob"},
+		},
+		"main:docs/api":  {{Name: "ref.md", Path: "docs/api/ref.md", Type: "blob"}},
+		"main:docs/api/": {{Name: "ref.md", Path: "docs/api/ref.md", Type: "blob"}},
+		"main:guides": {
+			{Name: "setup.md", Path: "guides/setup.md", Type: "blob"},
+			{Name: "setup.mdx", Path: "guides/setup.mdx", Type: "blob"},
+		},
+	}
+
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForListFiles"), mock.Anything).Run(func(args mock.Arguments) {
+		expression := string(args.Get(2).(map[string]interface{})["expression"].(githubv4.String))
+		args.Get(1).(*GHQueryForListFiles).Repository.Object.Tree.Entries = trees[expression]
+	}).Return(nil)
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1", "repo2"},
+		DefaultBranch: "main",
+		Filter: filter.GitHubFilter{
+			FileTypes: []string{".md"},
+			Paths: []filter.PathFilter{
+				{Path: "docs"},
+				{Path: "guides", FileTypes: []string{".mdx"}},
+				{Path: "docs/api/"},
+			},
+		},
+		RepositoryPaths: map[string][]filter.PathFilter{"repo2": {{Path: "docs", Exclude: []string{"docs/api/**"}}}},
+	})
+
+	// The directories are merged in order, files below overlapping ones are listed once.
+	paths, err := gh.GetFilePathsFromRepositoriesWithContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/api/ref.md", "docs/index.md", "guides/setup.mdx", "docs/index.md"}, paths)
+
+	assert.Equal(t, "", gh.historyPath("repo1"))
+	assert.Equal(t, "docs", gh.historyPath("repo2"))
+
+	rules, err := gh.getFileRules(context.Background(), "repo1")
+	require.NoError(t, err)
+	assert.True(t, gh.includeChangedFile("guides/setup.mdx", rules))
+	assert.False(t, gh.includeChangedFile("guides/setup.md", rules))
+	assert.False(t, gh.includeChangedFile("guidesextra/setup.mdx", rules))
+	assert.False(t, gh.includeChangedFile("README.md", rules))
+
+	rules, err = gh.getFileRules(context.Background(), "repo2")
+	require.NoError(t, err)
+	assert.False(t, gh.includeChangedFile("docs/api/ref.md", rules))
+	assert.True(t, gh.includeChangedFile("docs/index.md", rules))
+}
+
+func TestGitHub_Paths_Unset(t *testing.T) {
+	gh := NewGitHubClient(new(CommitOpsClientMock), new(GraphQLClientMock), GitHubConfig{
+		Filter: filter.GitHubFilter{FilePath: "docs"},
+	})
+
+	assert.Nil(t, gh.pathFilters("repo1"))
+	assert.Equal(t, []string{"docs"}, gh.rootPaths("repo1"))
+	assert.Equal(t, "docs", gh.historyPath("repo1"))
+	assert.True(t, gh.includeChangedFile("docs/index.md", fileRules{}))
+}
@@ -0,0 +1,191 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shurcooL/githubv4"
+)
+
+// GHQueryForListProjectItems is a struct representing the GraphQL query for listing the items of a GitHub
+// project (ProjectV2) owned by a user or an organization, together with their field values.
+type GHQueryForListProjectItems struct {
+	RepositoryOwner struct {
+		ProjectV2Owner struct {
+			ProjectV2 struct {
+				Number int
+				Title  string
+				URL    string
+				Items  struct {
+					Nodes    []GHProjectItem
+					PageInfo struct {
+						HasNextPage bool
+						EndCursor   githubv4.String
+					}
+				} `graphql:"items(first: 50, after: $cursor)"`
+			} `graphql:"projectV2(number: $number)"`
+		} `graphql:"... on ProjectV2Owner"`
+	} `graphql:"repositoryOwner(login: $owner)"`
+}
+
+// GHProjectItem is a single project item as returned by the GitHub GraphQL API. The content is an issue, a
+// pull request or a draft issue, as told by Content.Typename.
+type GHProjectItem struct {
+	ID        string
+	Type      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Content   struct {
+		Typename string             `graphql:"__typename"`
+		Issue    GHProjectItemIssue `graphql:"... on Issue"`
+		Pull     GHProjectItemIssue `graphql:"... on PullRequest"`
+		Draft    struct {
+			Title string
+			Body  string
+		} `graphql:"... on DraftIssue"`
+	}
+	FieldValues struct {
+		Nodes []GHProjectFieldValue
+	} `graphql:"fieldValues(first: 50)"`
+}
+
+// GHProjectItemIssue holds the fields shared by issues and pull requests in a project item.
+type GHProjectItemIssue struct {
+	Number     int
+	Title      string
+	Body       string
+	URL        string
+	Repository struct {
+		Name string
+	}
+	Author struct {
+		Login string
+	}
+}
+
+// GHProjectFieldValue is the value of a project field of a project item, as told by Typename.
+type GHProjectFieldValue struct {
+	Typename string `graphql:"__typename"`
+	Text     struct {
+		Text  string
+		Field GHProjectField
+	} `graphql:"... on ProjectV2ItemFieldTextValue"`
+	SingleSelect struct {
+		Name  string
+		Field GHProjectField
+	} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+	Date struct {
+		Date  string
+		Field GHProjectField
+	} `graphql:"... on ProjectV2ItemFieldDateValue"`
+	Number struct {
+		Number float64
+		Field  GHProjectField
+	} `graphql:"... on ProjectV2ItemFieldNumberValue"`
+	Iteration struct {
+		Title string
+		Field GHProjectField
+	} `graphql:"... on ProjectV2ItemFieldIterationValue"`
+}
+
+// GHProjectField is the project field a field value belongs to.
+type GHProjectField struct {
+	Common struct {
+		Name string
+	} `graphql:"... on ProjectV2FieldCommon"`
+}
+
+// GetProjectItemDocuments retrieves the items of the GitHub project with the given number, owned by the
+// configured owner, as documents. The title and body of the document are taken from the issue, pull request
+// or draft issue of the item, and every field value of the item (Status, Iteration, ...) is added to the
+// metadata under the name of its field.
+//
+// Usage:
+//
+//	docs, err := c.GetProjectItemDocuments(ctx, 7)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, doc := range docs {
+//	    fmt.Println(doc.Title, doc.Metadata["Status"])
+//	}
+func (c *GitHub) GetProjectItemDocuments(ctx context.Context, number int) ([]document.Document, error) {
+	variables := map[string]interface{}{
+		"owner":  githubv4.String(c.Configuration.Owner),
+		"number": githubv4.Int(number),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	var docs []document.Document
+	for {
+		var query GHQueryForListProjectItems
+		if err := c.query(ctx, &query, variables); err != nil {
+			return nil, err
+		}
+
+		project := query.RepositoryOwner.ProjectV2Owner.ProjectV2
+		for _, item := range project.Items.Nodes {
+			docs = append(docs, c.projectItemDocument(number, project.Title, item))
+		}
+
+		if !project.Items.PageInfo.HasNextPage {
+			return docs, nil
+		}
+		variables["cursor"] = githubv4.NewString(project.Items.PageInfo.EndCursor)
+	}
+}
+
+// projectItemDocument converts a project item into a document.
+func (c *GitHub) projectItemDocument(number int, title string, item GHProjectItem) document.Document {
+	doc := document.Document{
+		ID:        fmt.Sprintf("%s/projects/%d/items/%s", c.Configuration.Owner, number, item.ID),
+		Kind:      document.DocumentKindProjectItem,
+		Owner:     c.Configuration.Owner,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+		Metadata: map[string]string{
+			"project":       strconv.Itoa(number),
+			"project_title": title,
+			"type":          item.Type,
+		},
+	}
+
+	var content GHProjectItemIssue
+	switch item.Content.Typename {
+	case "Issue":
+		content = item.Content.Issue
+	case "PullRequest":
+		content = item.Content.Pull
+	case "DraftIssue":
+		content = GHProjectItemIssue{Title: item.Content.Draft.Title, Body: item.Content.Draft.Body}
+	}
+	doc.Repository = content.Repository.Name
+	doc.Title = content.Title
+	doc.Body = content.Body
+	doc.URL = content.URL
+	doc.Author = content.Author.Login
+	if content.Number != 0 {
+		doc.Metadata["number"] = strconv.Itoa(content.Number)
+	}
+
+	for _, value := range item.FieldValues.Nodes {
+		switch value.Typename {
+		case "ProjectV2ItemFieldTextValue":
+			doc.Metadata[value.Text.Field.Common.Name] = value.Text.Text
+		case "ProjectV2ItemFieldSingleSelectValue":
+			doc.Metadata[value.SingleSelect.Field.Common.Name] = value.SingleSelect.Name
+		case "ProjectV2ItemFieldDateValue":
+			doc.Metadata[value.Date.Field.Common.Name] = value.Date.Date
+		case "ProjectV2ItemFieldNumberValue":
+			doc.Metadata[value.Number.Field.Common.Name] = strconv.FormatFloat(value.Number.Number, 'f', -1, 64)
+		case "ProjectV2ItemFieldIterationValue":
+			doc.Metadata[value.Iteration.Field.Common.Name] = value.Iteration.Title
+		}
+	}
+
+	return doc
+}
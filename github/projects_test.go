@@ -0,0 +1,71 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGitHub_GetProjectItemDocuments(t *testing.T) {
+	issue := GHProjectItem{ID: "PVTI_1", Type: "ISSUE"}
+	issue.Content.Typename = "Issue"
+	issue.Content.Issue = GHProjectItemIssue{Number: 5, Title: "Write docs", Body: "Setup guide", URL: "https://github.com/testowner/repo1/issues/5"}
+	issue.Content.Issue.Repository.Name = "repo1"
+
+	status := GHProjectFieldValue{Typename: "ProjectV2ItemFieldSingleSelectValue"}
+	status.SingleSelect.Name = "In Progress"
+	status.SingleSelect.Field.Common.Name = "Status"
+	points := GHProjectFieldValue{Typename: "ProjectV2ItemFieldNumberValue"}
+	points.Number.Number = 3
+	points.Number.Field.Common.Name = "Points"
+	issue.FieldValues.Nodes = []GHProjectFieldValue{status, points}
+
+	draft := GHProjectItem{ID: "PVTI_2", Type: "DRAFT_ISSUE"}
+	draft.Content.Typename = "DraftIssue"
+	draft.Content.Draft.Title = "Idea"
+
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.MatchedBy(func(v map[string]interface{}) bool {
+		return v["owner"] == githubv4.String("testowner") && v["number"] == githubv4.Int(7) && v["cursor"] == (*githubv4.String)(nil)
+	})).Run(func(args mock.Arguments) {
+		project := &args.Get(1).(*GHQueryForListProjectItems).RepositoryOwner.ProjectV2Owner.ProjectV2
+		project.Title = "Roadmap"
+		project.Items.Nodes = []GHProjectItem{issue}
+		project.Items.PageInfo.HasNextPage = true
+		project.Items.PageInfo.EndCursor = "next"
+	}).Return(nil).Once()
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.MatchedBy(func(v map[string]interface{}) bool {
+		return v["cursor"] != (*githubv4.String)(nil)
+	})).Run(func(args mock.Arguments) {
+		project := &args.Get(1).(*GHQueryForListProjectItems).RepositoryOwner.ProjectV2Owner.ProjectV2
+		project.Title = "Roadmap"
+		project.Items.Nodes = []GHProjectItem{draft}
+	}).Return(nil).Once()
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{Owner: "testowner"})
+
+	docs, err := gh.GetProjectItemDocuments(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Len(t, docs, 2)
+
+	assert.Equal(t, "testowner/projects/7/items/PVTI_1", docs[0].ID)
+	assert.Equal(t, document.DocumentKindProjectItem, docs[0].Kind)
+	assert.Equal(t, "repo1", docs[0].Repository)
+	assert.Equal(t, "Write docs", docs[0].Title)
+	assert.Equal(t, map[string]string{
+		"project":       "7",
+		"project_title": "Roadmap",
+		"type":          "ISSUE",
+		"number":        "5",
+		"Status":        "In Progress",
+		"Points":        "3",
+	}, docs[0].Metadata)
+
+	assert.Equal(t, "Idea", docs[1].Title)
+	assert.Equal(t, "DRAFT_ISSUE", docs[1].Metadata["type"])
+	graphQLClient.AssertExpectations(t)
+}
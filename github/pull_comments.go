@@ -0,0 +1,128 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// ListPullRequestComments lists the review comments of a specific pull request, or of all pull requests of
+// the repository if number is 0.
+func (gClient *GitHubCommitsOpsClient) ListPullRequestComments(ctx context.Context, owner, repo string, number int, opts *github.PullRequestListCommentsOptions) ([]*github.PullRequestComment, *github.Response, error) {
+	return gClient.GitHubClient.PullRequests.ListComments(ctx, owner, repo, number, opts)
+}
+
+// GetPullRequestReviewComments retrieves the review comments of a pull request of the given repository as
+// documents. Only comments on files passing the configured filter are returned, so the discussion around
+// the collected files can be indexed alongside their content.
+//
+// Every document carries the pull request number, the thread it belongs to (the ID of the first comment
+// of the thread), the commit it was made on and the diff hunk it refers to in its metadata.
+//
+// Usage:
+//
+//	docs, err := c.GetPullRequestReviewComments(ctx, "website", 42)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, doc := range docs {
+//	    fmt.Println(doc.Path, doc.Author, doc.Body)
+//	}
+func (c *GitHub) GetPullRequestReviewComments(ctx context.Context, repo string, number int) ([]document.Document, error) {
+	return c.getPullRequestReviewComments(ctx, repo, number, PullRequestFilter{})
+}
+
+// GetPullRequestReviewCommentsSince retrieves the review comments made on any pull request of the configured
+// repositories that were updated since filter.Since, as documents. Only comments on files passing the configured
+// filter are returned. The state of the filter is ignored, GitHub lists review comments regardless of the state
+// of their pull request.
+func (c *GitHub) GetPullRequestReviewCommentsSince(ctx context.Context, filter PullRequestFilter) ([]document.Document, error) {
+	repoDocs := make([][]document.Document, len(c.repositories()))
+	err := c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		docs, err := c.getPullRequestReviewComments(ctx, repo, 0, filter)
+		repoDocs[i] = docs
+		return err
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	return flattenDocuments(repoDocs), err
+}
+
+// getPullRequestReviewComments lists the review comments of one or, if number is 0, all pull requests of a
+// repository and converts the ones passing the configured filter into documents.
+func (c *GitHub) getPullRequestReviewComments(ctx context.Context, repo string, number int, filter PullRequestFilter) ([]document.Document, error) {
+	client, err := c.pullRequestOpsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := c.getFileRules(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.PullRequestListCommentsOptions{
+		Sort:        "created",
+		Direction:   "asc",
+		Since:       filter.Since,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var docs []document.Document
+	for {
+		comments, resp, err := client.ListPullRequestComments(ctx, c.ownerOf(repo), c.nameOf(repo), number, opts)
+		if err != nil {
+			return nil, c.classifyError(err)
+		}
+
+		for _, comment := range comments {
+			if !c.includeChangedFile(comment.GetPath(), rules) {
+				continue
+			}
+			docs = append(docs, c.reviewCommentDocument(repo, number, comment))
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			return docs, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// reviewCommentDocument converts a pull request review comment into a document.
+func (c *GitHub) reviewCommentDocument(repo string, number int, comment *github.PullRequestComment) document.Document {
+	if number == 0 {
+		number, _ = strconv.Atoi(path.Base(comment.GetPullRequestURL()))
+	}
+
+	thread := comment.GetInReplyTo()
+	if thread == 0 {
+		thread = comment.GetID()
+	}
+
+	return document.Document{
+		ID:         fmt.Sprintf("%s/%s/pull/%d/comments/%d", c.ownerOf(repo), c.nameOf(repo), number, comment.GetID()),
+		Kind:       document.DocumentKindReviewComment,
+		Owner:      c.ownerOf(repo),
+		Repository: c.nameOf(repo),
+		Path:       c.normalizePath(comment.GetPath()),
+		Body:       comment.GetBody(),
+		URL:        comment.GetHTMLURL(),
+		Author:     comment.GetUser().GetLogin(),
+		CreatedAt:  comment.GetCreatedAt().Time,
+		UpdatedAt:  comment.GetUpdatedAt().Time,
+		Metadata: map[string]string{
+			"pull_request": strconv.Itoa(number),
+			"thread_id":    strconv.FormatInt(thread, 10),
+			"commit_id":    comment.GetCommitID(),
+			"diff_hunk":    comment.GetDiffHunk(),
+		},
+	}
+}
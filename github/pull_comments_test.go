@@ -0,0 +1,100 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGitHub_GetPullRequestReviewComments(t *testing.T) {
+	created := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	client := new(PullRequestOpsClientMock)
+	client.On("ListPullRequestComments", mock.Anything, "testowner", "repo1", 42, mock.Anything).
+		Return([]*github.PullRequestComment{
+			{
+				ID:        github.Int64(100),
+				Path:      github.String("docs/a.md"),
+				Body:      github.String("Typo here"),
+				HTMLURL:   github.String("https://github.com/testowner/repo1/pull/42#discussion_r100"),
+				User:      &github.User{Login: github.String("alice")},
+				CommitID:  github.String("abc"),
+				DiffHunk:  github.String("@@ -1 +1 @@"),
+				CreatedAt: &github.Timestamp{Time: created},
+				UpdatedAt: &github.Timestamp{Time: created},
+			},
+			{
+				ID:        github.Int64(101),
+				InReplyTo: github.Int64(100),
+				Path:      github.String("docs/a.md"),
+				Body:      github.String("Fixed"),
+				User:      &github.User{Login: github.String("bob")},
+			},
+			{
+				ID:   github.Int64(102),
+				Path: github.String("src/main.go"),
+				Body: github.String("Not collected"),
+			},
+		}, nil, nil)
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+		Filter:       filter.GitHubFilter{FilePath: "docs"},
+	})
+
+	docs, err := gh.GetPullRequestReviewComments(context.Background(), "repo1", 42)
+	assert.NoError(t, err)
+	assert.Len(t, docs, 2)
+	assert.Equal(t, document.Document{
+		ID:         "testowner/repo1/pull/42/comments/100",
+		Kind:       document.DocumentKindReviewComment,
+		Owner:      "testowner",
+		Repository: "repo1",
+		Path:       "docs/a.md",
+		Body:       "Typo here",
+		URL:        "https://github.com/testowner/repo1/pull/42#discussion_r100",
+		Author:     "alice",
+		CreatedAt:  created,
+		UpdatedAt:  created,
+		Metadata: map[string]string{
+			"pull_request": "42",
+			"thread_id":    "100",
+			"commit_id":    "abc",
+			"diff_hunk":    "@@ -1 +1 @@",
+		},
+	}, docs[0])
+	assert.Equal(t, "100", docs[1].Metadata["thread_id"])
+}
+
+func TestGitHub_GetPullRequestReviewCommentsSince(t *testing.T) {
+	since := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	client := new(PullRequestOpsClientMock)
+	client.On("ListPullRequestComments", mock.Anything, "testowner", "repo1", 0, mock.MatchedBy(func(opts *github.PullRequestListCommentsOptions) bool {
+		return opts.Since.Equal(since)
+	})).Return([]*github.PullRequestComment{
+		{
+			ID:             github.Int64(7),
+			Path:           github.String("docs/a.md"),
+			PullRequestURL: github.String("https://api.github.com/repos/testowner/repo1/pulls/13"),
+		},
+	}, nil, nil)
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	docs, err := gh.GetPullRequestReviewCommentsSince(context.Background(), PullRequestFilter{Since: since})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 1)
+	assert.Equal(t, "testowner/repo1/pull/13/comments/7", docs[0].ID)
+	assert.Equal(t, "13", docs[0].Metadata["pull_request"])
+}
@@ -0,0 +1,343 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// PullRequestOpsClient is an interface to help test the GitHub pull request operations.
+// The pull request methods, e.g. ListPullRequestsSince and GetPullRequestFiles, fail with
+// ErrUnsupportedClient if the CommitOpsClient does not implement it.
+type PullRequestOpsClient interface {
+	ListPullRequests(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error)
+	ListPullRequestFiles(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error)
+	ListPullRequestComments(ctx context.Context, owner, repo string, number int, opts *github.PullRequestListCommentsOptions) ([]*github.PullRequestComment, *github.Response, error)
+}
+
+// PullRequestFilter narrows down the pull requests that are listed or whose files are collected.
+//
+// Since skips pull requests that were last updated before the given time.
+// State is the pull request state to collect: "open", "closed" or "all" (the default).
+// Labels only selects pull requests carrying all the given labels and ExcludeLabels skips pull requests
+// carrying any of the given labels.
+type PullRequestFilter struct {
+	Since         time.Time
+	State         string
+	Labels        []string
+	ExcludeLabels []string
+}
+
+// PullRequest is the metadata of a pull request.
+//
+// Repository is the configured repository the pull request belongs to, so it can be passed on to
+// GetPullRequestFiles with Number. Draft, BaseRef, HeadRef, HeadSHA and MergedAt are only known for pull
+// requests listed through the pull requests endpoint, not for those listed by label through the issues
+// endpoint, see ListPullRequestsSince.
+type PullRequest struct {
+	Repository string
+	Number     int
+	Title      string
+	Body       string
+	State      string
+	Author     string
+	Labels     []string
+	Draft      bool
+	BaseRef    string
+	HeadRef    string
+	HeadSHA    string
+	URL        string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	ClosedAt   time.Time
+	MergedAt   time.Time
+}
+
+// ListPullRequests lists the pull requests of a specific repository.
+func (gClient *GitHubCommitsOpsClient) ListPullRequests(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error) {
+	return gClient.GitHubClient.PullRequests.List(ctx, owner, repo, opts)
+}
+
+// ListPullRequestFiles lists the files changed by a specific pull request.
+func (gClient *GitHubCommitsOpsClient) ListPullRequestFiles(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+	return gClient.GitHubClient.PullRequests.ListFiles(ctx, owner, repo, number, opts)
+}
+
+// GetPullRequestFiles retrieves the files changed by a pull request of the given repository, classified into
+// added, removed and modified paths. Only the files passing the configured filter are returned.
+//
+// Usage:
+//
+//	paths, err := c.GetPullRequestFiles(ctx, "website", 42)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	fmt.Println("Modified files:", paths.Modified)
+func (c *GitHub) GetPullRequestFiles(ctx context.Context, repo string, number int) (document.Paths, error) {
+	client, err := c.pullRequestOpsClient()
+	if err != nil {
+		return document.Paths{}, err
+	}
+
+	rules, err := c.getFileRules(ctx, repo)
+	if err != nil {
+		return document.Paths{}, err
+	}
+
+	var paths document.Paths
+	if err := c.addPullRequestFiles(ctx, client, repo, number, rules, &paths); err != nil {
+		return document.Paths{}, err
+	}
+
+	return c.normalizeChangedPaths(paths), nil
+}
+
+// GetPullRequestFilesSince retrieves the files changed by the pull requests of all configured repositories
+// that match the given filter. Only the files passing the configured filter are returned.
+//
+// Usage:
+//
+//	paths, err := c.GetPullRequestFilesSince(ctx, PullRequestFilter{
+//	    Since: time.Now().Add(-24 * time.Hour),
+//	    State: "open",
+//	})
+func (c *GitHub) GetPullRequestFilesSince(ctx context.Context, filter PullRequestFilter) (document.Paths, error) {
+	client, err := c.pullRequestOpsClient()
+	if err != nil {
+		return document.Paths{}, err
+	}
+
+	repoPaths := make([]document.Paths, len(c.repositories()))
+	err = c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		pullRequests, err := c.listPullRequestsSince(ctx, client, repo, filter)
+		if err != nil {
+			return err
+		}
+
+		rules, err := c.getFileRules(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		for _, pr := range pullRequests {
+			if err := c.addPullRequestFiles(ctx, client, repo, pr.GetNumber(), rules, &repoPaths[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if !partialResult(err) {
+		return document.Paths{}, err
+	}
+
+	var paths document.Paths
+	for _, changed := range repoPaths {
+		paths.Added = append(paths.Added, changed.Added...)
+		paths.Removed = append(paths.Removed, changed.Removed...)
+		paths.Modified = append(paths.Modified, changed.Modified...)
+		paths.Renamed = append(paths.Renamed, changed.Renamed...)
+	}
+	return c.normalizeChangedPaths(paths), err
+}
+
+// ListPullRequestsSince retrieves the metadata of the pull requests of all configured repositories that
+// match the given filter, most recently updated first within each repository. If the filter requires
+// labels and the commit ops client implements IssueOpsClient, the pull requests are listed through the
+// issues endpoint, which does not report their draft state, refs and merge time.
+//
+// Usage:
+//
+//	prs, err := c.ListPullRequestsSince(ctx, PullRequestFilter{
+//	    Since:  time.Now().Add(-7 * 24 * time.Hour),
+//	    State:  "open",
+//	    Labels: []string{"documentation"},
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, pr := range prs {
+//	    paths, err := c.GetPullRequestFiles(ctx, pr.Repository, pr.Number)
+//	    // ...
+//	}
+func (c *GitHub) ListPullRequestsSince(ctx context.Context, filter PullRequestFilter) ([]PullRequest, error) {
+	client, err := c.pullRequestOpsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	repoPullRequests := make([][]PullRequest, len(c.repositories()))
+	err = c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		prs, err := c.listPullRequestsSince(ctx, client, repo, filter)
+		if err != nil {
+			return err
+		}
+
+		for _, pr := range prs {
+			repoPullRequests[i] = append(repoPullRequests[i], newPullRequest(repo, pr))
+		}
+		return nil
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	var pullRequests []PullRequest
+	for _, prs := range repoPullRequests {
+		pullRequests = append(pullRequests, prs...)
+	}
+	return pullRequests, err
+}
+
+// newPullRequest converts a pull request of the given configured repository.
+func newPullRequest(repo string, pr *github.PullRequest) PullRequest {
+	return PullRequest{
+		Repository: repo,
+		Number:     pr.GetNumber(),
+		Title:      pr.GetTitle(),
+		Body:       pr.GetBody(),
+		State:      pr.GetState(),
+		Author:     pr.GetUser().GetLogin(),
+		Labels:     labelNames(pr.Labels),
+		Draft:      pr.GetDraft(),
+		BaseRef:    pr.GetBase().GetRef(),
+		HeadRef:    pr.GetHead().GetRef(),
+		HeadSHA:    pr.GetHead().GetSHA(),
+		URL:        pr.GetHTMLURL(),
+		CreatedAt:  pr.GetCreatedAt().Time,
+		UpdatedAt:  pr.GetUpdatedAt().Time,
+		ClosedAt:   pr.GetClosedAt().Time,
+		MergedAt:   pr.GetMergedAt().Time,
+	}
+}
+
+// listPullRequestsSince lists the pull requests of a repository matching the filter, most recently
+// updated first.
+//
+// If the filter requires labels and the commit ops client also implements IssueOpsClient, the pull
+// requests are listed through the issues endpoint, which filters by label and time server-side.
+func (c *GitHub) listPullRequestsSince(ctx context.Context, client PullRequestOpsClient, repo string, filter PullRequestFilter) ([]*github.PullRequest, error) {
+	if issueClient, ok := client.(IssueOpsClient); ok && len(filter.Labels) > 0 {
+		return c.listLabeledPullRequests(ctx, issueClient, repo, filter)
+	}
+
+	state := filter.State
+	if state == "" {
+		state = "all"
+	}
+
+	opts := &github.PullRequestListOptions{
+		State:       state,
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var pullRequests []*github.PullRequest
+	for {
+		prs, resp, err := client.ListPullRequests(ctx, c.ownerOf(repo), c.nameOf(repo), opts)
+		if err != nil {
+			return nil, c.classifyError(err)
+		}
+
+		for _, pr := range prs {
+			if pr.GetUpdatedAt().Time.Before(filter.Since) {
+				return pullRequests, nil
+			}
+			if matchesLabels(labelNames(pr.Labels), filter.Labels, filter.ExcludeLabels) {
+				pullRequests = append(pullRequests, pr)
+			}
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			return pullRequests, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// listLabeledPullRequests lists the pull requests of a repository matching the filter through the issues
+// endpoint, which GitHub lets filter by labels.
+func (c *GitHub) listLabeledPullRequests(ctx context.Context, client IssueOpsClient, repo string, filter PullRequestFilter) ([]*github.PullRequest, error) {
+	issues, err := c.listIssues(ctx, client, repo, IssueFilter{
+		Labels:        filter.Labels,
+		ExcludeLabels: filter.ExcludeLabels,
+		State:         filter.State,
+		Since:         filter.Since,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var pullRequests []*github.PullRequest
+	for _, issue := range issues {
+		if !issue.IsPullRequest() {
+			continue
+		}
+		pullRequests = append(pullRequests, &github.PullRequest{
+			Number:    issue.Number,
+			Title:     issue.Title,
+			Body:      issue.Body,
+			State:     issue.State,
+			HTMLURL:   issue.HTMLURL,
+			User:      issue.User,
+			Labels:    issue.Labels,
+			CreatedAt: issue.CreatedAt,
+			UpdatedAt: issue.UpdatedAt,
+			ClosedAt:  issue.ClosedAt,
+		})
+	}
+
+	return pullRequests, nil
+}
+
+// addPullRequestFiles adds the files changed by a pull request that pass the configured filter to paths.
+func (c *GitHub) addPullRequestFiles(ctx context.Context, client PullRequestOpsClient, repo string, number int, rules fileRules, paths *document.Paths) error {
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		files, resp, err := client.ListPullRequestFiles(ctx, c.ownerOf(repo), c.nameOf(repo), number, opts)
+		if err != nil {
+			return c.classifyError(err)
+		}
+
+		for _, file := range files {
+			addCommitFile(paths, file, func(filePath string) bool { return c.includeChangedFile(filePath, rules) })
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// normalizeChangedPaths applies the configured normalization form to all paths.
+func (c *GitHub) normalizeChangedPaths(paths document.Paths) document.Paths {
+	paths.Added = c.normalizePaths(paths.Added)
+	paths.Removed = c.normalizePaths(paths.Removed)
+	paths.Modified = c.normalizePaths(paths.Modified)
+	for i := range paths.Renamed {
+		paths.Renamed[i].From = c.normalizePath(paths.Renamed[i].From)
+		paths.Renamed[i].To = c.normalizePath(paths.Renamed[i].To)
+	}
+	for i := range paths.Events {
+		paths.Events[i].Path = c.normalizePath(paths.Events[i].Path)
+		if paths.Events[i].PreviousPath != "" {
+			paths.Events[i].PreviousPath = c.normalizePath(paths.Events[i].PreviousPath)
+		}
+	}
+	return paths
+}
+
+// pullRequestOpsClient returns the commit ops client as a PullRequestOpsClient.
+func (c *GitHub) pullRequestOpsClient() (PullRequestOpsClient, error) {
+	client, ok := c.commitOpsClient.(PullRequestOpsClient)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not implement PullRequestOpsClient", ErrUnsupportedClient, c.commitOpsClient)
+	}
+	return client, nil
+}
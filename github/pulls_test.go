@@ -0,0 +1,245 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// PullRequestOpsClientMock is a mock type for a CommitOpsClient that also implements PullRequestOpsClient
+type PullRequestOpsClientMock struct {
+	CommitOpsClientMock
+}
+
+// ListPullRequests provides a mock function with given fields: ctx, owner, repo, opts
+func (_m *PullRequestOpsClientMock) ListPullRequests(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, opts)
+	prs, _ := ret.Get(0).([]*github.PullRequest)
+	resp, _ := ret.Get(1).(*github.Response)
+	return prs, resp, ret.Error(2)
+}
+
+// ListPullRequestFiles provides a mock function with given fields: ctx, owner, repo, number, opts
+func (_m *PullRequestOpsClientMock) ListPullRequestFiles(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, number, opts)
+	files, _ := ret.Get(0).([]*github.CommitFile)
+	resp, _ := ret.Get(1).(*github.Response)
+	return files, resp, ret.Error(2)
+}
+
+// ListPullRequestComments provides a mock function with given fields: ctx, owner, repo, number, opts
+func (_m *PullRequestOpsClientMock) ListPullRequestComments(ctx context.Context, owner, repo string, number int, opts *github.PullRequestListCommentsOptions) ([]*github.PullRequestComment, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, number, opts)
+	comments, _ := ret.Get(0).([]*github.PullRequestComment)
+	resp, _ := ret.Get(1).(*github.Response)
+	return comments, resp, ret.Error(2)
+}
+
+func TestGitHub_GetPullRequestFiles(t *testing.T) {
+	client := new(PullRequestOpsClientMock)
+	client.On("ListPullRequestFiles", mock.Anything, "testowner", "repo1", 42, &github.ListOptions{PerPage: 100}).
+		Return([]*github.CommitFile{
+			{Filename: github.String("docs/a.md"), Status: github.String("added")},
+			{Filename: github.String("docs/a.png"), Status: github.String("added")},
+			{Filename: github.String("src/main.go"), Status: github.String("modified")},
+		}, &github.Response{NextPage: 2}, nil).Once()
+	client.On("ListPullRequestFiles", mock.Anything, "testowner", "repo1", 42, &github.ListOptions{PerPage: 100, Page: 2}).
+		Return([]*github.CommitFile{
+			{Filename: github.String("docs/b.md"), Status: github.String("removed")},
+		}, &github.Response{}, nil).Once()
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+		Filter:       filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+	})
+
+	paths, err := gh.GetPullRequestFiles(context.Background(), "repo1", 42)
+	assert.NoError(t, err)
+	assert.Equal(t, document.Paths{Added: []string{"docs/a.md"}, Removed: []string{"docs/b.md"}}, paths)
+	client.AssertExpectations(t)
+}
+
+func TestGitHub_GetPullRequestFilesSince(t *testing.T) {
+	since := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	client := new(PullRequestOpsClientMock)
+	client.On("ListPullRequests", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.PullRequestListOptions) bool {
+		return opts.State == "open" && opts.Sort == "updated" && opts.Direction == "desc"
+	})).Return([]*github.PullRequest{
+		{Number: github.Int(2), UpdatedAt: &github.Timestamp{Time: since.Add(time.Hour)}},
+		{Number: github.Int(1), UpdatedAt: &github.Timestamp{Time: since.Add(-time.Hour)}},
+	}, &github.Response{NextPage: 2}, nil).Once()
+	client.On("ListPullRequestFiles", mock.Anything, "testowner", "repo1", 2, mock.Anything).
+		Return([]*github.CommitFile{
+			{Filename: github.String("docs/a.md"), Status: github.String("modified")},
+		}, nil, nil).Once()
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+		Filter:       filter.GitHubFilter{FilePath: "docs"},
+	})
+
+	paths, err := gh.GetPullRequestFilesSince(context.Background(), PullRequestFilter{Since: since, State: "open"})
+	assert.NoError(t, err)
+	assert.Equal(t, document.Paths{Modified: []string{"docs/a.md"}}, paths)
+	client.AssertExpectations(t)
+}
+
+func TestGitHub_GetPullRequestFiles_UnsupportedClient(t *testing.T) {
+	gh := NewGitHubClient(new(CommitOpsClientMock), new(GraphQLClientMock), GitHubConfig{})
+
+	_, err := gh.GetPullRequestFiles(context.Background(), "repo1", 1)
+	assert.True(t, errors.Is(err, ErrUnsupportedClient))
+}
+
+// PullRequestAndIssueOpsClientMock is a mock type for a CommitOpsClient that implements both
+// PullRequestOpsClient and IssueOpsClient
+type PullRequestAndIssueOpsClientMock struct {
+	PullRequestOpsClientMock
+}
+
+// ListIssues provides a mock function with given fields: ctx, owner, repo, opts
+func (_m *PullRequestAndIssueOpsClientMock) ListIssues(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, opts)
+	issues, _ := ret.Get(0).([]*github.Issue)
+	resp, _ := ret.Get(1).(*github.Response)
+	return issues, resp, ret.Error(2)
+}
+
+// ListIssueComments provides a mock function with given fields: ctx, owner, repo, number, opts
+func (_m *PullRequestAndIssueOpsClientMock) ListIssueComments(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, number, opts)
+	comments, _ := ret.Get(0).([]*github.IssueComment)
+	resp, _ := ret.Get(1).(*github.Response)
+	return comments, resp, ret.Error(2)
+}
+
+func TestGitHub_GetPullRequestFilesSince_Labels(t *testing.T) {
+	prLinks := &github.PullRequestLinks{URL: github.String("https://api.github.com/repos/testowner/repo1/pulls/3")}
+
+	client := new(PullRequestAndIssueOpsClientMock)
+	client.On("ListIssues", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.IssueListByRepoOptions) bool {
+		return assert.ObjectsAreEqual([]string{"documentation"}, opts.Labels)
+	})).Return([]*github.Issue{
+		{Number: github.Int(3), PullRequestLinks: prLinks, Labels: []*github.Label{{Name: github.String("documentation")}}},
+		{Number: github.Int(4), PullRequestLinks: prLinks, Labels: []*github.Label{{Name: github.String("documentation")}, {Name: github.String("wontfix")}}},
+		{Number: github.Int(5), Labels: []*github.Label{{Name: github.String("documentation")}}},
+	}, nil, nil)
+	client.On("ListPullRequestFiles", mock.Anything, "testowner", "repo1", 3, mock.Anything).
+		Return([]*github.CommitFile{{Filename: github.String("docs/a.md"), Status: github.String("added")}}, nil, nil).Once()
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	paths, err := gh.GetPullRequestFilesSince(context.Background(), PullRequestFilter{
+		Labels:        []string{"documentation"},
+		ExcludeLabels: []string{"wontfix"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, document.Paths{Added: []string{"docs/a.md"}}, paths)
+	client.AssertNotCalled(t, "ListPullRequests", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	client.AssertExpectations(t)
+}
+
+func TestGitHub_GetPullRequestFilesSince_LabelsClientSide(t *testing.T) {
+	client := new(PullRequestOpsClientMock)
+	client.On("ListPullRequests", mock.Anything, "testowner", "repo1", mock.Anything).Return([]*github.PullRequest{
+		{Number: github.Int(1), Labels: []*github.Label{{Name: github.String("documentation")}}},
+		{Number: github.Int(2)},
+	}, nil, nil)
+	client.On("ListPullRequestFiles", mock.Anything, "testowner", "repo1", 1, mock.Anything).
+		Return([]*github.CommitFile{{Filename: github.String("docs/a.md"), Status: github.String("modified")}}, nil, nil).Once()
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	paths, err := gh.GetPullRequestFilesSince(context.Background(), PullRequestFilter{Labels: []string{"documentation"}})
+	assert.NoError(t, err)
+	assert.Equal(t, document.Paths{Modified: []string{"docs/a.md"}}, paths)
+	client.AssertExpectations(t)
+}
+
+func TestGitHub_ListPullRequestsSince(t *testing.T) {
+	since := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	client := new(PullRequestOpsClientMock)
+	client.On("ListPullRequests", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.PullRequestListOptions) bool {
+		return opts.State == "all"
+	})).Return([]*github.PullRequest{
+		{
+			Number:    github.Int(2),
+			Title:     github.String("Add docs"),
+			Body:      github.String("Adds the getting started guide."),
+			State:     github.String("closed"),
+			User:      &github.User{Login: github.String("octocat")},
+			Labels:    []*github.Label{{Name: github.String("documentation")}},
+			Base:      &github.PullRequestBranch{Ref: github.String("main")},
+			Head:      &github.PullRequestBranch{Ref: github.String("docs"), SHA: github.String("abc")},
+			HTMLURL:   github.String("https://github.com/testowner/repo1/pull/2"),
+			CreatedAt: &github.Timestamp{Time: since},
+			UpdatedAt: &github.Timestamp{Time: since.Add(2 * time.Hour)},
+			ClosedAt:  &github.Timestamp{Time: since.Add(2 * time.Hour)},
+			MergedAt:  &github.Timestamp{Time: since.Add(2 * time.Hour)},
+		},
+		{Number: github.Int(1), UpdatedAt: &github.Timestamp{Time: since.Add(-time.Hour)}},
+	}, nil, nil)
+	client.On("ListPullRequests", mock.Anything, "other", "repo2", mock.Anything).Return([]*github.PullRequest{
+		{Number: github.Int(7), Draft: github.Bool(true), UpdatedAt: &github.Timestamp{Time: since.Add(time.Hour)}},
+	}, nil, nil)
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:          "testowner",
+		Repositories:   []string{"repo1"},
+		RepositoryRefs: []RepositoryRef{{Owner: "other", Name: "repo2"}},
+	})
+
+	prs, err := gh.ListPullRequestsSince(context.Background(), PullRequestFilter{Since: since})
+	require.NoError(t, err)
+	assert.Equal(t, []PullRequest{
+		{
+			Repository: "repo1",
+			Number:     2,
+			Title:      "Add docs",
+			Body:       "Adds the getting started guide.",
+			State:      "closed",
+			Author:     "octocat",
+			Labels:     []string{"documentation"},
+			BaseRef:    "main",
+			HeadRef:    "docs",
+			HeadSHA:    "abc",
+			URL:        "https://github.com/testowner/repo1/pull/2",
+			CreatedAt:  since,
+			UpdatedAt:  since.Add(2 * time.Hour),
+			ClosedAt:   since.Add(2 * time.Hour),
+			MergedAt:   since.Add(2 * time.Hour),
+		},
+		{
+			Repository: "other/repo2",
+			Number:     7,
+			Labels:     []string{},
+			Draft:      true,
+			UpdatedAt:  since.Add(time.Hour),
+		},
+	}, prs)
+}
+
+func TestGitHub_ListPullRequestsSince_UnsupportedClient(t *testing.T) {
+	gh := NewGitHubClient(new(CommitOpsClientMock), new(GraphQLClientMock), GitHubConfig{Repositories: []string{"repo1"}})
+
+	_, err := gh.ListPullRequestsSince(context.Background(), PullRequestFilter{})
+	assert.ErrorIs(t, err, ErrUnsupportedClient)
+}
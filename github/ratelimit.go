@@ -0,0 +1,260 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/internal/timeutil"
+)
+
+// DefaultRateLimitRetries is the number of times a rate limited request is retried when RateLimitOptions
+// does not say otherwise.
+const DefaultRateLimitRetries = 3
+
+// The rate limit headers GitHub sends with REST and GraphQL responses.
+const (
+	headerRateLimit     = "X-RateLimit-Limit"
+	headerRateRemaining = "X-RateLimit-Remaining"
+	headerRateUsed      = "X-RateLimit-Used"
+	headerRateReset     = "X-RateLimit-Reset"
+	headerRateResource  = "X-RateLimit-Resource"
+	headerRetryAfter    = "Retry-After"
+)
+
+// RateLimitOptions configures a RateLimitTransport.
+//
+// MinRemaining is the remaining quota at which requests are held back until the quota resets; zero only
+// holds requests back once the quota is exhausted. MaxRetries is the number of times a request rejected by
+// a primary or secondary rate limit is retried after waiting; zero uses DefaultRateLimitRetries, a negative
+// value disables retries. MaxWait is the longest the transport waits at once; a longer wait returns the
+// rate limit response to the caller instead. Zero waits as long as needed. Clock is the source of the
+// current time; nil uses the wall clock. Logger, if set, receives a debug log for every pause.
+type RateLimitOptions struct {
+	MinRemaining int
+	MaxRetries   int
+	MaxWait      time.Duration
+	Clock        Clock
+	Logger       Logger
+}
+
+// RateLimitQuota is the last known state of a GitHub rate limit.
+//
+// Resource is the rate limit the quota belongs to, e.g. "core" for the REST API or "graphql" for the
+// GraphQL API, where the cost of every query is deducted from Remaining.
+type RateLimitQuota struct {
+	Resource  string
+	Limit     int
+	Remaining int
+	Used      int
+	Reset     time.Time
+}
+
+// RateLimitTransport is an http.RoundTripper that keeps requests within the GitHub rate limits. It tracks
+// the rate limit headers of every response, holds requests back while a quota is exhausted and retries
+// requests rejected by primary or secondary rate limits once the limit allows it.
+//
+// Both the REST and the GraphQL client are covered when they share the HTTP client:
+//
+//	httpClient := oauth2.NewClient(ctx, src)
+//	httpClient.Transport = github.NewRateLimitTransport(httpClient.Transport, github.RateLimitOptions{MinRemaining: 10})
+//
+//	ghCommitsOpsClient := github.NewGitHubCommitsOpsClient(httpClient)
+//	graphQLClient := githubv4.NewClient(httpClient)
+type RateLimitTransport struct {
+	base    http.RoundTripper
+	options RateLimitOptions
+
+	mu     sync.Mutex
+	quotas map[string]RateLimitQuota
+
+	// sleep waits for d or until the context is done. Tests replace it to avoid waiting.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewRateLimitTransport wraps the base transport, nil uses http.DefaultTransport.
+func NewRateLimitTransport(base http.RoundTripper, options RateLimitOptions) *RateLimitTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RateLimitTransport{
+		base:    base,
+		options: options,
+		quotas:  make(map[string]RateLimitQuota),
+		sleep:   timeutil.Sleep,
+	}
+}
+
+// Quota returns the last known quota of the given rate limit resource, e.g. "core" or "graphql".
+func (t *RateLimitTransport) Quota(resource string) (RateLimitQuota, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	quota, ok := t.quotas[resource]
+	return quota, ok
+}
+
+// Quotas returns the last known quotas of all rate limit resources seen so far, keyed by resource.
+func (t *RateLimitTransport) Quotas() map[string]RateLimitQuota {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	quotas := make(map[string]RateLimitQuota, len(t.quotas))
+	for resource, quota := range t.quotas {
+		quotas[resource] = quota
+	}
+	return quotas
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	resource := requestResource(req)
+
+	// retried is set after waiting for a rate limit response, which already waited for the reset.
+	retried := false
+	for attempt := 0; ; attempt++ {
+		if wait := t.throttle(resource); wait > 0 && !retried {
+			if t.options.MaxWait > 0 && wait > t.options.MaxWait {
+				wait = t.options.MaxWait
+			}
+			logDebug(t.options.Logger, "waiting for rate limit reset", "resource", resource, "wait", wait)
+			if err := t.sleep(ctx, wait); err != nil {
+				return nil, err
+			}
+		}
+
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		t.record(resp)
+
+		// Requests whose body cannot be read again are not retried.
+		wait, limited := t.retryAfter(resp)
+		if !limited || attempt >= t.maxRetries() || (req.Body != nil && req.GetBody == nil) ||
+			(t.options.MaxWait > 0 && wait > t.options.MaxWait) {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		logDebug(t.options.Logger, "rate limited, retrying", "resource", resource, "status", resp.StatusCode, "wait", wait)
+		if err := t.sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+		retried = true
+	}
+}
+
+// maxRetries returns the configured number of retries.
+func (t *RateLimitTransport) maxRetries() int {
+	switch {
+	case t.options.MaxRetries < 0:
+		return 0
+	case t.options.MaxRetries == 0:
+		return DefaultRateLimitRetries
+	default:
+		return t.options.MaxRetries
+	}
+}
+
+// throttle returns how long a request for the resource has to wait for its quota to reset, zero if it may
+// be sent right away.
+func (t *RateLimitTransport) throttle(resource string) time.Duration {
+	t.mu.Lock()
+	quota, ok := t.quotas[resource]
+	t.mu.Unlock()
+
+	if !ok || quota.Remaining > t.options.MinRemaining {
+		return 0
+	}
+	if wait := quota.Reset.Sub(timeutil.Now(t.options.Clock)); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// record stores the quota reported by the response headers.
+func (t *RateLimitTransport) record(resp *http.Response) {
+	quota, ok := responseQuota(resp)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.quotas[quota.Resource] = quota
+}
+
+// responseQuota reads the quota reported by the rate limit headers of the response. The boolean is false if
+// the response has none.
+func responseQuota(resp *http.Response) (RateLimitQuota, bool) {
+	limit, err := strconv.Atoi(resp.Header.Get(headerRateLimit))
+	if err != nil {
+		return RateLimitQuota{}, false
+	}
+
+	quota := RateLimitQuota{
+		Resource: resp.Header.Get(headerRateResource),
+		Limit:    limit,
+	}
+	if quota.Resource == "" {
+		quota.Resource = requestResource(resp.Request)
+	}
+	quota.Remaining, _ = strconv.Atoi(resp.Header.Get(headerRateRemaining))
+	quota.Used, _ = strconv.Atoi(resp.Header.Get(headerRateUsed))
+	if reset, err := strconv.ParseInt(resp.Header.Get(headerRateReset), 10, 64); err == nil {
+		quota.Reset = time.Unix(reset, 0)
+	}
+	return quota, true
+}
+
+// retryAfter reports whether the response was rejected by a rate limit and how long to wait before
+// retrying.
+func (t *RateLimitTransport) retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	// Secondary rate limits tell how long to wait.
+	if value := resp.Header.Get(headerRetryAfter); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	// Primary rate limits are exhausted until the reset.
+	if resp.Header.Get(headerRateRemaining) == "0" {
+		reset, err := strconv.ParseInt(resp.Header.Get(headerRateReset), 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		wait := time.Unix(reset, 0).Sub(timeutil.Now(t.options.Clock))
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// requestResource guesses the rate limit resource of a request before GitHub reports it.
+func requestResource(req *http.Request) string {
+	if req != nil && req.URL != nil && strings.HasSuffix(req.URL.Path, "/graphql") {
+		return "graphql"
+	}
+	return "core"
+}
@@ -0,0 +1,181 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rateLimitServer answers with the queued responses in order, then with 200 OK.
+type rateLimitServer struct {
+	mu        sync.Mutex
+	responses []func(w http.ResponseWriter)
+	bodies    []string
+}
+
+func (s *rateLimitServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.bodies = append(s.bodies, string(body))
+	var respond func(w http.ResponseWriter)
+	if len(s.responses) > 0 {
+		respond, s.responses = s.responses[0], s.responses[1:]
+	}
+	s.mu.Unlock()
+
+	if respond == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	respond(w)
+}
+
+func quotaHeaders(w http.ResponseWriter, resource string, remaining int, reset time.Time) {
+	w.Header().Set("X-RateLimit-Limit", "5000")
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	w.Header().Set("X-RateLimit-Used", strconv.Itoa(5000-remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	w.Header().Set("X-RateLimit-Resource", resource)
+}
+
+func newTestRateLimitTransport(options RateLimitOptions) (*RateLimitTransport, *[]time.Duration) {
+	var sleeps []time.Duration
+	transport := NewRateLimitTransport(nil, options)
+	transport.sleep = func(_ context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	}
+	return transport, &sleeps
+}
+
+func TestRateLimitTransport_SecondaryRateLimit(t *testing.T) {
+	handler := &rateLimitServer{responses: []func(w http.ResponseWriter){
+		func(w http.ResponseWriter) {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusForbidden)
+		},
+	}}
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	transport, sleeps := newTestRateLimitTransport(RateLimitOptions{})
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Post(srv.URL+"/graphql", "application/json", strings.NewReader(`{"query":"{}"}`))
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []time.Duration{2 * time.Second}, *sleeps)
+	assert.Equal(t, []string{`{"query":"{}"}`, `{"query":"{}"}`}, handler.bodies)
+}
+
+func TestRateLimitTransport_PrimaryRateLimit(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	handler := &rateLimitServer{responses: []func(w http.ResponseWriter){
+		func(w http.ResponseWriter) {
+			quotaHeaders(w, "core", 0, now.Add(time.Minute))
+			w.WriteHeader(http.StatusForbidden)
+		},
+		func(w http.ResponseWriter) {
+			quotaHeaders(w, "core", 4999, now.Add(time.Hour))
+			w.WriteHeader(http.StatusOK)
+		},
+	}}
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	transport, sleeps := newTestRateLimitTransport(RateLimitOptions{Clock: fixedClock(now)})
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get(srv.URL + "/repos/o/r")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []time.Duration{time.Minute}, *sleeps)
+
+	quota, ok := transport.Quota("core")
+	assert.True(t, ok)
+	assert.Equal(t, RateLimitQuota{Resource: "core", Limit: 5000, Remaining: 4999, Used: 1, Reset: now.Add(time.Hour).Local()}, quota)
+}
+
+func TestRateLimitTransport_Throttle(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	handler := &rateLimitServer{responses: []func(w http.ResponseWriter){
+		func(w http.ResponseWriter) {
+			quotaHeaders(w, "graphql", 8, now.Add(30*time.Second))
+			w.WriteHeader(http.StatusOK)
+		},
+	}}
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	transport, sleeps := newTestRateLimitTransport(RateLimitOptions{MinRemaining: 10, MaxWait: 20 * time.Second, Clock: fixedClock(now)})
+	client := &http.Client{Transport: transport}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Post(srv.URL+"/graphql", "application/json", strings.NewReader("{}"))
+		assert.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.Equal(t, []time.Duration{20 * time.Second}, *sleeps)
+	assert.Contains(t, transport.Quotas(), "graphql")
+}
+
+func TestRateLimitTransport_NoRetry(t *testing.T) {
+	tests := []struct {
+		name    string
+		options RateLimitOptions
+		header  string
+	}{
+		{name: "retries disabled", options: RateLimitOptions{MaxRetries: -1}, header: "1"},
+		{name: "wait too long", options: RateLimitOptions{MaxWait: time.Second}, header: "60"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &rateLimitServer{responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) {
+					w.Header().Set("Retry-After", tt.header)
+					w.WriteHeader(http.StatusTooManyRequests)
+				},
+			}}
+			srv := httptest.NewServer(handler)
+			defer srv.Close()
+
+			transport, sleeps := newTestRateLimitTransport(tt.options)
+			resp, err := (&http.Client{Transport: transport}).Get(srv.URL)
+			assert.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+			assert.Empty(t, *sleeps)
+		})
+	}
+}
+
+func TestRateLimitTransport_ContextCancelled(t *testing.T) {
+	handler := &rateLimitServer{responses: []func(w http.ResponseWriter){
+		func(w http.ResponseWriter) {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusForbidden)
+		},
+	}}
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	assert.NoError(t, err)
+
+	_, err = (&http.Client{Transport: NewRateLimitTransport(nil, RateLimitOptions{})}).Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,250 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// ReleaseOpsClient is an interface to help test the GitHub release operations.
+// GetReleasesSince, GetReleaseAssets and OpenReleaseAsset list releases and download their assets
+// through it.
+type ReleaseOpsClient interface {
+	ListReleases(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error)
+	ListReleaseAssets(ctx context.Context, owner, repo string, id int64, opts *github.ListOptions) ([]*github.ReleaseAsset, *github.Response, error)
+	DownloadReleaseAsset(ctx context.Context, owner, repo string, id int64, followRedirectsClient *http.Client) (io.ReadCloser, string, error)
+}
+
+// Release is a release of a repository together with its release notes and assets.
+//
+// Repository is the configured repository the release belongs to, so it can be passed on to GetReleaseAssets
+// and OpenReleaseAsset. Body holds the release notes as Markdown. PublishedAt is zero for drafts.
+type Release struct {
+	Repository      string
+	ID              int64
+	TagName         string
+	Name            string
+	Body            string
+	TargetCommitish string
+	Author          string
+	Draft           bool
+	Prerelease      bool
+	URL             string
+	CreatedAt       time.Time
+	PublishedAt     time.Time
+	Assets          []ReleaseAsset
+}
+
+// ReleaseAsset is a file attached to a release. DownloadURL is the URL browsers download it from.
+type ReleaseAsset struct {
+	ID            int64
+	Name          string
+	Label         string
+	ContentType   string
+	Size          int
+	DownloadCount int
+	DownloadURL   string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// ListReleases lists the releases of a specific repository.
+func (gClient *GitHubCommitsOpsClient) ListReleases(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+	return gClient.GitHubClient.Repositories.ListReleases(ctx, owner, repo, opts)
+}
+
+// ListReleaseAssets lists the assets of a specific release.
+func (gClient *GitHubCommitsOpsClient) ListReleaseAssets(ctx context.Context, owner, repo string, id int64, opts *github.ListOptions) ([]*github.ReleaseAsset, *github.Response, error) {
+	return gClient.GitHubClient.Repositories.ListReleaseAssets(ctx, owner, repo, id, opts)
+}
+
+// DownloadReleaseAsset downloads the content of a specific release asset, following redirects with
+// followRedirectsClient.
+func (gClient *GitHubCommitsOpsClient) DownloadReleaseAsset(ctx context.Context, owner, repo string, id int64, followRedirectsClient *http.Client) (io.ReadCloser, string, error) {
+	return gClient.GitHubClient.Repositories.DownloadReleaseAsset(ctx, owner, repo, id, followRedirectsClient)
+}
+
+// ListReleases retrieves the releases of all configured repositories with their release notes and assets,
+// most recently created first within each repository. Drafts are only visible with push access.
+//
+// Usage:
+//
+//	releases, err := c.ListReleases(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, release := range releases {
+//	    fmt.Println(release.TagName, release.Name, len(release.Assets))
+//	}
+func (c *GitHub) ListReleases(ctx context.Context) ([]Release, error) {
+	return c.GetReleasesSince(ctx, time.Time{})
+}
+
+// GetReleasesSince retrieves the releases of all configured repositories that were published since the
+// given time, or created since then for drafts, most recently created first within each repository. A zero
+// time retrieves all releases.
+//
+// Usage:
+//
+//	releases, err := c.GetReleasesSince(ctx, time.Now().Add(-7*24*time.Hour))
+func (c *GitHub) GetReleasesSince(ctx context.Context, since time.Time) ([]Release, error) {
+	client, err := c.releaseOpsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	repoReleases := make([][]Release, len(c.repositories()))
+	err = c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		page, err := c.listReleases(ctx, client, repo)
+		if err != nil {
+			return fmt.Errorf("failed to list releases of %s: %w", repo, err)
+		}
+
+		for _, release := range page {
+			if releaseTime(release).Before(since) {
+				continue
+			}
+			repoReleases[i] = append(repoReleases[i], newRelease(repo, release))
+		}
+		return nil
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	var releases []Release
+	for _, r := range repoReleases {
+		releases = append(releases, r...)
+	}
+	return releases, err
+}
+
+// GetReleaseAssets retrieves the assets of a release of the given repository. ListReleases already returns
+// the assets of every release, this fetches them again, e.g. to refresh their download counts.
+func (c *GitHub) GetReleaseAssets(ctx context.Context, repo string, releaseID int64) ([]ReleaseAsset, error) {
+	client, err := c.releaseOpsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.ListOptions{PerPage: 100}
+
+	var assets []ReleaseAsset
+	for {
+		releaseSlot, err := c.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		page, resp, err := client.ListReleaseAssets(ctx, c.ownerOf(repo), c.nameOf(repo), releaseID, opts)
+		releaseSlot()
+		if err != nil {
+			return nil, c.classifyError(err)
+		}
+		for _, asset := range page {
+			assets = append(assets, newReleaseAsset(asset))
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			return assets, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// OpenReleaseAsset opens the content of a release asset of the given repository. GitHub redirects the
+// download to its storage, which is followed with http.DefaultClient. The caller has to close the reader.
+func (c *GitHub) OpenReleaseAsset(ctx context.Context, repo string, assetID int64) (io.ReadCloser, error) {
+	client, err := c.releaseOpsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	rc, _, err := client.DownloadReleaseAsset(ctx, c.ownerOf(repo), c.nameOf(repo), assetID, http.DefaultClient)
+	if err != nil {
+		return nil, c.classifyError(err)
+	}
+	return rc, nil
+}
+
+// listReleases lists all releases of a repository.
+func (c *GitHub) listReleases(ctx context.Context, client ReleaseOpsClient, repo string) ([]*github.RepositoryRelease, error) {
+	opts := &github.ListOptions{PerPage: 100}
+
+	var releases []*github.RepositoryRelease
+	for {
+		releaseSlot, err := c.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		page, resp, err := client.ListReleases(ctx, c.ownerOf(repo), c.nameOf(repo), opts)
+		releaseSlot()
+		if err != nil {
+			return nil, c.classifyError(err)
+		}
+		releases = append(releases, page...)
+
+		if resp == nil || resp.NextPage == 0 {
+			return releases, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// releaseTime returns when a release was published, or created if it is a draft.
+func releaseTime(release *github.RepositoryRelease) time.Time {
+	if release.PublishedAt != nil {
+		return release.GetPublishedAt().Time
+	}
+	return release.GetCreatedAt().Time
+}
+
+// newRelease converts a release of the given configured repository.
+func newRelease(repo string, release *github.RepositoryRelease) Release {
+	result := Release{
+		Repository:      repo,
+		ID:              release.GetID(),
+		TagName:         release.GetTagName(),
+		Name:            release.GetName(),
+		Body:            release.GetBody(),
+		TargetCommitish: release.GetTargetCommitish(),
+		Author:          release.GetAuthor().GetLogin(),
+		Draft:           release.GetDraft(),
+		Prerelease:      release.GetPrerelease(),
+		URL:             release.GetHTMLURL(),
+		CreatedAt:       release.GetCreatedAt().Time,
+		PublishedAt:     release.GetPublishedAt().Time,
+	}
+	for _, asset := range release.Assets {
+		result.Assets = append(result.Assets, newReleaseAsset(asset))
+	}
+	return result
+}
+
+// newReleaseAsset converts a release asset.
+func newReleaseAsset(asset *github.ReleaseAsset) ReleaseAsset {
+	return ReleaseAsset{
+		ID:            asset.GetID(),
+		Name:          asset.GetName(),
+		Label:         asset.GetLabel(),
+		ContentType:   asset.GetContentType(),
+		Size:          asset.GetSize(),
+		DownloadCount: asset.GetDownloadCount(),
+		DownloadURL:   asset.GetBrowserDownloadURL(),
+		CreatedAt:     asset.GetCreatedAt().Time,
+		UpdatedAt:     asset.GetUpdatedAt().Time,
+	}
+}
+
+// releaseOpsClient returns the commit ops client as a ReleaseOpsClient.
+func (c *GitHub) releaseOpsClient() (ReleaseOpsClient, error) {
+	client, ok := c.commitOpsClient.(ReleaseOpsClient)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not implement ReleaseOpsClient", ErrUnsupportedClient, c.commitOpsClient)
+	}
+	return client, nil
+}
@@ -0,0 +1,166 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// ReleaseOpsClientMock is a mock type for a CommitOpsClient that also implements ReleaseOpsClient
+type ReleaseOpsClientMock struct {
+	CommitOpsClientMock
+}
+
+// ListReleases provides a mock function with given fields: ctx, owner, repo, opts
+func (_m *ReleaseOpsClientMock) ListReleases(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, opts)
+	releases, _ := ret.Get(0).([]*github.RepositoryRelease)
+	resp, _ := ret.Get(1).(*github.Response)
+	return releases, resp, ret.Error(2)
+}
+
+// ListReleaseAssets provides a mock function with given fields: ctx, owner, repo, id, opts
+func (_m *ReleaseOpsClientMock) ListReleaseAssets(ctx context.Context, owner, repo string, id int64, opts *github.ListOptions) ([]*github.ReleaseAsset, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, id, opts)
+	assets, _ := ret.Get(0).([]*github.ReleaseAsset)
+	resp, _ := ret.Get(1).(*github.Response)
+	return assets, resp, ret.Error(2)
+}
+
+// DownloadReleaseAsset provides a mock function with given fields: ctx, owner, repo, id, followRedirectsClient
+func (_m *ReleaseOpsClientMock) DownloadReleaseAsset(ctx context.Context, owner, repo string, id int64, followRedirectsClient *http.Client) (io.ReadCloser, string, error) {
+	ret := _m.Called(ctx, owner, repo, id, followRedirectsClient)
+	rc, _ := ret.Get(0).(io.ReadCloser)
+	return rc, ret.String(1), ret.Error(2)
+}
+
+func TestGitHub_GetReleasesSince(t *testing.T) {
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	client := new(ReleaseOpsClientMock)
+	client.On("ListReleases", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.ListOptions) bool {
+		return opts.Page == 0
+	})).Return([]*github.RepositoryRelease{
+		{ID: github.Int64(3), TagName: github.String("v1.2.0-rc1"), Draft: github.Bool(true), CreatedAt: &github.Timestamp{Time: since.Add(48 * time.Hour)}},
+		{
+			ID:              github.Int64(2),
+			TagName:         github.String("v1.1.0"),
+			Name:            github.String("Spring release"),
+			Body:            github.String("## Changes\n- Faster crawls"),
+			TargetCommitish: github.String("main"),
+			Author:          &github.User{Login: github.String("octocat")},
+			HTMLURL:         github.String("https://github.com/testowner/repo1/releases/tag/v1.1.0"),
+			CreatedAt:       &github.Timestamp{Time: since.Add(-time.Hour)},
+			PublishedAt:     &github.Timestamp{Time: since.Add(time.Hour)},
+			Assets: []*github.ReleaseAsset{{
+				ID:                 github.Int64(20),
+				Name:               github.String("coco.tar.gz"),
+				ContentType:        github.String("application/gzip"),
+				Size:               github.Int(1024),
+				DownloadCount:      github.Int(7),
+				BrowserDownloadURL: github.String("https://github.com/testowner/repo1/releases/download/v1.1.0/coco.tar.gz"),
+			}},
+		},
+	}, &github.Response{NextPage: 2}, nil).Once()
+	client.On("ListReleases", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.ListOptions) bool {
+		return opts.Page == 2
+	})).Return([]*github.RepositoryRelease{
+		{ID: github.Int64(1), TagName: github.String("v1.0.0"), PublishedAt: &github.Timestamp{Time: since.Add(-24 * time.Hour)}},
+	}, nil, nil).Once()
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	releases, err := gh.GetReleasesSince(context.Background(), since)
+	require.NoError(t, err)
+	assert.Equal(t, []Release{
+		{Repository: "repo1", ID: 3, TagName: "v1.2.0-rc1", Draft: true, CreatedAt: since.Add(48 * time.Hour)},
+		{
+			Repository:      "repo1",
+			ID:              2,
+			TagName:         "v1.1.0",
+			Name:            "Spring release",
+			Body:            "## Changes\n- Faster crawls",
+			TargetCommitish: "main",
+			Author:          "octocat",
+			URL:             "https://github.com/testowner/repo1/releases/tag/v1.1.0",
+			CreatedAt:       since.Add(-time.Hour),
+			PublishedAt:     since.Add(time.Hour),
+			Assets: []ReleaseAsset{{
+				ID:            20,
+				Name:          "coco.tar.gz",
+				ContentType:   "application/gzip",
+				Size:          1024,
+				DownloadCount: 7,
+				DownloadURL:   "https://github.com/testowner/repo1/releases/download/v1.1.0/coco.tar.gz",
+			}},
+		},
+	}, releases)
+	client.AssertExpectations(t)
+}
+
+func TestGitHub_ListReleases_Error(t *testing.T) {
+	client := new(ReleaseOpsClientMock)
+	client.On("ListReleases", mock.Anything, "testowner", "repo1", mock.Anything).Return(nil, nil, errors.New("boom"))
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	_, err := gh.ListReleases(context.Background())
+	assert.EqualError(t, err, "failed to list releases of repo1: boom")
+}
+
+func TestGitHub_GetReleaseAssets(t *testing.T) {
+	client := new(ReleaseOpsClientMock)
+	client.On("ListReleaseAssets", mock.Anything, "testowner", "repo1", int64(2), mock.Anything).Return([]*github.ReleaseAsset{
+		{ID: github.Int64(20), Name: github.String("coco.tar.gz"), DownloadCount: github.Int(8)},
+	}, nil, nil)
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	assets, err := gh.GetReleaseAssets(context.Background(), "repo1", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []ReleaseAsset{{ID: 20, Name: "coco.tar.gz", DownloadCount: 8}}, assets)
+}
+
+func TestGitHub_OpenReleaseAsset(t *testing.T) {
+	client := new(ReleaseOpsClientMock)
+	client.On("DownloadReleaseAsset", mock.Anything, "testowner", "repo1", int64(20), http.DefaultClient).
+		Return(io.NopCloser(strings.NewReader("archive")), "", nil)
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	rc, err := gh.OpenReleaseAsset(context.Background(), "repo1", 20)
+	require.NoError(t, err)
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, "archive", string(content))
+}
+
+func TestGitHub_ListReleases_UnsupportedClient(t *testing.T) {
+	gh := NewGitHubClient(new(CommitOpsClientMock), new(GraphQLClientMock), GitHubConfig{})
+
+	_, err := gh.ListReleases(context.Background())
+	assert.ErrorIs(t, err, ErrUnsupportedClient)
+}
@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// RepositoryRef identifies a repository of any owner, so one client can collect from several owners or
+// organizations. Methods taking a repository name also accept the full name "owner/name" of such
+// repositories.
+//
+// Branch is the branch to read the repository from, empty uses the branch GitHubConfig selects. Ref is a
+// tag, e.g. "v1.2.0", a fully qualified ref such as "refs/tags/v1.2.0" or a commit SHA to read the
+// repository at instead, so content can be collected as of a specific release. Ref takes precedence over
+// Branch.
+type RepositoryRef struct {
+	Owner  string
+	Name   string
+	Branch string
+	Ref    string
+}
+
+// String returns the full name of the repository, "owner/name".
+func (r RepositoryRef) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// repositories returns the configured repositories: the names listed in Repositories, which belong to Owner,
+// followed by the full names of RepositoryRefs. Methods taking a repository accept both forms.
+func (c *GitHub) repositories() []string {
+	repos := make([]string, 0, len(c.Configuration.Repositories)+len(c.Configuration.RepositoryRefs))
+	repos = append(repos, c.Configuration.Repositories...)
+	for _, ref := range c.Configuration.RepositoryRefs {
+		repos = append(repos, ref.String())
+	}
+	return repos
+}
+
+// GHQueryForDefaultBranch is the GraphQL query for the default branch of a repository.
+type GHQueryForDefaultBranch struct {
+	Repository struct {
+		DefaultBranchRef struct {
+			Name string
+		}
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// repositoryRef resolves a repository given by name, belonging to the configured Owner, or by full name.
+// Branch is the branch configured for the repository in RepositoryRefs or Branches, empty if there is none,
+// Ref the ref configured for it in RepositoryRefs.
+func (c *GitHub) repositoryRef(repo string) RepositoryRef {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return RepositoryRef{Owner: c.Configuration.Owner, Name: repo, Branch: c.Configuration.Branches[repo]}
+	}
+
+	ref := RepositoryRef{Owner: owner, Name: name}
+	for _, configured := range c.Configuration.RepositoryRefs {
+		if configured.Owner == owner && configured.Name == name {
+			ref.Branch = configured.Branch
+			ref.Ref = configured.Ref
+			break
+		}
+	}
+	if ref.Branch == "" {
+		ref.Branch = c.Configuration.Branches[repo]
+	}
+	return ref
+}
+
+// ownerOf returns the owner of the repository.
+func (c *GitHub) ownerOf(repo string) string {
+	return c.repositoryRef(repo).Owner
+}
+
+// nameOf returns the name of the repository without its owner.
+func (c *GitHub) nameOf(repo string) string {
+	return c.repositoryRef(repo).Name
+}
+
+// branch returns the branch or ref the repository is read from: the ref or branch configured for it, its
+// default branch as reported by GitHub if DetectDefaultBranch is set, or DefaultBranch. Detected branches
+// are cached for the lifetime of the client.
+func (c *GitHub) branch(ctx context.Context, repo string) (string, error) {
+	ref := c.repositoryRef(repo)
+	if ref.Ref != "" {
+		return ref.Ref, nil
+	}
+	if ref.Branch != "" {
+		return ref.Branch, nil
+	}
+	if !c.Configuration.DetectDefaultBranch {
+		return c.Configuration.DefaultBranch, nil
+	}
+
+	key := ref.String()
+	c.branchesMu.Lock()
+	branch, ok := c.detectedBranches[key]
+	c.branchesMu.Unlock()
+	if ok {
+		return branch, nil
+	}
+
+	var query GHQueryForDefaultBranch
+	variables := map[string]interface{}{
+		"owner": githubv4.String(ref.Owner),
+		"name":  githubv4.String(ref.Name),
+	}
+
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return "", err
+	}
+	err = c.query(ctx, &query, variables)
+	release()
+	if err != nil {
+		return "", fmt.Errorf("failed to detect the default branch of %s: %w", key, err)
+	}
+
+	branch = query.Repository.DefaultBranchRef.Name
+	if branch == "" {
+		return "", fmt.Errorf("failed to detect the default branch of %s: the repository is empty", key)
+	}
+
+	c.branchesMu.Lock()
+	defer c.branchesMu.Unlock()
+	if c.detectedBranches == nil {
+		c.detectedBranches = make(map[string]string)
+	}
+	c.detectedBranches[key] = branch
+	return branch, nil
+}
@@ -0,0 +1,76 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// GHQueryForRepositoryAttributes is the GraphQL query for the attributes of a repository a RepositoryFilter
+// selects by.
+type GHQueryForRepositoryAttributes struct {
+	Repository struct {
+		IsArchived      bool
+		IsFork          bool
+		PrimaryLanguage struct {
+			Name string
+		}
+		RepositoryTopics struct {
+			Nodes []struct {
+				Topic struct {
+					Name string
+				}
+			}
+		} `graphql:"repositoryTopics(first: 100)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// matchRepository reports whether a configured repository passes GitHubConfig.RepositoryFilter, looking up
+// its attributes through the API unless the filter is empty. Results are cached for the lifetime of the
+// client.
+func (c *GitHub) matchRepository(ctx context.Context, repo string) (bool, error) {
+	repositoryFilter := c.Configuration.RepositoryFilter
+	if repositoryFilter.IsZero() {
+		return true, nil
+	}
+
+	ref := c.repositoryRef(repo)
+	key := ref.String()
+	c.matchedMu.Lock()
+	matched, ok := c.matchedRepositories[key]
+	c.matchedMu.Unlock()
+	if ok {
+		return matched, nil
+	}
+
+	var query GHQueryForRepositoryAttributes
+	variables := map[string]interface{}{
+		"owner": githubv4.String(ref.Owner),
+		"name":  githubv4.String(ref.Name),
+	}
+
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	err = c.query(ctx, &query, variables)
+	release()
+	if err != nil {
+		return false, fmt.Errorf("failed to get the attributes of %s: %w", key, err)
+	}
+
+	var topics []string
+	for _, node := range query.Repository.RepositoryTopics.Nodes {
+		topics = append(topics, node.Topic.Name)
+	}
+	matched = repositoryFilter.Match(topics, query.Repository.PrimaryLanguage.Name, query.Repository.IsArchived, query.Repository.IsFork)
+
+	c.matchedMu.Lock()
+	defer c.matchedMu.Unlock()
+	if c.matchedRepositories == nil {
+		c.matchedRepositories = make(map[string]bool)
+	}
+	c.matchedRepositories[key] = matched
+	return matched, nil
+}
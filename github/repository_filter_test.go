@@ -0,0 +1,120 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHub_RepositoryFilter(t *testing.T) {
+	graphQLClient := new(GraphQLClientMock)
+	expectAttributes := func(name string, fork bool, topics ...string) {
+		graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForRepositoryAttributes"), map[string]interface{}{
+			"owner": githubv4.String("testowner"),
+			"name":  githubv4.String(name),
+		}).Run(func(args mock.Arguments) {
+			query := args.Get(1).(*GHQueryForRepositoryAttributes)
+			query.Repository.IsFork = fork
+			query.Repository.PrimaryLanguage.Name = "Go"
+			for _, topic := range topics {
+				node := struct{ Topic struct{ Name string } }{}
+				node.Topic.Name = topic
+				query.Repository.RepositoryTopics.Nodes = append(query.Repository.RepositoryTopics.Nodes, node)
+			}
+		}).Return(nil).Once()
+	}
+	expectAttributes("repo1", false, "documentation")
+	expectAttributes("repo2", true, "documentation")
+	expectAttributes("repo3", false, "cli")
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForListFiles"), mock.MatchedBy(func(variables map[string]interface{}) bool {
+		return variables["name"] == githubv4.String("repo1")
+	})).Run(func(args mock.Arguments) {
+		query := args.Get(1).(*GHQueryForListFiles)
+		query.Repository.Object.Tree.Entries = []GHTreeEntry{{Name: "README.md", Path: "README.md", Type: "blob"}}
+	}).Return(nil)
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:            "testowner",
+		Repositories:     []string{"repo1", "repo2", "repo3"},
+		RepositoryFilter: filter.RepositoryFilter{Topics: []string{"documentation"}, ExcludeForks: true},
+		DefaultBranch:    "main",
+	})
+
+	// The attributes are looked up once per repository.
+	for i := 0; i < 2; i++ {
+		paths, err := gh.GetFilePathsFromRepositories()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"README.md"}, paths)
+	}
+	graphQLClient.AssertExpectations(t)
+}
+
+func TestGitHub_RepositoryFilter_Error(t *testing.T) {
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForRepositoryAttributes"), mock.Anything).Return(errors.New("not found"))
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:            "testowner",
+		Repositories:     []string{"missing"},
+		RepositoryFilter: filter.RepositoryFilter{ExcludeArchived: true},
+	})
+
+	_, err := gh.GetFilePathsFromRepositoriesWithContext(context.Background())
+	assert.EqualError(t, err, "failed to get the attributes of testowner/missing: not found")
+}
+
+func TestGitHub_RepositoryFilter_EntryPoints(t *testing.T) {
+	graphQLClient := new(GraphQLClientMock)
+	for name, archived := range map[string]bool{"repo1": false, "repo2": true} {
+		archived := archived
+		graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForRepositoryAttributes"), map[string]interface{}{
+			"owner": githubv4.String("testowner"),
+			"name":  githubv4.String(name),
+		}).Run(func(args mock.Arguments) {
+			args.Get(1).(*GHQueryForRepositoryAttributes).Repository.IsArchived = archived
+		}).Return(nil)
+	}
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForListFiles"), mock.MatchedBy(func(variables map[string]interface{}) bool {
+		return variables["name"] == githubv4.String("repo1")
+	})).Run(func(args mock.Arguments) {
+		query := args.Get(1).(*GHQueryForListFiles)
+		query.Repository.Object.Tree.Entries = []GHTreeEntry{{Name: "README.md", Path: "README.md", Type: "blob", Oid: "abc"}}
+	}).Return(nil)
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForBlobText"), mock.Anything).Return(nil)
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:            "testowner",
+		Repositories:     []string{"repo1", "repo2"},
+		RepositoryFilter: filter.RepositoryFilter{ExcludeArchived: true},
+		DefaultBranch:    "main",
+	})
+	ctx := context.Background()
+
+	snapshot, err := gh.GetSnapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, document.Snapshot{"repo1": {"README.md": "abc"}}, snapshot)
+
+	roots, err := gh.GetFileTreeFromRepositories(ctx)
+	require.NoError(t, err)
+	require.Len(t, roots, 1)
+	assert.Equal(t, "repo1", roots[0].Name)
+
+	var walked []string
+	err = gh.WalkFiles(ctx, FileOptions{}, func(file document.File) error {
+		walked = append(walked, file.Repository+"/"+file.Path)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"repo1/README.md"}, walked)
+
+	graphQLClient.AssertNotCalled(t, "Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForListFiles"), mock.MatchedBy(func(variables map[string]interface{}) bool {
+		return variables["name"] == githubv4.String("repo2")
+	}))
+}
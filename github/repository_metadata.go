@@ -0,0 +1,143 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// ReadmeOpsClient is an interface to help test the GitHub README and Markdown rendering operations.
+// GetRepositoryMetadata reads the README through it and renders it to HTML.
+type ReadmeOpsClient interface {
+	GetReadme(ctx context.Context, owner, repo string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, *github.Response, error)
+	RenderMarkdown(ctx context.Context, text string, opts *github.MarkdownOptions) (string, *github.Response, error)
+}
+
+// RepositoryMetadata describes a repository as a whole, so documents collected from it can be enriched with
+// repository level context.
+//
+// Repository is the repository as configured. License is the SPDX identifier of the license GitHub detected,
+// empty if it detected none. Readme holds the README of the default branch as stored, ReadmeHTML the README
+// rendered by GitHub with relative links resolved against the repository; both are empty if the repository
+// has no README.
+type RepositoryMetadata struct {
+	Repository    string
+	FullName      string
+	Description   string
+	Topics        []string
+	Homepage      string
+	License       string
+	DefaultBranch string
+	Language      string
+	Stars         int
+	Forks         int
+	Watchers      int
+	OpenIssues    int
+	Archived      bool
+	Visibility    string
+	URL           string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	PushedAt      time.Time
+	ReadmePath    string
+	Readme        string
+	ReadmeHTML    string
+}
+
+// GetReadme retrieves the README of a specific repository.
+func (gClient *GitHubCommitsOpsClient) GetReadme(ctx context.Context, owner, repo string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, *github.Response, error) {
+	return gClient.GitHubClient.Repositories.GetReadme(ctx, owner, repo, opts)
+}
+
+// RenderMarkdown renders a Markdown document to HTML.
+func (gClient *GitHubCommitsOpsClient) RenderMarkdown(ctx context.Context, text string, opts *github.MarkdownOptions) (string, *github.Response, error) {
+	return gClient.GitHubClient.Markdown.Render(ctx, text, opts)
+}
+
+// GetRepositoryMetadata retrieves the description, topics, homepage, license, default branch, counters and
+// the README of a configured repository. The README is rendered the way GitHub shows it, which takes one
+// more API call.
+//
+// Usage:
+//
+//	metadata, err := c.GetRepositoryMetadata(ctx, "website")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	fmt.Println(metadata.Description, metadata.Topics, metadata.Stars)
+func (c *GitHub) GetRepositoryMetadata(ctx context.Context, repo string) (RepositoryMetadata, error) {
+	repositoryClient, err := c.repositoryOpsClient()
+	if err != nil {
+		return RepositoryMetadata{}, err
+	}
+	readmeClient, err := c.readmeOpsClient()
+	if err != nil {
+		return RepositoryMetadata{}, err
+	}
+
+	repository, _, err := repositoryClient.GetRepository(ctx, c.ownerOf(repo), c.nameOf(repo))
+	if err != nil {
+		return RepositoryMetadata{}, fmt.Errorf("failed to get repository %s: %w", repo, c.classifyError(err))
+	}
+
+	metadata := RepositoryMetadata{
+		Repository:    repo,
+		FullName:      repository.GetFullName(),
+		Description:   repository.GetDescription(),
+		Topics:        repository.Topics,
+		Homepage:      repository.GetHomepage(),
+		License:       repository.GetLicense().GetSPDXID(),
+		DefaultBranch: repository.GetDefaultBranch(),
+		Language:      repository.GetLanguage(),
+		Stars:         repository.GetStargazersCount(),
+		Forks:         repository.GetForksCount(),
+		Watchers:      repository.GetSubscribersCount(),
+		OpenIssues:    repository.GetOpenIssuesCount(),
+		Archived:      repository.GetArchived(),
+		Visibility:    repository.GetVisibility(),
+		URL:           repository.GetHTMLURL(),
+		CreatedAt:     repository.GetCreatedAt().Time,
+		UpdatedAt:     repository.GetUpdatedAt().Time,
+		PushedAt:      repository.GetPushedAt().Time,
+	}
+	// GitHub reports NOASSERTION for licenses it found but could not identify.
+	if metadata.License == "NOASSERTION" {
+		metadata.License = ""
+	}
+
+	readme, _, err := readmeClient.GetReadme(ctx, c.ownerOf(repo), c.nameOf(repo), nil)
+	if isNotFound(err) {
+		return metadata, nil
+	}
+	if err != nil {
+		return RepositoryMetadata{}, fmt.Errorf("failed to get the README of %s: %w", repo, c.classifyError(err))
+	}
+
+	metadata.ReadmePath = readme.GetPath()
+	metadata.Readme, err = readme.GetContent()
+	if err != nil {
+		return RepositoryMetadata{}, fmt.Errorf("failed to decode the README of %s: %w", repo, err)
+	}
+
+	metadata.ReadmeHTML, _, err = readmeClient.RenderMarkdown(ctx, metadata.Readme, &github.MarkdownOptions{
+		Mode:    "gfm",
+		Context: c.repositoryRef(repo).String(),
+	})
+	if err != nil {
+		return RepositoryMetadata{}, fmt.Errorf("failed to render the README of %s: %w", repo, c.classifyError(err))
+	}
+
+	return metadata, nil
+}
+
+// readmeOpsClient returns the commit ops client as a ReadmeOpsClient.
+func (c *GitHub) readmeOpsClient() (ReadmeOpsClient, error) {
+	client, ok := c.commitOpsClient.(ReadmeOpsClient)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not implement ReadmeOpsClient", ErrUnsupportedClient, c.commitOpsClient)
+	}
+	return client, nil
+}
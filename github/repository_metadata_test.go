@@ -0,0 +1,118 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// ReadmeOpsClientMock is a mock type for a CommitOpsClient that also implements RepositoryOpsClient and
+// ReadmeOpsClient
+type ReadmeOpsClientMock struct {
+	RepositoryOpsClientMock
+}
+
+// GetReadme provides a mock function with given fields: ctx, owner, repo, opts
+func (_m *ReadmeOpsClientMock) GetReadme(ctx context.Context, owner, repo string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, opts)
+	content, _ := ret.Get(0).(*github.RepositoryContent)
+	resp, _ := ret.Get(1).(*github.Response)
+	return content, resp, ret.Error(2)
+}
+
+// RenderMarkdown provides a mock function with given fields: ctx, text, opts
+func (_m *ReadmeOpsClientMock) RenderMarkdown(ctx context.Context, text string, opts *github.MarkdownOptions) (string, *github.Response, error) {
+	ret := _m.Called(ctx, text, opts)
+	resp, _ := ret.Get(1).(*github.Response)
+	return ret.String(0), resp, ret.Error(2)
+}
+
+func TestGitHub_GetRepositoryMetadata(t *testing.T) {
+	created := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	client := new(ReadmeOpsClientMock)
+	client.On("GetRepository", mock.Anything, "other", "repo2").Return(&github.Repository{
+		FullName:         github.String("other/repo2"),
+		Description:      github.String("The website"),
+		Topics:           []string{"docs", "hugo"},
+		Homepage:         github.String("https://example.com"),
+		License:          &github.License{SPDXID: github.String("MIT")},
+		DefaultBranch:    github.String("main"),
+		Language:         github.String("Go"),
+		StargazersCount:  github.Int(42),
+		ForksCount:       github.Int(7),
+		SubscribersCount: github.Int(3),
+		OpenIssuesCount:  github.Int(5),
+		Visibility:       github.String("public"),
+		HTMLURL:          github.String("https://github.com/other/repo2"),
+		CreatedAt:        &github.Timestamp{Time: created},
+	}, nil, nil)
+	client.On("GetReadme", mock.Anything, "other", "repo2", mock.Anything).Return(&github.RepositoryContent{
+		Path:     github.String("README.md"),
+		Encoding: github.String("base64"),
+		Content:  github.String(base64.StdEncoding.EncodeToString([]byte("# Website"))),
+	}, nil, nil)
+	client.On("RenderMarkdown", mock.Anything, "# Website", &github.MarkdownOptions{Mode: "gfm", Context: "other/repo2"}).
+		Return("<h1>Website</h1>", nil, nil)
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		RepositoryRefs: []RepositoryRef{{Owner: "other", Name: "repo2"}},
+	})
+
+	metadata, err := gh.GetRepositoryMetadata(context.Background(), "other/repo2")
+	require.NoError(t, err)
+	assert.Equal(t, RepositoryMetadata{
+		Repository:    "other/repo2",
+		FullName:      "other/repo2",
+		Description:   "The website",
+		Topics:        []string{"docs", "hugo"},
+		Homepage:      "https://example.com",
+		License:       "MIT",
+		DefaultBranch: "main",
+		Language:      "Go",
+		Stars:         42,
+		Forks:         7,
+		Watchers:      3,
+		OpenIssues:    5,
+		Visibility:    "public",
+		URL:           "https://github.com/other/repo2",
+		CreatedAt:     created,
+		ReadmePath:    "README.md",
+		Readme:        "# Website",
+		ReadmeHTML:    "<h1>Website</h1>",
+	}, metadata)
+}
+
+func TestGitHub_GetRepositoryMetadata_NoReadme(t *testing.T) {
+	client := new(ReadmeOpsClientMock)
+	client.On("GetRepository", mock.Anything, "testowner", "repo1").Return(&github.Repository{
+		License: &github.License{SPDXID: github.String("NOASSERTION")},
+	}, nil, nil)
+	client.On("GetReadme", mock.Anything, "testowner", "repo1", mock.Anything).Return(nil, nil, &github.ErrorResponse{
+		Response: &http.Response{StatusCode: http.StatusNotFound},
+	})
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	metadata, err := gh.GetRepositoryMetadata(context.Background(), "repo1")
+	require.NoError(t, err)
+	assert.Equal(t, RepositoryMetadata{Repository: "repo1"}, metadata)
+	client.AssertNotCalled(t, "RenderMarkdown", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGitHub_GetRepositoryMetadata_UnsupportedClient(t *testing.T) {
+	gh := NewGitHubClient(new(RepositoryOpsClientMock), new(GraphQLClientMock), GitHubConfig{})
+
+	_, err := gh.GetRepositoryMetadata(context.Background(), "repo1")
+	assert.ErrorIs(t, err, ErrUnsupportedClient)
+}
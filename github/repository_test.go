@@ -0,0 +1,97 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGitHub_RepositoryRef(t *testing.T) {
+	gh := NewGitHubClient(new(CommitOpsClientMock), new(GraphQLClientMock), GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1", "legacy"},
+		DefaultBranch: "main",
+		Branches:      map[string]string{"legacy": "master", "third-org/repo1": "develop"},
+		RepositoryRefs: []RepositoryRef{
+			{Owner: "other-org", Name: "docs", Branch: "gh-pages"},
+			{Owner: "third-org", Name: "repo1"},
+			{Owner: "other-org", Name: "release", Branch: "main", Ref: "v1.2.0"},
+		},
+	})
+
+	assert.Equal(t, []string{"repo1", "legacy", "other-org/docs", "third-org/repo1", "other-org/release"}, gh.repositories())
+
+	tests := []struct {
+		repo   string
+		want   RepositoryRef
+		branch string
+	}{
+		{repo: "repo1", want: RepositoryRef{Owner: "testowner", Name: "repo1"}, branch: "main"},
+		{repo: "legacy", want: RepositoryRef{Owner: "testowner", Name: "legacy", Branch: "master"}, branch: "master"},
+		{repo: "other-org/docs", want: RepositoryRef{Owner: "other-org", Name: "docs", Branch: "gh-pages"}, branch: "gh-pages"},
+		{repo: "third-org/repo1", want: RepositoryRef{Owner: "third-org", Name: "repo1", Branch: "develop"}, branch: "develop"},
+		{repo: "other-org/release", want: RepositoryRef{Owner: "other-org", Name: "release", Branch: "main", Ref: "v1.2.0"}, branch: "v1.2.0"},
+		{repo: "unlisted/repo", want: RepositoryRef{Owner: "unlisted", Name: "repo"}, branch: "main"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.repo, func(t *testing.T) {
+			assert.Equal(t, tt.want, gh.repositoryRef(tt.repo))
+			assert.Equal(t, tt.want.Owner, gh.ownerOf(tt.repo))
+			assert.Equal(t, tt.want.Name, gh.nameOf(tt.repo))
+
+			branch, err := gh.branch(context.Background(), tt.repo)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.branch, branch)
+		})
+	}
+
+	assert.Equal(t, "other-org/docs", RepositoryRef{Owner: "other-org", Name: "docs"}.String())
+}
+
+func TestGitHub_DetectDefaultBranch(t *testing.T) {
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForDefaultBranch"), map[string]interface{}{
+		"owner": githubv4.String("testowner"),
+		"name":  githubv4.String("repo1"),
+	}).Run(func(args mock.Arguments) {
+		args.Get(1).(*GHQueryForDefaultBranch).Repository.DefaultBranchRef.Name = "develop"
+	}).Return(nil).Once()
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForDefaultBranch"), map[string]interface{}{
+		"owner": githubv4.String("testowner"),
+		"name":  githubv4.String("empty"),
+	}).Return(nil)
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForDefaultBranch"), map[string]interface{}{
+		"owner": githubv4.String("testowner"),
+		"name":  githubv4.String("missing"),
+	}).Return(errors.New("not found"))
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:               "testowner",
+		DefaultBranch:       "main",
+		Branches:            map[string]string{"legacy": "master"},
+		DetectDefaultBranch: true,
+	})
+
+	for i := 0; i < 2; i++ {
+		branch, err := gh.branch(context.Background(), "repo1")
+		assert.NoError(t, err)
+		assert.Equal(t, "develop", branch)
+	}
+
+	// A configured branch wins over the detected one.
+	branch, err := gh.branch(context.Background(), "legacy")
+	assert.NoError(t, err)
+	assert.Equal(t, "master", branch)
+
+	_, err = gh.branch(context.Background(), "empty")
+	assert.EqualError(t, err, "failed to detect the default branch of testowner/empty: the repository is empty")
+
+	_, err = gh.branch(context.Background(), "missing")
+	assert.EqualError(t, err, "failed to detect the default branch of testowner/missing: not found")
+
+	graphQLClient.AssertExpectations(t)
+}
@@ -0,0 +1,165 @@
+package github
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/internal/timeutil"
+)
+
+// The defaults of a RetryPolicy.
+const (
+	DefaultRetries        = 3
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	DefaultRetryMaxDelay  = 30 * time.Second
+)
+
+// RetryPolicy configures a RetryTransport.
+//
+// MaxRetries is the number of times a request failing transiently is retried; zero uses DefaultRetries, a
+// negative value disables retries. The n-th retry waits BaseDelay doubled n-1 times, at most MaxDelay, of
+// which a random half is jitter, so parallel crawls do not retry in lockstep. Zero delays use
+// DefaultRetryBaseDelay and DefaultRetryMaxDelay. A Retry-After header sent with the response takes
+// precedence over the computed delay. Logger, if set, receives a debug log for every retry.
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+	Logger     Logger
+}
+
+// RetryTransport is an http.RoundTripper retrying requests that failed transiently: network errors,
+// 502 Bad Gateway, 503 Service Unavailable, 504 Gateway Timeout and secondary rate limits, which GitHub
+// answers with 403 Forbidden or 429 Too Many Requests and a Retry-After header. Other responses are
+// returned as they are.
+//
+// Both the REST and the GraphQL client are covered when they share the HTTP client. NewGitHub and
+// NewClientsFromToken use a RetryTransport with the default policy unless WithoutRetry is passed.
+//
+//	httpClient.Transport = github.NewRetryTransport(httpClient.Transport, github.RetryPolicy{MaxRetries: 5})
+type RetryTransport struct {
+	base   http.RoundTripper
+	policy RetryPolicy
+
+	// sleep waits for d or until the context is done, jitter returns a number in [0, 1). Tests replace them.
+	sleep  func(ctx context.Context, d time.Duration) error
+	jitter func() float64
+}
+
+// NewRetryTransport wraps the base transport, nil uses http.DefaultTransport.
+func NewRetryTransport(base http.RoundTripper, policy RetryPolicy) *RetryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RetryTransport{
+		base:   base,
+		policy: policy,
+		sleep:  timeutil.Sleep,
+		jitter: rand.Float64,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(req)
+
+		// Requests whose body cannot be read again are not retried.
+		if attempt >= t.maxRetries() || (req.Body != nil && req.GetBody == nil) || ctx.Err() != nil {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt)
+		if err == nil {
+			retryAfter, retry := transientResponse(resp)
+			if !retry {
+				return resp, nil
+			}
+			if retryAfter > 0 {
+				wait = retryAfter
+			}
+			resp.Body.Close()
+			logDebug(t.policy.Logger, "retrying request", "url", req.URL.String(), "attempt", attempt+1, "status", resp.StatusCode, "wait", wait)
+		} else {
+			logDebug(t.policy.Logger, "retrying request", "url", req.URL.String(), "attempt", attempt+1, "error", err, "wait", wait)
+		}
+
+		if err := t.sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// maxRetries returns the configured number of retries.
+func (t *RetryTransport) maxRetries() int {
+	switch {
+	case t.policy.MaxRetries < 0:
+		return 0
+	case t.policy.MaxRetries == 0:
+		return DefaultRetries
+	default:
+		return t.policy.MaxRetries
+	}
+}
+
+// backoff returns the jittered delay before the retry following the given attempt.
+func (t *RetryTransport) backoff(attempt int) time.Duration {
+	base, maxDelay := t.policy.BaseDelay, t.policy.MaxDelay
+	if base <= 0 {
+		base = DefaultRetryBaseDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryMaxDelay
+	}
+
+	delay := base
+	for i := 0; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay/2 + time.Duration(t.jitter()*float64(delay/2))
+}
+
+// transientResponse reports whether the response is worth retrying and how long the server asked to wait,
+// zero if it did not say.
+func transientResponse(resp *http.Response) (time.Duration, bool) {
+	retryAfter := parseRetryAfter(resp.Header.Get(headerRetryAfter))
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return retryAfter, true
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		return retryAfter, resp.Header.Get(headerRetryAfter) != ""
+	}
+	return 0, false
+}
+
+// parseRetryAfter parses a Retry-After header holding seconds or an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
@@ -0,0 +1,187 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func status(code int, headers ...string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		for i := 0; i+1 < len(headers); i += 2 {
+			w.Header().Set(headers[i], headers[i+1])
+		}
+		w.WriteHeader(code)
+	}
+}
+
+func newTestRetryTransport(base http.RoundTripper, policy RetryPolicy) (*RetryTransport, *[]time.Duration) {
+	var sleeps []time.Duration
+	transport := NewRetryTransport(base, policy)
+	transport.sleep = func(_ context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	}
+	transport.jitter = func() float64 { return 0 }
+	return transport, &sleeps
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name       string
+		responses  []func(w http.ResponseWriter)
+		policy     RetryPolicy
+		wantStatus int
+		wantSleeps []time.Duration
+	}{
+		{
+			name:       "transient server errors are retried with backoff",
+			responses:  []func(w http.ResponseWriter){status(http.StatusBadGateway), status(http.StatusServiceUnavailable), status(http.StatusGatewayTimeout)},
+			policy:     RetryPolicy{BaseDelay: 100 * time.Millisecond},
+			wantStatus: http.StatusOK,
+			wantSleeps: []time.Duration{50 * time.Millisecond, 100 * time.Millisecond, 200 * time.Millisecond},
+		},
+		{
+			name:       "Retry-After is honoured",
+			responses:  []func(w http.ResponseWriter){status(http.StatusServiceUnavailable, "Retry-After", "7")},
+			wantStatus: http.StatusOK,
+			wantSleeps: []time.Duration{7 * time.Second},
+		},
+		{
+			name:       "secondary rate limits are retried",
+			responses:  []func(w http.ResponseWriter){status(http.StatusForbidden, "Retry-After", "2"), status(http.StatusTooManyRequests, "Retry-After", "3")},
+			wantStatus: http.StatusOK,
+			wantSleeps: []time.Duration{2 * time.Second, 3 * time.Second},
+		},
+		{
+			name:       "forbidden without Retry-After is returned",
+			responses:  []func(w http.ResponseWriter){status(http.StatusForbidden)},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "client errors are returned",
+			responses:  []func(w http.ResponseWriter){status(http.StatusNotFound)},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "the last response is returned when the retries are used up",
+			responses:  []func(w http.ResponseWriter){status(http.StatusBadGateway), status(http.StatusBadGateway), status(http.StatusBadGateway)},
+			policy:     RetryPolicy{MaxRetries: 2, BaseDelay: time.Second, MaxDelay: 1500 * time.Millisecond},
+			wantStatus: http.StatusBadGateway,
+			wantSleeps: []time.Duration{500 * time.Millisecond, 750 * time.Millisecond},
+		},
+		{
+			name:       "negative MaxRetries disables retries",
+			responses:  []func(w http.ResponseWriter){status(http.StatusBadGateway)},
+			policy:     RetryPolicy{MaxRetries: -1},
+			wantStatus: http.StatusBadGateway,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &rateLimitServer{responses: tt.responses}
+			srv := httptest.NewServer(server)
+			defer srv.Close()
+
+			transport, sleeps := newTestRetryTransport(nil, tt.policy)
+			req, _ := http.NewRequest(http.MethodPost, srv.URL+"/graphql", strings.NewReader(`{"query":"{}"}`))
+			resp, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantSleeps, *sleeps)
+			for _, body := range server.bodies {
+				assert.Equal(t, `{"query":"{}"}`, body, "the body is sent again with every retry")
+			}
+		})
+	}
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRetryTransport_NetworkError(t *testing.T) {
+	attempts := 0
+	base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("connection reset by peer")
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+	})
+
+	transport, sleeps := newTestRetryTransport(base, RetryPolicy{})
+	req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/repos/o/r", nil)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, []time.Duration{DefaultRetryBaseDelay / 2}, *sleeps)
+}
+
+func TestRetryTransport_ContextCancelled(t *testing.T) {
+	server := &rateLimitServer{responses: []func(w http.ResponseWriter){status(http.StatusBadGateway)}}
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	transport := NewRetryTransport(nil, RetryPolicy{})
+	transport.sleep = func(ctx context.Context, _ time.Duration) error {
+		cancel()
+		return ctx.Err()
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	_, err := transport.RoundTrip(req)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNewGitHub_Retry(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+	}{
+		{name: "retried by default", opts: []Option{WithRetryPolicy(RetryPolicy{BaseDelay: time.Millisecond})}},
+		{name: "disabled", opts: []Option{WithoutRetry()}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failures := 1
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if failures > 0 {
+					failures--
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("[]"))
+			}))
+			defer srv.Close()
+
+			opts := append([]Option{WithBaseURL(srv.URL), WithRepositories("testowner", "repo1"), WithDefaultBranch("main")}, tt.opts...)
+			client, err := NewGitHub("token", opts...)
+			require.NoError(t, err)
+
+			_, err = client.GetChangedFilePathsSince(time.Time{})
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "502")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
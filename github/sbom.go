@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// DependencyGraphOpsClient is an interface to help test the GitHub dependency graph operations.
+// GetSBOMDocuments exports the SBOM of every repository through it.
+type DependencyGraphOpsClient interface {
+	GetSBOM(ctx context.Context, owner, repo string) (json.RawMessage, *github.Response, error)
+}
+
+// GetSBOM exports the software bill of materials of a specific repository in SPDX format and returns the
+// response body as is, the SPDX document wrapped in an "sbom" field. The response is not decoded into
+// github.SBOM, which would drop the SPDX fields it does not declare.
+func (gClient *GitHubCommitsOpsClient) GetSBOM(ctx context.Context, owner, repo string) (json.RawMessage, *github.Response, error) {
+	req, err := gClient.GitHubClient.NewRequest(http.MethodGet, fmt.Sprintf("repos/%v/%v/dependency-graph/sbom", owner, repo), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var body json.RawMessage
+	resp, err := gClient.GitHubClient.Do(ctx, req, &body)
+	if err != nil {
+		return nil, resp, err
+	}
+	return body, resp, nil
+}
+
+// GetSBOMDocuments exports the dependency graph of every configured repository as a software bill of
+// materials and returns one document per repository. The document body holds the SPDX JSON exactly as
+// GitHub produced it, the metadata summarizes the SPDX version and the declared packages.
+//
+// The dependency graph must be enabled for the repositories.
+//
+// Usage:
+//
+//	docs, err := c.GetSBOMDocuments(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, doc := range docs {
+//	    fmt.Println(doc.Repository, doc.Metadata["packages"])
+//	}
+func (c *GitHub) GetSBOMDocuments(ctx context.Context) ([]document.Document, error) {
+	client, err := c.dependencyGraphOpsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	repoDocs := make([][]document.Document, len(c.repositories()))
+	err = c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		body, _, err := client.GetSBOM(ctx, c.ownerOf(repo), c.nameOf(repo))
+		if err != nil {
+			return c.classifyError(err)
+		}
+
+		doc, err := c.sbomDocument(repo, body)
+		if err != nil {
+			return err
+		}
+		repoDocs[i] = []document.Document{doc}
+		return nil
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	return flattenDocuments(repoDocs), err
+}
+
+// sbomDocument converts the SBOM response of a repository into a document holding its SPDX document.
+func (c *GitHub) sbomDocument(repo string, response json.RawMessage) (document.Document, error) {
+	var wrapper struct {
+		SBOM json.RawMessage `json:"sbom"`
+	}
+	if err := json.Unmarshal(response, &wrapper); err != nil {
+		return document.Document{}, fmt.Errorf("failed to decode SBOM of %s/%s: %w", c.ownerOf(repo), c.nameOf(repo), err)
+	}
+	if len(wrapper.SBOM) == 0 || string(wrapper.SBOM) == "null" {
+		return document.Document{}, fmt.Errorf("no SBOM returned for %s/%s", c.ownerOf(repo), c.nameOf(repo))
+	}
+
+	var info github.SBOMInfo
+	if err := json.Unmarshal(wrapper.SBOM, &info); err != nil {
+		return document.Document{}, fmt.Errorf("failed to decode SBOM of %s/%s: %w", c.ownerOf(repo), c.nameOf(repo), err)
+	}
+
+	packages := make([]string, 0, len(info.Packages))
+	for _, pkg := range info.Packages {
+		name := pkg.GetName()
+		if version := pkg.GetVersionInfo(); version != "" {
+			name += "@" + version
+		}
+		packages = append(packages, name)
+	}
+
+	created := info.GetCreationInfo().GetCreated().Time
+
+	return document.Document{
+		ID:         fmt.Sprintf("%s/%s/sbom", c.ownerOf(repo), c.nameOf(repo)),
+		Kind:       document.DocumentKindSBOM,
+		Owner:      c.ownerOf(repo),
+		Repository: c.nameOf(repo),
+		Title:      info.GetName(),
+		Body:       string(wrapper.SBOM),
+		URL:        fmt.Sprintf("%s/%s/%s/network/dependencies", c.webBaseURL(), c.ownerOf(repo), c.nameOf(repo)),
+		CreatedAt:  created,
+		UpdatedAt:  created,
+		Metadata: map[string]string{
+			"spdx_version":  info.GetSPDXVersion(),
+			"namespace":     info.GetDocumentNamespace(),
+			"package_count": strconv.Itoa(len(info.Packages)),
+			"packages":      strings.Join(packages, ","),
+		},
+	}, nil
+}
+
+// dependencyGraphOpsClient returns the commit ops client as a DependencyGraphOpsClient.
+func (c *GitHub) dependencyGraphOpsClient() (DependencyGraphOpsClient, error) {
+	client, ok := c.commitOpsClient.(DependencyGraphOpsClient)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not implement DependencyGraphOpsClient", ErrUnsupportedClient, c.commitOpsClient)
+	}
+	return client, nil
+}
@@ -0,0 +1,112 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// DependencyGraphOpsClientMock is a mock type for a CommitOpsClient that also implements DependencyGraphOpsClient
+type DependencyGraphOpsClientMock struct {
+	CommitOpsClientMock
+}
+
+// GetSBOM provides a mock function with given fields: ctx, owner, repo
+func (_m *DependencyGraphOpsClientMock) GetSBOM(ctx context.Context, owner, repo string) (json.RawMessage, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo)
+	body, _ := ret.Get(0).(json.RawMessage)
+	resp, _ := ret.Get(1).(*github.Response)
+	return body, resp, ret.Error(2)
+}
+
+const sbomSPDX = `{
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "spdxVersion": "SPDX-2.3",
+  "creationInfo": {"created": "2024-03-01T08:00:00Z", "creators": ["Tool: GitHub.com-Dependency-Graph"]},
+  "name": "com.github.testowner/repo1",
+  "dataLicense": "CC0-1.0",
+  "documentNamespace": "https://github.com/testowner/repo1/dependency_graph/sbom-1",
+  "packages": [
+    {"SPDXID": "SPDXRef-go-testify", "name": "go:github.com/stretchr/testify", "versionInfo": "1.8.4", "licenseConcluded": "MIT",
+     "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:golang/github.com/stretchr/testify@1.8.4"}]},
+    {"SPDXID": "SPDXRef-go-text", "name": "go:golang.org/x/text"}
+  ],
+  "relationships": [{"spdxElementId": "SPDXRef-DOCUMENT", "relatedSpdxElement": "SPDXRef-go-testify", "relationshipType": "DESCRIBES"}]
+}`
+
+func TestGitHub_GetSBOMDocuments(t *testing.T) {
+	created := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	client := new(DependencyGraphOpsClientMock)
+	client.On("GetSBOM", mock.Anything, "testowner", "repo1").Return(json.RawMessage(`{"sbom": `+sbomSPDX+`}`), nil, nil)
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	docs, err := gh.GetSBOMDocuments(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, docs, 1)
+
+	assert.Equal(t, "testowner/repo1/sbom", docs[0].ID)
+	assert.Equal(t, document.DocumentKindSBOM, docs[0].Kind)
+	assert.Equal(t, "com.github.testowner/repo1", docs[0].Title)
+	assert.Equal(t, created, docs[0].CreatedAt)
+	assert.Equal(t, map[string]string{
+		"spdx_version":  "SPDX-2.3",
+		"namespace":     "https://github.com/testowner/repo1/dependency_graph/sbom-1",
+		"package_count": "2",
+		"packages":      "go:github.com/stretchr/testify@1.8.4,go:golang.org/x/text",
+	}, docs[0].Metadata)
+	assert.Equal(t, "https://github.com/testowner/repo1/network/dependencies", docs[0].URL)
+
+	// The body keeps the SPDX fields github.SBOMInfo does not declare.
+	assert.Equal(t, sbomSPDX, docs[0].Body)
+
+	gh.Configuration.BaseURL = "https://github.example.com/api/v3/"
+	docs, err = gh.GetSBOMDocuments(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.example.com/testowner/repo1/network/dependencies", docs[0].URL)
+}
+
+func TestGitHub_GetSBOMDocuments_Empty(t *testing.T) {
+	client := new(DependencyGraphOpsClientMock)
+	client.On("GetSBOM", mock.Anything, "testowner", "repo1").Return(json.RawMessage(`{}`), nil, nil)
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	_, err := gh.GetSBOMDocuments(context.Background())
+	assert.EqualError(t, err, "no SBOM returned for testowner/repo1")
+}
+
+func TestGitHubCommitsOpsClient_GetSBOM(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/testowner/repo1/dependency-graph/sbom", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sbom": ` + sbomSPDX + `}`))
+	}))
+	defer srv.Close()
+
+	client := NewGitHubCommitsOpsClient(srv.Client())
+	baseURL, err := url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	client.GitHubClient.BaseURL = baseURL
+
+	body, _, err := client.GetSBOM(context.Background(), "testowner", "repo1")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"sbom": `+sbomSPDX+`}`, string(body))
+}
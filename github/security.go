@@ -0,0 +1,232 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// SecurityOpsClient is an interface to help test the GitHub security advisory and Dependabot operations.
+// GetSecurityAdvisoryDocuments lists the advisories and Dependabot alerts of every repository through it.
+type SecurityOpsClient interface {
+	ListRepositorySecurityAdvisories(ctx context.Context, owner, repo string, opts *github.ListRepositorySecurityAdvisoriesOptions) ([]*github.SecurityAdvisory, *github.Response, error)
+	ListDependabotAlerts(ctx context.Context, owner, repo string, opts *github.ListAlertsOptions) ([]*github.DependabotAlert, *github.Response, error)
+}
+
+// SecurityAdvisoryFilter narrows down the security advisories that are collected.
+//
+// State is the advisory state to collect: "triage", "draft", "published" or "closed". All states are
+// collected by default. IncludeDependabotAlerts also collects the Dependabot alerts of every repository,
+// optionally limited to the given AlertState: "open", "dismissed", "fixed" or "auto_dismissed".
+type SecurityAdvisoryFilter struct {
+	State                   string
+	IncludeDependabotAlerts bool
+	AlertState              string
+}
+
+// ListRepositorySecurityAdvisories lists the security advisories of a specific repository.
+func (gClient *GitHubCommitsOpsClient) ListRepositorySecurityAdvisories(ctx context.Context, owner, repo string, opts *github.ListRepositorySecurityAdvisoriesOptions) ([]*github.SecurityAdvisory, *github.Response, error) {
+	return gClient.GitHubClient.SecurityAdvisories.ListRepositorySecurityAdvisories(ctx, owner, repo, opts)
+}
+
+// ListDependabotAlerts lists the Dependabot alerts of a specific repository.
+func (gClient *GitHubCommitsOpsClient) ListDependabotAlerts(ctx context.Context, owner, repo string, opts *github.ListAlertsOptions) ([]*github.DependabotAlert, *github.Response, error) {
+	return gClient.GitHubClient.Dependabot.ListRepoAlerts(ctx, owner, repo, opts)
+}
+
+// GetSecurityAdvisoryDocuments retrieves the security advisories of all configured repositories as
+// documents, and their Dependabot alerts if the filter asks for them. Advisory identifiers, severity,
+// state and affected packages are kept in the document metadata.
+//
+// Listing advisories requires a token with the "repo" or "repository_advisories:read" scope, listing
+// Dependabot alerts additionally requires the "security_events" scope.
+//
+// Usage:
+//
+//	docs, err := c.GetSecurityAdvisoryDocuments(ctx, SecurityAdvisoryFilter{
+//	    State:                   "published",
+//	    IncludeDependabotAlerts: true,
+//	    AlertState:              "open",
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, doc := range docs {
+//	    fmt.Println(doc.Kind, doc.Metadata["ghsa_id"], doc.Metadata["severity"], doc.Title)
+//	}
+func (c *GitHub) GetSecurityAdvisoryDocuments(ctx context.Context, filter SecurityAdvisoryFilter) ([]document.Document, error) {
+	client, err := c.securityOpsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	repoDocs := make([][]document.Document, len(c.repositories()))
+	err = c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		advisories, err := c.listSecurityAdvisories(ctx, client, repo, filter.State)
+		if err != nil {
+			return err
+		}
+
+		var docs []document.Document
+		for _, advisory := range advisories {
+			docs = append(docs, c.securityAdvisoryDocument(repo, advisory))
+		}
+
+		if filter.IncludeDependabotAlerts {
+			alerts, err := c.listDependabotAlerts(ctx, client, repo, filter.AlertState)
+			if err != nil {
+				return err
+			}
+			for _, alert := range alerts {
+				docs = append(docs, c.dependabotAlertDocument(repo, alert))
+			}
+		}
+		repoDocs[i] = docs
+		return nil
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	return flattenDocuments(repoDocs), err
+}
+
+// listSecurityAdvisories lists all security advisories of a repository in the given state.
+// The endpoint is paginated with cursors rather than page numbers.
+func (c *GitHub) listSecurityAdvisories(ctx context.Context, client SecurityOpsClient, repo, state string) ([]*github.SecurityAdvisory, error) {
+	opts := &github.ListRepositorySecurityAdvisoriesOptions{
+		ListCursorOptions: github.ListCursorOptions{PerPage: 100},
+		State:             state,
+	}
+
+	var advisories []*github.SecurityAdvisory
+	for {
+		page, resp, err := client.ListRepositorySecurityAdvisories(ctx, c.ownerOf(repo), c.nameOf(repo), opts)
+		if err != nil {
+			return nil, c.classifyError(err)
+		}
+		advisories = append(advisories, page...)
+
+		if resp == nil || resp.After == "" {
+			return advisories, nil
+		}
+		opts.After = resp.After
+	}
+}
+
+// listDependabotAlerts lists all Dependabot alerts of a repository in the given state.
+func (c *GitHub) listDependabotAlerts(ctx context.Context, client SecurityOpsClient, repo, state string) ([]*github.DependabotAlert, error) {
+	opts := &github.ListAlertsOptions{
+		ListCursorOptions: github.ListCursorOptions{PerPage: 100},
+	}
+	if state != "" {
+		opts.State = github.String(state)
+	}
+
+	var alerts []*github.DependabotAlert
+	for {
+		page, resp, err := client.ListDependabotAlerts(ctx, c.ownerOf(repo), c.nameOf(repo), opts)
+		if err != nil {
+			return nil, c.classifyError(err)
+		}
+		alerts = append(alerts, page...)
+
+		if resp == nil || resp.After == "" {
+			return alerts, nil
+		}
+		opts.After = resp.After
+	}
+}
+
+// securityAdvisoryDocument converts a repository security advisory into a document.
+func (c *GitHub) securityAdvisoryDocument(repo string, advisory *github.SecurityAdvisory) document.Document {
+	metadata := map[string]string{
+		"ghsa_id":  advisory.GetGHSAID(),
+		"cve_id":   advisory.GetCVEID(),
+		"severity": advisory.GetSeverity(),
+		"state":    advisory.GetState(),
+		"cwe_ids":  strings.Join(advisory.CWEIDs, ","),
+		"packages": strings.Join(vulnerablePackages(advisory.Vulnerabilities), ","),
+	}
+	if score := advisory.GetCVSS().GetScore(); score != nil {
+		metadata["cvss_score"] = strconv.FormatFloat(*score, 'f', -1, 64)
+	}
+	if advisory.PublishedAt != nil {
+		metadata["published_at"] = advisory.GetPublishedAt().UTC().Format(time.RFC3339)
+	}
+
+	return document.Document{
+		ID:         fmt.Sprintf("%s/%s/security/advisories/%s", c.ownerOf(repo), c.nameOf(repo), advisory.GetGHSAID()),
+		Kind:       document.DocumentKindSecurityAdvisory,
+		Owner:      c.ownerOf(repo),
+		Repository: c.nameOf(repo),
+		Title:      advisory.GetSummary(),
+		Body:       advisory.GetDescription(),
+		URL:        advisory.GetHTMLURL(),
+		Author:     advisory.GetAuthor().GetLogin(),
+		CreatedAt:  advisory.GetCreatedAt().Time,
+		UpdatedAt:  advisory.GetUpdatedAt().Time,
+		Metadata:   metadata,
+	}
+}
+
+// dependabotAlertDocument converts a Dependabot alert into a document. The document is about the
+// manifest that declares the vulnerable dependency.
+func (c *GitHub) dependabotAlertDocument(repo string, alert *github.DependabotAlert) document.Document {
+	advisory := alert.GetSecurityAdvisory()
+	pkg := alert.GetDependency().GetPackage()
+
+	metadata := map[string]string{
+		"alert":            strconv.Itoa(alert.GetNumber()),
+		"ghsa_id":          advisory.GetGHSAID(),
+		"cve_id":           advisory.GetCVEID(),
+		"severity":         advisory.GetSeverity(),
+		"state":            alert.GetState(),
+		"ecosystem":        pkg.GetEcosystem(),
+		"package":          pkg.GetName(),
+		"vulnerable_range": alert.GetSecurityVulnerability().GetVulnerableVersionRange(),
+		"patched_version":  alert.GetSecurityVulnerability().GetFirstPatchedVersion().GetIdentifier(),
+	}
+	if alert.DismissedReason != nil {
+		metadata["dismissed_reason"] = alert.GetDismissedReason()
+	}
+
+	return document.Document{
+		ID:         fmt.Sprintf("%s/%s/security/dependabot/%d", c.ownerOf(repo), c.nameOf(repo), alert.GetNumber()),
+		Kind:       document.DocumentKindDependabotAlert,
+		Owner:      c.ownerOf(repo),
+		Repository: c.nameOf(repo),
+		Path:       c.normalizePath(alert.GetDependency().GetManifestPath()),
+		Title:      advisory.GetSummary(),
+		Body:       advisory.GetDescription(),
+		URL:        alert.GetHTMLURL(),
+		CreatedAt:  alert.GetCreatedAt().Time,
+		UpdatedAt:  alert.GetUpdatedAt().Time,
+		Metadata:   metadata,
+	}
+}
+
+// vulnerablePackages returns the affected packages of an advisory as "ecosystem:name".
+func vulnerablePackages(vulnerabilities []*github.AdvisoryVulnerability) []string {
+	packages := make([]string, 0, len(vulnerabilities))
+	for _, vulnerability := range vulnerabilities {
+		pkg := vulnerability.GetPackage()
+		packages = append(packages, pkg.GetEcosystem()+":"+pkg.GetName())
+	}
+	return packages
+}
+
+// securityOpsClient returns the commit ops client as a SecurityOpsClient.
+func (c *GitHub) securityOpsClient() (SecurityOpsClient, error) {
+	client, ok := c.commitOpsClient.(SecurityOpsClient)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not implement SecurityOpsClient", ErrUnsupportedClient, c.commitOpsClient)
+	}
+	return client, nil
+}
@@ -0,0 +1,162 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// SecurityOpsClientMock is a mock type for a CommitOpsClient that also implements SecurityOpsClient
+type SecurityOpsClientMock struct {
+	CommitOpsClientMock
+}
+
+// ListRepositorySecurityAdvisories provides a mock function with given fields: ctx, owner, repo, opts
+func (_m *SecurityOpsClientMock) ListRepositorySecurityAdvisories(ctx context.Context, owner, repo string, opts *github.ListRepositorySecurityAdvisoriesOptions) ([]*github.SecurityAdvisory, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, opts)
+	advisories, _ := ret.Get(0).([]*github.SecurityAdvisory)
+	resp, _ := ret.Get(1).(*github.Response)
+	return advisories, resp, ret.Error(2)
+}
+
+// ListDependabotAlerts provides a mock function with given fields: ctx, owner, repo, opts
+func (_m *SecurityOpsClientMock) ListDependabotAlerts(ctx context.Context, owner, repo string, opts *github.ListAlertsOptions) ([]*github.DependabotAlert, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, opts)
+	alerts, _ := ret.Get(0).([]*github.DependabotAlert)
+	resp, _ := ret.Get(1).(*github.Response)
+	return alerts, resp, ret.Error(2)
+}
+
+func TestGitHub_GetSecurityAdvisoryDocuments(t *testing.T) {
+	published := time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC)
+	score := 7.5
+
+	client := new(SecurityOpsClientMock)
+	client.On("ListRepositorySecurityAdvisories", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.ListRepositorySecurityAdvisoriesOptions) bool {
+		return opts.State == "published" && opts.After == ""
+	})).Return([]*github.SecurityAdvisory{
+		{
+			GHSAID:      github.String("GHSA-aaaa-bbbb-cccc"),
+			CVEID:       github.String("CVE-2024-0001"),
+			Summary:     github.String("Path traversal in loader"),
+			Description: github.String("The loader follows symlinks outside the root."),
+			Severity:    github.String("high"),
+			State:       github.String("published"),
+			HTMLURL:     github.String("https://github.com/testowner/repo1/security/advisories/GHSA-aaaa-bbbb-cccc"),
+			Author:      &github.User{Login: github.String("alice")},
+			CVSS:        &github.AdvisoryCVSS{Score: &score},
+			CWEIDs:      []string{"CWE-22"},
+			PublishedAt: &github.Timestamp{Time: published},
+			Vulnerabilities: []*github.AdvisoryVulnerability{
+				{Package: &github.VulnerabilityPackage{Ecosystem: github.String("go"), Name: github.String("github.com/testowner/repo1")}},
+			},
+		},
+	}, &github.Response{After: "cursor1"}, nil).Once()
+	client.On("ListRepositorySecurityAdvisories", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.ListRepositorySecurityAdvisoriesOptions) bool {
+		return opts.After == "cursor1"
+	})).Return([]*github.SecurityAdvisory{
+		{GHSAID: github.String("GHSA-dddd-eeee-ffff"), Summary: github.String("Second advisory")},
+	}, nil, nil).Once()
+	client.On("ListDependabotAlerts", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.ListAlertsOptions) bool {
+		return opts.GetState() == "open"
+	})).Return([]*github.DependabotAlert{
+		{
+			Number:  github.Int(3),
+			State:   github.String("open"),
+			HTMLURL: github.String("https://github.com/testowner/repo1/security/dependabot/3"),
+			Dependency: &github.Dependency{
+				Package:      &github.VulnerabilityPackage{Ecosystem: github.String("npm"), Name: github.String("lodash")},
+				ManifestPath: github.String("web/package-lock.json"),
+			},
+			SecurityAdvisory: &github.DependabotSecurityAdvisory{
+				GHSAID:   github.String("GHSA-gggg-hhhh-iiii"),
+				Summary:  github.String("Prototype pollution in lodash"),
+				Severity: github.String("critical"),
+			},
+			SecurityVulnerability: &github.AdvisoryVulnerability{
+				VulnerableVersionRange: github.String("< 4.17.21"),
+				FirstPatchedVersion:    &github.FirstPatchedVersion{Identifier: github.String("4.17.21")},
+			},
+		},
+	}, nil, nil)
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	docs, err := gh.GetSecurityAdvisoryDocuments(context.Background(), SecurityAdvisoryFilter{
+		State:                   "published",
+		IncludeDependabotAlerts: true,
+		AlertState:              "open",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 3)
+
+	assert.Equal(t, "testowner/repo1/security/advisories/GHSA-aaaa-bbbb-cccc", docs[0].ID)
+	assert.Equal(t, document.DocumentKindSecurityAdvisory, docs[0].Kind)
+	assert.Equal(t, "Path traversal in loader", docs[0].Title)
+	assert.Equal(t, "alice", docs[0].Author)
+	assert.Equal(t, map[string]string{
+		"ghsa_id":      "GHSA-aaaa-bbbb-cccc",
+		"cve_id":       "CVE-2024-0001",
+		"severity":     "high",
+		"state":        "published",
+		"cwe_ids":      "CWE-22",
+		"packages":     "go:github.com/testowner/repo1",
+		"cvss_score":   "7.5",
+		"published_at": "2024-02-01T12:00:00Z",
+	}, docs[0].Metadata)
+
+	assert.Equal(t, "testowner/repo1/security/advisories/GHSA-dddd-eeee-ffff", docs[1].ID)
+
+	assert.Equal(t, "testowner/repo1/security/dependabot/3", docs[2].ID)
+	assert.Equal(t, document.DocumentKindDependabotAlert, docs[2].Kind)
+	assert.Equal(t, "web/package-lock.json", docs[2].Path)
+	assert.Equal(t, "Prototype pollution in lodash", docs[2].Title)
+	assert.Equal(t, map[string]string{
+		"alert":            "3",
+		"ghsa_id":          "GHSA-gggg-hhhh-iiii",
+		"cve_id":           "",
+		"severity":         "critical",
+		"state":            "open",
+		"ecosystem":        "npm",
+		"package":          "lodash",
+		"vulnerable_range": "< 4.17.21",
+		"patched_version":  "4.17.21",
+	}, docs[2].Metadata)
+
+	client.AssertExpectations(t)
+}
+
+func TestGitHub_GetSecurityAdvisoryDocuments_WithoutDependabotAlerts(t *testing.T) {
+	client := new(SecurityOpsClientMock)
+	client.On("ListRepositorySecurityAdvisories", mock.Anything, "testowner", "repo1", mock.Anything).
+		Return([]*github.SecurityAdvisory{}, nil, nil)
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	docs, err := gh.GetSecurityAdvisoryDocuments(context.Background(), SecurityAdvisoryFilter{})
+	assert.NoError(t, err)
+	assert.Empty(t, docs)
+	client.AssertNotCalled(t, "ListDependabotAlerts", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGitHub_GetSecurityAdvisoryDocuments_UnsupportedClient(t *testing.T) {
+	gh := NewGitHubClient(new(CommitOpsClientMock), new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	_, err := gh.GetSecurityAdvisoryDocuments(context.Background(), SecurityAdvisoryFilter{})
+	assert.True(t, errors.Is(err, ErrUnsupportedClient))
+}
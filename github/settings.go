@@ -0,0 +1,249 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// RepositoryOpsClient is an interface to help test the GitHub repository settings operations.
+// GetRepositorySettings and GetRepositoryMetadata read a repository, its branches and their protection
+// through it.
+type RepositoryOpsClient interface {
+	GetRepository(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error)
+	ListBranches(ctx context.Context, owner, repo string, opts *github.BranchListOptions) ([]*github.Branch, *github.Response, error)
+	GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error)
+}
+
+// RepositorySettings holds the settings of a repository that matter for compliance reviews.
+type RepositorySettings struct {
+	DefaultBranch                string             `json:"default_branch"`
+	Visibility                   string             `json:"visibility"`
+	Archived                     bool               `json:"archived"`
+	AllowMergeCommit             bool               `json:"allow_merge_commit"`
+	AllowSquashMerge             bool               `json:"allow_squash_merge"`
+	AllowRebaseMerge             bool               `json:"allow_rebase_merge"`
+	AllowAutoMerge               bool               `json:"allow_auto_merge"`
+	DeleteBranchOnMerge          bool               `json:"delete_branch_on_merge"`
+	SecretScanning               string             `json:"secret_scanning,omitempty"`
+	SecretScanningPushProtection string             `json:"secret_scanning_push_protection,omitempty"`
+	DependabotSecurityUpdates    string             `json:"dependabot_security_updates,omitempty"`
+	BranchProtection             []BranchProtection `json:"branch_protection"`
+}
+
+// BranchProtection holds the protection rules of a protected branch.
+type BranchProtection struct {
+	Branch                        string   `json:"branch"`
+	RequiredChecks                []string `json:"required_checks"`
+	RequireUpToDate               bool     `json:"require_up_to_date"`
+	RequiredApprovals             int      `json:"required_approvals"`
+	DismissStaleReviews           bool     `json:"dismiss_stale_reviews"`
+	RequireCodeOwnerReviews       bool     `json:"require_code_owner_reviews"`
+	EnforceAdmins                 bool     `json:"enforce_admins"`
+	RequireLinearHistory          bool     `json:"require_linear_history"`
+	RequireConversationResolution bool     `json:"require_conversation_resolution"`
+	RequireSignatures             bool     `json:"require_signatures"`
+	AllowForcePushes              bool     `json:"allow_force_pushes"`
+	AllowDeletions                bool     `json:"allow_deletions"`
+}
+
+// GetRepository retrieves a specific repository.
+func (gClient *GitHubCommitsOpsClient) GetRepository(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+	return gClient.GitHubClient.Repositories.Get(ctx, owner, repo)
+}
+
+// ListBranches lists the branches of a specific repository.
+func (gClient *GitHubCommitsOpsClient) ListBranches(ctx context.Context, owner, repo string, opts *github.BranchListOptions) ([]*github.Branch, *github.Response, error) {
+	return gClient.GitHubClient.Repositories.ListBranches(ctx, owner, repo, opts)
+}
+
+// GetBranchProtection retrieves the protection rules of a specific branch.
+func (gClient *GitHubCommitsOpsClient) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error) {
+	return gClient.GitHubClient.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+}
+
+// GetRepositorySettings retrieves the settings and the branch protection rules of a configured repository.
+// Reading branch protection requires a token with admin access to the repository.
+func (c *GitHub) GetRepositorySettings(ctx context.Context, repo string) (RepositorySettings, error) {
+	client, err := c.repositoryOpsClient()
+	if err != nil {
+		return RepositorySettings{}, err
+	}
+
+	repository, _, err := client.GetRepository(ctx, c.ownerOf(repo), c.nameOf(repo))
+	if err != nil {
+		return RepositorySettings{}, c.classifyError(err)
+	}
+
+	analysis := repository.GetSecurityAndAnalysis()
+	settings := RepositorySettings{
+		DefaultBranch:                repository.GetDefaultBranch(),
+		Visibility:                   repository.GetVisibility(),
+		Archived:                     repository.GetArchived(),
+		AllowMergeCommit:             repository.GetAllowMergeCommit(),
+		AllowSquashMerge:             repository.GetAllowSquashMerge(),
+		AllowRebaseMerge:             repository.GetAllowRebaseMerge(),
+		AllowAutoMerge:               repository.GetAllowAutoMerge(),
+		DeleteBranchOnMerge:          repository.GetDeleteBranchOnMerge(),
+		SecretScanning:               analysis.GetSecretScanning().GetStatus(),
+		SecretScanningPushProtection: analysis.GetSecretScanningPushProtection().GetStatus(),
+		DependabotSecurityUpdates:    analysis.GetDependabotSecurityUpdates().GetStatus(),
+		BranchProtection:             []BranchProtection{},
+	}
+
+	branches, err := c.listProtectedBranches(ctx, client, repo)
+	if err != nil {
+		return RepositorySettings{}, err
+	}
+
+	for _, branch := range branches {
+		protection, _, err := client.GetBranchProtection(ctx, c.ownerOf(repo), c.nameOf(repo), branch.GetName())
+		if errors.Is(err, github.ErrBranchNotProtected) {
+			continue
+		}
+		if err != nil {
+			return RepositorySettings{}, c.classifyError(err)
+		}
+		settings.BranchProtection = append(settings.BranchProtection, branchProtection(branch.GetName(), protection))
+	}
+
+	return settings, nil
+}
+
+// GetRepositorySettingsDocuments exports the settings and branch protection rules of every configured
+// repository as documents. The document body holds the settings as JSON, the metadata the settings
+// reviewers filter on most.
+//
+// Usage:
+//
+//	docs, err := c.GetRepositorySettingsDocuments(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, doc := range docs {
+//	    fmt.Println(doc.Repository, doc.Metadata["protected_branches"])
+//	}
+func (c *GitHub) GetRepositorySettingsDocuments(ctx context.Context) ([]document.Document, error) {
+	repoDocs := make([][]document.Document, len(c.repositories()))
+	err := c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		settings, err := c.GetRepositorySettings(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		body, err := json.MarshalIndent(settings, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode settings of %s/%s: %w", c.ownerOf(repo), c.nameOf(repo), err)
+		}
+
+		protected := make([]string, 0, len(settings.BranchProtection))
+		defaultBranchProtected := false
+		for _, protection := range settings.BranchProtection {
+			protected = append(protected, protection.Branch)
+			if protection.Branch == settings.DefaultBranch {
+				defaultBranchProtected = true
+			}
+		}
+
+		repoDocs[i] = []document.Document{{
+			ID:         path.Join(c.ownerOf(repo), c.nameOf(repo), "settings"),
+			Kind:       document.DocumentKindRepositorySettings,
+			Owner:      c.ownerOf(repo),
+			Repository: c.nameOf(repo),
+			Title:      fmt.Sprintf("Settings of %s/%s", c.ownerOf(repo), c.nameOf(repo)),
+			Body:       string(body),
+			URL:        fmt.Sprintf("%s/%s/%s/settings", c.webBaseURL(), c.ownerOf(repo), c.nameOf(repo)),
+			Metadata: map[string]string{
+				"visibility":               settings.Visibility,
+				"archived":                 strconv.FormatBool(settings.Archived),
+				"default_branch":           settings.DefaultBranch,
+				"default_branch_protected": strconv.FormatBool(defaultBranchProtected),
+				"protected_branches":       strings.Join(protected, ","),
+			},
+		}}
+		return nil
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	return flattenDocuments(repoDocs), err
+}
+
+// listProtectedBranches lists all protected branches of a repository.
+func (c *GitHub) listProtectedBranches(ctx context.Context, client RepositoryOpsClient, repo string) ([]*github.Branch, error) {
+	opts := &github.BranchListOptions{
+		Protected:   github.Bool(true),
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var branches []*github.Branch
+	for {
+		page, resp, err := client.ListBranches(ctx, c.ownerOf(repo), c.nameOf(repo), opts)
+		if err != nil {
+			return nil, c.classifyError(err)
+		}
+		branches = append(branches, page...)
+
+		if resp == nil || resp.NextPage == 0 {
+			return branches, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// branchProtection converts the protection rules GitHub reports for a branch.
+func branchProtection(branch string, protection *github.Protection) BranchProtection {
+	result := BranchProtection{
+		Branch:            branch,
+		RequiredChecks:    []string{},
+		RequireSignatures: protection.GetRequiredSignatures().GetEnabled(),
+	}
+
+	if checks := protection.GetRequiredStatusChecks(); checks != nil {
+		result.RequireUpToDate = checks.Strict
+		for _, check := range checks.Checks {
+			result.RequiredChecks = appendUnique(result.RequiredChecks, check.Context)
+		}
+		result.RequiredChecks = appendUnique(result.RequiredChecks, checks.Contexts...)
+	}
+	if reviews := protection.GetRequiredPullRequestReviews(); reviews != nil {
+		result.RequiredApprovals = reviews.RequiredApprovingReviewCount
+		result.DismissStaleReviews = reviews.DismissStaleReviews
+		result.RequireCodeOwnerReviews = reviews.RequireCodeOwnerReviews
+	}
+	if admins := protection.GetEnforceAdmins(); admins != nil {
+		result.EnforceAdmins = admins.Enabled
+	}
+	if linear := protection.GetRequireLinearHistory(); linear != nil {
+		result.RequireLinearHistory = linear.Enabled
+	}
+	if resolution := protection.GetRequiredConversationResolution(); resolution != nil {
+		result.RequireConversationResolution = resolution.Enabled
+	}
+	if forcePushes := protection.GetAllowForcePushes(); forcePushes != nil {
+		result.AllowForcePushes = forcePushes.Enabled
+	}
+	if deletions := protection.GetAllowDeletions(); deletions != nil {
+		result.AllowDeletions = deletions.Enabled
+	}
+
+	return result
+}
+
+// repositoryOpsClient returns the commit ops client as a RepositoryOpsClient.
+func (c *GitHub) repositoryOpsClient() (RepositoryOpsClient, error) {
+	client, ok := c.commitOpsClient.(RepositoryOpsClient)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not implement RepositoryOpsClient", ErrUnsupportedClient, c.commitOpsClient)
+	}
+	return client, nil
+}
@@ -0,0 +1,121 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// RepositoryOpsClientMock is a mock type for a CommitOpsClient that also implements RepositoryOpsClient
+type RepositoryOpsClientMock struct {
+	CommitOpsClientMock
+}
+
+// GetRepository provides a mock function with given fields: ctx, owner, repo
+func (_m *RepositoryOpsClientMock) GetRepository(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo)
+	repository, _ := ret.Get(0).(*github.Repository)
+	resp, _ := ret.Get(1).(*github.Response)
+	return repository, resp, ret.Error(2)
+}
+
+// ListBranches provides a mock function with given fields: ctx, owner, repo, opts
+func (_m *RepositoryOpsClientMock) ListBranches(ctx context.Context, owner, repo string, opts *github.BranchListOptions) ([]*github.Branch, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, opts)
+	branches, _ := ret.Get(0).([]*github.Branch)
+	resp, _ := ret.Get(1).(*github.Response)
+	return branches, resp, ret.Error(2)
+}
+
+// GetBranchProtection provides a mock function with given fields: ctx, owner, repo, branch
+func (_m *RepositoryOpsClientMock) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, branch)
+	protection, _ := ret.Get(0).(*github.Protection)
+	resp, _ := ret.Get(1).(*github.Response)
+	return protection, resp, ret.Error(2)
+}
+
+func TestGitHub_GetRepositorySettings(t *testing.T) {
+	client := new(RepositoryOpsClientMock)
+	client.On("GetRepository", mock.Anything, "testowner", "repo1").Return(&github.Repository{
+		DefaultBranch:    github.String("main"),
+		Visibility:       github.String("private"),
+		AllowSquashMerge: github.Bool(true),
+		SecurityAndAnalysis: &github.SecurityAndAnalysis{
+			SecretScanning: &github.SecretScanning{Status: github.String("enabled")},
+		},
+	}, nil, nil)
+	client.On("ListBranches", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.BranchListOptions) bool {
+		return opts.Protected != nil && *opts.Protected
+	})).Return([]*github.Branch{{Name: github.String("main")}, {Name: github.String("release")}}, nil, nil)
+	client.On("GetBranchProtection", mock.Anything, "testowner", "repo1", "main").Return(&github.Protection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Strict:   true,
+			Checks:   []*github.RequiredStatusCheck{{Context: "build"}, {Context: "test"}},
+			Contexts: []string{"build"},
+		},
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{
+			RequiredApprovingReviewCount: 2,
+			RequireCodeOwnerReviews:      true,
+		},
+		EnforceAdmins:        &github.AdminEnforcement{Enabled: true},
+		RequireLinearHistory: &github.RequireLinearHistory{Enabled: true},
+		RequiredSignatures:   &github.SignaturesProtectedBranch{Enabled: github.Bool(true)},
+	}, nil, nil)
+	client.On("GetBranchProtection", mock.Anything, "testowner", "repo1", "release").Return(nil, nil, github.ErrBranchNotProtected)
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+	})
+
+	settings, err := gh.GetRepositorySettings(context.Background(), "repo1")
+	assert.NoError(t, err)
+	assert.Equal(t, RepositorySettings{
+		DefaultBranch:    "main",
+		Visibility:       "private",
+		AllowSquashMerge: true,
+		SecretScanning:   "enabled",
+		BranchProtection: []BranchProtection{
+			{
+				Branch:                  "main",
+				RequiredChecks:          []string{"build", "test"},
+				RequireUpToDate:         true,
+				RequiredApprovals:       2,
+				RequireCodeOwnerReviews: true,
+				EnforceAdmins:           true,
+				RequireLinearHistory:    true,
+				RequireSignatures:       true,
+			},
+		},
+	}, settings)
+
+	docs, err := gh.GetRepositorySettingsDocuments(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, docs, 1)
+	assert.Equal(t, "testowner/repo1/settings", docs[0].ID)
+	assert.Equal(t, document.DocumentKindRepositorySettings, docs[0].Kind)
+	assert.Equal(t, map[string]string{
+		"visibility":               "private",
+		"archived":                 "false",
+		"default_branch":           "main",
+		"default_branch_protected": "true",
+		"protected_branches":       "main",
+	}, docs[0].Metadata)
+
+	assert.Equal(t, "https://github.com/testowner/repo1/settings", docs[0].URL)
+
+	var decoded RepositorySettings
+	assert.NoError(t, json.Unmarshal([]byte(docs[0].Body), &decoded))
+	assert.Equal(t, settings, decoded)
+
+	gh.Configuration.BaseURL = "https://github.example.com/api/v3/"
+	docs, err = gh.GetRepositorySettingsDocuments(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.example.com/testowner/repo1/settings", docs[0].URL)
+}
@@ -0,0 +1,94 @@
+package github
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// GetSnapshot retrieves the blob SHA of every file passing the configured filter in the repositories
+// specified in the GitHub configuration.
+//
+// Usage:
+//
+//	snapshot, err := c.GetSnapshot(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	if snapshot.Hash() != lastHash {
+//	    // something in scope changed
+//	}
+func (c *GitHub) GetSnapshot(ctx context.Context) (document.Snapshot, error) {
+	ctx = c.prefetchFileEntries(ctx)
+	repoFiles := make([]map[string]string, len(c.repositories()))
+	err := c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		entries, err := c.getFilteredFileEntries(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		files := make(map[string]string, len(entries))
+		for _, entry := range entries {
+			files[entry.Path] = entry.Oid
+		}
+		repoFiles[i] = files
+		return nil
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	snapshot := make(document.Snapshot)
+	for i, repo := range c.repositories() {
+		if repoFiles[i] != nil {
+			snapshot[repo] = repoFiles[i]
+		}
+	}
+	return snapshot, err
+}
+
+// GetChangedFilePathsFromSnapshot computes the file paths changed since a previous snapshot by listing the
+// current trees with their blob SHAs, without touching the commits API. Besides the changed paths it returns
+// the current snapshot, which the caller stores for the next run. Repositories missing from previous, such as
+// on the first run, report all their files as added; repositories of previous that are no longer configured
+// are ignored. With ContinueOnError, repositories that fail keep their files of previous in the returned
+// snapshot along with the *PartialError.
+//
+// This takes one request per repository for most trees, far fewer than walking the commit history, and
+// yields the same result after force pushes and history rewrites.
+//
+// Usage:
+//
+//	paths, snapshot, err := c.GetChangedFilePathsFromSnapshot(ctx, previous)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	fmt.Println("Modified files:", paths.Modified)
+//	previous = snapshot
+func (c *GitHub) GetChangedFilePathsFromSnapshot(ctx context.Context, previous document.Snapshot) (document.Paths, document.Snapshot, error) {
+	current, err := c.GetSnapshot(ctx)
+	if !partialResult(err) {
+		return document.Paths{}, nil, err
+	}
+
+	// Repositories failing with ContinueOnError keep their previous files, so the next run detects their
+	// changes instead of reporting all their files as added.
+	var partial *PartialError
+	if errors.As(err, &partial) {
+		for repo := range partial.Failed {
+			if files, ok := previous[repo]; ok {
+				current[repo] = files
+			}
+		}
+	}
+
+	configured := make(document.Snapshot, len(current))
+	for repo := range current {
+		configured[repo] = previous[repo]
+	}
+
+	return c.normalizeChangedPaths(configured.Diff(current)), current, err
+}
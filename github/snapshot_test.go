@@ -0,0 +1,102 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGitHub_GetSnapshot(t *testing.T) {
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		query := args.Get(1).(*GHQueryForListFiles)
+		repo := args.Get(2).(map[string]interface{})["name"].(githubv4.String)
+		query.Repository.Object.Tree.Entries = []GHTreeEntry{
+			{Name: "a.md", Path: "docs/a.md", Type: "blob", Oid: string(repo) + "-a"},
+			{Name: "a.png", Path: "docs/a.png", Type: "blob", Oid: string(repo) + "-png"},
+		}
+	}).Return(nil)
+
+	client := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1", "repo2"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+	})
+
+	snapshot, err := client.GetSnapshot(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, document.Snapshot{
+		"repo1": {"docs/a.md": "repo1-a"},
+		"repo2": {"docs/a.md": "repo2-a"},
+	}, snapshot)
+}
+
+func TestGitHub_GetChangedFilePathsFromSnapshot(t *testing.T) {
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		query := args.Get(1).(*GHQueryForListFiles)
+		query.Repository.Object.Tree.Entries = []GHTreeEntry{
+			{Name: "a.md", Path: "docs/a.md", Type: "blob", Oid: "sha-a"},
+			{Name: "b.md", Path: "docs/b.md", Type: "blob", Oid: "sha-x"},
+		}
+	}).Return(nil)
+
+	client := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+	})
+
+	paths, snapshot, err := client.GetChangedFilePathsFromSnapshot(context.Background(), document.Snapshot{
+		"repo1":   {"docs/b.md": "sha-b", "docs/c.md": "sha-c"},
+		"retired": {"docs/a.md": "sha-a"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, document.Paths{
+		Added:    []string{"docs/a.md"},
+		Removed:  []string{"docs/c.md"},
+		Modified: []string{"docs/b.md"},
+	}, paths)
+	assert.Equal(t, document.Snapshot{"repo1": {"docs/a.md": "sha-a", "docs/b.md": "sha-x"}}, snapshot)
+}
+
+func TestGitHub_GetChangedFilePathsFromSnapshot_ContinueOnError(t *testing.T) {
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.MatchedBy(func(variables map[string]interface{}) bool {
+		return variables["name"] == githubv4.String("repo1")
+	})).Run(func(args mock.Arguments) {
+		query := args.Get(1).(*GHQueryForListFiles)
+		query.Repository.Object.Tree.Entries = []GHTreeEntry{{Name: "a.md", Path: "docs/a.md", Type: "blob", Oid: "sha-a"}}
+	}).Return(nil)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("unavailable"))
+
+	client := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:           "testowner",
+		Repositories:    []string{"repo1", "repo2"},
+		DefaultBranch:   "main",
+		Filter:          filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+		ContinueOnError: true,
+	})
+
+	// The failing repository keeps its previous files instead of reporting them as added next time.
+	paths, snapshot, err := client.GetChangedFilePathsFromSnapshot(context.Background(), document.Snapshot{
+		"repo2": {"docs/b.md": "sha-b"},
+	})
+	var partial *PartialError
+	assert.ErrorAs(t, err, &partial)
+	assert.Equal(t, []string{"repo1"}, partial.Succeeded)
+	assert.Equal(t, []string{"docs/a.md"}, paths.Added)
+	assert.Empty(t, paths.Removed)
+	assert.Equal(t, document.Snapshot{
+		"repo1": {"docs/a.md": "sha-a"},
+		"repo2": {"docs/b.md": "sha-b"},
+	}, snapshot)
+}
@@ -0,0 +1,23 @@
+package github
+
+import (
+	"context"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// ListFiles implements Source, see GetFiles.
+func (c *GitHub) ListFiles(ctx context.Context) ([]document.File, error) {
+	return c.GetFiles(ctx, FileOptions{})
+}
+
+// GetContent implements Source, see GetFileContent.
+func (c *GitHub) GetContent(ctx context.Context, repo, filePath string) (document.FileContent, error) {
+	return c.GetFileContent(ctx, repo, filePath)
+}
+
+// ChangesSince implements Source, see GetChangeSetSince.
+func (c *GitHub) ChangesSince(ctx context.Context, since time.Time) (document.ChangeSet, error) {
+	return c.GetChangeSetSince(ctx, since)
+}
@@ -0,0 +1,196 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// SubmoduleRef is a submodule of a repository: a tree entry of type "commit" pinning a commit of another
+// repository, which file listings skip as it holds no files of the repository itself.
+//
+// Repository is the repository holding the submodule, as configured or, for nested submodules, the full name
+// of the submodule repository holding it. Path is the path of the submodule in it, SHA the pinned commit and
+// URL the URL declared in .gitmodules. Target is the repository the submodule points at, read at SHA, if it
+// is hosted on the same GitHub; it is the zero RepositoryRef for submodules hosted elsewhere, so the
+// submodules worth crawling can be added to GitHubConfig.RepositoryRefs.
+type SubmoduleRef struct {
+	Repository string
+	Path       string
+	SHA        string
+	URL        string
+	Target     RepositoryRef
+}
+
+// gitmodule is a submodule declared in a .gitmodules file.
+type gitmodule struct {
+	path, url string
+}
+
+// parseGitmodules parses the submodules declared in the text of a .gitmodules file, in the order of their
+// sections. Sections without a path are skipped.
+func parseGitmodules(text string) []gitmodule {
+	var modules []gitmodule
+	var current *gitmodule
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			current = nil
+			if strings.HasPrefix(line, "[submodule ") {
+				modules = append(modules, gitmodule{})
+				current = &modules[len(modules)-1]
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || current == nil {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "path":
+			current.path = strings.Trim(strings.TrimSpace(value), "\"/")
+		case "url":
+			current.url = strings.Trim(strings.TrimSpace(value), "\"")
+		}
+	}
+
+	declared := modules[:0]
+	for _, module := range modules {
+		if module.path != "" {
+			declared = append(declared, module)
+		}
+	}
+	return declared
+}
+
+// GetSubmodules retrieves the submodules of a configured repository, declared in the .gitmodules file at the
+// root of its branch, with the commits its tree pins them to. Submodules declared in .gitmodules but missing
+// from the tree are skipped.
+//
+// If GitHubConfig.FollowSubmodules is set, the submodules of submodules hosted on the same GitHub are listed
+// too, read at their pinned commits, following each submodule repository and commit once.
+//
+// Usage:
+//
+//	submodules, err := c.GetSubmodules(ctx, "repo1")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, submodule := range submodules {
+//	    fmt.Println(submodule.Path, submodule.URL, submodule.SHA)
+//	}
+func (c *GitHub) GetSubmodules(ctx context.Context, repo string) ([]SubmoduleRef, error) {
+	branch, err := c.branch(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := RepositoryRef{Owner: c.ownerOf(repo), Name: c.nameOf(repo)}
+	submodules, err := c.listSubmodules(ctx, repo, parent, branch)
+	if err != nil {
+		return nil, err
+	}
+	if !c.Configuration.FollowSubmodules {
+		return submodules, nil
+	}
+
+	followed := make(map[string]bool)
+	for i := 0; i < len(submodules); i++ {
+		target := submodules[i].Target
+		key := target.String() + "@" + target.Ref
+		if target.Owner == "" || followed[key] {
+			continue
+		}
+		followed[key] = true
+
+		nested, err := c.listSubmodules(ctx, target.String(), target, target.Ref)
+		if err != nil {
+			return nil, err
+		}
+		submodules = append(submodules, nested...)
+	}
+	return submodules, nil
+}
+
+// listSubmodules lists the submodules of the repository at ref, reported as submodules of repo.
+func (c *GitHub) listSubmodules(ctx context.Context, repo string, parent RepositoryRef, ref string) ([]SubmoduleRef, error) {
+	text, err := c.getBlobText(ctx, parent.Owner, parent.Name, fmt.Sprintf("%s:.gitmodules", ref))
+	if err != nil {
+		return nil, err
+	}
+
+	var submodules []SubmoduleRef
+	trees := make(map[string][]GHTreeEntry)
+	for _, module := range parseGitmodules(text) {
+		dir, name := path.Split(module.path)
+		dir = strings.TrimSuffix(dir, "/")
+		entries, ok := trees[dir]
+		if !ok {
+			entries, err = c.listTreeEntries(ctx, parent.Owner, parent.Name, fmt.Sprintf("%s:%s", ref, dir))
+			if err != nil {
+				return nil, err
+			}
+			trees[dir] = entries
+		}
+
+		for _, entry := range entries {
+			if entry.Name != name || entry.Type != "commit" {
+				continue
+			}
+			submodule := SubmoduleRef{Repository: repo, Path: module.path, SHA: entry.Oid, URL: module.url}
+			if target, ok := c.submoduleTarget(parent, module.url); ok {
+				target.Ref = entry.Oid
+				submodule.Target = target
+			}
+			submodules = append(submodules, submodule)
+		}
+	}
+	return submodules, nil
+}
+
+// submoduleTarget resolves the URL of a submodule of the parent repository to a repository on the same
+// GitHub. URLs relative to the parent, such as "../lib.git", resolve against the parent's owner.
+func (c *GitHub) submoduleTarget(parent RepositoryRef, rawURL string) (RepositoryRef, bool) {
+	var host, repoPath string
+	switch {
+	case strings.HasPrefix(rawURL, "./") || strings.HasPrefix(rawURL, "../"):
+		host, repoPath = "", path.Join("/"+parent.String(), rawURL)
+	case strings.Contains(rawURL, "://"):
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return RepositoryRef{}, false
+		}
+		host, repoPath = u.Hostname(), u.Path
+	default:
+		// scp-like syntax: git@github.com:owner/name.git
+		userHost, p, ok := strings.Cut(rawURL, ":")
+		if !ok {
+			return RepositoryRef{}, false
+		}
+		_, host, _ = strings.Cut(userHost, "@")
+		if host == "" {
+			host = userHost
+		}
+		repoPath = p
+	}
+
+	if host != "" {
+		web, err := url.Parse(c.webBaseURL())
+		if err != nil || !strings.EqualFold(host, web.Hostname()) {
+			return RepositoryRef{}, false
+		}
+	}
+
+	owner, name, ok := strings.Cut(strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git"), "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return RepositoryRef{}, false
+	}
+	return RepositoryRef{Owner: owner, Name: name}, true
+}
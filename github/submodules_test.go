@@ -0,0 +1,110 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGitmodules(t *testing.T) {
+	modules := parseGitmodules(`# vendored libraries
+[submodule "vendor/lib"]
+	path = vendor/lib
+	url = https://github.com/octo/lib.git
+[core]
+	path = ignored
+[submodule "no-path"]
+	url = https://github.com/octo/other.git
+[submodule "theme"]
+	url = "../theme"
+	path = "theme/"
+`)
+	assert.Equal(t, []gitmodule{
+		{path: "vendor/lib", url: "https://github.com/octo/lib.git"},
+		{path: "theme", url: "../theme"},
+	}, modules)
+}
+
+func TestGitHub_submoduleTarget(t *testing.T) {
+	parent := RepositoryRef{Owner: "testowner", Name: "repo1"}
+	tests := []struct {
+		url    string
+		want   RepositoryRef
+		wantOK bool
+	}{
+		{url: "https://github.com/octo/lib.git", want: RepositoryRef{Owner: "octo", Name: "lib"}, wantOK: true},
+		{url: "https://github.com/octo/lib/", want: RepositoryRef{Owner: "octo", Name: "lib"}, wantOK: true},
+		{url: "ssh://git@github.com/octo/lib.git", want: RepositoryRef{Owner: "octo", Name: "lib"}, wantOK: true},
+		{url: "git@github.com:octo/lib.git", want: RepositoryRef{Owner: "octo", Name: "lib"}, wantOK: true},
+		{url: "../theme.git", want: RepositoryRef{Owner: "testowner", Name: "theme"}, wantOK: true},
+		{url: "https://gitlab.com/octo/lib.git"},
+		{url: "git@gitlab.com:octo/lib.git"},
+		{url: "https://github.com/octo"},
+		{url: "/srv/git/lib.git"},
+	}
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), new(GraphQLClientMock), GitHubConfig{})
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, ok := gh.submoduleTarget(parent, tt.url)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGitHub_GetSubmodules(t *testing.T) {
+	gitmodules := map[string]string{
+		"testowner/repo1": "[submodule \"lib\"]\n\tpath = vendor/lib\n\turl = https://github.com/octo/lib.git\n" +
+			"[submodule \"tools\"]\n\tpath = vendor/tools\n\turl = https://gitlab.com/octo/tools.git\n" +
+			"[submodule \"stale\"]\n\tpath = stale\n\turl = https://github.com/octo/stale.git\n",
+		"octo/lib": "[submodule \"theme\"]\n\tpath = theme\n\turl = ../theme.git\n",
+	}
+	trees := map[string][]GHTreeEntry{
+		"testowner/repo1 main:vendor": {
+			{Name: "lib", Type: "commit", Oid: "sha-lib"},
+			{Name: "tools", Type: "commit", Oid: "sha-tools"},
+		},
+		"testowner/repo1 main:": {{Name: "README.md", Type: "blob"}},
+		"octo/lib sha-lib:":     {{Name: "theme", Type: "commit", Oid: "sha-theme"}},
+		"octo/theme sha-theme:": {},
+	}
+
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		variables := args.Get(2).(map[string]interface{})
+		repo := string(variables["owner"].(githubv4.String)) + "/" + string(variables["name"].(githubv4.String))
+		expression := string(variables["expression"].(githubv4.String))
+		switch query := args.Get(1).(type) {
+		case *GHQueryForListFiles:
+			query.Repository.Object.Tree.Entries = trees[repo+" "+expression]
+		case *GHQueryForBlobText:
+			query.Repository.Object.Blob.Text = gitmodules[repo]
+		}
+	}).Return(nil)
+
+	config := GitHubConfig{Owner: "testowner", Repositories: []string{"repo1"}, DefaultBranch: "main"}
+	submodules, err := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, config).GetSubmodules(context.Background(), "repo1")
+	require.NoError(t, err)
+	assert.Equal(t, []SubmoduleRef{
+		{Repository: "repo1", Path: "vendor/lib", SHA: "sha-lib", URL: "https://github.com/octo/lib.git", Target: RepositoryRef{Owner: "octo", Name: "lib", Ref: "sha-lib"}},
+		{Repository: "repo1", Path: "vendor/tools", SHA: "sha-tools", URL: "https://gitlab.com/octo/tools.git"},
+	}, submodules)
+
+	// Nested submodules are listed at the commits their parents pin.
+	config.FollowSubmodules = true
+	submodules, err = NewGitHubClient(new(CommitOpsClientMock), graphQLClient, config).GetSubmodules(context.Background(), "repo1")
+	require.NoError(t, err)
+	require.Len(t, submodules, 3)
+	assert.Equal(t, SubmoduleRef{
+		Repository: "octo/lib",
+		Path:       "theme",
+		SHA:        "sha-theme",
+		URL:        "../theme.git",
+		Target:     RepositoryRef{Owner: "octo", Name: "theme", Ref: "sha-theme"},
+	}, submodules[2])
+}
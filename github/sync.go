@@ -0,0 +1,267 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/internal/fileutil"
+	"github.com/shaharia-lab/coco-gh/v2/internal/timeutil"
+)
+
+// SyncState is the progress of incremental syncs of a repository.
+//
+// Repository is the full name of the repository, "owner/name". CommitSHA is the newest commit processed by
+// the last sync, SyncedAt the time that sync started. A state with only SyncedAt set continues from that
+// time instead of a commit.
+type SyncState struct {
+	Repository string    `json:"repository"`
+	CommitSHA  string    `json:"commit_sha,omitempty"`
+	SyncedAt   time.Time `json:"synced_at"`
+}
+
+// SyncStore persists the SyncState of repositories between syncs. Load reports false if there is no state
+// for the repository yet. Implementations backed by databases or object storage, e.g. SQLite or S3, only
+// have to provide these two methods.
+type SyncStore interface {
+	Load(ctx context.Context, repository string) (SyncState, bool, error)
+	Save(ctx context.Context, state SyncState) error
+}
+
+// MemorySyncStore is a SyncStore keeping states in memory. It is safe for concurrent use.
+type MemorySyncStore struct {
+	mu     sync.Mutex
+	states map[string]SyncState
+}
+
+// NewMemorySyncStore creates an empty MemorySyncStore.
+func NewMemorySyncStore() *MemorySyncStore {
+	return &MemorySyncStore{states: make(map[string]SyncState)}
+}
+
+// Load returns the state saved for the repository.
+func (s *MemorySyncStore) Load(_ context.Context, repository string) (SyncState, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.states[repository]
+	return state, ok, nil
+}
+
+// Save stores the state of its repository.
+func (s *MemorySyncStore) Save(_ context.Context, state SyncState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[state.Repository] = state
+	return nil
+}
+
+// FileSyncStore is a SyncStore keeping the states of all repositories in a JSON file. A missing file holds
+// no states. The file is replaced atomically on every save. It is safe for concurrent use within a
+// process, but not across processes sharing the file.
+type FileSyncStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// NewFileSyncStore creates a FileSyncStore for the JSON file at path.
+func NewFileSyncStore(path string) *FileSyncStore {
+	return &FileSyncStore{Path: path}
+}
+
+// Load returns the state saved for the repository.
+func (s *FileSyncStore) Load(_ context.Context, repository string) (SyncState, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states, err := s.read()
+	if err != nil {
+		return SyncState{}, false, err
+	}
+	state, ok := states[repository]
+	return state, ok, nil
+}
+
+// Save stores the state of its repository, keeping the states of other repositories.
+func (s *FileSyncStore) Save(_ context.Context, state SyncState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states, err := s.read()
+	if err != nil {
+		return err
+	}
+	states[state.Repository] = state
+
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := fileutil.WriteAtomic(s.Path, append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+	return nil
+}
+
+// read reads all states from the file.
+func (s *FileSyncStore) read() (map[string]SyncState, error) {
+	states := make(map[string]SyncState)
+
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return states, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &states); err != nil {
+		return nil, fmt.Errorf("failed to read sync state %s: %w", s.Path, err)
+	}
+	return states, nil
+}
+
+// SyncChanges returns the files changed in the configured repositories since the previous sync recorded in
+// the store, and records the newest commit of every repository for the next sync. The first sync of a
+// repository returns all its files passing the configured filter as added.
+//
+// Changes are collected commit by commit down to the commit recorded by the previous sync. If that commit
+// is no longer part of the history, e.g. after a force push, the whole history is reported. The store is
+// only updated when all repositories were synced, so a failed sync is retried from the same point. With
+// GitHubConfig.ContinueOnError the states of the synced repositories are saved and the failed ones are
+// retried by the next sync.
+//
+// Usage:
+//
+//	store := NewFileSyncStore("sync-state.json")
+//
+//	changes, err := c.SyncChanges(ctx, store)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	fmt.Println("Added files:", changes.Added)
+//	fmt.Println("Removed files:", changes.Removed)
+func (c *GitHub) SyncChanges(ctx context.Context, store SyncStore) (document.Paths, error) {
+	started := timeutil.Now(c.Configuration.Clock)
+
+	repoPaths := make([]document.Paths, len(c.repositories()))
+	states := make([]SyncState, len(c.repositories()))
+	err := c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		paths, state, err := c.syncRepository(ctx, store, repo, started)
+		if err != nil {
+			return err
+		}
+		repoPaths[i], states[i] = paths, state
+		return nil
+	})
+	if !partialResult(err) {
+		return document.Paths{}, err
+	}
+
+	for _, state := range states {
+		// Skipped and failed repositories keep their previous state.
+		if state.Repository == "" {
+			continue
+		}
+		if err := store.Save(ctx, state); err != nil {
+			return document.Paths{}, fmt.Errorf("failed to save sync state of %s: %w", state.Repository, err)
+		}
+	}
+
+	var paths document.Paths
+	for _, changed := range repoPaths {
+		paths.Added = append(paths.Added, changed.Added...)
+		paths.Removed = append(paths.Removed, changed.Removed...)
+		paths.Modified = append(paths.Modified, changed.Modified...)
+		paths.Renamed = append(paths.Renamed, changed.Renamed...)
+		paths.Events = append(paths.Events, changed.Events...)
+	}
+
+	return c.normalizeChangedPaths(paths), err
+}
+
+// syncRepository collects the changes of a repository since its stored state and returns the state to save
+// afterwards.
+func (c *GitHub) syncRepository(ctx context.Context, store SyncStore, repo string, started time.Time) (document.Paths, SyncState, error) {
+	fullName := c.repositoryRef(repo).String()
+	previous, ok, err := store.Load(ctx, fullName)
+	if err != nil {
+		return document.Paths{}, SyncState{}, fmt.Errorf("failed to load sync state of %s: %w", fullName, err)
+	}
+
+	if !ok {
+		return c.initialSync(ctx, repo, started)
+	}
+
+	opt := &github.CommitsListOptions{
+		Path: c.historyPath(repo),
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+	if previous.CommitSHA == "" {
+		opt.Since = previous.SyncedAt
+	}
+
+	paths, head, err := c.getChangedFilePathsForRepo(ctx, repo, opt, previous.CommitSHA)
+	if err != nil {
+		return document.Paths{}, SyncState{}, err
+	}
+
+	state := SyncState{Repository: fullName, CommitSHA: previous.CommitSHA, SyncedAt: started}
+	if head != "" {
+		state.CommitSHA = head
+	}
+	return paths, state, nil
+}
+
+// initialSync returns all files of a repository passing the filter as added, together with the state
+// pointing at the newest commit.
+func (c *GitHub) initialSync(ctx context.Context, repo string, started time.Time) (document.Paths, SyncState, error) {
+	branch, err := c.branch(ctx, repo)
+	if err != nil {
+		return document.Paths{}, SyncState{}, err
+	}
+
+	opt := &github.CommitsListOptions{
+		SHA:  branch,
+		Path: c.historyPath(repo),
+		ListOptions: github.ListOptions{
+			PerPage: 1,
+		},
+	}
+
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return document.Paths{}, SyncState{}, err
+	}
+	commits, _, err := c.commitOpsClient.ListCommits(ctx, c.ownerOf(repo), c.nameOf(repo), opt)
+	release()
+	if err != nil {
+		return document.Paths{}, SyncState{}, c.classifyError(err)
+	}
+
+	entries, err := c.filterFileEntries(ctx, repo)
+	if err != nil {
+		return document.Paths{}, SyncState{}, err
+	}
+
+	var paths document.Paths
+	for _, entry := range entries {
+		paths.Added = append(paths.Added, entry.Path)
+	}
+
+	state := SyncState{Repository: c.repositoryRef(repo).String(), SyncedAt: started}
+	if len(commits) > 0 {
+		state.CommitSHA = commits[0].GetSHA()
+	}
+	return paths, state, nil
+}
@@ -0,0 +1,166 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newSyncTestGitHub(commitOpsClient *CommitOpsClientMock, now time.Time) *GitHub {
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		expression := string(args.Get(2).(map[string]interface{})["expression"].(githubv4.String))
+		if query, ok := args.Get(1).(*GHQueryForListFiles); ok && expression == "main:docs" {
+			query.Repository.Object.Tree.Entries = []GHTreeEntry{
+				{Name: "index.md", Path: "docs/index.md", Type: "blob"},
+				{Name: "setup.md", Path: "docs/setup.md", Type: "blob"},
+			}
+		}
+	}).Return(nil)
+
+	return NewGitHubClient(commitOpsClient, graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+		Clock:         fixedClock(now),
+	})
+}
+
+func TestGitHub_SyncChanges(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	store := NewMemorySyncStore()
+
+	// The first sync returns all files and records the newest commit.
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.CommitsListOptions) bool {
+		return opts.PerPage == 1 && opts.Path == "docs"
+	})).Return([]*github.RepositoryCommit{{SHA: github.String("c1")}}, &github.Response{}, nil)
+
+	paths, err := newSyncTestGitHub(commitOpsClient, now).SyncChanges(context.Background(), store)
+	require.NoError(t, err)
+	assert.Equal(t, document.Paths{Added: []string{"docs/index.md", "docs/setup.md"}}, paths)
+
+	state, ok, err := store.Load(context.Background(), "testowner/repo1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, SyncState{Repository: "testowner/repo1", CommitSHA: "c1", SyncedAt: now}, state)
+
+	// The next sync only returns the changes of the commits newer than the recorded one.
+	commitOpsClient = new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.CommitsListOptions) bool {
+		return opts.Since.IsZero()
+	})).Return([]*github.RepositoryCommit{{SHA: github.String("c3")}, {SHA: github.String("c2")}, {SHA: github.String("c1")}, {SHA: github.String("c0")}}, &github.Response{NextPage: 2}, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "c3", mock.Anything).
+		Return(&github.RepositoryCommit{Files: []*github.CommitFile{{Filename: github.String("docs/new.md"), Status: github.String("added")}}}, &github.Response{}, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "c2", mock.Anything).
+		Return(&github.RepositoryCommit{Files: []*github.CommitFile{{Filename: github.String("docs/index.md"), Status: github.String("modified")}}}, &github.Response{}, nil)
+
+	later := now.Add(time.Hour)
+	paths, err = newSyncTestGitHub(commitOpsClient, later).SyncChanges(context.Background(), store)
+	require.NoError(t, err)
+	assert.Equal(t, document.Paths{Added: []string{"docs/new.md"}, Modified: []string{"docs/index.md"}}, paths)
+	commitOpsClient.AssertExpectations(t)
+	commitOpsClient.AssertNotCalled(t, "GetCommit", mock.Anything, "testowner", "repo1", "c1", mock.Anything)
+
+	state, _, err = store.Load(context.Background(), "testowner/repo1")
+	require.NoError(t, err)
+	assert.Equal(t, SyncState{Repository: "testowner/repo1", CommitSHA: "c3", SyncedAt: later}, state)
+}
+
+func TestGitHub_SyncChanges_Filter(t *testing.T) {
+	store := NewMemorySyncStore()
+	require.NoError(t, store.Save(context.Background(), SyncState{Repository: "testowner/repo1", CommitSHA: "c1"}))
+
+	// Later syncs filter the changed files like the first sync filters the listed ones.
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.Anything).
+		Return([]*github.RepositoryCommit{{SHA: github.String("c2")}, {SHA: github.String("c1")}}, &github.Response{}, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "c2", mock.Anything).
+		Return(&github.RepositoryCommit{Files: []*github.CommitFile{
+			{Filename: github.String("docs/b.go"), Status: github.String("added")},
+			{Filename: github.String("docs/b.md"), Status: github.String("added")},
+			{Filename: github.String("docs/index.md"), PreviousFilename: github.String("docs/index.go"), Status: github.String("renamed")},
+		}}, &github.Response{}, nil)
+
+	paths, err := newSyncTestGitHub(commitOpsClient, time.Now()).SyncChanges(context.Background(), store)
+	require.NoError(t, err)
+	assert.Equal(t, document.Paths{Added: []string{"docs/b.md", "docs/index.md"}}, paths)
+}
+
+func TestGitHub_SyncChanges_Timestamp(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	synced := now.Add(-24 * time.Hour)
+	store := NewMemorySyncStore()
+	require.NoError(t, store.Save(context.Background(), SyncState{Repository: "testowner/repo1", SyncedAt: synced}))
+
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.MatchedBy(func(opts *github.CommitsListOptions) bool {
+		return opts.Since.Equal(synced)
+	})).Return([]*github.RepositoryCommit{}, &github.Response{}, nil)
+
+	paths, err := newSyncTestGitHub(commitOpsClient, now).SyncChanges(context.Background(), store)
+	require.NoError(t, err)
+	assert.Equal(t, document.Paths{}, paths)
+
+	// Without new commits, the state moves on in time only.
+	state, _, err := store.Load(context.Background(), "testowner/repo1")
+	require.NoError(t, err)
+	assert.Equal(t, SyncState{Repository: "testowner/repo1", SyncedAt: now}, state)
+}
+
+func TestGitHub_SyncChanges_Error(t *testing.T) {
+	store := NewMemorySyncStore()
+	previous := SyncState{Repository: "testowner/repo1", CommitSHA: "c1"}
+	require.NoError(t, store.Save(context.Background(), previous))
+
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.Anything).
+		Return(nil, nil, errors.New("boom"))
+
+	_, err := newSyncTestGitHub(commitOpsClient, time.Now()).SyncChanges(context.Background(), store)
+	assert.EqualError(t, err, "boom")
+
+	state, _, err := store.Load(context.Background(), "testowner/repo1")
+	require.NoError(t, err)
+	assert.Equal(t, previous, state)
+}
+
+func TestFileSyncStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	store := NewFileSyncStore(path)
+	ctx := context.Background()
+
+	_, ok, err := store.Load(ctx, "testowner/repo1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	synced := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Save(ctx, SyncState{Repository: "testowner/repo1", CommitSHA: "c1", SyncedAt: synced}))
+	require.NoError(t, store.Save(ctx, SyncState{Repository: "testowner/repo2", SyncedAt: synced}))
+
+	// A new store reads the states back from the file.
+	state, ok, err := NewFileSyncStore(path).Load(ctx, "testowner/repo1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, SyncState{Repository: "testowner/repo1", CommitSHA: "c1", SyncedAt: synced}, state)
+
+	_, ok, err = store.Load(ctx, "testowner/repo2")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, _, err = store.Load(ctx, "testowner/repo1")
+	assert.ErrorContains(t, err, "failed to read sync state")
+}
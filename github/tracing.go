@@ -0,0 +1,108 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// The names of the spans a Tracer receives.
+const (
+	SpanCrawlRepository = "cocogh.crawl_repository"
+	SpanGraphQLQuery    = "cocogh.graphql_query"
+	SpanFetchCommit     = "cocogh.fetch_commit"
+)
+
+// The keys of the span attributes.
+const (
+	AttributeOwner      = "github.owner"
+	AttributeRepository = "github.repository"
+	AttributeRef        = "github.ref"
+	AttributePath       = "github.path"
+	AttributeCommit     = "github.commit"
+	AttributeQuery      = "github.graphql.query"
+)
+
+// Tracer starts spans for the work of a client, so a collection can be observed inside the traces of a larger
+// pipeline. An adapter to OpenTelemetry starts a span with its trace.Tracer, converts the attributes and ends
+// the span with the recorded error:
+//
+//	type otelTracer struct{ tracer trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string, attributes ...github.SpanAttribute) (context.Context, github.Span) {
+//	    kvs := make([]attribute.KeyValue, 0, len(attributes))
+//	    for _, a := range attributes {
+//	        kvs = append(kvs, attribute.String(a.Key, a.Value))
+//	    }
+//	    ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(kvs...))
+//	    return ctx, otelSpan{span}
+//	}
+//
+// A span is started per repository crawl (SpanCrawlRepository), per GraphQL query (SpanGraphQLQuery) and per
+// commit whose changed files are fetched (SpanFetchCommit). Spans of queries and commits are children of the
+// span of the repository crawl they belong to.
+type Tracer interface {
+	Start(ctx context.Context, name string, attributes ...SpanAttribute) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer. End is called once the work is done, with the error if it failed.
+type Span interface {
+	End(err error)
+}
+
+// SpanAttribute is a key-value pair describing a span, e.g. AttributeRepository with the repository name.
+type SpanAttribute struct {
+	Key   string
+	Value string
+}
+
+// startSpan starts a span with the configured Tracer and returns the function ending it. Without a Tracer the
+// context is returned as is.
+func (c *GitHub) startSpan(ctx context.Context, name string, attributes ...SpanAttribute) (context.Context, func(err error)) {
+	if c.Configuration.Tracer == nil {
+		return ctx, func(error) {}
+	}
+	ctx, span := c.Configuration.Tracer.Start(ctx, name, attributes...)
+	return ctx, span.End
+}
+
+// repositoryAttributes returns the span attributes identifying a configured repository.
+func (c *GitHub) repositoryAttributes(repo string, attributes ...SpanAttribute) []SpanAttribute {
+	return append([]SpanAttribute{
+		{Key: AttributeOwner, Value: c.ownerOf(repo)},
+		{Key: AttributeRepository, Value: c.nameOf(repo)},
+	}, attributes...)
+}
+
+// query runs a GraphQL query within a span carrying the repository and tree expression of its variables.
+func (c *GitHub) query(ctx context.Context, q interface{}, variables map[string]interface{}) error {
+	ctx, end := c.startSpan(ctx, SpanGraphQLQuery, queryAttributes(q, variables)...)
+	countRequest(ctx, graphQLAPI)
+	err := c.classifyError(c.graphQLClient.Query(ctx, q, variables))
+	end(err)
+	return err
+}
+
+// queryAttributes returns the span attributes of a GraphQL query: the query type, and the owner, repository,
+// ref and path found in its variables.
+func queryAttributes(q interface{}, variables map[string]interface{}) []SpanAttribute {
+	name := fmt.Sprintf("%T", q)
+	name = name[strings.LastIndex(name, ".")+1:]
+	attributes := []SpanAttribute{{Key: AttributeQuery, Value: name}}
+
+	if owner, ok := variables["owner"]; ok {
+		attributes = append(attributes, SpanAttribute{Key: AttributeOwner, Value: fmt.Sprint(owner)})
+	}
+	if repo, ok := variables["name"]; ok {
+		attributes = append(attributes, SpanAttribute{Key: AttributeRepository, Value: fmt.Sprint(repo)})
+	}
+	if expression, ok := variables["expression"]; ok {
+		// Expressions have the form "<ref>:<path>".
+		ref, path, _ := strings.Cut(fmt.Sprint(expression), ":")
+		attributes = append(attributes,
+			SpanAttribute{Key: AttributeRef, Value: ref},
+			SpanAttribute{Key: AttributePath, Value: path},
+		)
+	}
+	return attributes
+}
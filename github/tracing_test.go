@@ -0,0 +1,100 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// spanParent is the context key of the name of the current span.
+type spanParent struct{}
+
+// recordingTracer records every span as "parent > name attributes" and its error once ended.
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []string
+}
+
+type recordingSpan struct {
+	tracer *recordingTracer
+	span   string
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, attributes ...SpanAttribute) (context.Context, Span) {
+	parent, _ := ctx.Value(spanParent{}).(string)
+
+	values := make([]string, 0, len(attributes))
+	for _, attribute := range attributes {
+		values = append(values, attribute.Key+"="+attribute.Value)
+	}
+	span := fmt.Sprintf("%s > %s %s", parent, name, strings.Join(values, " "))
+	return context.WithValue(ctx, spanParent{}, name), &recordingSpan{tracer: t, span: span}
+}
+
+func (s *recordingSpan) End(err error) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.tracer.spans = append(s.tracer.spans, fmt.Sprintf("%s error=%v", s.span, err))
+}
+
+func TestGitHubClient_Tracer_Files(t *testing.T) {
+	var queried []string
+	tracer := &recordingTracer{}
+	client := NewGitHubClient(new(CommitOpsClientMock), newWalkTestGraphQLClient(&queried), GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "/docs", FileTypes: []string{".md"}},
+		Tracer:        tracer,
+	})
+
+	paths, err := client.GetFilePathsFromRepositories()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/index.md"}, paths)
+	assert.Equal(t, []string{
+		"cocogh.crawl_repository > cocogh.graphql_query github.graphql.query=GHQueryForListFiles github.owner=testowner github.repository=repo1 github.ref=main github.path=/docs error=<nil>",
+		" > cocogh.crawl_repository github.owner=testowner github.repository=repo1 error=<nil>",
+	}, tracer.spans)
+}
+
+func TestGitHubClient_Tracer_Commits(t *testing.T) {
+	failed := errors.New("boom")
+	commitOpsClient := new(CommitOpsClientMock)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.Anything).
+		Return([]*github.RepositoryCommit{{SHA: github.String("abc")}}, &github.Response{}, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "abc", mock.Anything).Return(nil, nil, failed)
+
+	tracer := &recordingTracer{}
+	client := NewGitHubClient(commitOpsClient, new(GraphQLClientMock), GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Tracer:        tracer,
+	})
+
+	_, err := client.GetChangedFilePathsSince(time.Time{})
+	assert.ErrorIs(t, err, failed)
+	assert.Equal(t, []string{
+		"cocogh.crawl_repository > cocogh.fetch_commit github.owner=testowner github.repository=repo1 github.commit=abc error=boom",
+		" > cocogh.crawl_repository github.owner=testowner github.repository=repo1 error=boom",
+	}, tracer.spans)
+}
+
+func TestGitHubClient_Tracer_Disabled(t *testing.T) {
+	client := NewGitHubClient(new(CommitOpsClientMock), new(GraphQLClientMock), GitHubConfig{})
+
+	ctx := context.WithValue(context.Background(), spanParent{}, "parent")
+	spanCtx, end := client.startSpan(ctx, SpanCrawlRepository)
+	end(nil)
+	assert.Equal(t, ctx, spanCtx)
+}
@@ -0,0 +1,128 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+)
+
+// TreeNode represents a directory or a file in the filtered tree of a GitHub repository.
+//
+// Directory nodes have the type "tree" and hold their entries in Children, file nodes have
+// the type "blob" and no children. Mode holds the git file mode of the entry, which tells
+// regular files, executables, symlinks and submodules apart, and Linguist holds the overrides
+// declared for the entry in the repository's .gitattributes. Directories that do not contain
+// any file matching the configured filter are pruned from the tree.
+type TreeNode struct {
+	Name     string
+	Path     string
+	Type     string
+	Mode     document.FileMode
+	Linguist filter.LinguistAttributes
+	Children []*TreeNode
+}
+
+// IsDir reports whether the node is a directory.
+func (n *TreeNode) IsDir() bool {
+	return n.Type == "tree"
+}
+
+// GetFileTreeFromRepositories retrieves the filtered files of every repository specified in the GitHub
+// configuration as a nested structure, which is handy for rendering a navigation tree.
+//
+// One root node is returned per crawled repository, in the order of the configuration. The root node is named
+// after the repository and its path is the configured filter path. Files are filtered by the configured
+// filter the same way GetFilePathsFromRepositories does.
+//
+// Usage:
+//
+//	roots, err := c.GetFileTreeFromRepositories(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, child := range roots[0].Children {
+//	    fmt.Println(child.Path, child.IsDir())
+//	}
+func (c *GitHub) GetFileTreeFromRepositories(ctx context.Context) ([]*TreeNode, error) {
+	repoRoots := make([]*TreeNode, len(c.repositories()))
+	err := c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		root := &TreeNode{
+			Name: repo,
+			Path: c.normalizePath(c.Configuration.Filter.FilePath),
+			Type: "tree",
+		}
+
+		attributes, err := c.getGitAttributes(ctx, repo)
+		if err != nil {
+			return err
+		}
+		ignore, err := c.getIgnoreFile(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		branch, err := c.branch(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		expression := fmt.Sprintf("%s:%s", branch, c.Configuration.Filter.FilePath)
+		if err := c.buildTree(ctx, c.ownerOf(repo), c.nameOf(repo), expression, fileRules{attributes: attributes, ignore: ignore}, root); err != nil {
+			return err
+		}
+		repoRoots[i] = root
+		return nil
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	var roots []*TreeNode
+	for _, root := range repoRoots {
+		if root != nil {
+			roots = append(roots, root)
+		}
+	}
+	return roots, err
+}
+
+// buildTree recursively populates the children of node from the git tree identified by expression.
+func (c *GitHub) buildTree(ctx context.Context, owner, name, expression string, rules fileRules, node *TreeNode) error {
+	entries, err := c.listTreeEntries(ctx, owner, name, expression)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		child := &TreeNode{
+			Name: c.normalizePath(entry.Name),
+			Path: c.normalizePath(entry.Path),
+			Type: entry.Type,
+			Mode: document.FileMode(entry.Mode),
+		}
+		child.Linguist = rules.attributes.Linguist(entry.Path)
+
+		switch entry.Type {
+		case "blob":
+			if !c.includeEntry(entry, rules) {
+				continue
+			}
+		case "tree":
+			if err := c.buildTree(ctx, owner, name, expression+"/"+entry.Name, rules, child); err != nil {
+				return err
+			}
+			if len(child.Children) == 0 {
+				continue
+			}
+		default:
+			continue
+		}
+
+		node.Children = append(node.Children, child)
+	}
+
+	return nil
+}
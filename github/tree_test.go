@@ -0,0 +1,75 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGitHub_GetFileTreeFromRepositories(t *testing.T) {
+	trees := map[string][]GHTreeEntry{
+		"main:docs": {
+			{Name: "guides", Path: "docs/guides", Type: "tree"},
+			{Name: "images", Path: "docs/images", Type: "tree"},
+			{Name: "index.md", Path: "docs/index.md", Type: "blob", Mode: 0100644},
+		},
+		"main:docs/guides": {
+			{Name: "setup.md", Path: "docs/guides/setup.md", Type: "blob", Mode: 0100755},
+			{Name: "setup.png", Path: "docs/guides/setup.png", Type: "blob"},
+		},
+		"main:docs/images": {
+			{Name: "logo.png", Path: "docs/images/logo.png", Type: "blob"},
+		},
+	}
+
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		expression := args.Get(2).(map[string]interface{})["expression"].(githubv4.String)
+		switch query := args.Get(1).(type) {
+		case *GHQueryForListFiles:
+			query.Repository.Object.Tree.Entries = trees[string(expression)]
+		case *GHQueryForBlobText:
+			if expression == "main:.gitattributes" {
+				query.Repository.Object.Blob.Text = "docs/guides/** linguist-documentation\n"
+			}
+		}
+	}).Return(nil)
+
+	client := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter: filter.GitHubFilter{
+			FilePath:  "docs",
+			FileTypes: []string{".md"},
+		},
+	})
+
+	roots, err := client.GetFileTreeFromRepositories(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []*TreeNode{
+		{
+			Name: "repo1",
+			Path: "docs",
+			Type: "tree",
+			Children: []*TreeNode{
+				{
+					Name: "guides",
+					Path: "docs/guides",
+					Type: "tree",
+					Children: []*TreeNode{
+						{Name: "setup.md", Path: "docs/guides/setup.md", Type: "blob", Mode: document.FileModeExecutable, Linguist: filter.LinguistAttributes{Documentation: true}},
+					},
+				},
+				{Name: "index.md", Path: "docs/index.md", Type: "blob", Mode: document.FileModeRegular},
+			},
+		},
+	}, roots)
+	assert.True(t, roots[0].IsDir())
+	assert.False(t, roots[0].Children[1].IsDir())
+}
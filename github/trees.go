@@ -0,0 +1,101 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// TreeOpsClient is an interface to help test the GitHub Git tree operations.
+// File listings use the recursive tree of a commit if the CommitOpsClient implements it, and walk the tree
+// directory by directory through GraphQL otherwise.
+type TreeOpsClient interface {
+	GetTree(ctx context.Context, owner, repo, sha string, recursive bool) (*github.Tree, *github.Response, error)
+}
+
+// GetTree retrieves the Git tree of a commit, branch or tree SHA of a specific repository, with all sub-trees
+// if recursive is set.
+func (gClient *GitHubCommitsOpsClient) GetTree(ctx context.Context, owner, repo, sha string, recursive bool) (*github.Tree, *github.Response, error) {
+	return gClient.GitHubClient.Git.GetTree(ctx, owner, repo, sha, recursive)
+}
+
+// listFileEntries fetches the blob entries below the tree identified by the "<ref>:<path>" expression.
+//
+// If the commit ops client implements TreeOpsClient, the whole tree of the ref is fetched with a single
+// recursive Git Trees API request. GitHub truncates the recursive tree of very large repositories; in that
+// case, for clients without TreeOpsClient and for filters skipping binary files, which only GraphQL reports,
+// the tree is walked one directory at a time through GraphQL with getFileEntriesForRepo. A truncated tree is
+// reported as a warning to the configured Logger, as walking it takes a query per directory.
+func (c *GitHub) listFileEntries(ctx context.Context, owner, name, expression string) ([]GHTreeEntry, error) {
+	if c.Configuration.Fetcher != nil {
+		return c.fetchFileEntries(ctx, owner, name, expression)
+	}
+
+	client, ok := c.commitOpsClient.(TreeOpsClient)
+	if !ok || c.Configuration.Filter.SkipBinary {
+		return c.getFileEntriesForRepo(ctx, owner, name, expression)
+	}
+
+	ref, dir, _ := strings.Cut(expression, ":")
+
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	countRequest(ctx, restAPI)
+	tree, _, err := client.GetTree(ctx, owner, name, ref, true)
+	release()
+	if err == nil && tree.GetTruncated() {
+		c.warn("tree truncated, listing it directory by directory", "repository", owner+"/"+name, "ref", ref)
+		return c.getFileEntriesForRepo(ctx, owner, name, expression)
+	}
+	if isEmptyRepository(err) {
+		return c.getFileEntriesForRepo(ctx, owner, name, expression)
+	}
+	if err != nil {
+		return nil, c.classifyError(err)
+	}
+
+	return recursiveTreeFiles(tree, dir), nil
+}
+
+// recursiveTreeFiles converts the blobs below dir of a recursive tree into tree entries.
+func recursiveTreeFiles(tree *github.Tree, dir string) []GHTreeEntry {
+	prefix := strings.Trim(dir, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	var files []GHTreeEntry
+	for _, entry := range tree.Entries {
+		if entry.GetType() != "blob" || !strings.HasPrefix(entry.GetPath(), prefix) {
+			continue
+		}
+
+		mode, _ := strconv.ParseInt(entry.GetMode(), 8, 64)
+		files = append(files, GHTreeEntry{
+			Name: path.Base(entry.GetPath()),
+			Path: entry.GetPath(),
+			Type: entry.GetType(),
+			Mode: int(mode),
+			Oid:  entry.GetSHA(),
+			Size: entry.GetSize(),
+		})
+	}
+	return files
+}
+
+// isEmptyRepository checks if the error is the response of the Git Trees API for a repository or ref
+// without commits, which the GraphQL walk reports as an empty tree.
+func isEmptyRepository(err error) bool {
+	var errResp *github.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil {
+		return false
+	}
+	return errResp.Response.StatusCode == http.StatusNotFound || errResp.Response.StatusCode == http.StatusConflict
+}
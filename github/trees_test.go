@@ -0,0 +1,147 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TreeOpsClientMock is a mock type for a CommitOpsClient that also implements TreeOpsClient
+type TreeOpsClientMock struct {
+	CommitOpsClientMock
+}
+
+// GetTree provides a mock function with given fields: ctx, owner, repo, sha, recursive
+func (_m *TreeOpsClientMock) GetTree(ctx context.Context, owner, repo, sha string, recursive bool) (*github.Tree, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, sha, recursive)
+	tree, _ := ret.Get(0).(*github.Tree)
+	resp, _ := ret.Get(1).(*github.Response)
+	return tree, resp, ret.Error(2)
+}
+
+func newRecursiveTree(truncated bool) *github.Tree {
+	return &github.Tree{
+		Truncated: github.Bool(truncated),
+		Entries: []*github.TreeEntry{
+			{Path: github.String("README.md"), Type: github.String("blob"), Mode: github.String("100644"), SHA: github.String("a"), Size: github.Int(8)},
+			{Path: github.String("docs"), Type: github.String("tree"), Mode: github.String("040000"), SHA: github.String("b")},
+			{Path: github.String("docs/index.md"), Type: github.String("blob"), Mode: github.String("100644"), SHA: github.String("c"), Size: github.Int(7)},
+			{Path: github.String("docs/run.sh"), Type: github.String("blob"), Mode: github.String("100755"), SHA: github.String("d"), Size: github.Int(9)},
+			{Path: github.String("docs/lib"), Type: github.String("commit"), Mode: github.String("160000"), SHA: github.String("e")},
+		},
+	}
+}
+
+func TestGitHubClient_ListFileEntries_RecursiveTree(t *testing.T) {
+	client := new(TreeOpsClientMock)
+	client.On("GetTree", mock.Anything, "testowner", "repo1", "main", true).Return(newRecursiveTree(false), &github.Response{}, nil)
+	graphQLClient := new(GraphQLClientMock)
+
+	gh := NewGitHubClient(client, graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs"},
+	})
+
+	entries, err := gh.filterFileEntries(context.Background(), "repo1")
+	require.NoError(t, err)
+	assert.Equal(t, []GHTreeEntry{
+		{Name: "index.md", Path: "docs/index.md", Type: "blob", Mode: 0o100644, Oid: "c", Size: 7},
+		{Name: "run.sh", Path: "docs/run.sh", Type: "blob", Mode: 0o100755, Oid: "d", Size: 9},
+	}, entries)
+	graphQLClient.AssertNotCalled(t, "Query", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGitHubClient_ListFileEntries_Fallback(t *testing.T) {
+	notFound := &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}
+	tests := []struct {
+		name     string
+		tree     *github.Tree
+		err      error
+		warnings []string
+	}{
+		{name: "truncated tree", tree: newRecursiveTree(true), warnings: []string{"tree truncated, listing it directory by directory repository=testowner/repo1"}},
+		{name: "empty repository", err: notFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(TreeOpsClientMock)
+			client.On("GetTree", mock.Anything, "testowner", "repo1", "main", true).Return(tt.tree, &github.Response{}, tt.err)
+
+			graphQLClient := new(GraphQLClientMock)
+			graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForListFiles"), mock.Anything).Run(func(args mock.Arguments) {
+				args.Get(1).(*GHQueryForListFiles).Repository.Object.Tree.Entries = []GHTreeEntry{
+					{Name: "README.md", Path: "README.md", Type: "blob"},
+				}
+			}).Return(nil)
+
+			logger := &recordingWarnLogger{}
+			gh := NewGitHubClient(client, graphQLClient, GitHubConfig{Owner: "testowner", Repositories: []string{"repo1"}, DefaultBranch: "main", Logger: logger})
+
+			entries, err := gh.listFileEntries(context.Background(), "testowner", "repo1", "main:")
+			require.NoError(t, err)
+			assert.Equal(t, []GHTreeEntry{{Name: "README.md", Path: "README.md", Type: "blob"}}, entries)
+			graphQLClient.AssertNumberOfCalls(t, "Query", 1)
+			assert.Equal(t, tt.warnings, logger.warnings)
+		})
+	}
+}
+
+func TestGitHubClient_ListFileEntries_Error(t *testing.T) {
+	client := new(TreeOpsClientMock)
+	client.On("GetTree", mock.Anything, "testowner", "repo1", "main", true).Return(nil, nil, errors.New("boom"))
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{Owner: "testowner", Repositories: []string{"repo1"}, DefaultBranch: "main"})
+
+	_, err := gh.listFileEntries(context.Background(), "testowner", "repo1", "main:")
+	assert.EqualError(t, err, "boom")
+}
+
+func TestGitHubClient_ListFileEntries_SizeAndBinary(t *testing.T) {
+	client := new(TreeOpsClientMock)
+	client.On("GetTree", mock.Anything, "testowner", "repo1", "main", true).Return(newRecursiveTree(false), &github.Response{}, nil)
+
+	gh := NewGitHubClient(client, new(GraphQLClientMock), GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs", MaxFileSize: 8},
+	})
+
+	entries, err := gh.filterFileEntries(context.Background(), "repo1")
+	require.NoError(t, err)
+	assert.Equal(t, []GHTreeEntry{{Name: "index.md", Path: "docs/index.md", Type: "blob", Mode: 0o100644, Oid: "c", Size: 7}}, entries)
+
+	// Only GraphQL reports binary files, so the recursive tree is not fetched.
+	logo := GHTreeEntry{Name: "logo.png", Path: "docs/logo.png", Type: "blob", Size: 5}
+	logo.Object.Blob.IsBinary = true
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForListFiles"), mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*GHQueryForListFiles).Repository.Object.Tree.Entries = []GHTreeEntry{
+			{Name: "index.md", Path: "docs/index.md", Type: "blob", Size: 7},
+			logo,
+		}
+	}).Return(nil)
+
+	client = new(TreeOpsClientMock)
+	gh = NewGitHubClient(client, graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+		Filter:        filter.GitHubFilter{FilePath: "docs", SkipBinary: true},
+	})
+
+	entries, err = gh.filterFileEntries(context.Background(), "repo1")
+	require.NoError(t, err)
+	assert.Equal(t, []GHTreeEntry{{Name: "index.md", Path: "docs/index.md", Type: "blob", Size: 7}}, entries)
+	client.AssertNotCalled(t, "GetTree", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
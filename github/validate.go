@@ -0,0 +1,251 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+)
+
+// ErrInvalidConfig matches the errors returned by GitHubConfig.Validate and Config.Validate with errors.Is.
+var ErrInvalidConfig = errors.New("invalid configuration")
+
+// ConfigError is an invalid field of a configuration. Field is the path of the field, e.g.
+// "Filter.Include[1]" for a GitHubConfig or "filter.include[1]" for a Config read from a file.
+type ConfigError struct {
+	Field  string
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *ConfigError) Error() string {
+	return e.Field + ": " + e.Reason
+}
+
+// ConfigErrors are all invalid fields of a configuration, so they can be fixed at once. errors.As finds the
+// individual ConfigErrors, errors.Is matches ErrInvalidConfig.
+type ConfigErrors []*ConfigError
+
+// Error implements the error interface, listing one invalid field per line.
+func (e ConfigErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the individual ConfigErrors.
+func (e ConfigErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Is reports whether target is ErrInvalidConfig.
+func (e ConfigErrors) Is(target error) bool {
+	return target == ErrInvalidConfig
+}
+
+// add records an invalid field.
+func (e *ConfigErrors) add(field, format string, args ...interface{}) {
+	*e = append(*e, &ConfigError{Field: field, Reason: fmt.Sprintf(format, args...)})
+}
+
+// err returns the errors, or nil if there are none.
+func (e ConfigErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Validate checks the configuration for crawling repositories, so misconfiguration fails before the first
+// API call: an owner and at least one repository, valid repository and branch names, branches of configured
+// repositories, valid and non-conflicting filter patterns, known modes and normalization forms, and
+// absolute Enterprise URLs. It returns ConfigErrors listing every invalid field, or nil.
+//
+// Usage:
+//
+//	if err := config.Validate(); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	client := NewGitHubClient(ops, graphQLClient, config)
+func (c GitHubConfig) Validate() error {
+	var errs ConfigErrors
+
+	if c.Owner == "" && len(c.Repositories) > 0 {
+		errs.add("Owner", "is required")
+	}
+	if len(c.Repositories) == 0 && len(c.RepositoryRefs) == 0 {
+		errs.add("Repositories", "at least one repository is required")
+	}
+
+	known := make(map[string]bool)
+	for i, repo := range c.Repositories {
+		field := fmt.Sprintf("Repositories[%d]", i)
+		switch {
+		case strings.TrimSpace(repo) == "":
+			errs.add(field, "is empty")
+		case strings.Contains(repo, "/"):
+			errs.add(field, "%q is not a repository name", repo)
+		case known[repo]:
+			errs.add(field, "duplicate repository %q", repo)
+		}
+		known[repo] = true
+		known[c.Owner+"/"+repo] = true
+	}
+	for i, ref := range c.RepositoryRefs {
+		field := fmt.Sprintf("RepositoryRefs[%d]", i)
+		if ref.Owner == "" || ref.Name == "" {
+			errs.add(field, "owner and name are required")
+			continue
+		}
+		if known[ref.String()] {
+			errs.add(field, "duplicate repository %q", ref.String())
+		}
+		known[ref.String()] = true
+		known[ref.Name] = true
+		if ref.Branch != "" && !validBranchName(ref.Branch) {
+			errs.add(field+".Branch", "%q is not a valid branch name", ref.Branch)
+		}
+	}
+
+	if c.DefaultBranch != "" && !validBranchName(c.DefaultBranch) {
+		errs.add("DefaultBranch", "%q is not a valid branch name", c.DefaultBranch)
+	}
+	repos := make([]string, 0, len(c.Branches))
+	for repo := range c.Branches {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	for _, repo := range repos {
+		field := fmt.Sprintf("Branches[%s]", repo)
+		if !known[repo] {
+			errs.add(field, "%q is not a configured repository", repo)
+		}
+		if branch := c.Branches[repo]; !validBranchName(branch) {
+			errs.add(field, "%q is not a valid branch name", branch)
+		}
+	}
+
+	validateFilter(c.Filter, &errs)
+
+	switch c.PathNormalization {
+	case document.NoNormalization, document.NormalizeNFC, document.NormalizeNFD:
+	default:
+		errs.add("PathNormalization", "unknown form %q, want %q or %q", c.PathNormalization, document.NormalizeNFC, document.NormalizeNFD)
+	}
+	switch c.MergeStrategy {
+	case MergeStrategyInclude, MergeStrategySkip, MergeStrategyFirstParent:
+	default:
+		errs.add("MergeStrategy", "unknown strategy %q, want %q or %q", c.MergeStrategy, MergeStrategySkip, MergeStrategyFirstParent)
+	}
+	if c.MaxConcurrency < 0 {
+		errs.add("MaxConcurrency", "must not be negative")
+	}
+
+	for _, u := range []struct{ field, value string }{
+		{"BaseURL", c.BaseURL},
+		{"UploadURL", c.UploadURL},
+		{"GraphQLEndpoint", c.GraphQLEndpoint},
+	} {
+		if u.value == "" {
+			continue
+		}
+		if parsed, err := url.Parse(u.value); err != nil || !parsed.IsAbs() || parsed.Host == "" {
+			errs.add(u.field, "%q is not an absolute URL", u.value)
+		}
+	}
+	if c.UploadURL != "" && c.BaseURL == "" {
+		errs.add("UploadURL", "requires a base URL")
+	}
+
+	return errs.err()
+}
+
+// validateFilter checks the filter, recording invalid fields below "Filter".
+func validateFilter(f filter.GitHubFilter, errs *ConfigErrors) {
+	switch f.Mode {
+	case filter.FilterModeAll, filter.FilterModeDocumentationOnly:
+	default:
+		errs.add("Filter.Mode", "unknown mode %q, want %q", f.Mode, filter.FilterModeDocumentationOnly)
+	}
+	for i, fileType := range f.FileTypes {
+		if fileType == "" {
+			errs.add(fmt.Sprintf("Filter.FileTypes[%d]", i), "is empty and matches every file")
+		}
+	}
+
+	included := make(map[string]bool)
+	for i, glob := range f.Include {
+		if !validGlob(glob) {
+			errs.add(fmt.Sprintf("Filter.Include[%d]", i), "%q is not a valid glob", glob)
+		}
+		included[glob] = true
+	}
+	for i, glob := range f.Exclude {
+		field := fmt.Sprintf("Filter.Exclude[%d]", i)
+		if !validGlob(glob) {
+			errs.add(field, "%q is not a valid glob", glob)
+		}
+		if included[glob] {
+			errs.add(field, "%q is also included, no file can match both", glob)
+		}
+	}
+	if f.IncludeRegexp != nil && f.ExcludeRegexp != nil && f.IncludeRegexp.String() == f.ExcludeRegexp.String() {
+		errs.add("Filter.ExcludeRegexp", "equals the include expression, no file can match both")
+	}
+
+	if strings.Contains(f.IgnoreFile, "/") {
+		errs.add("Filter.IgnoreFile", "%q must be a file name at the repository root", f.IgnoreFile)
+	}
+	if f.MaxFileSize < 0 {
+		errs.add("Filter.MaxFileSize", "must not be negative")
+	}
+}
+
+// validGlob reports whether every segment of a doublestar-style glob is a valid path.Match pattern.
+func validGlob(glob string) bool {
+	if strings.TrimSpace(glob) == "" {
+		return false
+	}
+	for _, segment := range strings.Split(strings.Trim(glob, "/"), "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// validBranchName reports whether name is a valid branch name following the rules of git check-ref-format.
+func validBranchName(name string) bool {
+	if name == "" || name == "@" || strings.HasPrefix(name, "-") {
+		return false
+	}
+	if strings.HasPrefix(name, "/") || strings.HasSuffix(name, "/") || strings.HasSuffix(name, ".") {
+		return false
+	}
+	if strings.Contains(name, "..") || strings.Contains(name, "//") || strings.Contains(name, "@{") {
+		return false
+	}
+	for _, r := range name {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(" ~^:?*[\\", r) {
+			return false
+		}
+	}
+	for _, component := range strings.Split(name, "/") {
+		if strings.HasPrefix(component, ".") || strings.HasSuffix(component, ".lock") {
+			return false
+		}
+	}
+	return true
+}
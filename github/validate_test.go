@@ -0,0 +1,105 @@
+package github
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubConfig_Validate(t *testing.T) {
+	require.NoError(t, GitHubConfig{
+		Owner:          "testowner",
+		Repositories:   []string{"repo1", "repo.js"},
+		RepositoryRefs: []RepositoryRef{{Owner: "other", Name: "repo2", Branch: "release/v1.x"}},
+		DefaultBranch:  "main",
+		Branches:       map[string]string{"repo.js": "develop", "other/repo2": "feature/a-b"},
+		Filter: filter.GitHubFilter{
+			Mode:       filter.FilterModeDocumentationOnly,
+			Include:    []string{"docs/**/*.md"},
+			Exclude:    []string{"**/[._]*"},
+			IgnoreFile: filter.DefaultIgnoreFile,
+		},
+		PathNormalization: document.NormalizeNFC,
+		BaseURL:           "https://github.example.com/",
+		UploadURL:         "https://uploads.github.example.com/",
+	}.Validate())
+
+	assert.NoError(t, GitHubConfig{RepositoryRefs: []RepositoryRef{{Owner: "other", Name: "repo2"}}}.Validate())
+
+	err := GitHubConfig{
+		Repositories:   []string{"repo1", "", "owner/repo2", "repo1"},
+		RepositoryRefs: []RepositoryRef{{Name: "repo3"}, {Owner: "other", Name: "repo4", Branch: "feature..x"}},
+		DefaultBranch:  "main branch",
+		Branches:       map[string]string{"repo9": "main", "repo1": "topic.lock"},
+		Filter: filter.GitHubFilter{
+			Mode:          "code-only",
+			FileTypes:     []string{".md", ""},
+			Include:       []string{"docs/[a-", "**/*.md"},
+			Exclude:       []string{"**/*.md", " "},
+			IncludeRegexp: regexp.MustCompile(`\.go$`),
+			ExcludeRegexp: regexp.MustCompile(`\.go$`),
+			IgnoreFile:    "config/.cocoignore",
+			MaxFileSize:   -1,
+		},
+		PathNormalization: "NFKC",
+		MergeStrategy:     "squash",
+		MaxConcurrency:    -2,
+		UploadURL:         "uploads.example.com",
+		GraphQLEndpoint:   "/api/graphql",
+	}.Validate()
+	assert.EqualError(t, err, `Owner: is required
+Repositories[1]: is empty
+Repositories[2]: "owner/repo2" is not a repository name
+Repositories[3]: duplicate repository "repo1"
+RepositoryRefs[0]: owner and name are required
+RepositoryRefs[1].Branch: "feature..x" is not a valid branch name
+DefaultBranch: "main branch" is not a valid branch name
+Branches[repo1]: "topic.lock" is not a valid branch name
+Branches[repo9]: "repo9" is not a configured repository
+Filter.Mode: unknown mode "code-only", want "documentation-only"
+Filter.FileTypes[1]: is empty and matches every file
+Filter.Include[0]: "docs/[a-" is not a valid glob
+Filter.Exclude[0]: "**/*.md" is also included, no file can match both
+Filter.Exclude[1]: " " is not a valid glob
+Filter.ExcludeRegexp: equals the include expression, no file can match both
+Filter.IgnoreFile: "config/.cocoignore" must be a file name at the repository root
+Filter.MaxFileSize: must not be negative
+PathNormalization: unknown form "NFKC", want "NFC" or "NFD"
+MergeStrategy: unknown strategy "squash", want "skip-merges" or "first-parent"
+MaxConcurrency: must not be negative
+UploadURL: "uploads.example.com" is not an absolute URL
+GraphQLEndpoint: "/api/graphql" is not an absolute URL
+UploadURL: requires a base URL`)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	var configErrs ConfigErrors
+	require.ErrorAs(t, err, &configErrs)
+	assert.Len(t, configErrs, 23)
+
+	var configErr *ConfigError
+	require.True(t, errors.As(err, &configErr))
+	assert.Equal(t, "Owner", configErr.Field)
+
+	assert.EqualError(t, GitHubConfig{}.Validate(), "Repositories: at least one repository is required")
+}
+
+func TestValidBranchName(t *testing.T) {
+	for _, name := range []string{"main", "release/v1.2", "feature/JIRA-1_x", "v1@2", "ü"} {
+		assert.True(t, validBranchName(name), name)
+	}
+	for _, name := range []string{"", "@", "-x", "/main", "main/", "main.", "a..b", "a//b", "a@{1}", "a b", "a~1", "a^", "a:b", "a?", "a*", "a[b", `a\b`, "a\tb", ".hidden", "a/.b", "a.lock", "a/b.lock/c"} {
+		assert.False(t, validBranchName(name), name)
+	}
+}
+
+func TestConfigFieldName(t *testing.T) {
+	assert.Equal(t, "filter.include[1]", configFieldName("Filter.Include[1]"))
+	assert.Equal(t, "branches[my.repo]", configFieldName("Branches[my.repo]"))
+	assert.Equal(t, "concurrency", configFieldName("MaxConcurrency"))
+	assert.Equal(t, "PathNormalization", configFieldName("PathNormalization"))
+}
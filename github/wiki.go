@@ -0,0 +1,196 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/internal/gitutil"
+)
+
+// ErrWikiNotFound is returned by a WikiFetcher when a repository has no wiki, or no page was ever created in
+// it. GetWikiPages skips such repositories.
+var ErrWikiNotFound = errors.New("wiki not found")
+
+// wikiPageExtensions are the file extensions of the markup languages GitHub renders as wiki pages.
+var wikiPageExtensions = map[string]bool{
+	".md":        true,
+	".markdown":  true,
+	".mediawiki": true,
+	".wiki":      true,
+	".rst":       true,
+	".textile":   true,
+	".org":       true,
+	".asciidoc":  true,
+	".adoc":      true,
+	".creole":    true,
+	".pod":       true,
+	".rdoc":      true,
+}
+
+// WikiFetcher fetches the git repository of a wiki, e.g. "https://github.com/owner/repo.wiki.git", into an
+// empty local directory. GitHub does not serve wikis through its REST or GraphQL API, so they are read from
+// a checkout.
+type WikiFetcher interface {
+	Fetch(ctx context.Context, url, dir string) error
+}
+
+// GitWikiFetcher is a WikiFetcher running a shallow "git clone", so git has to be installed. Token
+// authenticates the clone, which is needed for the wikis of private repositories; it is passed to git
+// through the environment, not the command line. NewGitHub sets it to the token of the client.
+type GitWikiFetcher struct {
+	Token string
+}
+
+// Fetch implements WikiFetcher.
+func (f GitWikiFetcher) Fetch(ctx context.Context, url, dir string) error {
+	cmd := gitutil.Command(ctx, f.Token, "clone", "--quiet", "--depth", "1", url, dir)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if strings.Contains(message, "not found") || strings.Contains(message, "does not appear to be a git repository") {
+			return fmt.Errorf("%w: %s", ErrWikiNotFound, url)
+		}
+		return fmt.Errorf("failed to clone %s: %w: %s", url, err, message)
+	}
+	return nil
+}
+
+// WikiPage is a page of the wiki of a repository.
+//
+// File describes the page like the files of the repository itself: Path is the path in the wiki repository,
+// SHA the blob SHA, Branch the branch of the wiki and URL the rendered page. LastModifiedAt is not set. Title
+// is the page name GitHub shows, derived from the file name.
+type WikiPage struct {
+	document.File
+	Title   string
+	Content string
+}
+
+// GetWikiPages retrieves the pages of the wikis of all configured repositories, skipping repositories without
+// a wiki. Only files in one of the markup languages GitHub renders are returned; the configured filter, which
+// applies to the repositories themselves, is not applied.
+//
+// The wikis are fetched with the configured WikiFetcher, a GitWikiFetcher by default, into temporary
+// directories that are removed afterwards.
+//
+// Usage:
+//
+//	pages, err := c.GetWikiPages(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, page := range pages {
+//	    fmt.Println(page.Title, page.URL)
+//	}
+func (c *GitHub) GetWikiPages(ctx context.Context) ([]WikiPage, error) {
+	repoPages := make([][]WikiPage, len(c.repositories()))
+	err := c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		pages, err := c.getWikiPages(ctx, repo)
+		repoPages[i] = pages
+		return err
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	var pages []WikiPage
+	for _, p := range repoPages {
+		pages = append(pages, p...)
+	}
+	return pages, err
+}
+
+// getWikiPages fetches the wiki of a repository and reads its pages.
+func (c *GitHub) getWikiPages(ctx context.Context, repo string) ([]WikiPage, error) {
+	dir, err := os.MkdirTemp("", "cocogh-wiki-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	url := fmt.Sprintf("%s/%s/%s.wiki.git", c.webBaseURL(), c.ownerOf(repo), c.nameOf(repo))
+	if err := c.wikiFetcher().Fetch(ctx, url, dir); err != nil {
+		if errors.Is(err, ErrWikiNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	branch := gitutil.CheckoutBranch(dir)
+
+	var pages []WikiPage
+	err = filepath.WalkDir(dir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() || !wikiPageExtensions[strings.ToLower(filepath.Ext(name))] {
+			return nil
+		}
+
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, name)
+		if err != nil {
+			return err
+		}
+
+		pages = append(pages, c.newWikiPage(repo, branch, filepath.ToSlash(rel), content))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the wiki of %s: %w", repo, err)
+	}
+
+	return pages, nil
+}
+
+// newWikiPage describes a wiki page of the repository.
+func (c *GitHub) newWikiPage(repo, branch, filePath string, content []byte) WikiPage {
+	// GitHub addresses pages by their file name alone, wherever they are in the wiki repository.
+	name := strings.TrimSuffix(path.Base(filePath), path.Ext(filePath))
+
+	page := WikiPage{
+		File: document.File{
+			Path:       filePath,
+			SHA:        gitutil.BlobSHA(content),
+			Size:       len(content),
+			Mode:       document.FileModeRegular,
+			Repository: repo,
+			Branch:     branch,
+			URL:        fmt.Sprintf("%s/%s/%s/wiki/%s", c.webBaseURL(), c.ownerOf(repo), c.nameOf(repo), name),
+		},
+		Title:   strings.ReplaceAll(name, "-", " "),
+		Content: string(content),
+	}
+	if c.Configuration.FrontMatter {
+		page.Metadata = document.FrontMatterMetadata(filePath, content)
+	}
+	return page
+}
+
+// wikiFetcher returns the configured WikiFetcher, a GitWikiFetcher without a token if there is none.
+func (c *GitHub) wikiFetcher() WikiFetcher {
+	if c.Configuration.WikiFetcher == nil {
+		return GitWikiFetcher{}
+	}
+	return c.Configuration.WikiFetcher
+}
@@ -0,0 +1,162 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/internal/gitutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wikiFetcherFunc is a WikiFetcher calling a function.
+type wikiFetcherFunc func(ctx context.Context, url, dir string) error
+
+func (f wikiFetcherFunc) Fetch(ctx context.Context, url, dir string) error {
+	return f(ctx, url, dir)
+}
+
+// writeFiles writes the files, keyed by slash separated path, below dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o644))
+	}
+}
+
+func TestGitHub_GetWikiPages(t *testing.T) {
+	var fetched []string
+	fetcher := wikiFetcherFunc(func(ctx context.Context, url, dir string) error {
+		fetched = append(fetched, url)
+		if url == "https://github.com/testowner/repo2.wiki.git" {
+			return ErrWikiNotFound
+		}
+		writeFiles(t, dir, map[string]string{
+			".git/HEAD":                 "ref: refs/heads/master\n",
+			"Home.md":                   "# Welcome",
+			"guides/Getting-Started.md": "Install it.",
+			"images/logo.png":           "png",
+		})
+		return nil
+	})
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1", "repo2"},
+		WikiFetcher:  fetcher,
+	})
+
+	pages, err := gh.GetWikiPages(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://github.com/testowner/repo1.wiki.git", "https://github.com/testowner/repo2.wiki.git"}, fetched)
+	assert.Equal(t, []WikiPage{
+		{
+			File: document.File{
+				Path:       "Home.md",
+				SHA:        "dd6974f04bd8b0881ba14b1da2fd55a3d8dd1c55",
+				Size:       9,
+				Mode:       document.FileModeRegular,
+				Repository: "repo1",
+				Branch:     "master",
+				URL:        "https://github.com/testowner/repo1/wiki/Home",
+			},
+			Title:   "Home",
+			Content: "# Welcome",
+		},
+		{
+			File: document.File{
+				Path:       "guides/Getting-Started.md",
+				SHA:        gitutil.BlobSHA([]byte("Install it.")),
+				Size:       11,
+				Mode:       document.FileModeRegular,
+				Repository: "repo1",
+				Branch:     "master",
+				URL:        "https://github.com/testowner/repo1/wiki/Getting-Started",
+			},
+			Title:   "Getting Started",
+			Content: "Install it.",
+		},
+	}, pages)
+}
+
+func TestGitHub_GetWikiPages_FetchError(t *testing.T) {
+	gh := NewGitHubClient(new(CommitOpsClientMock), new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+		WikiFetcher: wikiFetcherFunc(func(ctx context.Context, url, dir string) error {
+			return errors.New("boom")
+		}),
+	})
+
+	_, err := gh.GetWikiPages(context.Background())
+	assert.EqualError(t, err, "boom")
+}
+
+func TestGitWikiFetcher(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	// A local repository stands in for the wiki on GitHub.
+	wiki := t.TempDir()
+	writeFiles(t, wiki, map[string]string{"Home.md": "# Welcome"})
+	for _, args := range [][]string{
+		{"init", "--quiet", "--initial-branch=master"},
+		{"add", "Home.md"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "Initial commit"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = wiki
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	dir := filepath.Join(t.TempDir(), "checkout")
+	require.NoError(t, GitWikiFetcher{Token: "secret"}.Fetch(context.Background(), "file://"+wiki, dir))
+
+	content, err := os.ReadFile(filepath.Join(dir, "Home.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Welcome", string(content))
+	assert.Equal(t, "master", gitutil.CheckoutBranch(dir))
+
+	err = GitWikiFetcher{}.Fetch(context.Background(), "file://"+filepath.Join(wiki, "missing"), filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorIs(t, err, ErrWikiNotFound)
+}
+
+func TestBlobSHA(t *testing.T) {
+	// The SHA git hash-object reports for an empty file.
+	assert.Equal(t, "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391", gitutil.BlobSHA(nil))
+}
+
+func TestGitHub_GetWikiPages_FrontMatter(t *testing.T) {
+	fetcher := wikiFetcherFunc(func(ctx context.Context, url, dir string) error {
+		writeFiles(t, dir, map[string]string{
+			"Home.md":  "---\ntitle: Welcome\ntags: [wiki]\n---\n# Home",
+			"Setup.md": "# Setup",
+		})
+		return nil
+	})
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), new(GraphQLClientMock), GitHubConfig{
+		Owner:        "testowner",
+		Repositories: []string{"repo1"},
+		WikiFetcher:  fetcher,
+		FrontMatter:  true,
+	})
+
+	pages, err := gh.GetWikiPages(context.Background())
+	require.NoError(t, err)
+	require.Len(t, pages, 2)
+	assert.Equal(t, map[string]string{"title": "Welcome", "tags": "wiki"}, pages[0].Metadata)
+	assert.Nil(t, pages[1].Metadata)
+
+	assert.Equal(t, "Welcome", pages[0].File.Metadata["title"])
+	assert.Equal(t, "100644", pages[0].File.Mode.String())
+}
@@ -0,0 +1,231 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"gopkg.in/yaml.v3"
+)
+
+// workflowsDir is where GitHub Actions looks for workflow files.
+const workflowsDir = ".github/workflows"
+
+// Workflow holds the key fields of a GitHub Actions workflow file.
+type Workflow struct {
+	Name     string
+	Triggers []string
+	Jobs     []WorkflowJob
+}
+
+// WorkflowJob is a job of a GitHub Actions workflow. Uses is set for jobs calling a reusable workflow
+// instead of running steps.
+type WorkflowJob struct {
+	ID     string
+	Name   string
+	RunsOn []string
+	Uses   string
+}
+
+// workflowFile mirrors the parts of the workflow syntax ParseWorkflow reads. The triggers and jobs are
+// decoded as nodes, as triggers may be a string, a list or a map and jobs must keep their file order.
+type workflowFile struct {
+	Name string    `yaml:"name"`
+	On   yaml.Node `yaml:"on"`
+	Jobs yaml.Node `yaml:"jobs"`
+}
+
+type workflowJobFile struct {
+	Name   string    `yaml:"name"`
+	RunsOn yaml.Node `yaml:"runs-on"`
+	Uses   string    `yaml:"uses"`
+}
+
+// ParseWorkflow parses the content of a GitHub Actions workflow file.
+//
+// Triggers are the event names in the order they are declared, whether "on" is a single event, a list
+// of events or a map of events to their configuration. Jobs are returned in file order.
+func ParseWorkflow(content string) (Workflow, error) {
+	var file workflowFile
+	if err := yaml.Unmarshal([]byte(content), &file); err != nil {
+		return Workflow{}, fmt.Errorf("failed to parse workflow: %w", err)
+	}
+
+	workflow := Workflow{
+		Name:     file.Name,
+		Triggers: nodeKeysOrValues(&file.On),
+	}
+
+	if file.Jobs.Kind != yaml.MappingNode {
+		return workflow, nil
+	}
+	for i := 0; i+1 < len(file.Jobs.Content); i += 2 {
+		var job workflowJobFile
+		if err := file.Jobs.Content[i+1].Decode(&job); err != nil {
+			return Workflow{}, fmt.Errorf("failed to parse workflow job %q: %w", file.Jobs.Content[i].Value, err)
+		}
+
+		runsOn := nodeKeysOrValues(&job.RunsOn)
+		if job.RunsOn.Kind == yaml.MappingNode {
+			runsOn = runsOnLabels(&job.RunsOn)
+		}
+
+		workflow.Jobs = append(workflow.Jobs, WorkflowJob{
+			ID:     file.Jobs.Content[i].Value,
+			Name:   job.Name,
+			RunsOn: runsOn,
+			Uses:   job.Uses,
+		})
+	}
+
+	return workflow, nil
+}
+
+// GetWorkflowDocuments retrieves the GitHub Actions workflow files of all configured repositories as
+// documents. The document body holds the workflow file, the metadata its name, triggers and jobs, so CI
+// usage can be inventoried across repositories. Workflow files that cannot be parsed are still collected,
+// with the parser error recorded in the "parse_error" metadata.
+//
+// Usage:
+//
+//	docs, err := c.GetWorkflowDocuments(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, doc := range docs {
+//	    fmt.Println(doc.Repository, doc.Path, doc.Metadata["triggers"])
+//	}
+func (c *GitHub) GetWorkflowDocuments(ctx context.Context) ([]document.Document, error) {
+	repoDocs := make([][]document.Document, len(c.repositories()))
+	err := c.forEachRepository(ctx, func(ctx context.Context, i int, repo string) error {
+		branch, err := c.branch(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		entries, err := c.listTreeEntries(ctx, c.ownerOf(repo), c.nameOf(repo), fmt.Sprintf("%s:%s", branch, workflowsDir))
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			ext := path.Ext(entry.Name)
+			if entry.Type != "blob" || (ext != ".yml" && ext != ".yaml") {
+				continue
+			}
+
+			content, err := c.getBlobText(ctx, c.ownerOf(repo), c.nameOf(repo), fmt.Sprintf("%s:%s", branch, entry.Path))
+			if err != nil {
+				return err
+			}
+			repoDocs[i] = append(repoDocs[i], c.workflowDocument(repo, branch, entry.Path, content))
+		}
+		return nil
+	})
+	if !partialResult(err) {
+		return nil, err
+	}
+
+	return flattenDocuments(repoDocs), err
+}
+
+// workflowDocument converts a workflow file read from the branch into a document.
+func (c *GitHub) workflowDocument(repo, branch, filePath, content string) document.Document {
+	metadata := make(map[string]string)
+	title := path.Base(filePath)
+
+	workflow, err := ParseWorkflow(content)
+	if err != nil {
+		metadata["parse_error"] = err.Error()
+	} else {
+		if workflow.Name != "" {
+			title = workflow.Name
+		}
+
+		jobs := make([]string, 0, len(workflow.Jobs))
+		var runners, reusable []string
+		for _, job := range workflow.Jobs {
+			jobs = append(jobs, job.ID)
+			runners = appendUnique(runners, job.RunsOn...)
+			if job.Uses != "" {
+				reusable = appendUnique(reusable, job.Uses)
+			}
+		}
+
+		metadata["name"] = workflow.Name
+		metadata["triggers"] = strings.Join(workflow.Triggers, ",")
+		metadata["jobs"] = strings.Join(jobs, ",")
+		metadata["runs_on"] = strings.Join(runners, ",")
+		metadata["uses"] = strings.Join(reusable, ",")
+	}
+
+	return document.Document{
+		ID:         path.Join(c.ownerOf(repo), c.nameOf(repo), filePath),
+		Kind:       document.DocumentKindWorkflow,
+		Owner:      c.ownerOf(repo),
+		Repository: c.nameOf(repo),
+		Path:       c.normalizePath(filePath),
+		Title:      title,
+		Body:       content,
+		URL:        c.fileURL(repo, branch, filePath),
+		Metadata:   metadata,
+	}
+}
+
+// nodeKeysOrValues returns the value of a scalar node, the values of a sequence node or the keys of a
+// mapping node.
+func nodeKeysOrValues(node *yaml.Node) []string {
+	var values []string
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Value != "" {
+			values = append(values, node.Value)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			values = append(values, item.Value)
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content); i += 2 {
+			values = append(values, node.Content[i].Value)
+		}
+	}
+	return values
+}
+
+// runsOnLabels returns the runner group and labels of a "runs-on" mapping.
+func runsOnLabels(node *yaml.Node) []string {
+	var runsOn struct {
+		Group  string    `yaml:"group"`
+		Labels yaml.Node `yaml:"labels"`
+	}
+	if err := node.Decode(&runsOn); err != nil {
+		return nil
+	}
+
+	var labels []string
+	if runsOn.Group != "" {
+		labels = append(labels, runsOn.Group)
+	}
+	return append(labels, nodeKeysOrValues(&runsOn.Labels)...)
+}
+
+// appendUnique appends the values that are not in the slice yet.
+func appendUnique(slice []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, s := range slice {
+			if s == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			slice = append(slice, value)
+		}
+	}
+	return slice
+}
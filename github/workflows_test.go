@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseWorkflow(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Workflow
+		wantErr bool
+	}{
+		{
+			name:    "single trigger",
+			content: "name: Lint\non: push\njobs:\n  lint:\n    runs-on: ubuntu-latest\n",
+			want: Workflow{
+				Name:     "Lint",
+				Triggers: []string{"push"},
+				Jobs:     []WorkflowJob{{ID: "lint", RunsOn: []string{"ubuntu-latest"}}},
+			},
+		},
+		{
+			name:    "trigger list",
+			content: "on: [push, pull_request]\njobs:\n  test:\n    name: Unit tests\n    runs-on: [self-hosted, linux]\n",
+			want: Workflow{
+				Triggers: []string{"push", "pull_request"},
+				Jobs:     []WorkflowJob{{ID: "test", Name: "Unit tests", RunsOn: []string{"self-hosted", "linux"}}},
+			},
+		},
+		{
+			name: "trigger map and jobs in file order",
+			content: `name: CI
+on:
+  push:
+    branches: [main]
+  workflow_dispatch:
+jobs:
+  test:
+    runs-on:
+      group: large-runners
+      labels: linux
+  deploy:
+    uses: octo-org/workflows/.github/workflows/deploy.yml@v1
+  build:
+    runs-on: ubuntu-latest
+`,
+			want: Workflow{
+				Name:     "CI",
+				Triggers: []string{"push", "workflow_dispatch"},
+				Jobs: []WorkflowJob{
+					{ID: "test", RunsOn: []string{"large-runners", "linux"}},
+					{ID: "deploy", Uses: "octo-org/workflows/.github/workflows/deploy.yml@v1"},
+					{ID: "build", RunsOn: []string{"ubuntu-latest"}},
+				},
+			},
+		},
+		{
+			name:    "invalid yaml",
+			content: "name: [unclosed\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWorkflow(tt.content)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGitHub_GetWorkflowDocuments(t *testing.T) {
+	blobs := map[string]string{
+		"main:.github/workflows/ci.yml":     "name: CI\non: [push, pull_request]\njobs:\n  test:\n    runs-on: ubuntu-latest\n  lint:\n    runs-on: ubuntu-latest\n",
+		"main:.github/workflows/broken.yml": "on: [\n",
+	}
+
+	graphQLClient := new(GraphQLClientMock)
+	graphQLClient.On("Query", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		expression := string(args.Get(2).(map[string]interface{})["expression"].(githubv4.String))
+		switch query := args.Get(1).(type) {
+		case *GHQueryForListFiles:
+			if expression == "main:.github/workflows" {
+				query.Repository.Object.Tree.Entries = []GHTreeEntry{
+					{Name: "ci.yml", Path: ".github/workflows/ci.yml", Type: "blob"},
+					{Name: "broken.yml", Path: ".github/workflows/broken.yml", Type: "blob"},
+					{Name: "README.md", Path: ".github/workflows/README.md", Type: "blob"},
+				}
+			}
+		case *GHQueryForBlobText:
+			query.Repository.Object.Blob.Text = blobs[expression]
+		}
+	}).Return(nil)
+
+	gh := NewGitHubClient(new(CommitOpsClientMock), graphQLClient, GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+	})
+
+	docs, err := gh.GetWorkflowDocuments(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, docs, 2)
+
+	assert.Equal(t, "testowner/repo1/.github/workflows/ci.yml", docs[0].ID)
+	assert.Equal(t, document.DocumentKindWorkflow, docs[0].Kind)
+	assert.Equal(t, ".github/workflows/ci.yml", docs[0].Path)
+	assert.Equal(t, "CI", docs[0].Title)
+	assert.Equal(t, "https://github.com/testowner/repo1/blob/main/.github/workflows/ci.yml", docs[0].URL)
+	assert.Equal(t, map[string]string{
+		"name":     "CI",
+		"triggers": "push,pull_request",
+		"jobs":     "test,lint",
+		"runs_on":  "ubuntu-latest",
+		"uses":     "",
+	}, docs[0].Metadata)
+
+	assert.Equal(t, "broken.yml", docs[1].Title)
+	assert.Contains(t, docs[1].Metadata["parse_error"], "failed to parse workflow")
+
+	gh.Configuration.BaseURL = "https://github.example.com/api/v3/"
+	docs, err = gh.GetWorkflowDocuments(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.example.com/testowner/repo1/blob/main/.github/workflows/ci.yml", docs[0].URL)
+}
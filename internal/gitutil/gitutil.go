@@ -0,0 +1,179 @@
+// Package gitutil holds the helpers for git commits and the git command line shared by the GitHub client and
+// the local source.
+package gitutil
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// CommitAuthor returns the login of the author of a commit, or the author name in the commit if it is not
+// linked to a GitHub account.
+func CommitAuthor(commit *github.RepositoryCommit) string {
+	if login := commit.GetAuthor().GetLogin(); login != "" {
+		return login
+	}
+	return commit.GetCommit().GetAuthor().GetName()
+}
+
+// SplitCommitMessage splits a commit message into its subject line and the body following the blank line.
+func SplitCommitMessage(message string) (string, string) {
+	subject, body, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	return strings.TrimSpace(subject), strings.TrimSpace(body)
+}
+
+// ChangeEvent converts a file changed by a commit into a ChangeEvent.
+func ChangeEvent(repo string, commit *github.RepositoryCommit, file *github.CommitFile) document.ChangeEvent {
+	return document.ChangeEvent{
+		Repository:   repo,
+		CommitSHA:    commit.GetSHA(),
+		CommittedAt:  commit.GetCommit().GetCommitter().GetDate().Time,
+		Author:       CommitAuthor(commit),
+		Message:      commit.GetCommit().GetMessage(),
+		Path:         file.GetFilename(),
+		PreviousPath: file.GetPreviousFilename(),
+		Status:       file.GetStatus(),
+	}
+}
+
+// LogArgs returns the arguments of a git log listing the commits of rev matching opts in the record format
+// ParseLog reads.
+func LogArgs(rev string, opts *github.CommitsListOptions) []string {
+	args := []string{"log", "-z", "-M", "--name-status", "--diff-merges=first-parent",
+		"--format=%x1e%H%x1f%an%x1f%ae%x1f%aI%x1f%cn%x1f%ce%x1f%cI%x1f%P%x1f%B"}
+	if !opts.Since.IsZero() {
+		args = append(args, "--since="+opts.Since.Format(time.RFC3339))
+	}
+	if !opts.Until.IsZero() {
+		args = append(args, "--until="+opts.Until.Format(time.RFC3339))
+	}
+	args = append(args, rev, "--")
+	if opts.Path != "" {
+		args = append(args, opts.Path)
+	}
+	return args
+}
+
+// Command prepares a git command that never prompts for credentials. A token is sent as the basic
+// authentication GitHub accepts for HTTPS remotes, through the environment so it does not show up in the
+// process list.
+func Command(ctx context.Context, token string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if token != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + token))
+		cmd.Env = append(cmd.Env,
+			"GIT_CONFIG_COUNT=1",
+			"GIT_CONFIG_KEY_0=http.extraHeader",
+			"GIT_CONFIG_VALUE_0=Authorization: Basic "+credentials,
+		)
+	}
+	return cmd
+}
+
+// ParseLog parses the output of git log with the arguments of LogArgs into commits with
+// their changed files.
+func ParseLog(out string) ([]*github.RepositoryCommit, error) {
+	var commits []*github.RepositoryCommit
+	for _, record := range strings.Split(out, "\x1e") {
+		if record == "" {
+			continue
+		}
+
+		header, changes, _ := strings.Cut(record, "\x00")
+		fields := strings.SplitN(header, "\x1f", 9)
+		if len(fields) != 9 {
+			return nil, fmt.Errorf("unexpected git log record %q", header)
+		}
+		authored, err := time.Parse(time.RFC3339, fields[3])
+		if err != nil {
+			return nil, err
+		}
+		committed, err := time.Parse(time.RFC3339, fields[6])
+		if err != nil {
+			return nil, err
+		}
+
+		commit := &github.RepositoryCommit{
+			SHA: github.String(fields[0]),
+			Commit: &github.Commit{
+				Author:    &github.CommitAuthor{Name: github.String(fields[1]), Email: github.String(fields[2]), Date: &github.Timestamp{Time: authored}},
+				Committer: &github.CommitAuthor{Name: github.String(fields[4]), Email: github.String(fields[5]), Date: &github.Timestamp{Time: committed}},
+				Message:   github.String(strings.TrimSpace(fields[8])),
+			},
+		}
+		for _, parent := range strings.Fields(fields[7]) {
+			commit.Parents = append(commit.Parents, &github.Commit{SHA: github.String(parent)})
+		}
+		commit.Files = ParseNameStatus(strings.TrimPrefix(changes, "\n"))
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// ParseNameStatus parses the NUL separated output of git log --name-status -z into the files of a commit,
+// with the statuses the Commits API reports.
+func ParseNameStatus(out string) []*github.CommitFile {
+	tokens := strings.Split(strings.TrimSuffix(out, "\x00"), "\x00")
+
+	var files []*github.CommitFile
+	for i := 0; i+1 < len(tokens); i += 2 {
+		status, filename := tokens[i], tokens[i+1]
+		if status == "" {
+			break
+		}
+
+		file := &github.CommitFile{Filename: github.String(filename)}
+		switch status[0] {
+		case 'A':
+			file.Status = github.String("added")
+		case 'D':
+			file.Status = github.String("removed")
+		case 'R', 'C':
+			if i+2 >= len(tokens) {
+				return files
+			}
+			file.Status = github.String("renamed")
+			if status[0] == 'C' {
+				file.Status = github.String("copied")
+			}
+			file.PreviousFilename = github.String(filename)
+			file.Filename = github.String(tokens[i+2])
+			i++
+		case 'T':
+			file.Status = github.String("changed")
+		default:
+			file.Status = github.String("modified")
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// CheckoutBranch reads the checked out branch of a git checkout, empty if the directory is none.
+func CheckoutBranch(dir string) string {
+	head, err := os.ReadFile(filepath.Join(dir, ".git", "HEAD"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimSpace(string(head)), "ref: refs/heads/")
+}
+
+// BlobSHA computes the SHA git identifies a blob with the given content by.
+func BlobSHA(content []byte) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "blob %d\x00", len(content))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,44 @@
+package gitutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitCommitMessage(t *testing.T) {
+	tests := []struct {
+		message string
+		subject string
+		body    string
+	}{
+		{message: "Fix typo", subject: "Fix typo"},
+		{message: "Fix typo\n\nThe setup guide misspelled the flag.\n", subject: "Fix typo", body: "The setup guide misspelled the flag."},
+		{message: "  Add docs  \nSecond line", subject: "Add docs", body: "Second line"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			subject, body := SplitCommitMessage(tt.message)
+			assert.Equal(t, tt.subject, subject)
+			assert.Equal(t, tt.body, body)
+		})
+	}
+}
+
+func TestParseNameStatus(t *testing.T) {
+	files := ParseNameStatus("A\x00new.md\x00D\x00old.md\x00R087\x00a.md\x00b.md\x00C100\x00b.md\x00c.md\x00T\x00link\x00M\x00README.md\x00")
+
+	var got [][3]string
+	for _, file := range files {
+		got = append(got, [3]string{file.GetStatus(), file.GetFilename(), file.GetPreviousFilename()})
+	}
+	assert.Equal(t, [][3]string{
+		{"added", "new.md", ""},
+		{"removed", "old.md", ""},
+		{"renamed", "b.md", "a.md"},
+		{"copied", "c.md", "b.md"},
+		{"changed", "link", ""},
+		{"modified", "README.md", ""},
+	}, got)
+}
@@ -0,0 +1,33 @@
+// Package timeutil holds the time helpers shared by the client, the sinks and the runner.
+package timeutil
+
+import (
+	"context"
+	"time"
+)
+
+// Clock tells the current time, see github.Clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// Now returns the current time of clock, falling back to the wall clock if it is nil.
+func Now(clock Clock) time.Time {
+	if clock == nil {
+		return time.Now()
+	}
+	return clock.Now()
+}
+
+// Sleep waits for d or until the context is done.
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
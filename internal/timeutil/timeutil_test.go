@@ -0,0 +1,31 @@
+package timeutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestNow(t *testing.T) {
+	before := time.Now()
+	assert.False(t, Now(nil).Before(before))
+
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, now, Now(fixedClock(now)))
+}
+
+func TestSleep(t *testing.T) {
+	assert.NoError(t, Sleep(context.Background(), time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, Sleep(ctx, time.Hour), context.Canceled)
+}
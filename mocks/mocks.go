@@ -0,0 +1,175 @@
+// Package mocks provides testify mocks of the coco-gh client and pipeline interfaces, so code built on coco-gh can be
+// tested without each consumer generating its own mocks.
+package mocks
+
+import (
+	"context"
+	"time"
+
+	gogithub "github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/github"
+	"github.com/shaharia-lab/coco-gh/v2/source"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestingT is the part of testing.TB the constructors need.
+type TestingT interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+var (
+	_ github.GraphQLClient   = (*GraphQLClient)(nil)
+	_ github.CommitOpsClient = (*CommitOpsClient)(nil)
+	_ source.ContentSource   = (*ContentSource)(nil)
+)
+
+// GraphQLClient is a mock type for the github.GraphQLClient type.
+type GraphQLClient struct {
+	mock.Mock
+}
+
+// NewGraphQLClient creates a GraphQLClient whose expectations are asserted when the test finishes.
+func NewGraphQLClient(t TestingT) *GraphQLClient {
+	m := &GraphQLClient{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+// Query provides a mock function with given fields: ctx, q, variables
+func (_m *GraphQLClient) Query(ctx context.Context, q interface{}, variables map[string]interface{}) error {
+	ret := _m.Called(ctx, q, variables)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, map[string]interface{}) error); ok {
+		r0 = rf(ctx, q, variables)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CommitOpsClient is a mock type for the github.CommitOpsClient type.
+type CommitOpsClient struct {
+	mock.Mock
+}
+
+// NewCommitOpsClient creates a CommitOpsClient whose expectations are asserted when the test finishes.
+func NewCommitOpsClient(t TestingT) *CommitOpsClient {
+	m := &CommitOpsClient{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+// GetCommit provides a mock function with given fields: ctx, owner, repo, sha, opts
+func (_m *CommitOpsClient) GetCommit(ctx context.Context, owner string, repo string, sha string, opts *gogithub.ListOptions) (*gogithub.RepositoryCommit, *gogithub.Response, error) {
+	ret := _m.Called(ctx, owner, repo, sha, opts)
+
+	var r0 *gogithub.RepositoryCommit
+	var r1 *gogithub.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *gogithub.ListOptions) (*gogithub.RepositoryCommit, *gogithub.Response, error)); ok {
+		return rf(ctx, owner, repo, sha, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *gogithub.ListOptions) *gogithub.RepositoryCommit); ok {
+		r0 = rf(ctx, owner, repo, sha, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gogithub.RepositoryCommit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *gogithub.ListOptions) *gogithub.Response); ok {
+		r1 = rf(ctx, owner, repo, sha, opts)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*gogithub.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string, *gogithub.ListOptions) error); ok {
+		r2 = rf(ctx, owner, repo, sha, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListCommits provides a mock function with given fields: ctx, owner, repo, opts
+func (_m *CommitOpsClient) ListCommits(ctx context.Context, owner string, repo string, opts *gogithub.CommitsListOptions) ([]*gogithub.RepositoryCommit, *gogithub.Response, error) {
+	ret := _m.Called(ctx, owner, repo, opts)
+
+	var r0 []*gogithub.RepositoryCommit
+	var r1 *gogithub.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *gogithub.CommitsListOptions) ([]*gogithub.RepositoryCommit, *gogithub.Response, error)); ok {
+		return rf(ctx, owner, repo, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *gogithub.CommitsListOptions) []*gogithub.RepositoryCommit); ok {
+		r0 = rf(ctx, owner, repo, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gogithub.RepositoryCommit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *gogithub.CommitsListOptions) *gogithub.Response); ok {
+		r1 = rf(ctx, owner, repo, opts)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*gogithub.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, *gogithub.CommitsListOptions) error); ok {
+		r2 = rf(ctx, owner, repo, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ContentSource is a mock type for the source.ContentSource type.
+type ContentSource struct {
+	mock.Mock
+}
+
+// NewContentSource creates a ContentSource whose expectations are asserted when the test finishes.
+func NewContentSource(t TestingT) *ContentSource {
+	m := &ContentSource{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+// Collect provides a mock function with given fields: ctx, since
+func (_m *ContentSource) Collect(ctx context.Context, since time.Time) ([]document.Document, error) {
+	ret := _m.Called(ctx, since)
+
+	var r0 []document.Document
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]document.Document, error)); ok {
+		return rf(ctx, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []document.Document); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]document.Document)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,69 @@
+package mocks_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gogithub "github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/github"
+	"github.com/shaharia-lab/coco-gh/v2/mocks"
+	"github.com/shaharia-lab/coco-gh/v2/watch"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMocks(t *testing.T) {
+	graphQLClient := mocks.NewGraphQLClient(t)
+	graphQLClient.On("Query", mock.Anything, mock.AnythingOfType("*github.GHQueryForListFiles"), mock.Anything).Run(func(args mock.Arguments) {
+		q := args.Get(1).(*github.GHQueryForListFiles)
+		if args.Get(2).(map[string]interface{})["expression"].(githubv4.String) == "main:" {
+			q.Repository.Object.Tree.Entries = []github.GHTreeEntry{{Name: "README.md", Path: "README.md", Type: "blob"}}
+		}
+	}).Return(nil)
+
+	commitOpsClient := mocks.NewCommitOpsClient(t)
+	commitOpsClient.On("ListCommits", mock.Anything, "testowner", "repo1", mock.Anything).
+		Return([]*gogithub.RepositoryCommit{{SHA: gogithub.String("abc")}}, nil, nil)
+	commitOpsClient.On("GetCommit", mock.Anything, "testowner", "repo1", "abc", mock.Anything).
+		Return(&gogithub.RepositoryCommit{Files: []*gogithub.CommitFile{{Filename: gogithub.String("README.md"), Status: gogithub.String("modified")}}}, nil, nil)
+
+	gh := github.NewGitHubClient(commitOpsClient, graphQLClient, github.GitHubConfig{
+		Owner:         "testowner",
+		Repositories:  []string{"repo1"},
+		DefaultBranch: "main",
+	})
+
+	files, err := gh.GetFilePathsFromRepositories()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"README.md"}, files)
+
+	paths, err := gh.GetChangedFilePathsSince(time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"README.md"}, paths.Modified)
+}
+
+func TestContentSource(t *testing.T) {
+	source := mocks.NewContentSource(t)
+	source.On("Collect", mock.Anything, time.Time{}).Return([]document.Document{{ID: "o/r/commit/1"}}, nil)
+
+	checkpoints := watch.NewMemoryCheckpointStore()
+	collector := &watch.Collector{
+		Name:        "commits",
+		Source:      source,
+		Sink:        sinkFunc(func(context.Context, []document.Document) error { return nil }),
+		Checkpoints: checkpoints,
+	}
+
+	result, err := collector.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Written)
+}
+
+type sinkFunc func(ctx context.Context, docs []document.Document) error
+
+func (f sinkFunc) Write(ctx context.Context, docs []document.Document) error {
+	return f(ctx, docs)
+}
@@ -0,0 +1,189 @@
+package cocogh
+
+import (
+	"context"
+	"database/sql"
+	"io"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/sink"
+)
+
+// ElasticsearchSink is an alias of sink.ElasticsearchSink.
+//
+// Deprecated: use sink.ElasticsearchSink instead.
+type ElasticsearchSink = sink.ElasticsearchSink
+
+// JSONLWriter is an alias of sink.JSONLWriter.
+//
+// Deprecated: use sink.JSONLWriter instead.
+type JSONLWriter = sink.JSONLWriter
+
+// MemoryObjectStore is an alias of sink.MemoryObjectStore.
+//
+// Deprecated: use sink.MemoryObjectStore instead.
+type MemoryObjectStore = sink.MemoryObjectStore
+
+// ObjectManifest is an alias of sink.ObjectManifest.
+//
+// Deprecated: use sink.ObjectManifest instead.
+type ObjectManifest = sink.ObjectManifest
+
+// ObjectManifestEntry is an alias of sink.ObjectManifestEntry.
+//
+// Deprecated: use sink.ObjectManifestEntry instead.
+type ObjectManifestEntry = sink.ObjectManifestEntry
+
+// ObjectStore is an alias of sink.ObjectStore.
+//
+// Deprecated: use sink.ObjectStore instead.
+type ObjectStore = sink.ObjectStore
+
+// ObjectStoreSink is an alias of sink.ObjectStoreSink.
+//
+// Deprecated: use sink.ObjectStoreSink instead.
+type ObjectStoreSink = sink.ObjectStoreSink
+
+// Record is an alias of sink.Record.
+//
+// Deprecated: use sink.Record instead.
+type Record = sink.Record
+
+// S3Store is an alias of sink.S3Store.
+//
+// Deprecated: use sink.S3Store instead.
+type S3Store = sink.S3Store
+
+// SQLiteSink is an alias of sink.SQLiteSink.
+//
+// Deprecated: use sink.SQLiteSink instead.
+type SQLiteSink = sink.SQLiteSink
+
+// Sink is an alias of sink.Sink.
+//
+// Deprecated: use sink.Sink instead.
+type Sink = sink.Sink
+
+// Writer is an alias of sink.Writer.
+//
+// Deprecated: use sink.Writer instead.
+type Writer = sink.Writer
+
+// DefaultElasticsearchBatchSize is sink.DefaultElasticsearchBatchSize.
+//
+// Deprecated: use sink.DefaultElasticsearchBatchSize instead.
+const DefaultElasticsearchBatchSize = sink.DefaultElasticsearchBatchSize
+
+// DefaultObjectKeyLayout is sink.DefaultObjectKeyLayout.
+//
+// Deprecated: use sink.DefaultObjectKeyLayout instead.
+const DefaultObjectKeyLayout = sink.DefaultObjectKeyLayout
+
+// SQLiteChangeAdded is sink.SQLiteChangeAdded.
+//
+// Deprecated: use sink.SQLiteChangeAdded instead.
+const SQLiteChangeAdded = sink.SQLiteChangeAdded
+
+// SQLiteChangeModified is sink.SQLiteChangeModified.
+//
+// Deprecated: use sink.SQLiteChangeModified instead.
+const SQLiteChangeModified = sink.SQLiteChangeModified
+
+// SQLiteChangeRemoved is sink.SQLiteChangeRemoved.
+//
+// Deprecated: use sink.SQLiteChangeRemoved instead.
+const SQLiteChangeRemoved = sink.SQLiteChangeRemoved
+
+// DefaultElasticsearchMapping is sink.DefaultElasticsearchMapping.
+//
+// Deprecated: use sink.DefaultElasticsearchMapping instead.
+var DefaultElasticsearchMapping = sink.DefaultElasticsearchMapping
+
+// ErrObjectNotFound is sink.ErrObjectNotFound.
+//
+// Deprecated: use sink.ErrObjectNotFound instead.
+var ErrObjectNotFound = sink.ErrObjectNotFound
+
+// DocumentRecord calls sink.DocumentRecord.
+//
+// Deprecated: use sink.DocumentRecord instead.
+func DocumentRecord(doc document.Document) sink.Record {
+	return sink.DocumentRecord(doc)
+}
+
+// FileContentRecord calls sink.FileContentRecord.
+//
+// Deprecated: use sink.FileContentRecord instead.
+func FileContentRecord(content document.FileContent) sink.Record {
+	return sink.FileContentRecord(content)
+}
+
+// FileRecord calls sink.FileRecord.
+//
+// Deprecated: use sink.FileRecord instead.
+func FileRecord(file document.File, content []byte) sink.Record {
+	return sink.FileRecord(file, content)
+}
+
+// NewElasticsearchSink calls sink.NewElasticsearchSink.
+//
+// Deprecated: use sink.NewElasticsearchSink instead.
+func NewElasticsearchSink(endpoint, index string) *sink.ElasticsearchSink {
+	return sink.NewElasticsearchSink(endpoint, index)
+}
+
+// NewGCSStore calls sink.NewGCSStore.
+//
+// Deprecated: use sink.NewGCSStore instead.
+func NewGCSStore(bucket, accessKeyID, secretAccessKey string) *sink.S3Store {
+	return sink.NewGCSStore(bucket, accessKeyID, secretAccessKey)
+}
+
+// NewJSONLWriter calls sink.NewJSONLWriter.
+//
+// Deprecated: use sink.NewJSONLWriter instead.
+func NewJSONLWriter(w io.Writer) *sink.JSONLWriter {
+	return sink.NewJSONLWriter(w)
+}
+
+// NewMemoryObjectStore calls sink.NewMemoryObjectStore.
+//
+// Deprecated: use sink.NewMemoryObjectStore instead.
+func NewMemoryObjectStore() *sink.MemoryObjectStore {
+	return sink.NewMemoryObjectStore()
+}
+
+// NewObjectStoreSink calls sink.NewObjectStoreSink.
+//
+// Deprecated: use sink.NewObjectStoreSink instead.
+func NewObjectStoreSink(store sink.ObjectStore) *sink.ObjectStoreSink {
+	return sink.NewObjectStoreSink(store)
+}
+
+// NewS3Store calls sink.NewS3Store.
+//
+// Deprecated: use sink.NewS3Store instead.
+func NewS3Store(bucket, region, accessKeyID, secretAccessKey string) *sink.S3Store {
+	return sink.NewS3Store(bucket, region, accessKeyID, secretAccessKey)
+}
+
+// NewSQLiteSink calls sink.NewSQLiteSink.
+//
+// Deprecated: use sink.NewSQLiteSink instead.
+func NewSQLiteSink(ctx context.Context, db *sql.DB) (*sink.SQLiteSink, error) {
+	return sink.NewSQLiteSink(ctx, db)
+}
+
+// TransformSink calls sink.TransformSink.
+//
+// Deprecated: use sink.TransformSink instead.
+func TransformSink(s sink.Sink, transformers ...document.Transformer) sink.Sink {
+	return sink.TransformSink(s, transformers...)
+}
+
+// WriterSink calls sink.WriterSink.
+//
+// Deprecated: use sink.WriterSink instead.
+func WriterSink(w sink.Writer) sink.Sink {
+	return sink.WriterSink(w)
+}
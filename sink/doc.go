@@ -0,0 +1,10 @@
+// Package sink writes collected documents and files to the stores they are indexed from.
+//
+// A Sink receives documents. A Writer such as JSONLWriter exports collected files as Records, built with
+// FileRecord and FileContentRecord, to other tools. ObjectStoreSink uploads documents and an
+// ObjectManifest to an ObjectStore such as an S3Store for S3 or GCS buckets and deletes the objects of
+// removed files. SQLiteSink keeps files, blob SHAs and their change history in a SQLite database opened by
+// the caller, and returns the stored state as a Snapshot to diff against the next run. ElasticsearchSink
+// indexes documents into an Elasticsearch or OpenSearch index in bulk batches. TransformSink runs
+// Transformers on the documents before writing them.
+package sink
@@ -0,0 +1,287 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// DefaultElasticsearchBatchSize is the number of documents an ElasticsearchSink without a configured
+// BatchSize sends in one bulk request.
+const DefaultElasticsearchBatchSize = 500
+
+// DefaultElasticsearchMapping is the mapping EnsureIndex creates indexes with unless Mapping is set.
+// Identifiers are keywords for exact filters and aggregations, titles and bodies full text. Metadata is
+// mapped dynamically.
+var DefaultElasticsearchMapping = json.RawMessage(`{
+  "mappings": {
+    "properties": {
+      "id": {"type": "keyword"},
+      "kind": {"type": "keyword"},
+      "owner": {"type": "keyword"},
+      "repository": {"type": "keyword"},
+      "path": {"type": "keyword"},
+      "title": {"type": "text"},
+      "body": {"type": "text"},
+      "url": {"type": "keyword", "index": false},
+      "author": {"type": "keyword"},
+      "created_at": {"type": "date"},
+      "updated_at": {"type": "date"}
+    }
+  }
+}`)
+
+// ElasticsearchSink is a Sink indexing the documents it receives into an Elasticsearch or OpenSearch index
+// through the bulk API, using the document ID as the ID in the index, so repeated runs update documents in
+// place.
+//
+// Endpoint is the base URL of the cluster, Index the name of the index. Documents are sent in bulk requests
+// of BatchSize documents, DefaultElasticsearchBatchSize if zero. Requests authenticate with APIKey if set,
+// otherwise with Username and Password if set. HTTPClient sends the requests; nil uses http.DefaultClient.
+// Mapping holds the settings and mappings EnsureIndex creates the index with.
+//
+// Usage:
+//
+//	sink := NewElasticsearchSink("https://search.example.com:9200", "coco-docs")
+//	sink.APIKey = os.Getenv("ES_API_KEY")
+//	if err := sink.EnsureIndex(ctx); err != nil {
+//	    log.Fatal(err)
+//	}
+//	collector := &watch.Collector{Name: "docs", Source: source.SourceDocuments(gh), Sink: sink}
+type ElasticsearchSink struct {
+	Endpoint   string
+	Index      string
+	BatchSize  int
+	Mapping    json.RawMessage
+	Username   string
+	Password   string
+	APIKey     string
+	HTTPClient *http.Client
+}
+
+// NewElasticsearchSink creates an ElasticsearchSink for the index of the cluster at endpoint.
+func NewElasticsearchSink(endpoint, index string) *ElasticsearchSink {
+	return &ElasticsearchSink{Endpoint: endpoint, Index: index}
+}
+
+// elasticsearchDocument is the source of a Document in the index.
+type elasticsearchDocument struct {
+	ID         string                `json:"id"`
+	Kind       document.DocumentKind `json:"kind"`
+	Owner      string                `json:"owner,omitempty"`
+	Repository string                `json:"repository,omitempty"`
+	Path       string                `json:"path,omitempty"`
+	Title      string                `json:"title,omitempty"`
+	Body       string                `json:"body"`
+	URL        string                `json:"url,omitempty"`
+	Author     string                `json:"author,omitempty"`
+	CreatedAt  *time.Time            `json:"created_at,omitempty"`
+	UpdatedAt  *time.Time            `json:"updated_at,omitempty"`
+	Metadata   map[string]string     `json:"metadata,omitempty"`
+}
+
+// elasticsearchBulkResponse is the part of a bulk API response reporting failed actions.
+type elasticsearchBulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		ID     string `json:"_id"`
+		Status int    `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+// EnsureIndex creates the index with the configured mapping unless it already exists.
+func (s *ElasticsearchSink) EnsureIndex(ctx context.Context) error {
+	resp, err := s.do(ctx, http.MethodHead, url.PathEscape(s.Index), nil, "")
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to check index %s: %s", s.Index, resp.Status)
+	}
+
+	mapping := s.Mapping
+	if mapping == nil {
+		mapping = DefaultElasticsearchMapping
+	}
+	resp, err = s.do(ctx, http.MethodPut, url.PathEscape(s.Index), mapping, "application/json")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to create index %s: %s", s.Index, elasticsearchError(resp))
+	}
+	return nil
+}
+
+// Write indexes the documents in bulk requests of BatchSize documents. It stops at the first batch with a
+// failed document, reporting the failure of its first document.
+func (s *ElasticsearchSink) Write(ctx context.Context, docs []document.Document) error {
+	var actions [][]byte
+	for _, doc := range docs {
+		source := elasticsearchDocument{
+			ID:         doc.ID,
+			Kind:       doc.Kind,
+			Owner:      doc.Owner,
+			Repository: doc.Repository,
+			Path:       doc.Path,
+			Title:      doc.Title,
+			Body:       doc.Body,
+			URL:        doc.URL,
+			Author:     doc.Author,
+			Metadata:   doc.Metadata,
+		}
+		if !doc.CreatedAt.IsZero() {
+			source.CreatedAt = &doc.CreatedAt
+		}
+		if !doc.UpdatedAt.IsZero() {
+			source.UpdatedAt = &doc.UpdatedAt
+		}
+
+		action, err := s.bulkAction("index", doc.ID, source)
+		if err != nil {
+			return err
+		}
+		actions = append(actions, action)
+	}
+	return s.bulk(ctx, actions)
+}
+
+// Delete removes the documents of removed files from the index. The ID of a file is its repository and
+// path joined by a slash, as SourceDocuments builds it; files that are not indexed are ignored.
+func (s *ElasticsearchSink) Delete(ctx context.Context, removed []document.FileChange) error {
+	var actions [][]byte
+	for _, change := range removed {
+		action, err := s.bulkAction("delete", change.Repository+"/"+change.Path, nil)
+		if err != nil {
+			return err
+		}
+		actions = append(actions, action)
+	}
+	return s.bulk(ctx, actions)
+}
+
+// bulkAction encodes a bulk API action for the document with the given ID, followed by its source if any.
+func (s *ElasticsearchSink) bulkAction(action, id string, source interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	meta := map[string]map[string]string{action: {"_index": s.Index, "_id": id}}
+	if err := enc.Encode(meta); err != nil {
+		return nil, err
+	}
+	if source != nil {
+		if err := enc.Encode(source); err != nil {
+			return nil, fmt.Errorf("failed to encode document %s: %w", id, err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// bulk sends the actions in batches of BatchSize.
+func (s *ElasticsearchSink) bulk(ctx context.Context, actions [][]byte) error {
+	batchSize := s.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultElasticsearchBatchSize
+	}
+
+	for start := 0; start < len(actions); start += batchSize {
+		end := start + batchSize
+		if end > len(actions) {
+			end = len(actions)
+		}
+		if err := s.sendBulk(ctx, bytes.Join(actions[start:end], nil)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendBulk sends one bulk request and reports the first failed action. Deleting a missing document is not
+// a failure.
+func (s *ElasticsearchSink) sendBulk(ctx context.Context, body []byte) error {
+	resp, err := s.do(ctx, http.MethodPost, "_bulk", body, "application/x-ndjson")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to index into %s: %s", s.Index, elasticsearchError(resp))
+	}
+
+	var result elasticsearchBulkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to read bulk response of %s: %w", s.Index, err)
+	}
+	if !result.Errors {
+		return nil
+	}
+	for _, item := range result.Items {
+		for action, status := range item {
+			if status.Error == nil || (action == "delete" && status.Status == http.StatusNotFound) {
+				continue
+			}
+			return fmt.Errorf("failed to %s document %s in %s: %s: %s", action, status.ID, s.Index, status.Error.Type, status.Error.Reason)
+		}
+	}
+	return nil
+}
+
+// do sends an authenticated request to the path below the endpoint.
+func (s *ElasticsearchSink) do(ctx context.Context, method, path string, body []byte, contentType string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(s.Endpoint, "/")+"/"+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	switch {
+	case s.APIKey != "":
+		req.Header.Set("Authorization", "ApiKey "+s.APIKey)
+	case s.Username != "":
+		req.SetBasicAuth(s.Username, s.Password)
+	}
+
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s: %w", s.Endpoint, err)
+	}
+	return resp, nil
+}
+
+// elasticsearchError returns the reason of an error response, or its status if it has none.
+func elasticsearchError(resp *http.Response) string {
+	var result struct {
+		Error struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err := json.Unmarshal(body, &result); err == nil && result.Error.Type != "" {
+		return result.Error.Type + ": " + result.Error.Reason
+	}
+	return resp.Status
+}
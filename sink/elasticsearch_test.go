@@ -0,0 +1,145 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// elasticsearchServer is a fake Elasticsearch cluster keeping the sources of a single index.
+type elasticsearchServer struct {
+	t *testing.T
+
+	mu      sync.Mutex
+	mapping string
+	docs    map[string]map[string]interface{}
+	bulks   int
+	failID  string
+}
+
+func (s *elasticsearchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user, password, _ := r.BasicAuth(); user != "elastic" || password != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"error":{"type":"security_exception","reason":"missing authentication credentials"}}`)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodHead && r.URL.Path == "/coco-docs":
+		if s.mapping == "" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case r.Method == http.MethodPut && r.URL.Path == "/coco-docs":
+		body, _ := io.ReadAll(r.Body)
+		s.mapping = string(body)
+	case r.Method == http.MethodPost && r.URL.Path == "/_bulk":
+		assert.Equal(s.t, "application/x-ndjson", r.Header.Get("Content-Type"))
+		s.bulks++
+
+		var items []map[string]interface{}
+		failed := false
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var action map[string]map[string]string
+			require.NoError(s.t, json.Unmarshal(scanner.Bytes(), &action))
+			for name, meta := range action {
+				assert.Equal(s.t, "coco-docs", meta["_index"])
+				id := meta["_id"]
+				status := map[string]interface{}{"_id": id, "status": 200}
+
+				switch {
+				case name == "index" && id == s.failID:
+					scanner.Scan()
+					failed = true
+					status["status"] = 400
+					status["error"] = map[string]string{"type": "mapper_parsing_exception", "reason": "failed to parse field [updated_at]"}
+				case name == "index":
+					scanner.Scan()
+					var source map[string]interface{}
+					require.NoError(s.t, json.Unmarshal(scanner.Bytes(), &source))
+					s.docs[id] = source
+				case name == "delete":
+					if _, ok := s.docs[id]; !ok {
+						failed = true
+						status["status"] = 404
+						status["error"] = map[string]string{"type": "not_found", "reason": "not found"}
+					}
+					delete(s.docs, id)
+				}
+				items = append(items, map[string]interface{}{name: status})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"errors": failed, "items": items})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestElasticsearchSink(t *testing.T) {
+	fake := &elasticsearchServer{t: t, docs: make(map[string]map[string]interface{})}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	sink := NewElasticsearchSink(server.URL+"/", "coco-docs")
+	sink.Username, sink.Password = "elastic", "secret"
+	sink.BatchSize = 2
+	ctx := context.Background()
+
+	require.NoError(t, sink.EnsureIndex(ctx))
+	assert.JSONEq(t, string(DefaultElasticsearchMapping), fake.mapping)
+
+	sink.Mapping = json.RawMessage(`{}`)
+	require.NoError(t, sink.EnsureIndex(ctx))
+	assert.JSONEq(t, string(DefaultElasticsearchMapping), fake.mapping, "an existing index is kept")
+
+	updatedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, sink.Write(ctx, []document.Document{
+		{ID: "repo1/README.md", Kind: document.DocumentKindFile, Repository: "repo1", Path: "README.md", Title: "README.md", Body: "# Repo 1 <b>",
+			UpdatedAt: updatedAt, Metadata: map[string]string{"sha": "abc"}},
+		{ID: "repo1/docs/a.md", Kind: document.DocumentKindFile, Repository: "repo1", Path: "docs/a.md", Body: "A"},
+		{ID: "repo1/docs/b.md", Kind: document.DocumentKindFile, Repository: "repo1", Path: "docs/b.md", Body: "B"},
+	}))
+	assert.Equal(t, 2, fake.bulks)
+	assert.Equal(t, map[string]interface{}{
+		"id":         "repo1/README.md",
+		"kind":       "file",
+		"repository": "repo1",
+		"path":       "README.md",
+		"title":      "README.md",
+		"body":       "# Repo 1 <b>",
+		"updated_at": "2024-03-01T12:00:00Z",
+		"metadata":   map[string]interface{}{"sha": "abc"},
+	}, fake.docs["repo1/README.md"])
+	assert.Len(t, fake.docs, 3)
+
+	// Removing a file that was never indexed is not a failure.
+	require.NoError(t, sink.Delete(ctx, []document.FileChange{
+		{Repository: "repo1", Path: "docs/a.md"},
+		{Repository: "repo1", Path: "unknown.md"},
+	}))
+	assert.Len(t, fake.docs, 2)
+	assert.NotContains(t, fake.docs, "repo1/docs/a.md")
+
+	fake.failID = "repo1/docs/c.md"
+	err := sink.Write(ctx, []document.Document{{ID: "repo1/docs/c.md", Body: "C"}})
+	assert.EqualError(t, err, "failed to index document repo1/docs/c.md in coco-docs: mapper_parsing_exception: failed to parse field [updated_at]")
+
+	sink.Password = "wrong"
+	err = sink.Write(ctx, []document.Document{{ID: "repo1/docs/d.md", Body: "D"}})
+	assert.EqualError(t, err, "failed to index into coco-docs: security_exception: missing authentication credentials")
+
+	assert.NoError(t, sink.Write(ctx, nil), "nothing to write sends no request")
+}
@@ -0,0 +1,158 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// Record is a collected file as exported by a Writer.
+//
+// Content holds the text of the file; it is empty for binary files, which Binary marks. Metadata holds
+// further attributes as plain strings: "mode", the git file mode, "last_modified_at", the time of the last
+// commit changing the file in RFC 3339 format, if known, and the File.Metadata of the file.
+type Record struct {
+	Repository string            `json:"repository"`
+	Path       string            `json:"path"`
+	SHA        string            `json:"sha,omitempty"`
+	Branch     string            `json:"branch,omitempty"`
+	URL        string            `json:"url,omitempty"`
+	Size       int               `json:"size"`
+	Binary     bool              `json:"binary,omitempty"`
+	Content    string            `json:"content,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+}
+
+// Writer exports collected files, e.g. to hand them over to other tools.
+type Writer interface {
+	WriteRecords(ctx context.Context, records []Record) error
+}
+
+// FileRecord converts a file and its content, e.g. an ArchiveFile, into a record. A nil content exports
+// the metadata of the file only.
+func FileRecord(file document.File, content []byte) Record {
+	record := Record{
+		Repository: file.Repository,
+		Path:       file.Path,
+		SHA:        file.SHA,
+		Branch:     file.Branch,
+		URL:        file.URL,
+		Size:       file.Size,
+		Binary:     bytes.IndexByte(content, 0) >= 0,
+		Metadata:   map[string]string{"mode": file.Mode.String()},
+	}
+	for key, value := range file.Metadata {
+		record.Metadata[key] = value
+	}
+	if !record.Binary {
+		record.Content = string(content)
+	}
+	if !file.LastModifiedAt.IsZero() {
+		record.Metadata["last_modified_at"] = file.LastModifiedAt.UTC().Format(time.RFC3339)
+	}
+	return record
+}
+
+// FileContentRecord converts a file read with GetFileContents into a record.
+func FileContentRecord(content document.FileContent) Record {
+	return Record{
+		Repository: content.Repository,
+		Path:       content.Path,
+		SHA:        content.Oid,
+		Size:       content.ByteSize,
+		Binary:     content.IsBinary,
+		Content:    content.Text,
+	}
+}
+
+// DocumentRecord converts a collected document, e.g. a file collected through SourceDocuments, into a record.
+// The metadata of the document is kept, completed by its "kind" and "title" and, if set, "updated_at".
+func DocumentRecord(doc document.Document) Record {
+	record := Record{
+		Repository: doc.Repository,
+		Path:       doc.Path,
+		SHA:        doc.Metadata["sha"],
+		Branch:     doc.Metadata["branch"],
+		URL:        doc.URL,
+		Size:       len(doc.Body),
+		Content:    doc.Body,
+		Metadata:   map[string]string{"kind": string(doc.Kind)},
+	}
+	if doc.Owner != "" {
+		record.Repository = doc.Owner + "/" + doc.Repository
+	}
+	for key, value := range doc.Metadata {
+		if key != "sha" && key != "branch" {
+			record.Metadata[key] = value
+		}
+	}
+	if doc.Title != "" {
+		record.Metadata["title"] = doc.Title
+	}
+	if !doc.UpdatedAt.IsZero() {
+		record.Metadata["updated_at"] = doc.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	return record
+}
+
+// WriterSink adapts a Writer to a Sink, writing every document as a record, see DocumentRecord.
+func WriterSink(w Writer) Sink {
+	return writerSink{w: w}
+}
+
+type writerSink struct {
+	w Writer
+}
+
+// Write converts the documents into records and writes them.
+func (s writerSink) Write(ctx context.Context, docs []document.Document) error {
+	records := make([]Record, 0, len(docs))
+	for _, doc := range docs {
+		records = append(records, DocumentRecord(doc))
+	}
+	return s.w.WriteRecords(ctx, records)
+}
+
+// JSONLWriter is a Writer serializing every record as one JSON object per line, the JSON Lines or NDJSON
+// format most data tools read. It is safe for concurrent use; records are never interleaved.
+//
+// Usage:
+//
+//	w := NewJSONLWriter(os.Stdout)
+//	err := c.DownloadRepositoryArchive(ctx, "repo1", "", func(file github.ArchiveFile) error {
+//	    return w.WriteRecords(ctx, []Record{FileRecord(file.File, file.Content)})
+//	})
+type JSONLWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONLWriter creates a JSONLWriter writing to w. Writes are not buffered, wrap w in a bufio.Writer for
+// large exports.
+func NewJSONLWriter(w io.Writer) *JSONLWriter {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &JSONLWriter{enc: enc}
+}
+
+// WriteRecords implements Writer. It stops at the first record that cannot be written.
+func (w *JSONLWriter) WriteRecords(ctx context.Context, records []Record) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, record := range records {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := w.enc.Encode(record); err != nil {
+			return fmt.Errorf("failed to write %s/%s: %w", record.Repository, record.Path, err)
+		}
+	}
+	return nil
+}
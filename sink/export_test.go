@@ -0,0 +1,100 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingWriter is an io.Writer failing every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestFileRecord(t *testing.T) {
+	file := document.File{
+		Path:           "docs/index.md",
+		SHA:            "abc",
+		Size:           7,
+		Mode:           document.FileModeRegular,
+		Repository:     "repo1",
+		Branch:         "main",
+		LastModifiedAt: time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600)),
+		URL:            "https://github.com/testowner/repo1/blob/main/docs/index.md",
+	}
+
+	assert.Equal(t, Record{
+		Repository: "repo1",
+		Path:       "docs/index.md",
+		SHA:        "abc",
+		Branch:     "main",
+		URL:        "https://github.com/testowner/repo1/blob/main/docs/index.md",
+		Size:       7,
+		Content:    "# Index",
+		Metadata:   map[string]string{"mode": "100644", "last_modified_at": "2024-03-01T11:00:00Z"},
+	}, FileRecord(file, []byte("# Index")))
+
+	binary := FileRecord(document.File{Path: "logo.png", Mode: document.FileModeRegular}, []byte("\x89PNG\x00"))
+	assert.True(t, binary.Binary)
+	assert.Empty(t, binary.Content)
+
+	assert.Equal(t, Record{Repository: "repo1", Path: "docs/index.md", SHA: "abc", Size: 7, Content: "# Index"},
+		FileContentRecord(document.FileContent{Repository: "repo1", Path: "docs/index.md", Oid: "abc", Text: "# Index", ByteSize: 7}))
+}
+
+func TestJSONLWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewJSONLWriter(&buf)
+
+	err := w.WriteRecords(context.Background(), []Record{
+		{Repository: "repo1", Path: "docs/index.md", SHA: "abc", Size: 7, Content: "# Index <b>"},
+		{Repository: "repo1", Path: "logo.png", Size: 5, Binary: true, Metadata: map[string]string{"mode": "100644"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, w.WriteRecords(context.Background(), nil))
+
+	assert.Equal(t, `{"repository":"repo1","path":"docs/index.md","sha":"abc","size":7,"content":"# Index <b>"}
+{"repository":"repo1","path":"logo.png","size":5,"binary":true,"metadata":{"mode":"100644"}}
+`, buf.String())
+
+	err = NewJSONLWriter(failingWriter{}).WriteRecords(context.Background(), []Record{{Repository: "repo1", Path: "a.md"}})
+	assert.EqualError(t, err, "failed to write repo1/a.md: disk full")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = w.WriteRecords(ctx, []Record{{Repository: "repo1", Path: "a.md"}})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := WriterSink(NewJSONLWriter(&buf))
+
+	require.NoError(t, sink.Write(context.Background(), []document.Document{{
+		ID:         "testowner/repo1/docs/guide.md",
+		Kind:       document.DocumentKindFile,
+		Owner:      "testowner",
+		Repository: "repo1",
+		Path:       "docs/guide.md",
+		Title:      "Guide",
+		Body:       "# Guide",
+		UpdatedAt:  time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Metadata:   map[string]string{"sha": "abc", "tags": "a"},
+	}}))
+	assert.JSONEq(t, `{
+		"repository": "testowner/repo1",
+		"path": "docs/guide.md",
+		"sha": "abc",
+		"size": 7,
+		"content": "# Guide",
+		"metadata": {"kind": "file", "title": "Guide", "tags": "a", "updated_at": "2024-03-01T12:00:00Z"}
+	}`, buf.String())
+}
@@ -0,0 +1,490 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// ErrObjectNotFound is returned by ObjectStore.GetObject for keys without an object.
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectStore stores objects under keys, like an S3 or GCS bucket. DeleteObject succeeds for keys without
+// an object. Implementations backed by the SDK of a cloud provider only have to provide these three
+// methods; S3Store talks to S3 compatible APIs directly.
+type ObjectStore interface {
+	GetObject(ctx context.Context, key string) ([]byte, error)
+	PutObject(ctx context.Context, key string, body []byte, contentType string) error
+	DeleteObject(ctx context.Context, key string) error
+}
+
+// MemoryObjectStore is an ObjectStore keeping objects in memory, e.g. for tests. It is safe for concurrent
+// use.
+type MemoryObjectStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+// NewMemoryObjectStore creates an empty MemoryObjectStore.
+func NewMemoryObjectStore() *MemoryObjectStore {
+	return &MemoryObjectStore{objects: make(map[string][]byte)}
+}
+
+// GetObject returns the object stored under the key.
+func (s *MemoryObjectStore) GetObject(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	body, ok := s.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+	}
+	return body, nil
+}
+
+// PutObject stores the object under the key, replacing any previous one.
+func (s *MemoryObjectStore) PutObject(_ context.Context, key string, body []byte, _ string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = append([]byte(nil), body...)
+	return nil
+}
+
+// DeleteObject removes the object stored under the key.
+func (s *MemoryObjectStore) DeleteObject(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, key)
+	return nil
+}
+
+// Keys returns the keys of all stored objects in lexical order.
+func (s *MemoryObjectStore) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// S3Store is an ObjectStore for a bucket of an S3 compatible API, signing its requests with AWS Signature
+// Version 4. Besides Amazon S3 that includes MinIO and the XML API of Google Cloud Storage with HMAC keys.
+//
+// Endpoint is the base URL of the API; objects are addressed path style as Endpoint/Bucket/key. Region is
+// the signing region, "auto" for GCS. HTTPClient sends the requests; nil uses http.DefaultClient.
+type S3Store struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	HTTPClient      *http.Client
+
+	now func() time.Time
+}
+
+// NewS3Store creates an S3Store for an Amazon S3 bucket in the given region.
+func NewS3Store(bucket, region, accessKeyID, secretAccessKey string) *S3Store {
+	return &S3Store{
+		Endpoint:        fmt.Sprintf("https://s3.%s.amazonaws.com", region),
+		Region:          region,
+		Bucket:          bucket,
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+	}
+}
+
+// NewGCSStore creates an S3Store for a Google Cloud Storage bucket, authenticating with the HMAC key of a
+// service account.
+func NewGCSStore(bucket, accessKeyID, secretAccessKey string) *S3Store {
+	return &S3Store{
+		Endpoint:        "https://storage.googleapis.com",
+		Region:          "auto",
+		Bucket:          bucket,
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+	}
+}
+
+// GetObject downloads the object stored under the key.
+func (s *S3Store) GetObject(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+	}
+	if err := s.checkResponse(resp, "get", key); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// PutObject uploads the object under the key.
+func (s *S3Store) PutObject(ctx context.Context, key string, body []byte, contentType string) error {
+	resp, err := s.do(ctx, http.MethodPut, key, body, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return s.checkResponse(resp, "put", key)
+}
+
+// DeleteObject deletes the object stored under the key.
+func (s *S3Store) DeleteObject(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return s.checkResponse(resp, "delete", key)
+}
+
+// do sends a signed request for the object.
+func (s *S3Store) do(ctx context.Context, method, key string, body []byte, contentType string) (*http.Response, error) {
+	endpoint := strings.TrimSuffix(s.Endpoint, "/") + "/" + escapeObjectPath(s.Bucket+"/"+key)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if s.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.SessionToken)
+	}
+
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	signV4(req, body, s.Region, s.AccessKeyID, s.SecretAccessKey, now())
+
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s object %s: %w", strings.ToLower(method), key, err)
+	}
+	return resp, nil
+}
+
+// checkResponse turns unsuccessful responses into errors carrying the error message of the API.
+func (s *S3Store) checkResponse(resp *http.Response, op, key string) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	var apiErr struct {
+		Code    string
+		Message string
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if xmlErr := xml.Unmarshal(body, &apiErr); xmlErr == nil && apiErr.Code != "" {
+		return fmt.Errorf("failed to %s object %s: %s: %s", op, key, apiErr.Code, apiErr.Message)
+	}
+	return fmt.Errorf("failed to %s object %s: %s", op, key, resp.Status)
+}
+
+// escapeObjectPath escapes every segment of an object path as Signature Version 4 expects: everything but
+// letters, digits and "-._~" is percent-encoded.
+func escapeObjectPath(p string) string {
+	var b strings.Builder
+	for _, c := range []byte(p) {
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', strings.IndexByte("-._~/", c) >= 0:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// signV4 signs the request with AWS Signature Version 4 for the s3 service. It signs the host and every
+// header set on the request.
+func signV4(req *http.Request, body []byte, region, accessKeyID, secretAccessKey string, now time.Time) {
+	payloadHash := sha256.Sum256(body)
+	amzDate := now.UTC().Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", amzDate[:8], region)
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(requestHash[:])}, "\n")
+
+	key := []byte("AWS4" + secretAccessKey)
+	for _, part := range []string{amzDate[:8], region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// DefaultObjectKeyLayout is the key layout of an ObjectStoreSink without a configured one.
+const DefaultObjectKeyLayout = "{owner}/{repo}/{branch}/{path}"
+
+// ObjectManifest lists the objects an ObjectStoreSink uploaded by their keys. The sink keeps it up to date
+// in the store, so consumers can find the collected files without listing the bucket.
+type ObjectManifest struct {
+	Objects map[string]ObjectManifestEntry `json:"objects"`
+}
+
+// ObjectManifestEntry describes an uploaded document.
+type ObjectManifestEntry struct {
+	ID         string    `json:"id"`
+	Owner      string    `json:"owner,omitempty"`
+	Repository string    `json:"repository"`
+	Branch     string    `json:"branch,omitempty"`
+	Path       string    `json:"path"`
+	SHA        string    `json:"sha,omitempty"`
+	Size       int       `json:"size"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ObjectStoreSink is a Sink uploading the bodies of the documents it receives, e.g. the files collected
+// through SourceDocuments, to an ObjectStore, together with an ObjectManifest.
+//
+// KeyLayout builds the key of every document from the placeholders {owner}, {repo}, {branch}, {path} and
+// {id}; empty segments are dropped and Prefix is prepended. The branch is read from the "branch" metadata
+// of the document. The manifest is stored under Prefix + "manifest.json" unless ManifestKey is set.
+//
+// Removed files yield no documents, so incremental runs propagate them with Delete:
+//
+//	sink := NewObjectStoreSink(NewGCSStore("coco-docs", accessKeyID, secret))
+//	sink.Prefix = "github/"
+//	changes, err := source.ChangesSince(ctx, since)
+//	...
+//	err = sink.Delete(ctx, changes.Removed)
+//
+// An ObjectStoreSink is safe for concurrent use within a process, but not across processes sharing the
+// manifest.
+type ObjectStoreSink struct {
+	Store       ObjectStore
+	Prefix      string
+	KeyLayout   string
+	ManifestKey string
+
+	mu sync.Mutex
+}
+
+// NewObjectStoreSink creates an ObjectStoreSink uploading to the store with the default key layout.
+func NewObjectStoreSink(store ObjectStore) *ObjectStoreSink {
+	return &ObjectStoreSink{Store: store}
+}
+
+// Write uploads the documents and records them in the manifest.
+func (s *ObjectStoreSink) Write(ctx context.Context, docs []document.Document) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	manifest, err := s.readManifest(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		key := s.key(doc)
+		if err := s.Store.PutObject(ctx, key, []byte(doc.Body), objectContentType(doc.Path)); err != nil {
+			return fmt.Errorf("failed to upload %s: %w", doc.ID, err)
+		}
+		manifest.Objects[key] = ObjectManifestEntry{
+			ID:         doc.ID,
+			Owner:      doc.Owner,
+			Repository: doc.Repository,
+			Branch:     doc.Metadata["branch"],
+			Path:       doc.Path,
+			SHA:        doc.Metadata["sha"],
+			Size:       len(doc.Body),
+			UpdatedAt:  doc.UpdatedAt,
+		}
+	}
+
+	return s.writeManifest(ctx, manifest)
+}
+
+// Delete deletes the objects of removed files and drops them from the manifest. The repository of a change
+// matches both the name and the full name of the repository of an uploaded document.
+func (s *ObjectStoreSink) Delete(ctx context.Context, removed []document.FileChange) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	manifest, err := s.readManifest(ctx)
+	if err != nil {
+		return err
+	}
+
+	deleted := false
+	for _, change := range removed {
+		for key, entry := range manifest.Objects {
+			if entry.Path != change.Path {
+				continue
+			}
+			if change.Repository != entry.Repository && change.Repository != entry.Owner+"/"+entry.Repository {
+				continue
+			}
+			if err := s.Store.DeleteObject(ctx, key); err != nil {
+				return fmt.Errorf("failed to delete %s: %w", key, err)
+			}
+			delete(manifest.Objects, key)
+			deleted = true
+		}
+	}
+
+	if !deleted {
+		return nil
+	}
+	return s.writeManifest(ctx, manifest)
+}
+
+// Manifest returns the manifest currently stored.
+func (s *ObjectStoreSink) Manifest(ctx context.Context) (ObjectManifest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.readManifest(ctx)
+}
+
+// key returns the object key of the document.
+func (s *ObjectStoreSink) key(doc document.Document) string {
+	owner, repo := doc.Owner, doc.Repository
+	if owner == "" {
+		if i := strings.LastIndex(repo, "/"); i >= 0 {
+			owner, repo = repo[:i], repo[i+1:]
+		}
+	}
+
+	layout := s.KeyLayout
+	if layout == "" {
+		layout = DefaultObjectKeyLayout
+	}
+	key := strings.NewReplacer(
+		"{owner}", owner,
+		"{repo}", repo,
+		"{branch}", doc.Metadata["branch"],
+		"{path}", strings.TrimPrefix(doc.Path, "/"),
+		"{id}", doc.ID,
+	).Replace(layout)
+
+	var segments []string
+	for _, segment := range strings.Split(key, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return s.Prefix + strings.Join(segments, "/")
+}
+
+// manifestKey returns the key of the manifest.
+func (s *ObjectStoreSink) manifestKey() string {
+	if s.ManifestKey != "" {
+		return s.ManifestKey
+	}
+	return s.Prefix + "manifest.json"
+}
+
+// readManifest reads the manifest from the store. A missing manifest lists no objects.
+func (s *ObjectStoreSink) readManifest(ctx context.Context) (ObjectManifest, error) {
+	manifest := ObjectManifest{Objects: make(map[string]ObjectManifestEntry)}
+
+	data, err := s.Store.GetObject(ctx, s.manifestKey())
+	if errors.Is(err, ErrObjectNotFound) {
+		return manifest, nil
+	}
+	if err != nil {
+		return ObjectManifest{}, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return ObjectManifest{}, fmt.Errorf("failed to read manifest %s: %w", s.manifestKey(), err)
+	}
+	if manifest.Objects == nil {
+		manifest.Objects = make(map[string]ObjectManifestEntry)
+	}
+	return manifest, nil
+}
+
+// writeManifest stores the manifest.
+func (s *ObjectStoreSink) writeManifest(ctx context.Context, manifest ObjectManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := s.Store.PutObject(ctx, s.manifestKey(), append(data, '\n'), "application/json"); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// objectContentType returns the content type to upload a file with. Documents hold text, so every file is
+// uploaded as text unless its extension says otherwise.
+func objectContentType(filePath string) string {
+	switch {
+	case strings.HasSuffix(filePath, ".json"):
+		return "application/json"
+	case strings.HasSuffix(filePath, ".md"):
+		return "text/markdown; charset=utf-8"
+	case strings.HasSuffix(filePath, ".html"), strings.HasSuffix(filePath, ".htm"):
+		return "text/html; charset=utf-8"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
@@ -0,0 +1,12 @@
+package sink
+
+import (
+	"context"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// Sink receives the documents of a collection, e.g. to index or store them.
+type Sink interface {
+	Write(ctx context.Context, docs []document.Document) error
+}
@@ -0,0 +1,191 @@
+package sink
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/github"
+	"github.com/shaharia-lab/coco-gh/v2/internal/gitutil"
+	"github.com/shaharia-lab/coco-gh/v2/internal/timeutil"
+)
+
+// The schema of a SQLiteSink. files holds the current content of every collected file, changes the history
+// of additions, modifications and removals across runs.
+const (
+	sqliteCreateFiles = `CREATE TABLE IF NOT EXISTS files (
+	repository TEXT NOT NULL,
+	path       TEXT NOT NULL,
+	owner      TEXT NOT NULL DEFAULT '',
+	sha        TEXT NOT NULL,
+	content    TEXT NOT NULL,
+	url        TEXT NOT NULL DEFAULT '',
+	updated_at TEXT NOT NULL DEFAULT '',
+	synced_at  TEXT NOT NULL,
+	PRIMARY KEY (repository, path)
+)`
+	sqliteCreateChanges = `CREATE TABLE IF NOT EXISTS changes (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	repository TEXT NOT NULL,
+	path       TEXT NOT NULL,
+	change     TEXT NOT NULL,
+	sha        TEXT NOT NULL DEFAULT '',
+	synced_at  TEXT NOT NULL
+)`
+	sqliteSelectSHA  = `SELECT sha FROM files WHERE repository = ? AND path = ?`
+	sqliteUpsertFile = `INSERT INTO files (repository, path, owner, sha, content, url, updated_at, synced_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (repository, path) DO UPDATE SET owner = excluded.owner, sha = excluded.sha,
+	content = excluded.content, url = excluded.url, updated_at = excluded.updated_at, synced_at = excluded.synced_at`
+	sqliteDeleteFile    = `DELETE FROM files WHERE repository = ? AND path = ?`
+	sqliteInsertChange  = `INSERT INTO changes (repository, path, change, sha, synced_at) VALUES (?, ?, ?, ?, ?)`
+	sqliteSelectFileSHA = `SELECT repository, path, sha FROM files`
+)
+
+// The values of the change column of the changes table.
+const (
+	SQLiteChangeAdded    = "added"
+	SQLiteChangeModified = "modified"
+	SQLiteChangeRemoved  = "removed"
+)
+
+// SQLiteSink is a Sink persisting the documents it receives, e.g. the files collected through
+// SourceDocuments, in a SQLite database: the files table holds the content and blob SHA of every file, the
+// changes table a row for every file added, modified or removed by a run. Documents whose blob SHA did not
+// change are skipped. The blob SHA is read from the "sha" metadata of a document, or computed from its body.
+//
+// The database is opened by the caller with the SQLite driver of their choice, e.g. mattn/go-sqlite3 or
+// modernc.org/sqlite, and can be queried directly:
+//
+//	db, err := sql.Open("sqlite", "coco.db")
+//	...
+//	sink, err := NewSQLiteSink(ctx, db)
+//	...
+//	rows, err := db.QueryContext(ctx, `SELECT path FROM changes WHERE change = 'removed' AND synced_at > ?`, since)
+//
+// Removed files yield no documents, so incremental runs propagate them with Delete. Snapshot returns the
+// stored blob SHAs, which Snapshot.Diff compares with the current state of the repositories in one step.
+// Times are stored as RFC 3339 text in UTC. Clock is the source of the sync time; nil uses the wall clock.
+type SQLiteSink struct {
+	DB    *sql.DB
+	Clock github.Clock
+}
+
+// NewSQLiteSink creates a SQLiteSink writing to db, creating its tables if they do not exist yet.
+func NewSQLiteSink(ctx context.Context, db *sql.DB) (*SQLiteSink, error) {
+	for _, stmt := range []string{sqliteCreateFiles, sqliteCreateChanges} {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return nil, fmt.Errorf("failed to create the SQLite schema: %w", err)
+		}
+	}
+	return &SQLiteSink{DB: db}, nil
+}
+
+// Write stores the documents and records the files they add or modify, in a single transaction.
+func (s *SQLiteSink) Write(ctx context.Context, docs []document.Document) error {
+	syncedAt := formatSQLiteTime(timeutil.Now(s.Clock))
+
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		for _, doc := range docs {
+			sha := doc.Metadata["sha"]
+			if sha == "" {
+				sha = gitutil.BlobSHA([]byte(doc.Body))
+			}
+
+			var previous string
+			err := tx.QueryRowContext(ctx, sqliteSelectSHA, doc.Repository, doc.Path).Scan(&previous)
+			change := SQLiteChangeModified
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				change = SQLiteChangeAdded
+			case err != nil:
+				return fmt.Errorf("failed to read %s/%s: %w", doc.Repository, doc.Path, err)
+			case previous == sha:
+				continue
+			}
+
+			if _, err := tx.ExecContext(ctx, sqliteUpsertFile, doc.Repository, doc.Path, doc.Owner, sha, doc.Body, doc.URL,
+				formatSQLiteTime(doc.UpdatedAt), syncedAt); err != nil {
+				return fmt.Errorf("failed to store %s/%s: %w", doc.Repository, doc.Path, err)
+			}
+			if _, err := tx.ExecContext(ctx, sqliteInsertChange, doc.Repository, doc.Path, change, sha, syncedAt); err != nil {
+				return fmt.Errorf("failed to record the change of %s/%s: %w", doc.Repository, doc.Path, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Delete removes the files of the changes and records their removal, in a single transaction. Files that
+// are not stored are ignored.
+func (s *SQLiteSink) Delete(ctx context.Context, removed []document.FileChange) error {
+	syncedAt := formatSQLiteTime(timeutil.Now(s.Clock))
+
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		for _, change := range removed {
+			result, err := tx.ExecContext(ctx, sqliteDeleteFile, change.Repository, change.Path)
+			if err != nil {
+				return fmt.Errorf("failed to delete %s/%s: %w", change.Repository, change.Path, err)
+			}
+			if n, err := result.RowsAffected(); err != nil || n == 0 {
+				continue
+			}
+			if _, err := tx.ExecContext(ctx, sqliteInsertChange, change.Repository, change.Path, SQLiteChangeRemoved, "", syncedAt); err != nil {
+				return fmt.Errorf("failed to record the removal of %s/%s: %w", change.Repository, change.Path, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Snapshot returns the blob SHAs of the stored files.
+func (s *SQLiteSink) Snapshot(ctx context.Context) (document.Snapshot, error) {
+	rows, err := s.DB.QueryContext(ctx, sqliteSelectFileSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the stored files: %w", err)
+	}
+	defer rows.Close()
+
+	snapshot := make(document.Snapshot)
+	for rows.Next() {
+		var repo, filePath, sha string
+		if err := rows.Scan(&repo, &filePath, &sha); err != nil {
+			return nil, fmt.Errorf("failed to read the stored files: %w", err)
+		}
+		if snapshot[repo] == nil {
+			snapshot[repo] = make(map[string]string)
+		}
+		snapshot[repo][filePath] = sha
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the stored files: %w", err)
+	}
+	return snapshot, nil
+}
+
+// inTx runs fn in a transaction, committing it if fn succeeds and rolling it back otherwise.
+func (s *SQLiteSink) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin a transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit the transaction: %w", err)
+	}
+	return nil
+}
+
+// formatSQLiteTime formats a time as stored in the database; the zero time is stored as an empty string.
+func formatSQLiteTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,118 @@
+//go:build cgo
+
+package sink
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/internal/gitutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openSQLite opens a SQLite database in a temporary file. The driver needs cgo, so the tests of SQLiteSink
+// only run where it is enabled.
+func openSQLite(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "coco.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// changeRows returns the repository, path and change of every recorded change, in the order they were
+// recorded.
+func changeRows(t *testing.T, db *sql.DB) [][3]string {
+	rows, err := db.Query(`SELECT repository, path, change FROM changes ORDER BY id`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var result [][3]string
+	for rows.Next() {
+		var row [3]string
+		require.NoError(t, rows.Scan(&row[0], &row[1], &row[2]))
+		result = append(result, row)
+	}
+	require.NoError(t, rows.Err())
+	return result
+}
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestSQLiteSink(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	sink, err := NewSQLiteSink(ctx, db)
+	require.NoError(t, err)
+	sink.Clock = fixedClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	require.NoError(t, sink.Write(ctx, []document.Document{
+		{Owner: "testowner", Repository: "repo1", Path: "README.md", Body: "# Repo 1", Metadata: map[string]string{"sha": "abc"}},
+		{Owner: "testowner", Repository: "repo1", Path: "docs/index.md", Body: "# Index",
+			UpdatedAt: time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)},
+	}))
+	var row [8]string
+	require.NoError(t, db.QueryRow(`SELECT * FROM files WHERE path = 'docs/index.md'`).Scan(
+		&row[0], &row[1], &row[2], &row[3], &row[4], &row[5], &row[6], &row[7]))
+	assert.Equal(t, [8]string{"repo1", "docs/index.md", "testowner", gitutil.BlobSHA([]byte("# Index")), "# Index", "",
+		"2024-02-01T09:00:00Z", "2024-03-01T12:00:00Z"}, row)
+
+	// Running the schema again keeps the stored files.
+	_, err = NewSQLiteSink(ctx, db)
+	require.NoError(t, err)
+
+	// Unchanged files are skipped, changed ones recorded as modified.
+	require.NoError(t, sink.Write(ctx, []document.Document{
+		{Repository: "repo1", Path: "README.md", Body: "# Repo 1", Metadata: map[string]string{"sha": "abc"}},
+		{Repository: "repo1", Path: "docs/index.md", Body: "# New index"},
+	}))
+	require.NoError(t, sink.Delete(ctx, []document.FileChange{
+		{Repository: "repo1", Path: "README.md"},
+		{Repository: "repo1", Path: "unknown.md"},
+	}))
+
+	assert.Equal(t, [][3]string{
+		{"repo1", "README.md", SQLiteChangeAdded},
+		{"repo1", "docs/index.md", SQLiteChangeAdded},
+		{"repo1", "docs/index.md", SQLiteChangeModified},
+		{"repo1", "README.md", SQLiteChangeRemoved},
+	}, changeRows(t, db))
+
+	snapshot, err := sink.Snapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, document.Snapshot{"repo1": {"docs/index.md": gitutil.BlobSHA([]byte("# New index"))}}, snapshot)
+
+	paths := snapshot.Diff(document.Snapshot{"repo1": {"docs/index.md": gitutil.BlobSHA([]byte("# New index")), "a.md": "def"}})
+	assert.Equal(t, []string{"a.md"}, paths.Added)
+}
+
+func TestSQLiteSink_Rollback(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	sink, err := NewSQLiteSink(ctx, db)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`CREATE TRIGGER fail BEFORE INSERT ON files WHEN NEW.path = 'fail.md'
+BEGIN SELECT RAISE(ABORT, 'disk I/O error'); END`)
+	require.NoError(t, err)
+
+	err = sink.Write(ctx, []document.Document{
+		{Repository: "repo1", Path: "README.md", Body: "# Repo 1"},
+		{Repository: "repo1", Path: "fail.md", Body: "fail"},
+	})
+	assert.EqualError(t, err, "failed to store repo1/fail.md: disk I/O error")
+
+	snapshot, err := sink.Snapshot(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, snapshot)
+	assert.Empty(t, changeRows(t, db))
+}
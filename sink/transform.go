@@ -0,0 +1,41 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+)
+
+// TransformSink returns a Sink applying the transformers to every document before writing it to sink, for
+// documents written without a Collector, which applies its Transformers itself.
+//
+// Usage:
+//
+//	sink := TransformSink(NewObjectStoreSink(store), document.NormalizeLineEndings(), document.RedactSecrets())
+//	err := sink.Write(ctx, docs)
+func TransformSink(sink Sink, transformers ...document.Transformer) Sink {
+	return transformSink{sink: sink, transformer: document.ChainTransformers(transformers...)}
+}
+
+type transformSink struct {
+	sink        Sink
+	transformer document.Transformer
+}
+
+// Write transforms the documents and writes them to the wrapped sink.
+func (s transformSink) Write(ctx context.Context, docs []document.Document) error {
+	transformed := make([]document.Document, 0, len(docs))
+	for _, doc := range docs {
+		result, err := s.transformer.Transform(ctx, doc)
+		if errors.Is(err, document.ErrSkipDocument) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to transform document %s: %w", doc.ID, err)
+		}
+		transformed = append(transformed, result)
+	}
+	return s.sink.Write(ctx, transformed)
+}
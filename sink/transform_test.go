@@ -0,0 +1,40 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sinkStub collects the documents written to it.
+type sinkStub struct {
+	docs []document.Document
+}
+
+func (s *sinkStub) Write(_ context.Context, docs []document.Document) error {
+	s.docs = append(s.docs, docs...)
+	return nil
+}
+
+func TestTransformSink(t *testing.T) {
+	upper := document.TransformerFunc(func(_ context.Context, doc document.Document) (document.Document, error) {
+		doc.Body = strings.ToUpper(doc.Body)
+		return doc, nil
+	})
+	failing := document.TransformerFunc(func(context.Context, document.Document) (document.Document, error) {
+		return document.Document{}, errors.New("boom")
+	})
+
+	sink := &sinkStub{}
+	require.NoError(t, TransformSink(sink, upper).Write(context.Background(), []document.Document{{ID: "1", Body: "a"}, {ID: "2", Body: "b"}}))
+	assert.Equal(t, []document.Document{{ID: "1", Body: "A"}, {ID: "2", Body: "B"}}, sink.docs)
+
+	err := TransformSink(sink, failing).Write(context.Background(), []document.Document{{ID: "3", Body: "c"}})
+	assert.EqualError(t, err, "failed to transform document 3: boom")
+	assert.Len(t, sink.docs, 2)
+}
@@ -0,0 +1,103 @@
+package cocogh
+
+import (
+	"net/http"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/source"
+)
+
+// Bitbucket is an alias of source.Bitbucket.
+//
+// Deprecated: use source.Bitbucket instead.
+type Bitbucket = source.Bitbucket
+
+// BitbucketConfig is an alias of source.BitbucketConfig.
+//
+// Deprecated: use source.BitbucketConfig instead.
+type BitbucketConfig = source.BitbucketConfig
+
+// ContentSource is an alias of source.ContentSource.
+//
+// Deprecated: use source.ContentSource instead.
+type ContentSource = source.ContentSource
+
+// ContentSourceFunc is an alias of source.ContentSourceFunc.
+//
+// Deprecated: use source.ContentSourceFunc instead.
+type ContentSourceFunc = source.ContentSourceFunc
+
+// GitLab is an alias of source.GitLab.
+//
+// Deprecated: use source.GitLab instead.
+type GitLab = source.GitLab
+
+// GitLabConfig is an alias of source.GitLabConfig.
+//
+// Deprecated: use source.GitLabConfig instead.
+type GitLabConfig = source.GitLabConfig
+
+// Gitea is an alias of source.Gitea.
+//
+// Deprecated: use source.Gitea instead.
+type Gitea = source.Gitea
+
+// GiteaConfig is an alias of source.GiteaConfig.
+//
+// Deprecated: use source.GiteaConfig instead.
+type GiteaConfig = source.GiteaConfig
+
+// LocalSource is an alias of source.LocalSource.
+//
+// Deprecated: use source.LocalSource instead.
+type LocalSource = source.LocalSource
+
+// Source is an alias of source.Source.
+//
+// Deprecated: use source.Source instead.
+type Source = source.Source
+
+// DefaultBitbucketURL is source.DefaultBitbucketURL.
+//
+// Deprecated: use source.DefaultBitbucketURL instead.
+const DefaultBitbucketURL = source.DefaultBitbucketURL
+
+// DefaultGitLabURL is source.DefaultGitLabURL.
+//
+// Deprecated: use source.DefaultGitLabURL instead.
+const DefaultGitLabURL = source.DefaultGitLabURL
+
+// ChunkDocuments calls source.ChunkDocuments.
+//
+// Deprecated: use source.ChunkDocuments instead.
+func ChunkDocuments(src source.ContentSource, chunker document.Chunker) source.ContentSource {
+	return source.ChunkDocuments(src, chunker)
+}
+
+// NewBitbucket calls source.NewBitbucket.
+//
+// Deprecated: use source.NewBitbucket instead.
+func NewBitbucket(httpClient *http.Client, configuration source.BitbucketConfig) *source.Bitbucket {
+	return source.NewBitbucket(httpClient, configuration)
+}
+
+// NewGitLab calls source.NewGitLab.
+//
+// Deprecated: use source.NewGitLab instead.
+func NewGitLab(httpClient *http.Client, configuration source.GitLabConfig) *source.GitLab {
+	return source.NewGitLab(httpClient, configuration)
+}
+
+// NewGitea calls source.NewGitea.
+//
+// Deprecated: use source.NewGitea instead.
+func NewGitea(httpClient *http.Client, configuration source.GiteaConfig) *source.Gitea {
+	return source.NewGitea(httpClient, configuration)
+}
+
+// SourceDocuments calls source.SourceDocuments.
+//
+// Deprecated: use source.SourceDocuments instead.
+func SourceDocuments(src source.Source) source.ContentSource {
+	return source.SourceDocuments(src)
+}
@@ -0,0 +1,438 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shaharia-lab/coco-gh/v2/internal/gitutil"
+)
+
+// DefaultBitbucketURL is the address of the Bitbucket Cloud API, used when BitbucketConfig.BaseURL is empty.
+const DefaultBitbucketURL = "https://api.bitbucket.org/2.0"
+
+// bitbucketWebURL is the address of the web interface of Bitbucket Cloud.
+const bitbucketWebURL = "https://bitbucket.org"
+
+// BitbucketConfig represents the configuration for Bitbucket Cloud repositories.
+//
+// BaseURL is the address of the API; empty uses api.bitbucket.org. Token is a repository, project or
+// workspace access token sent as bearer token; alternatively Username and AppPassword authenticate with an
+// app password. Without either only public repositories can be read. Repositories are the full names of the
+// repositories to collect from, "workspace/repo_slug". Branch is the branch to read, empty uses the main
+// branch of every repository. Filter selects files like it does for GitHub, except for the options relying
+// on .gitattributes, which are ignored.
+type BitbucketConfig struct {
+	BaseURL      string
+	Token        string
+	Username     string
+	AppPassword  string
+	Repositories []string
+	Branch       string
+	Filter       filter.GitHubFilter
+}
+
+// Bitbucket is a Source reading Bitbucket Cloud repositories through the Bitbucket REST API.
+type Bitbucket struct {
+	Configuration BitbucketConfig
+
+	httpClient *http.Client
+
+	branchesMu sync.Mutex
+	branches   map[string]string
+}
+
+// NewBitbucket creates a Bitbucket source. A nil httpClient uses http.DefaultClient.
+//
+// Usage:
+//
+//	bb := NewBitbucket(nil, BitbucketConfig{
+//	    Token:        os.Getenv("BITBUCKET_TOKEN"),
+//	    Repositories: []string{"workspace/repo"},
+//	    Filter:       filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+//	})
+//
+//	files, err := bb.ListFiles(ctx)
+func NewBitbucket(httpClient *http.Client, configuration BitbucketConfig) *Bitbucket {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Bitbucket{Configuration: configuration, httpClient: httpClient}
+}
+
+// bitbucketPage is a page of a paginated Bitbucket API resource. Next is the address of the next page,
+// empty on the last page.
+type bitbucketPage struct {
+	Values json.RawMessage `json:"values"`
+	Next   string          `json:"next"`
+}
+
+// bitbucketTreeEntry is a file or directory as returned by the Bitbucket source API.
+type bitbucketTreeEntry struct {
+	Type       string   `json:"type"`
+	Path       string   `json:"path"`
+	Size       int      `json:"size"`
+	Attributes []string `json:"attributes"`
+}
+
+// bitbucketCommit is a commit as returned by the Bitbucket API.
+type bitbucketCommit struct {
+	Hash    string    `json:"hash"`
+	Date    time.Time `json:"date"`
+	Message string    `json:"message"`
+	Author  struct {
+		Raw  string `json:"raw"`
+		User struct {
+			DisplayName string `json:"display_name"`
+		} `json:"user"`
+	} `json:"author"`
+}
+
+// bitbucketDiffStat is a file changed by a commit as returned by the Bitbucket API. Old is nil for added
+// files, New for removed ones.
+type bitbucketDiffStat struct {
+	Status string `json:"status"`
+	Old    *struct {
+		Path string `json:"path"`
+	} `json:"old"`
+	New *struct {
+		Path string `json:"path"`
+	} `json:"new"`
+}
+
+// ListFiles implements Source. The Bitbucket API does not report blob SHAs, so SHA is empty.
+func (b *Bitbucket) ListFiles(ctx context.Context) ([]document.File, error) {
+	var files []document.File
+	for _, repo := range b.Configuration.Repositories {
+		branch, err := b.branch(ctx, repo)
+		if err != nil {
+			return nil, err
+		}
+
+		repoFiles, err := b.listFiles(ctx, repo, branch, strings.Trim(b.Configuration.Filter.FilePath, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the files of %s: %w", repo, err)
+		}
+		files = append(files, repoFiles...)
+	}
+	return files, nil
+}
+
+// listFiles lists the files passing the filter below dir, walking the directories depth first.
+func (b *Bitbucket) listFiles(ctx context.Context, repo, branch, dir string) ([]document.File, error) {
+	var files []document.File
+	var dirs []string
+	u := b.srcURL(repo, branch, dir)
+	if dir != "" {
+		u += "/"
+	}
+	err := b.list(ctx, u, func(values json.RawMessage) error {
+		var entries []bitbucketTreeEntry
+		if err := json.Unmarshal(values, &entries); err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			if entry.Type == "commit_directory" {
+				dirs = append(dirs, entry.Path)
+				continue
+			}
+			if entry.Type != "commit_file" || !b.Configuration.Filter.Match(entry.Path) {
+				continue
+			}
+
+			mode := document.FileModeRegular
+			for _, attribute := range entry.Attributes {
+				switch attribute {
+				case "executable":
+					mode = document.FileModeExecutable
+				case "link":
+					mode = document.FileModeSymlink
+				case "subrepository":
+					mode = document.FileModeSubmodule
+				}
+			}
+			files = append(files, document.File{
+				Path:       entry.Path,
+				Size:       entry.Size,
+				Mode:       mode,
+				Repository: repo,
+				Branch:     branch,
+				URL:        fmt.Sprintf("%s/%s/src/%s/%s", bitbucketWebURL, repo, branch, entry.Path),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sub := range dirs {
+		subFiles, err := b.listFiles(ctx, repo, branch, sub)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, subFiles...)
+	}
+	return files, nil
+}
+
+// GetContent implements Source. The configured filter is not applied; Oid is the blob SHA computed from
+// the content.
+func (b *Bitbucket) GetContent(ctx context.Context, repo, filePath string) (document.FileContent, error) {
+	branch, err := b.branch(ctx, repo)
+	if err != nil {
+		return document.FileContent{}, err
+	}
+
+	resp, err := b.do(ctx, b.srcURL(repo, branch, filePath))
+	if err != nil {
+		return document.FileContent{}, fmt.Errorf("failed to read %s/%s: %w", repo, filePath, err)
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return document.FileContent{}, fmt.Errorf("failed to read %s/%s: %w", repo, filePath, err)
+	}
+
+	result := document.FileContent{
+		Repository: repo,
+		Path:       filePath,
+		Oid:        gitutil.BlobSHA(content),
+		ByteSize:   len(content),
+		IsBinary:   bytes.IndexByte(content, 0) >= 0,
+	}
+	if !result.IsBinary {
+		result.Text = string(content)
+	}
+	return result, nil
+}
+
+// ChangesSince implements Source. The changes of every commit are compared to its first parent.
+func (b *Bitbucket) ChangesSince(ctx context.Context, since time.Time) (document.ChangeSet, error) {
+	var changes document.ChangeSet
+	for _, repo := range b.Configuration.Repositories {
+		events, err := b.listChangeEvents(ctx, repo, since)
+		if err != nil {
+			return document.ChangeSet{}, fmt.Errorf("failed to list the changes of %s: %w", repo, err)
+		}
+
+		repoChanges := document.NewChangeSet(events)
+		changes.Added = append(changes.Added, repoChanges.Added...)
+		changes.Removed = append(changes.Removed, repoChanges.Removed...)
+		changes.Modified = append(changes.Modified, repoChanges.Modified...)
+	}
+	return changes, nil
+}
+
+// listChangeEvents lists the changes of the files passing the filter by the commits on the branch since the
+// given time, newest first. Bitbucket lists commits newest first without a time filter, so the listing stops
+// at the first older commit.
+func (b *Bitbucket) listChangeEvents(ctx context.Context, repo string, since time.Time) ([]document.ChangeEvent, error) {
+	branch, err := b.branch(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []bitbucketCommit
+	err = b.list(ctx, b.apiURL(repo, "/commits/"+url.PathEscape(branch)), func(values json.RawMessage) error {
+		var page []bitbucketCommit
+		if err := json.Unmarshal(values, &page); err != nil {
+			return err
+		}
+
+		for _, commit := range page {
+			if commit.Date.Before(since) {
+				return errStopListing
+			}
+			commits = append(commits, commit)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var events []document.ChangeEvent
+	for _, commit := range commits {
+		author := commit.Author.User.DisplayName
+		if author == "" {
+			author = commit.Author.Raw
+		}
+
+		err := b.list(ctx, b.apiURL(repo, "/diffstat/"+commit.Hash), func(values json.RawMessage) error {
+			var stats []bitbucketDiffStat
+			if err := json.Unmarshal(values, &stats); err != nil {
+				return err
+			}
+
+			for _, stat := range stats {
+				event := document.ChangeEvent{
+					Repository:  repo,
+					CommitSHA:   commit.Hash,
+					CommittedAt: commit.Date,
+					Author:      author,
+					Message:     strings.TrimSpace(commit.Message),
+					Status:      stat.Status,
+				}
+				switch {
+				case stat.Status == "removed" && stat.Old != nil:
+					event.Path = stat.Old.Path
+				case stat.New != nil:
+					event.Path = stat.New.Path
+					if stat.Status == "renamed" && stat.Old != nil {
+						event.PreviousPath = stat.Old.Path
+					}
+				}
+				if stat.Status != "added" && stat.Status != "removed" && stat.Status != "renamed" {
+					event.Status = "modified"
+				}
+
+				if event.Path == "" {
+					continue
+				}
+				if event, ok := event.Filter(b.Configuration.Filter.Match); ok {
+					events = append(events, event)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// branch returns the configured branch, or the main branch of the repository. Main branches are cached for
+// the lifetime of the source.
+func (b *Bitbucket) branch(ctx context.Context, repo string) (string, error) {
+	if b.Configuration.Branch != "" {
+		return b.Configuration.Branch, nil
+	}
+
+	b.branchesMu.Lock()
+	branch, ok := b.branches[repo]
+	b.branchesMu.Unlock()
+	if ok {
+		return branch, nil
+	}
+
+	var info struct {
+		MainBranch struct {
+			Name string `json:"name"`
+		} `json:"mainbranch"`
+	}
+	if err := b.get(ctx, b.apiURL(repo, ""), &info); err != nil {
+		return "", fmt.Errorf("failed to detect the main branch of %s: %w", repo, err)
+	}
+	if info.MainBranch.Name == "" {
+		return "", fmt.Errorf("failed to detect the main branch of %s: the repository is empty", repo)
+	}
+
+	b.branchesMu.Lock()
+	defer b.branchesMu.Unlock()
+	if b.branches == nil {
+		b.branches = make(map[string]string)
+	}
+	b.branches[repo] = info.MainBranch.Name
+	return info.MainBranch.Name, nil
+}
+
+// errStopListing stops a listing early without an error.
+var errStopListing = errors.New("stop listing")
+
+// list calls fn with the values of every page of a paginated API resource, until fn returns errStopListing.
+func (b *Bitbucket) list(ctx context.Context, u string, fn func(values json.RawMessage) error) error {
+	if strings.Contains(u, "?") {
+		u += "&pagelen=100"
+	} else {
+		u += "?pagelen=100"
+	}
+
+	for u != "" {
+		var page bitbucketPage
+		if err := b.get(ctx, u, &page); err != nil {
+			return err
+		}
+		if err := fn(page.Values); errors.Is(err, errStopListing) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		u = page.Next
+	}
+	return nil
+}
+
+// get reads a single API resource into v.
+func (b *Bitbucket) get(ctx context.Context, u string, v interface{}) error {
+	resp, err := b.do(ctx, u)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// do sends an authenticated GET request. The caller has to close the body of the response.
+func (b *Bitbucket) do(ctx context.Context, u string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case b.Configuration.Token != "":
+		req.Header.Set("Authorization", "Bearer "+b.Configuration.Token)
+	case b.Configuration.Username != "":
+		req.SetBasicAuth(b.Configuration.Username, b.Configuration.AppPassword)
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code: %s", resp.Status)
+	}
+	return resp, nil
+}
+
+// apiURL returns the address of a repository resource.
+func (b *Bitbucket) apiURL(repo, resource string) string {
+	return b.baseURL() + "/repositories/" + repo + resource
+}
+
+// srcURL returns the address of a file or directory of the repository at the branch.
+func (b *Bitbucket) srcURL(repo, branch, filePath string) string {
+	return b.apiURL(repo, "/src/"+url.PathEscape(branch)+"/"+escapePathSegments(filePath))
+}
+
+// baseURL returns the configured address of the API without a trailing slash.
+func (b *Bitbucket) baseURL() string {
+	if b.Configuration.BaseURL == "" {
+		return DefaultBitbucketURL
+	}
+	return strings.TrimSuffix(b.Configuration.BaseURL, "/")
+}
+
+// escapePathSegments escapes every segment of a slash separated path for use in a URL path.
+func escapePathSegments(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
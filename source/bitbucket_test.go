@@ -0,0 +1,171 @@
+package source
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shaharia-lab/coco-gh/v2/internal/gitutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBitbucketServer serves the Bitbucket API resources from a map of escaped paths to responses, see
+// newForgeServer.
+func newBitbucketServer(t *testing.T, responses map[string]interface{}) *httptest.Server {
+	return newForgeServer(t, "Authorization", "Bearer secret", bitbucketPaging, responses)
+}
+
+// bitbucketPaging turns the path of the next page paged resources list in "next" into an absolute address.
+func bitbucketPaging(_ http.ResponseWriter, response interface{}, _ int, serverURL string) {
+	if page, ok := response.(map[string]interface{}); ok {
+		if next, ok := page["next"].(string); ok && strings.HasPrefix(next, "/") {
+			page["next"] = serverURL + next
+		}
+	}
+}
+
+func TestBitbucket_ListFilesAndGetContent(t *testing.T) {
+	srv := newBitbucketServer(t, map[string]interface{}{
+		"/repositories/workspace/repo": map[string]interface{}{"mainbranch": map[string]string{"name": "main"}},
+		"/repositories/workspace/repo/src/main/docs/": map[string]interface{}{
+			"values": []bitbucketTreeEntry{
+				{Type: "commit_directory", Path: "docs/guides"},
+				{Type: "commit_file", Path: "docs/index.md", Size: 7},
+			},
+			"next": "/repositories/workspace/repo/src/main/docs/?page=2",
+		},
+		"/repositories/workspace/repo/src/main/docs/?page=2": map[string]interface{}{
+			"values": []bitbucketTreeEntry{{Type: "commit_file", Path: "docs/logo.png", Size: 5}},
+		},
+		"/repositories/workspace/repo/src/main/docs/guides/": map[string]interface{}{
+			"values": []bitbucketTreeEntry{{Type: "commit_file", Path: "docs/guides/run.md", Size: 4, Attributes: []string{"executable"}}},
+		},
+		"/repositories/workspace/repo/src/main/docs/index.md": "# Index",
+	})
+
+	bb := NewBitbucket(srv.Client(), BitbucketConfig{
+		BaseURL:      srv.URL,
+		Token:        "secret",
+		Repositories: []string{"workspace/repo"},
+		Filter:       filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+	})
+
+	files, err := bb.ListFiles(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []document.File{
+		{Path: "docs/index.md", Size: 7, Mode: document.FileModeRegular, Repository: "workspace/repo", Branch: "main", URL: "https://bitbucket.org/workspace/repo/src/main/docs/index.md"},
+		{Path: "docs/guides/run.md", Size: 4, Mode: document.FileModeExecutable, Repository: "workspace/repo", Branch: "main", URL: "https://bitbucket.org/workspace/repo/src/main/docs/guides/run.md"},
+	}, files)
+
+	content, err := bb.GetContent(context.Background(), "workspace/repo", "docs/index.md")
+	require.NoError(t, err)
+	assert.Equal(t, document.FileContent{
+		Repository: "workspace/repo",
+		Path:       "docs/index.md",
+		Oid:        gitutil.BlobSHA([]byte("# Index")),
+		Text:       "# Index",
+		ByteSize:   7,
+	}, content)
+
+	_, err = bb.GetContent(context.Background(), "workspace/repo", "docs/missing.md")
+	assert.EqualError(t, err, "failed to read workspace/repo/docs/missing.md: unexpected status code: 404 Not Found")
+}
+
+func TestBitbucket_ChangesSince(t *testing.T) {
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	commit := func(hash, message, author string, date time.Time) map[string]interface{} {
+		return map[string]interface{}{
+			"hash":    hash,
+			"message": message,
+			"date":    date,
+			"author":  map[string]interface{}{"raw": author + " <" + author + "@example.com>"},
+		}
+	}
+	path := func(p string) map[string]string { return map[string]string{"path": p} }
+
+	srv := newBitbucketServer(t, map[string]interface{}{
+		"/repositories/workspace/repo/commits/develop": map[string]interface{}{
+			"values": []interface{}{
+				commit("second", "Move setup guide\n", "alice", since.Add(2*time.Hour)),
+				commit("first", "Add index", "bob", since.Add(time.Hour)),
+				commit("old", "Before the window", "carol", since.Add(-time.Hour)),
+			},
+			"next": "/repositories/workspace/repo/commits/develop?page=2",
+		},
+		"/repositories/workspace/repo/diffstat/second": map[string]interface{}{
+			"values": []interface{}{
+				map[string]interface{}{"status": "renamed", "old": path("docs/setup.md"), "new": path("docs/guide.md")},
+				map[string]interface{}{"status": "removed", "old": path("docs/old.md")},
+			},
+		},
+		"/repositories/workspace/repo/diffstat/first": map[string]interface{}{
+			"values": []interface{}{
+				map[string]interface{}{"status": "added", "new": path("docs/index.md")},
+				map[string]interface{}{"status": "modified", "old": path("README.md"), "new": path("README.md")},
+				map[string]interface{}{"status": "merge conflict", "old": path("docs/faq.md"), "new": path("docs/faq.md")},
+			},
+		},
+	})
+
+	bb := NewBitbucket(srv.Client(), BitbucketConfig{
+		BaseURL:      srv.URL,
+		Token:        "secret",
+		Repositories: []string{"workspace/repo"},
+		Branch:       "develop",
+		Filter:       filter.GitHubFilter{FilePath: "docs"},
+	})
+
+	changes, err := bb.ChangesSince(context.Background(), since)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/guide.md", "docs/index.md"}, fileChangePaths(changes.Added))
+	assert.Equal(t, []string{"docs/setup.md", "docs/old.md"}, fileChangePaths(changes.Removed))
+	assert.Equal(t, []string{"docs/faq.md"}, fileChangePaths(changes.Modified))
+	assert.Equal(t, document.FileChange{
+		Repository:  "workspace/repo",
+		Path:        "docs/guide.md",
+		CommitSHA:   "second",
+		Author:      "alice <alice@example.com>",
+		CommittedAt: since.Add(2 * time.Hour),
+		Message:     "Move setup guide",
+	}, changes.Added[0])
+}
+
+func TestBitbucket_ChangesSince_RenamesAcrossFilePath(t *testing.T) {
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	path := func(p string) map[string]string { return map[string]string{"path": p} }
+
+	srv := newBitbucketServer(t, map[string]interface{}{
+		"/repositories/workspace/repo/commits/develop": map[string]interface{}{
+			"values": []interface{}{
+				map[string]interface{}{"hash": "move", "message": "Reorganize pages", "date": since.Add(time.Hour)},
+			},
+		},
+		"/repositories/workspace/repo/diffstat/move": map[string]interface{}{
+			"values": []interface{}{
+				map[string]interface{}{"status": "renamed", "old": path("docs-old/setup.md"), "new": path("docs/setup.md")},
+				map[string]interface{}{"status": "renamed", "old": path("docs/faq.md"), "new": path("archive/faq.md")},
+				map[string]interface{}{"status": "modified", "old": path("docs-old/index.md"), "new": path("docs-old/index.md")},
+			},
+		},
+	})
+
+	bb := NewBitbucket(srv.Client(), BitbucketConfig{
+		BaseURL:      srv.URL,
+		Token:        "secret",
+		Repositories: []string{"workspace/repo"},
+		Branch:       "develop",
+		Filter:       filter.GitHubFilter{FilePath: "docs"},
+	})
+
+	changes, err := bb.ChangesSince(context.Background(), since)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/setup.md"}, fileChangePaths(changes.Added))
+	assert.Equal(t, []string{"docs/faq.md"}, fileChangePaths(changes.Removed))
+	assert.Empty(t, changes.Modified)
+}
@@ -0,0 +1,6 @@
+// Package source lists files, reads their content and changes independently of the provider.
+//
+// A Source is implemented by the GitHub client, GitLab, Bitbucket and Gitea, which also serves Forgejo,
+// and by LocalSource for a directory tree. SourceDocuments turns a Source into a ContentSource for a
+// collector, and ChunkDocuments splits collected documents into Chunks with stable IDs for vector stores.
+package source
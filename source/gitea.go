@@ -0,0 +1,290 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shaharia-lab/coco-gh/v2/internal/gitutil"
+)
+
+// giteaPageSize is the number of commits requested per page, the default maximum of Gitea.
+const giteaPageSize = 50
+
+// GiteaConfig represents the configuration for repositories hosted on Gitea or Forgejo.
+//
+// BaseURL is the address of the instance, e.g. "https://gitea.example.com" or "https://codeberg.org". Token
+// is an access token, empty only reads public repositories. Repositories are the full names of the
+// repositories to collect from, "owner/name". Branch is the branch to read, empty uses the default branch of
+// every repository. Filter selects files like it does for GitHub, except for the options relying on
+// .gitattributes, which are ignored.
+type GiteaConfig struct {
+	BaseURL      string
+	Token        string
+	Repositories []string
+	Branch       string
+	Filter       filter.GitHubFilter
+}
+
+// Gitea is a Source reading repositories hosted on Gitea or Forgejo through their REST API, which mostly
+// follows the shape of the GitHub REST API.
+type Gitea struct {
+	Configuration GiteaConfig
+
+	httpClient *http.Client
+
+	branchesMu sync.Mutex
+	branches   map[string]string
+}
+
+// NewGitea creates a Gitea source. A nil httpClient uses http.DefaultClient.
+//
+// Usage:
+//
+//	gt := NewGitea(nil, GiteaConfig{
+//	    BaseURL:      "https://codeberg.org",
+//	    Token:        os.Getenv("GITEA_TOKEN"),
+//	    Repositories: []string{"owner/repo"},
+//	    Filter:       filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+//	})
+//
+//	files, err := gt.ListFiles(ctx)
+func NewGitea(httpClient *http.Client, configuration GiteaConfig) *Gitea {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Gitea{Configuration: configuration, httpClient: httpClient}
+}
+
+// giteaContent is a file as returned by the Gitea contents API.
+type giteaContent struct {
+	SHA      string `json:"sha"`
+	Size     int    `json:"size"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+// ListFiles implements Source.
+func (g *Gitea) ListFiles(ctx context.Context) ([]document.File, error) {
+	var files []document.File
+	for _, repo := range g.Configuration.Repositories {
+		branch, err := g.branch(ctx, repo)
+		if err != nil {
+			return nil, err
+		}
+
+		tree := &github.Tree{}
+		for page := 1; ; page++ {
+			query := url.Values{"recursive": {"true"}, "page": {strconv.Itoa(page)}}
+			// Gitea pages recursive trees and reports them as truncated while more pages follow.
+			var pageTree github.Tree
+			if _, err := g.get(ctx, g.repoPath(repo, "/git/trees/"+url.PathEscape(branch)), query, &pageTree); err != nil {
+				return nil, fmt.Errorf("failed to list the files of %s: %w", repo, err)
+			}
+
+			tree.Entries = append(tree.Entries, pageTree.Entries...)
+			if !pageTree.GetTruncated() || len(pageTree.Entries) == 0 {
+				break
+			}
+		}
+
+		for _, entry := range tree.Entries {
+			if entry.GetType() != "blob" || !g.Configuration.Filter.Match(entry.GetPath()) {
+				continue
+			}
+			mode, _ := strconv.ParseInt(entry.GetMode(), 8, 64)
+			files = append(files, document.File{
+				Path:       entry.GetPath(),
+				SHA:        entry.GetSHA(),
+				Size:       entry.GetSize(),
+				Mode:       document.FileMode(mode),
+				Repository: repo,
+				Branch:     branch,
+				URL:        fmt.Sprintf("%s/%s/src/branch/%s/%s", g.baseURL(), repo, branch, entry.GetPath()),
+			})
+		}
+	}
+	return files, nil
+}
+
+// GetContent implements Source. The configured filter is not applied.
+func (g *Gitea) GetContent(ctx context.Context, repo, filePath string) (document.FileContent, error) {
+	branch, err := g.branch(ctx, repo)
+	if err != nil {
+		return document.FileContent{}, err
+	}
+
+	var file giteaContent
+	apiPath := g.repoPath(repo, "/contents/"+escapePathSegments(filePath))
+	if _, err := g.get(ctx, apiPath, url.Values{"ref": {branch}}, &file); err != nil {
+		return document.FileContent{}, fmt.Errorf("failed to read %s/%s: %w", repo, filePath, err)
+	}
+
+	content := []byte(file.Content)
+	if file.Encoding == "base64" {
+		if content, err = base64.StdEncoding.DecodeString(file.Content); err != nil {
+			return document.FileContent{}, fmt.Errorf("failed to read %s/%s: %w", repo, filePath, err)
+		}
+	}
+
+	result := document.FileContent{
+		Repository: repo,
+		Path:       filePath,
+		Oid:        file.SHA,
+		ByteSize:   file.Size,
+		IsBinary:   bytes.IndexByte(content, 0) >= 0,
+	}
+	if !result.IsBinary {
+		result.Text = string(content)
+	}
+	return result, nil
+}
+
+// ChangesSince implements Source.
+func (g *Gitea) ChangesSince(ctx context.Context, since time.Time) (document.ChangeSet, error) {
+	var changes document.ChangeSet
+	for _, repo := range g.Configuration.Repositories {
+		events, err := g.listChangeEvents(ctx, repo, since)
+		if err != nil {
+			return document.ChangeSet{}, fmt.Errorf("failed to list the changes of %s: %w", repo, err)
+		}
+
+		repoChanges := document.NewChangeSet(events)
+		changes.Added = append(changes.Added, repoChanges.Added...)
+		changes.Removed = append(changes.Removed, repoChanges.Removed...)
+		changes.Modified = append(changes.Modified, repoChanges.Modified...)
+	}
+	return changes, nil
+}
+
+// listChangeEvents lists the changes of the files passing the filter by the commits on the branch since the
+// given time, newest first. Versions of Gitea ignoring the since parameter are covered by stopping at the
+// first older commit.
+func (g *Gitea) listChangeEvents(ctx context.Context, repo string, since time.Time) ([]document.ChangeEvent, error) {
+	branch, err := g.branch(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{
+		"sha":          {branch},
+		"limit":        {strconv.Itoa(giteaPageSize)},
+		"stat":         {"false"},
+		"verification": {"false"},
+		"files":        {"true"},
+	}
+	if !since.IsZero() {
+		query.Set("since", since.Format(time.RFC3339))
+	}
+	if dir := strings.Trim(g.Configuration.Filter.FilePath, "/"); dir != "" {
+		query.Set("path", dir)
+	}
+
+	var events []document.ChangeEvent
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+
+		var commits []*github.RepositoryCommit
+		resp, err := g.get(ctx, g.repoPath(repo, "/commits"), query, &commits)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, commit := range commits {
+			if commit.GetCommit().GetCommitter().GetDate().Time.Before(since) {
+				return events, nil
+			}
+			for _, file := range commit.Files {
+				if event, ok := gitutil.ChangeEvent(repo, commit, file).Filter(g.Configuration.Filter.Match); ok {
+					events = append(events, event)
+				}
+			}
+		}
+
+		if resp.Header.Get("X-HasMore") != "true" || len(commits) == 0 {
+			return events, nil
+		}
+	}
+}
+
+// branch returns the configured branch, or the default branch of the repository. Default branches are
+// cached for the lifetime of the source.
+func (g *Gitea) branch(ctx context.Context, repo string) (string, error) {
+	if g.Configuration.Branch != "" {
+		return g.Configuration.Branch, nil
+	}
+
+	g.branchesMu.Lock()
+	branch, ok := g.branches[repo]
+	g.branchesMu.Unlock()
+	if ok {
+		return branch, nil
+	}
+
+	var info struct {
+		DefaultBranch string `json:"default_branch"`
+		Empty         bool   `json:"empty"`
+	}
+	if _, err := g.get(ctx, g.repoPath(repo, ""), nil, &info); err != nil {
+		return "", fmt.Errorf("failed to detect the default branch of %s: %w", repo, err)
+	}
+	if info.Empty || info.DefaultBranch == "" {
+		return "", fmt.Errorf("failed to detect the default branch of %s: the repository is empty", repo)
+	}
+
+	g.branchesMu.Lock()
+	defer g.branchesMu.Unlock()
+	if g.branches == nil {
+		g.branches = make(map[string]string)
+	}
+	g.branches[repo] = info.DefaultBranch
+	return info.DefaultBranch, nil
+}
+
+// repoPath returns the API path of a repository resource.
+func (g *Gitea) repoPath(repo, resource string) string {
+	return "/repos/" + repo + resource
+}
+
+// baseURL returns the configured address of the instance without a trailing slash.
+func (g *Gitea) baseURL() string {
+	return strings.TrimSuffix(g.Configuration.BaseURL, "/")
+}
+
+// get sends a GET request to the API and decodes the JSON response into v.
+func (g *Gitea) get(ctx context.Context, apiPath string, query url.Values, v interface{}) (*http.Response, error) {
+	u := g.baseURL() + "/api/v1" + apiPath
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if g.Configuration.Token != "" {
+		req.Header.Set("Authorization", "token "+g.Configuration.Token)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, fmt.Errorf("unexpected status code: %s", resp.Status)
+	}
+	return resp, json.NewDecoder(resp.Body).Decode(v)
+}
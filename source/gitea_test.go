@@ -0,0 +1,155 @@
+package source
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGiteaServer serves the Gitea API resources from a map of escaped paths to JSON responses, see
+// newForgeServer.
+func newGiteaServer(t *testing.T, responses map[string]interface{}) *httptest.Server {
+	return newForgeServer(t, "Authorization", "token secret", giteaPaging, responses)
+}
+
+// giteaPaging announces that there is a next page with X-HasMore.
+func giteaPaging(w http.ResponseWriter, _ interface{}, next int, _ string) {
+	if next > 0 {
+		w.Header().Set("X-HasMore", "true")
+	}
+}
+
+func TestGitea_ListFilesAndGetContent(t *testing.T) {
+	srv := newGiteaServer(t, map[string]interface{}{
+		"/api/v1/repos/owner/repo": map[string]interface{}{"default_branch": "main"},
+		"/api/v1/repos/owner/repo/git/trees/main": map[string]interface{}{
+			"truncated": true,
+			"tree": []map[string]interface{}{
+				{"path": "README.md", "type": "blob", "mode": "100644", "sha": "a1", "size": 7},
+				{"path": "docs", "type": "tree", "mode": "040000", "sha": "t1"},
+				{"path": "docs/index.md", "type": "blob", "mode": "100644", "sha": "b1", "size": 7},
+			},
+		},
+		"/api/v1/repos/owner/repo/git/trees/main?page=2": map[string]interface{}{
+			"tree": []map[string]interface{}{
+				{"path": "docs/run.md", "type": "blob", "mode": "100755", "sha": "b2", "size": 4},
+				{"path": "docs/logo.png", "type": "blob", "mode": "100644", "sha": "b3", "size": 5},
+			},
+		},
+		"/api/v1/repos/owner/repo/contents/docs/index.md": giteaContent{SHA: "b1", Size: 7, Encoding: "base64", Content: "IyBJbmRleA=="},
+	})
+
+	gt := NewGitea(srv.Client(), GiteaConfig{
+		BaseURL:      srv.URL + "/",
+		Token:        "secret",
+		Repositories: []string{"owner/repo"},
+		Filter:       filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+	})
+
+	files, err := gt.ListFiles(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []document.File{
+		{Path: "docs/index.md", SHA: "b1", Size: 7, Mode: document.FileModeRegular, Repository: "owner/repo", Branch: "main", URL: srv.URL + "/owner/repo/src/branch/main/docs/index.md"},
+		{Path: "docs/run.md", SHA: "b2", Size: 4, Mode: document.FileModeExecutable, Repository: "owner/repo", Branch: "main", URL: srv.URL + "/owner/repo/src/branch/main/docs/run.md"},
+	}, files)
+
+	content, err := gt.GetContent(context.Background(), "owner/repo", "docs/index.md")
+	require.NoError(t, err)
+	assert.Equal(t, document.FileContent{Repository: "owner/repo", Path: "docs/index.md", Oid: "b1", Text: "# Index", ByteSize: 7}, content)
+
+	_, err = gt.GetContent(context.Background(), "owner/repo", "docs/missing.md")
+	assert.EqualError(t, err, "failed to read owner/repo/docs/missing.md: unexpected status code: 404 Not Found")
+}
+
+func TestGitea_ChangesSince(t *testing.T) {
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	commit := func(sha, message, login string, date time.Time, files ...map[string]string) map[string]interface{} {
+		return map[string]interface{}{
+			"sha":    sha,
+			"author": map[string]string{"login": login},
+			"commit": map[string]interface{}{
+				"message":   message,
+				"committer": map[string]interface{}{"date": date},
+			},
+			"files": files,
+		}
+	}
+	file := func(filename, status string) map[string]string {
+		return map[string]string{"filename": filename, "status": status}
+	}
+
+	srv := newGiteaServer(t, map[string]interface{}{
+		"/api/v1/repos/owner/repo/commits": []interface{}{
+			commit("third", "Remove old page", "alice", since.Add(3*time.Hour), file("docs/old.md", "removed")),
+		},
+		"/api/v1/repos/owner/repo/commits?page=2": []interface{}{
+			commit("second", "Update index", "bob", since.Add(2*time.Hour), file("docs/index.md", "modified"), file("README.md", "modified")),
+			commit("first", "Add guide", "carol", since.Add(time.Hour), file("docs/guide.md", "added")),
+			commit("old", "Before the window", "dave", since.Add(-time.Hour), file("docs/ancient.md", "added")),
+		},
+	})
+
+	gt := NewGitea(srv.Client(), GiteaConfig{
+		BaseURL:      srv.URL,
+		Token:        "secret",
+		Repositories: []string{"owner/repo"},
+		Branch:       "develop",
+		Filter:       filter.GitHubFilter{FilePath: "docs"},
+	})
+
+	changes, err := gt.ChangesSince(context.Background(), since)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/guide.md"}, fileChangePaths(changes.Added))
+	assert.Equal(t, []string{"docs/old.md"}, fileChangePaths(changes.Removed))
+	assert.Equal(t, []string{"docs/index.md"}, fileChangePaths(changes.Modified))
+	assert.Equal(t, document.FileChange{
+		Repository:  "owner/repo",
+		Path:        "docs/old.md",
+		CommitSHA:   "third",
+		Author:      "alice",
+		CommittedAt: since.Add(3 * time.Hour),
+		Message:     "Remove old page",
+	}, changes.Removed[0])
+}
+
+func TestGitea_ChangesSince_RenamesAcrossFilePath(t *testing.T) {
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	file := func(filename, previous, status string) map[string]string {
+		return map[string]string{"filename": filename, "previous_filename": previous, "status": status}
+	}
+
+	srv := newGiteaServer(t, map[string]interface{}{
+		"/api/v1/repos/owner/repo/commits": []interface{}{
+			map[string]interface{}{
+				"sha":    "move",
+				"commit": map[string]interface{}{"message": "Reorganize pages", "committer": map[string]interface{}{"date": since.Add(time.Hour)}},
+				"files": []map[string]string{
+					file("docs/setup.md", "docs-old/setup.md", "renamed"),
+					file("archive/faq.md", "docs/faq.md", "renamed"),
+					file("docs-old/index.md", "", "modified"),
+				},
+			},
+		},
+	})
+
+	gt := NewGitea(srv.Client(), GiteaConfig{
+		BaseURL:      srv.URL,
+		Token:        "secret",
+		Repositories: []string{"owner/repo"},
+		Branch:       "develop",
+		Filter:       filter.GitHubFilter{FilePath: "docs"},
+	})
+
+	changes, err := gt.ChangesSince(context.Background(), since)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/setup.md"}, fileChangePaths(changes.Added))
+	assert.Equal(t, []string{"docs/faq.md"}, fileChangePaths(changes.Removed))
+	assert.Empty(t, changes.Modified)
+}
@@ -0,0 +1,370 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+)
+
+// DefaultGitLabURL is the address of gitlab.com, used when GitLabConfig.BaseURL is empty.
+const DefaultGitLabURL = "https://gitlab.com"
+
+// GitLabConfig represents the configuration for GitLab projects.
+//
+// BaseURL is the address of a self-managed GitLab instance, e.g. "https://gitlab.example.com"; empty uses
+// gitlab.com. Token is a personal, project or group access token, empty only reads public projects. Projects
+// are the full paths of the projects to collect from, e.g. "group/subgroup/project". Branch is the branch
+// to read, empty uses the default branch of every project. Filter selects files like it does for GitHub,
+// except for the options relying on .gitattributes, which are ignored.
+type GitLabConfig struct {
+	BaseURL  string
+	Token    string
+	Projects []string
+	Branch   string
+	Filter   filter.GitHubFilter
+}
+
+// GitLab is a Source reading the repositories of GitLab projects through the GitLab REST API.
+type GitLab struct {
+	Configuration GitLabConfig
+
+	httpClient *http.Client
+
+	branchesMu sync.Mutex
+	branches   map[string]string
+}
+
+// NewGitLab creates a GitLab source. A nil httpClient uses http.DefaultClient.
+//
+// Usage:
+//
+//	gl := NewGitLab(nil, GitLabConfig{
+//	    Token:    os.Getenv("GITLAB_TOKEN"),
+//	    Projects: []string{"group/project"},
+//	    Filter:   filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+//	})
+//
+//	files, err := gl.ListFiles(ctx)
+func NewGitLab(httpClient *http.Client, configuration GitLabConfig) *GitLab {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &GitLab{Configuration: configuration, httpClient: httpClient}
+}
+
+// gitLabTreeEntry is an entry of a repository tree as returned by the GitLab API.
+type gitLabTreeEntry struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Path string `json:"path"`
+	Mode string `json:"mode"`
+}
+
+// gitLabFile is a file of a repository as returned by the GitLab API.
+type gitLabFile struct {
+	FilePath string `json:"file_path"`
+	Size     int    `json:"size"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+	BlobID   string `json:"blob_id"`
+}
+
+// gitLabCommit is a commit as returned by the GitLab API.
+type gitLabCommit struct {
+	ID            string    `json:"id"`
+	Message       string    `json:"message"`
+	AuthorName    string    `json:"author_name"`
+	CommittedDate time.Time `json:"committed_date"`
+}
+
+// gitLabDiff is a file changed by a commit as returned by the GitLab API.
+type gitLabDiff struct {
+	OldPath     string `json:"old_path"`
+	NewPath     string `json:"new_path"`
+	NewFile     bool   `json:"new_file"`
+	RenamedFile bool   `json:"renamed_file"`
+	DeletedFile bool   `json:"deleted_file"`
+}
+
+// ListFiles implements Source. GitLab does not report the size of the files in a tree, so Size is zero.
+func (g *GitLab) ListFiles(ctx context.Context) ([]document.File, error) {
+	var files []document.File
+	for _, project := range g.Configuration.Projects {
+		branch, err := g.branch(ctx, project)
+		if err != nil {
+			return nil, err
+		}
+
+		query := url.Values{"ref": {branch}, "recursive": {"true"}}
+		if dir := strings.Trim(g.Configuration.Filter.FilePath, "/"); dir != "" {
+			query.Set("path", dir)
+		}
+
+		var entries []gitLabTreeEntry
+		err = g.list(ctx, g.projectPath(project, "/repository/tree"), query, func(page json.RawMessage) error {
+			var pageEntries []gitLabTreeEntry
+			if err := json.Unmarshal(page, &pageEntries); err != nil {
+				return err
+			}
+			entries = append(entries, pageEntries...)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the files of %s: %w", project, err)
+		}
+
+		for _, entry := range entries {
+			if entry.Type != "blob" || !g.Configuration.Filter.Match(entry.Path) {
+				continue
+			}
+			mode, _ := strconv.ParseInt(entry.Mode, 8, 64)
+			files = append(files, document.File{
+				Path:       entry.Path,
+				SHA:        entry.ID,
+				Mode:       document.FileMode(mode),
+				Repository: project,
+				Branch:     branch,
+				URL:        g.fileURL(project, branch, entry.Path),
+			})
+		}
+	}
+	return files, nil
+}
+
+// GetContent implements Source. The configured filter is not applied.
+func (g *GitLab) GetContent(ctx context.Context, project, filePath string) (document.FileContent, error) {
+	branch, err := g.branch(ctx, project)
+	if err != nil {
+		return document.FileContent{}, err
+	}
+
+	var file gitLabFile
+	apiPath := g.projectPath(project, "/repository/files/"+url.PathEscape(filePath))
+	if err := g.get(ctx, apiPath, url.Values{"ref": {branch}}, &file); err != nil {
+		return document.FileContent{}, fmt.Errorf("failed to read %s/%s: %w", project, filePath, err)
+	}
+
+	content := []byte(file.Content)
+	if file.Encoding == "base64" {
+		if content, err = base64.StdEncoding.DecodeString(file.Content); err != nil {
+			return document.FileContent{}, fmt.Errorf("failed to read %s/%s: %w", project, filePath, err)
+		}
+	}
+
+	result := document.FileContent{
+		Repository: project,
+		Path:       filePath,
+		Oid:        file.BlobID,
+		ByteSize:   file.Size,
+		IsBinary:   bytes.IndexByte(content, 0) >= 0,
+	}
+	if !result.IsBinary {
+		result.Text = string(content)
+	}
+	return result, nil
+}
+
+// ChangesSince implements Source. The changes of every commit are compared to its first parent.
+func (g *GitLab) ChangesSince(ctx context.Context, since time.Time) (document.ChangeSet, error) {
+	var changes document.ChangeSet
+	for _, project := range g.Configuration.Projects {
+		events, err := g.listChangeEvents(ctx, project, since)
+		if err != nil {
+			return document.ChangeSet{}, fmt.Errorf("failed to list the changes of %s: %w", project, err)
+		}
+
+		projectChanges := document.NewChangeSet(events)
+		changes.Added = append(changes.Added, projectChanges.Added...)
+		changes.Removed = append(changes.Removed, projectChanges.Removed...)
+		changes.Modified = append(changes.Modified, projectChanges.Modified...)
+	}
+	return changes, nil
+}
+
+// listChangeEvents lists the changes of the files passing the filter by the commits on the branch since the
+// given time, newest first.
+func (g *GitLab) listChangeEvents(ctx context.Context, project string, since time.Time) ([]document.ChangeEvent, error) {
+	branch, err := g.branch(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{"ref_name": {branch}}
+	if !since.IsZero() {
+		query.Set("since", since.Format(time.RFC3339))
+	}
+	if dir := strings.Trim(g.Configuration.Filter.FilePath, "/"); dir != "" {
+		query.Set("path", dir)
+	}
+
+	var commits []gitLabCommit
+	err = g.list(ctx, g.projectPath(project, "/repository/commits"), query, func(page json.RawMessage) error {
+		var pageCommits []gitLabCommit
+		if err := json.Unmarshal(page, &pageCommits); err != nil {
+			return err
+		}
+		commits = append(commits, pageCommits...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var events []document.ChangeEvent
+	for _, commit := range commits {
+		err := g.list(ctx, g.projectPath(project, "/repository/commits/"+commit.ID+"/diff"), nil, func(page json.RawMessage) error {
+			var diffs []gitLabDiff
+			if err := json.Unmarshal(page, &diffs); err != nil {
+				return err
+			}
+
+			for _, diff := range diffs {
+				event := document.ChangeEvent{
+					Repository:  project,
+					CommitSHA:   commit.ID,
+					CommittedAt: commit.CommittedDate,
+					Author:      commit.AuthorName,
+					Message:     strings.TrimSpace(commit.Message),
+					Path:        diff.NewPath,
+					Status:      "modified",
+				}
+				switch {
+				case diff.NewFile:
+					event.Status = "added"
+				case diff.DeletedFile:
+					event.Path, event.Status = diff.OldPath, "removed"
+				case diff.RenamedFile:
+					event.PreviousPath, event.Status = diff.OldPath, "renamed"
+				}
+
+				if event, ok := event.Filter(g.Configuration.Filter.Match); ok {
+					events = append(events, event)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// branch returns the configured branch, or the default branch of the project. Default branches are cached
+// for the lifetime of the source.
+func (g *GitLab) branch(ctx context.Context, project string) (string, error) {
+	if g.Configuration.Branch != "" {
+		return g.Configuration.Branch, nil
+	}
+
+	g.branchesMu.Lock()
+	branch, ok := g.branches[project]
+	g.branchesMu.Unlock()
+	if ok {
+		return branch, nil
+	}
+
+	var info struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := g.get(ctx, g.projectPath(project, ""), nil, &info); err != nil {
+		return "", fmt.Errorf("failed to detect the default branch of %s: %w", project, err)
+	}
+	if info.DefaultBranch == "" {
+		return "", fmt.Errorf("failed to detect the default branch of %s: the repository is empty", project)
+	}
+
+	g.branchesMu.Lock()
+	defer g.branchesMu.Unlock()
+	if g.branches == nil {
+		g.branches = make(map[string]string)
+	}
+	g.branches[project] = info.DefaultBranch
+	return info.DefaultBranch, nil
+}
+
+// projectPath returns the API path of a project resource. The project is identified by its URL-encoded full
+// path.
+func (g *GitLab) projectPath(project, resource string) string {
+	return "/projects/" + url.PathEscape(project) + resource
+}
+
+// fileURL returns the address of a file on the web interface of GitLab.
+func (g *GitLab) fileURL(project, branch, filePath string) string {
+	return fmt.Sprintf("%s/%s/-/blob/%s/%s", g.baseURL(), project, branch, filePath)
+}
+
+// baseURL returns the configured address of the GitLab instance without a trailing slash.
+func (g *GitLab) baseURL() string {
+	if g.Configuration.BaseURL == "" {
+		return DefaultGitLabURL
+	}
+	return strings.TrimSuffix(g.Configuration.BaseURL, "/")
+}
+
+// list calls fn with every page of a paginated API resource.
+func (g *GitLab) list(ctx context.Context, apiPath string, query url.Values, fn func(page json.RawMessage) error) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("per_page", "100")
+
+	for page := "1"; page != ""; {
+		query.Set("page", page)
+
+		var body json.RawMessage
+		resp, err := g.do(ctx, apiPath, query, &body)
+		if err != nil {
+			return err
+		}
+		if err := fn(body); err != nil {
+			return err
+		}
+		page = resp.Header.Get("X-Next-Page")
+	}
+	return nil
+}
+
+// get reads a single API resource into v.
+func (g *GitLab) get(ctx context.Context, apiPath string, query url.Values, v interface{}) error {
+	_, err := g.do(ctx, apiPath, query, v)
+	return err
+}
+
+// do sends a GET request to the API and decodes the JSON response into v.
+func (g *GitLab) do(ctx context.Context, apiPath string, query url.Values, v interface{}) (*http.Response, error) {
+	u := g.baseURL() + "/api/v4" + apiPath
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if g.Configuration.Token != "" {
+		req.Header.Set("PRIVATE-TOKEN", g.Configuration.Token)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, fmt.Errorf("unexpected status code: %s", resp.Status)
+	}
+	return resp, json.NewDecoder(resp.Body).Decode(v)
+}
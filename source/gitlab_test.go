@@ -0,0 +1,143 @@
+package source
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGitLabServer serves the GitLab API resources from a map of escaped paths to JSON responses, see
+// newForgeServer.
+func newGitLabServer(t *testing.T, responses map[string]interface{}) *httptest.Server {
+	return newForgeServer(t, "PRIVATE-TOKEN", "secret", gitLabPaging, responses)
+}
+
+// gitLabPaging announces the next page in X-Next-Page.
+func gitLabPaging(w http.ResponseWriter, _ interface{}, next int, _ string) {
+	if next > 0 {
+		w.Header().Set("X-Next-Page", strconv.Itoa(next))
+	}
+}
+
+func TestGitLab_ListFilesAndGetContent(t *testing.T) {
+	srv := newGitLabServer(t, map[string]interface{}{
+		"/api/v4/projects/group%2Fsub%2Fproject": map[string]string{"default_branch": "main"},
+		"/api/v4/projects/group%2Fsub%2Fproject/repository/tree": []gitLabTreeEntry{
+			{ID: "t1", Type: "tree", Path: "docs/guides", Mode: "040000"},
+			{ID: "b1", Type: "blob", Path: "docs/index.md", Mode: "100644"},
+		},
+		"/api/v4/projects/group%2Fsub%2Fproject/repository/tree?page=2": []gitLabTreeEntry{
+			{ID: "b2", Type: "blob", Path: "docs/guides/setup.md", Mode: "100755"},
+			{ID: "b3", Type: "blob", Path: "docs/logo.png", Mode: "100644"},
+		},
+		"/api/v4/projects/group%2Fsub%2Fproject/repository/files/docs%2Findex.md": gitLabFile{
+			FilePath: "docs/index.md", Size: 7, Encoding: "base64", Content: "IyBJbmRleA==", BlobID: "b1",
+		},
+	})
+
+	gl := NewGitLab(srv.Client(), GitLabConfig{
+		BaseURL:  srv.URL + "/",
+		Token:    "secret",
+		Projects: []string{"group/sub/project"},
+		Filter:   filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}},
+	})
+
+	files, err := gl.ListFiles(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []document.File{
+		{Path: "docs/index.md", SHA: "b1", Mode: document.FileModeRegular, Repository: "group/sub/project", Branch: "main", URL: srv.URL + "/group/sub/project/-/blob/main/docs/index.md"},
+		{Path: "docs/guides/setup.md", SHA: "b2", Mode: document.FileModeExecutable, Repository: "group/sub/project", Branch: "main", URL: srv.URL + "/group/sub/project/-/blob/main/docs/guides/setup.md"},
+	}, files)
+
+	content, err := gl.GetContent(context.Background(), "group/sub/project", "docs/index.md")
+	require.NoError(t, err)
+	assert.Equal(t, document.FileContent{Repository: "group/sub/project", Path: "docs/index.md", Oid: "b1", Text: "# Index", ByteSize: 7}, content)
+
+	_, err = gl.GetContent(context.Background(), "group/sub/project", "docs/missing.md")
+	assert.EqualError(t, err, "failed to read group/sub/project/docs/missing.md: unexpected status code: 404 Not Found")
+}
+
+func TestGitLab_ChangesSince(t *testing.T) {
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	srv := newGitLabServer(t, map[string]interface{}{
+		"/api/v4/projects/group%2Fproject/repository/commits": []gitLabCommit{
+			{ID: "second", Message: "Move setup guide\n", AuthorName: "alice", CommittedDate: since.Add(2 * time.Hour)},
+			{ID: "first", Message: "Add index", AuthorName: "bob", CommittedDate: since.Add(time.Hour)},
+		},
+		"/api/v4/projects/group%2Fproject/repository/commits/second/diff": []gitLabDiff{
+			{OldPath: "docs/setup.md", NewPath: "docs/guide.md", RenamedFile: true},
+			{OldPath: "docs/old.md", NewPath: "docs/old.md", DeletedFile: true},
+		},
+		"/api/v4/projects/group%2Fproject/repository/commits/first/diff": []gitLabDiff{
+			{OldPath: "docs/index.md", NewPath: "docs/index.md", NewFile: true},
+			{OldPath: "README.md", NewPath: "README.md"},
+		},
+	})
+
+	gl := NewGitLab(srv.Client(), GitLabConfig{
+		BaseURL:  srv.URL,
+		Token:    "secret",
+		Projects: []string{"group/project"},
+		Branch:   "develop",
+		Filter:   filter.GitHubFilter{FilePath: "docs"},
+	})
+
+	changes, err := gl.ChangesSince(context.Background(), since)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/guide.md", "docs/index.md"}, fileChangePaths(changes.Added))
+	assert.Equal(t, []string{"docs/setup.md", "docs/old.md"}, fileChangePaths(changes.Removed))
+	assert.Empty(t, changes.Modified)
+	assert.Equal(t, document.FileChange{
+		Repository:  "group/project",
+		Path:        "docs/guide.md",
+		CommitSHA:   "second",
+		Author:      "alice",
+		CommittedAt: since.Add(2 * time.Hour),
+		Message:     "Move setup guide",
+	}, changes.Added[0])
+}
+
+func TestGitLab_ChangesSince_RenamesAcrossFilePath(t *testing.T) {
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	srv := newGitLabServer(t, map[string]interface{}{
+		"/api/v4/projects/group%2Fproject/repository/commits": []gitLabCommit{
+			{ID: "move", Message: "Reorganize pages", AuthorName: "alice", CommittedDate: since.Add(time.Hour)},
+		},
+		"/api/v4/projects/group%2Fproject/repository/commits/move/diff": []gitLabDiff{
+			{OldPath: "docs-old/setup.md", NewPath: "docs/setup.md", RenamedFile: true},
+			{OldPath: "docs/faq.md", NewPath: "archive/faq.md", RenamedFile: true},
+			{OldPath: "docs-old/index.md", NewPath: "docs-old/index.md"},
+		},
+	})
+
+	gl := NewGitLab(srv.Client(), GitLabConfig{
+		BaseURL:  srv.URL,
+		Token:    "secret",
+		Projects: []string{"group/project"},
+		Branch:   "develop",
+		Filter:   filter.GitHubFilter{FilePath: "docs"},
+	})
+
+	changes, err := gl.ChangesSince(context.Background(), since)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/setup.md"}, fileChangePaths(changes.Added))
+	assert.Equal(t, []string{"docs/faq.md"}, fileChangePaths(changes.Removed))
+	assert.Empty(t, changes.Modified)
+}
+
+// fileChangePaths returns the paths of the changes.
+func fileChangePaths(changes []document.FileChange) []string {
+	var paths []string
+	for _, change := range changes {
+		paths = append(paths, change.Path)
+	}
+	return paths
+}
@@ -0,0 +1,214 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shaharia-lab/coco-gh/v2/internal/gitutil"
+)
+
+// LocalSource is a Source reading a directory tree, e.g. a checkout or test fixtures, so ingestion pipelines
+// can be developed and tested offline or combine hosted and local content.
+//
+// Dir is the directory to read. Repository is the name files are reported under; empty uses the base name
+// of Dir. Filter selects files like it does for GitHub, except for the options relying on .gitattributes,
+// which are ignored. The .git directory is never listed.
+//
+// ChangesSince reads the git history if Dir is the root of a git checkout and git is installed. Otherwise it
+// reports every file modified since the given time as modified, as removals and additions cannot be told
+// apart without history.
+type LocalSource struct {
+	Dir        string
+	Repository string
+	Filter     filter.GitHubFilter
+}
+
+// ListFiles implements Source. SHA is the blob SHA computed from the content, Branch the checked out
+// branch if Dir is a git checkout and URL the file URL of the file.
+func (s LocalSource) ListFiles(ctx context.Context) ([]document.File, error) {
+	branch := gitutil.CheckoutBranch(s.Dir)
+
+	var files []document.File
+	err := filepath.WalkDir(s.Dir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(s.Dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !s.Filter.Match(rel) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		content, mode, err := readLocalFile(p, info)
+		if err != nil {
+			return err
+		}
+
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return err
+		}
+		files = append(files, document.File{
+			Path:           rel,
+			SHA:            gitutil.BlobSHA(content),
+			Size:           len(content),
+			Mode:           mode,
+			Repository:     s.repository(),
+			Branch:         branch,
+			LastModifiedAt: info.ModTime(),
+			URL:            "file://" + filepath.ToSlash(abs),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the files of %s: %w", s.Dir, err)
+	}
+	return files, nil
+}
+
+// GetContent implements Source. The configured filter is not applied.
+func (s LocalSource) GetContent(_ context.Context, repo, filePath string) (document.FileContent, error) {
+	if repo != s.repository() {
+		return document.FileContent{}, fmt.Errorf("failed to read %s/%s: unknown repository", repo, filePath)
+	}
+
+	p := filepath.Join(s.Dir, filepath.FromSlash(filePath))
+	info, err := os.Lstat(p)
+	if err != nil {
+		return document.FileContent{}, fmt.Errorf("failed to read %s/%s: %w", repo, filePath, err)
+	}
+	content, _, err := readLocalFile(p, info)
+	if err != nil {
+		return document.FileContent{}, fmt.Errorf("failed to read %s/%s: %w", repo, filePath, err)
+	}
+
+	result := document.FileContent{
+		Repository: repo,
+		Path:       filePath,
+		Oid:        gitutil.BlobSHA(content),
+		ByteSize:   len(content),
+		IsBinary:   bytes.IndexByte(content, 0) >= 0,
+	}
+	if !result.IsBinary {
+		result.Text = string(content)
+	}
+	return result, nil
+}
+
+// ChangesSince implements Source.
+func (s LocalSource) ChangesSince(ctx context.Context, since time.Time) (document.ChangeSet, error) {
+	if !s.hasHistory() {
+		return s.modifiedSince(ctx, since)
+	}
+
+	opts := &github.CommitsListOptions{Since: since, Path: s.Filter.FilePath}
+	cmd := gitutil.Command(ctx, "", gitutil.LogArgs("HEAD", opts)...)
+	cmd.Dir = s.Dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return document.ChangeSet{}, fmt.Errorf("failed to read the history of %s: %w: %s", s.Dir, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	commits, err := gitutil.ParseLog(string(out))
+	if err != nil {
+		return document.ChangeSet{}, fmt.Errorf("failed to read the history of %s: %w", s.Dir, err)
+	}
+
+	var events []document.ChangeEvent
+	for _, commit := range commits {
+		for _, file := range commit.Files {
+			if event, ok := gitutil.ChangeEvent(s.repository(), commit, file).Filter(s.Filter.Match); ok {
+				events = append(events, event)
+			}
+		}
+	}
+	return document.NewChangeSet(events), nil
+}
+
+// modifiedSince reports the files modified since the given time as modified, ordered by path.
+func (s LocalSource) modifiedSince(ctx context.Context, since time.Time) (document.ChangeSet, error) {
+	files, err := s.ListFiles(ctx)
+	if err != nil {
+		return document.ChangeSet{}, err
+	}
+
+	var changes document.ChangeSet
+	for _, file := range files {
+		if file.LastModifiedAt.Before(since) {
+			continue
+		}
+		changes.Modified = append(changes.Modified, document.FileChange{
+			Repository:  file.Repository,
+			Path:        file.Path,
+			CommittedAt: file.LastModifiedAt,
+		})
+	}
+	sort.Slice(changes.Modified, func(i, j int) bool {
+		return changes.Modified[i].Path < changes.Modified[j].Path
+	})
+	return changes, nil
+}
+
+// hasHistory reports whether the directory is the root of a git checkout whose history git can read.
+func (s LocalSource) hasHistory() bool {
+	if _, err := os.Stat(filepath.Join(s.Dir, ".git")); err != nil {
+		return false
+	}
+	_, err := exec.LookPath("git")
+	return err == nil
+}
+
+// repository returns the name files are reported under.
+func (s LocalSource) repository() string {
+	if s.Repository == "" {
+		dir, err := filepath.Abs(s.Dir)
+		if err != nil {
+			dir = s.Dir
+		}
+		return filepath.Base(dir)
+	}
+	return s.Repository
+}
+
+// readLocalFile reads a file the way git stores it: symbolic links hold their target.
+func readLocalFile(p string, info fs.FileInfo) ([]byte, document.FileMode, error) {
+	if info.Mode()&fs.ModeSymlink != 0 {
+		target, err := os.Readlink(p)
+		return []byte(filepath.ToSlash(target)), document.FileModeSymlink, err
+	}
+
+	content, err := os.ReadFile(p)
+	if info.Mode()&0111 != 0 {
+		return content, document.FileModeExecutable, err
+	}
+	return content, document.FileModeRegular, err
+}
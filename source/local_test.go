@@ -0,0 +1,152 @@
+package source
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shaharia-lab/coco-gh/v2/internal/gitutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o644))
+	}
+}
+
+func runGit(t *testing.T, dir string, date time.Time, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=alice", "GIT_AUTHOR_EMAIL=alice@example.com", "GIT_AUTHOR_DATE="+date.Format(time.RFC3339),
+		"GIT_COMMITTER_NAME=alice", "GIT_COMMITTER_EMAIL=alice@example.com", "GIT_COMMITTER_DATE="+date.Format(time.RFC3339),
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestLocalSource(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"README.md":     "# repo1",
+		"docs/index.md": "# Index",
+		"docs/logo.png": "\x89PNG\x00",
+		"docs/run.sh":   "make",
+	})
+	require.NoError(t, os.Chmod(filepath.Join(dir, "docs", "run.sh"), 0o755))
+	require.NoError(t, os.Symlink("index.md", filepath.Join(dir, "docs", "latest.md")))
+
+	old := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "docs", "run.sh"), old, old))
+
+	source := LocalSource{Dir: dir, Repository: "repo1", Filter: filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md", ".sh"}}}
+
+	files, err := source.ListFiles(context.Background())
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	assert.Equal(t, "docs/index.md", files[0].Path)
+	assert.Equal(t, gitutil.BlobSHA([]byte("# Index")), files[0].SHA)
+	assert.Equal(t, 7, files[0].Size)
+	assert.Equal(t, document.FileModeRegular, files[0].Mode)
+	assert.Equal(t, "repo1", files[0].Repository)
+	assert.Equal(t, "file://"+filepath.ToSlash(filepath.Join(dir, "docs", "index.md")), files[0].URL)
+	assert.Equal(t, "docs/latest.md", files[1].Path)
+	assert.Equal(t, document.FileModeSymlink, files[1].Mode)
+	assert.Equal(t, gitutil.BlobSHA([]byte("index.md")), files[1].SHA)
+	assert.Equal(t, document.FileModeExecutable, files[2].Mode)
+
+	content, err := source.GetContent(context.Background(), "repo1", "docs/index.md")
+	require.NoError(t, err)
+	assert.Equal(t, document.FileContent{Repository: "repo1", Path: "docs/index.md", Oid: gitutil.BlobSHA([]byte("# Index")), Text: "# Index", ByteSize: 7}, content)
+
+	content, err = source.GetContent(context.Background(), "repo1", "docs/logo.png")
+	require.NoError(t, err)
+	assert.True(t, content.IsBinary)
+	assert.Empty(t, content.Text)
+
+	_, err = source.GetContent(context.Background(), "other", "docs/index.md")
+	assert.EqualError(t, err, "failed to read other/docs/index.md: unknown repository")
+
+	changes, err := source.ChangesSince(context.Background(), old.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/index.md", "docs/latest.md"}, fileChangePaths(changes.Modified))
+	assert.Empty(t, changes.Added)
+
+	assert.Equal(t, filepath.Base(dir), LocalSource{Dir: dir}.repository())
+}
+
+func TestLocalSource_GitHistory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	runGit(t, dir, base, "init", "--quiet", "--initial-branch", "main")
+
+	writeFiles(t, dir, map[string]string{"README.md": "# repo1", "docs/setup.md": "# Setup"})
+	runGit(t, dir, base, "add", "--all")
+	runGit(t, dir, base, "commit", "--quiet", "--message", "Initial commit")
+
+	writeFiles(t, dir, map[string]string{"docs/index.md": "# Index", "README.md": "# repo1\n"})
+	runGit(t, dir, base.Add(48*time.Hour), "mv", "docs/setup.md", "docs/guide.md")
+	runGit(t, dir, base.Add(48*time.Hour), "add", "--all")
+	runGit(t, dir, base.Add(48*time.Hour), "commit", "--quiet", "--message", "Add index and move setup guide")
+
+	source := LocalSource{Dir: dir, Filter: filter.GitHubFilter{FilePath: "docs"}}
+
+	files, err := source.ListFiles(context.Background())
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, "main", files[0].Branch)
+
+	changes, err := source.ChangesSince(context.Background(), base.Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/guide.md", "docs/index.md"}, fileChangePaths(changes.Added))
+	assert.Equal(t, []string{"docs/setup.md"}, fileChangePaths(changes.Removed))
+	assert.Empty(t, changes.Modified)
+	assert.Equal(t, "alice", changes.Added[0].Author)
+	assert.Equal(t, "Add index and move setup guide", changes.Added[0].Message)
+	assert.Equal(t, filepath.Base(dir), changes.Added[0].Repository)
+}
+
+func TestLocalSource_GitHistory_RenamesAcrossFilePath(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	runGit(t, dir, base, "init", "--quiet", "--initial-branch", "main")
+
+	writeFiles(t, dir, map[string]string{"docs-old/setup.md": "# Setup", "docs-old/index.md": "# Index", "docs/faq.md": "# FAQ"})
+	runGit(t, dir, base, "add", "--all")
+	runGit(t, dir, base, "commit", "--quiet", "--message", "Initial commit")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "archive"), 0o755))
+	runGit(t, dir, base.Add(48*time.Hour), "mv", "docs-old/setup.md", "docs/setup.md")
+	runGit(t, dir, base.Add(48*time.Hour), "mv", "docs/faq.md", "archive/faq.md")
+	writeFiles(t, dir, map[string]string{"docs-old/index.md": "# Index\n"})
+	runGit(t, dir, base.Add(48*time.Hour), "add", "--all")
+	runGit(t, dir, base.Add(48*time.Hour), "commit", "--quiet", "--message", "Reorganize pages")
+
+	source := LocalSource{Dir: dir, Filter: filter.GitHubFilter{FilePath: "docs"}}
+
+	changes, err := source.ChangesSince(context.Background(), base.Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/setup.md"}, fileChangePaths(changes.Added))
+	assert.Equal(t, []string{"docs/faq.md"}, fileChangePaths(changes.Removed))
+	assert.Empty(t, changes.Modified)
+}
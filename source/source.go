@@ -0,0 +1,157 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/github"
+)
+
+// Source is the provider-agnostic view of the repositories a collection reads files from, so the same
+// pipeline works for repositories hosted on GitHub, GitLab, Bitbucket Cloud, Gitea and Forgejo. GitHub,
+// GitLab, Bitbucket and Gitea implement it.
+//
+// ListFiles returns the files passing the configured filter in all configured repositories. GetContent reads
+// a file of one of them, given by the Repository of its File. ChangesSince returns the files changed since
+// the given time with the commit that changed them last.
+type Source interface {
+	ListFiles(ctx context.Context) ([]document.File, error)
+	GetContent(ctx context.Context, repo, filePath string) (document.FileContent, error)
+	ChangesSince(ctx context.Context, since time.Time) (document.ChangeSet, error)
+}
+
+var (
+	_ Source = (*github.GitHub)(nil)
+	_ Source = (*GitLab)(nil)
+	_ Source = (*Bitbucket)(nil)
+	_ Source = (*Gitea)(nil)
+	_ Source = LocalSource{}
+)
+
+// SourceDocuments turns the files of a source into a ContentSource for a Collector. A full collection
+// returns a document for every listed file, an incremental one for every file added or modified since.
+// Removed files and binary files yield no documents. If the source fails with a *PartialError, the documents
+// of the successful repositories are returned together with it.
+//
+// Usage:
+//
+//	collector := &watch.Collector{
+//	    Name:        "gitlab-docs",
+//	    Source:      SourceDocuments(gl),
+//	    Sink:        sink,
+//	    Checkpoints: watch.NewMemoryCheckpointStore(),
+//	}
+func SourceDocuments(source Source) ContentSource {
+	return ContentSourceFunc(func(ctx context.Context, since time.Time) ([]document.Document, error) {
+		type change struct {
+			repo, path string
+			updatedAt  time.Time
+		}
+
+		var changes []change
+		var partialErr error
+		if since.IsZero() {
+			files, err := source.ListFiles(ctx)
+			if !partialResult(err) {
+				return nil, err
+			}
+			partialErr = err
+			for _, file := range files {
+				changes = append(changes, change{repo: file.Repository, path: file.Path})
+			}
+		} else {
+			changeSet, err := source.ChangesSince(ctx, since)
+			if !partialResult(err) {
+				return nil, err
+			}
+			partialErr = err
+			for _, list := range [][]document.FileChange{changeSet.Added, changeSet.Modified} {
+				for _, fileChange := range list {
+					changes = append(changes, change{repo: fileChange.Repository, path: fileChange.Path, updatedAt: fileChange.CommittedAt})
+				}
+			}
+		}
+
+		var docs []document.Document
+		for _, change := range changes {
+			content, err := source.GetContent(ctx, change.repo, change.path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s/%s: %w", change.repo, change.path, err)
+			}
+			if content.IsBinary {
+				continue
+			}
+
+			doc := document.Document{
+				ID:         change.repo + "/" + change.path,
+				Kind:       document.DocumentKindFile,
+				Repository: change.repo,
+				Path:       change.path,
+				Title:      change.path,
+				Body:       content.Text,
+				UpdatedAt:  change.updatedAt,
+			}
+			// GitLab projects may be nested in several groups, which all belong to the owner.
+			if i := strings.LastIndex(change.repo, "/"); i >= 0 {
+				doc.Owner, doc.Repository = change.repo[:i], change.repo[i+1:]
+			}
+			if content.Oid != "" {
+				doc.Metadata = map[string]string{"sha": content.Oid}
+			}
+			docs = append(docs, doc)
+		}
+		return docs, partialErr
+	})
+}
+
+// ChunkDocuments wraps a ContentSource, replacing every document it collects with the documents of its
+// chunks, so chunks flow through a Collector into any Sink, e.g. the one feeding a vector store:
+//
+//	collector := &watch.Collector{
+//	    Name:   "docs-chunks",
+//	    Source: ChunkDocuments(SourceDocuments(gh), document.Chunker{MaxTokens: 256, OverlapLines: 2}),
+//	    Sink:   sink,
+//	}
+func ChunkDocuments(source ContentSource, chunker document.Chunker) ContentSource {
+	return ContentSourceFunc(func(ctx context.Context, since time.Time) ([]document.Document, error) {
+		docs, err := source.Collect(ctx, since)
+		if err != nil {
+			return nil, err
+		}
+
+		var chunks []document.Document
+		for _, doc := range docs {
+			for _, chunk := range chunker.Split(doc) {
+				chunks = append(chunks, chunk.Document(doc))
+			}
+		}
+		return chunks, nil
+	})
+}
+
+// ContentSource produces the documents of a collection. Since is the start of an incremental collection;
+// the zero time asks for a full collection.
+type ContentSource interface {
+	Collect(ctx context.Context, since time.Time) ([]document.Document, error)
+}
+
+// ContentSourceFunc adapts a function to a ContentSource, so the document methods of the client can be used
+// as sources directly:
+//
+//	source := ContentSourceFunc(gh.GetCommitDocumentsSince)
+type ContentSourceFunc func(ctx context.Context, since time.Time) ([]document.Document, error)
+
+// Collect calls f(ctx, since).
+func (f ContentSourceFunc) Collect(ctx context.Context, since time.Time) ([]document.Document, error) {
+	return f(ctx, since)
+}
+
+// partialResult reports whether err leaves documents to return: there is no error or only some repositories
+// failed.
+func partialResult(err error) bool {
+	_, partial := err.(*github.PartialError)
+	return err == nil || partial
+}
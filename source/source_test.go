@@ -0,0 +1,173 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sourceStub is a Source serving fixed files and changes.
+type sourceStub struct {
+	files    []document.File
+	changes  document.ChangeSet
+	contents map[string]document.FileContent
+	err      error
+}
+
+func (s *sourceStub) ListFiles(context.Context) ([]document.File, error) {
+	return s.files, s.err
+}
+
+func (s *sourceStub) GetContent(_ context.Context, repo, filePath string) (document.FileContent, error) {
+	return s.contents[repo+"/"+filePath], nil
+}
+
+func (s *sourceStub) ChangesSince(context.Context, time.Time) (document.ChangeSet, error) {
+	return s.changes, nil
+}
+
+// forgePaging announces the next page of a paged resource the way the API of a forge does. next is the number
+// of the next page, 0 on the last one.
+type forgePaging func(w http.ResponseWriter, response interface{}, next int, serverURL string)
+
+// newForgeServer serves the API resources of a forge from a map of escaped paths, followed by "?page=N" for
+// pages after the first, to responses. Strings are served as raw content, everything else as JSON. Every
+// request has to authenticate with the value auth in the header authHeader.
+func newForgeServer(t *testing.T, authHeader, auth string, paging forgePaging, responses map[string]interface{}) *httptest.Server {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, auth, r.Header.Get(authHeader))
+
+		path, page := r.URL.EscapedPath(), 1
+		if n, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
+			page = n
+		}
+		key := path
+		if page > 1 {
+			key += "?page=" + strconv.Itoa(page)
+		}
+
+		response, ok := responses[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		next := 0
+		if _, ok := responses[path+"?page="+strconv.Itoa(page+1)]; ok {
+			next = page + 1
+		}
+		paging(w, response, next, srv.URL)
+
+		if content, ok := response.(string); ok {
+			w.Write([]byte(content))
+			return
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSourceDocuments(t *testing.T) {
+	committed := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	source := &sourceStub{
+		files: []document.File{
+			{Repository: "group/sub/project", Path: "docs/index.md"},
+			{Repository: "group/sub/project", Path: "docs/logo.png"},
+		},
+		changes: document.ChangeSet{
+			Added:    []document.FileChange{{Repository: "repo1", Path: "README.md", CommittedAt: committed}},
+			Removed:  []document.FileChange{{Repository: "repo1", Path: "docs/old.md"}},
+			Modified: []document.FileChange{{Repository: "group/sub/project", Path: "docs/index.md", CommittedAt: committed}},
+		},
+		contents: map[string]document.FileContent{
+			"group/sub/project/docs/index.md": {Text: "# Index", Oid: "b1"},
+			"group/sub/project/docs/logo.png": {IsBinary: true},
+			"repo1/README.md":                 {Text: "# repo1"},
+		},
+	}
+
+	docs, err := SourceDocuments(source).Collect(context.Background(), time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, []document.Document{{
+		ID:         "group/sub/project/docs/index.md",
+		Kind:       document.DocumentKindFile,
+		Owner:      "group/sub",
+		Repository: "project",
+		Path:       "docs/index.md",
+		Title:      "docs/index.md",
+		Body:       "# Index",
+		Metadata:   map[string]string{"sha": "b1"},
+	}}, docs)
+
+	docs, err = SourceDocuments(source).Collect(context.Background(), committed.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "repo1/README.md", docs[0].ID)
+	assert.Equal(t, "", docs[0].Owner)
+	assert.Equal(t, "repo1", docs[0].Repository)
+	assert.Equal(t, committed, docs[0].UpdatedAt)
+	assert.Equal(t, "group/sub/project/docs/index.md", docs[1].ID)
+}
+
+func TestSourceDocuments_Partial(t *testing.T) {
+	partial := &github.PartialError{Succeeded: []string{"repo1"}, Failed: map[string]error{"archived": errors.New("boom")}}
+	source := &sourceStub{
+		files:    []document.File{{Repository: "repo1", Path: "README.md"}},
+		contents: map[string]document.FileContent{"repo1/README.md": {Text: "# repo1"}},
+		err:      partial,
+	}
+
+	docs, err := SourceDocuments(source).Collect(context.Background(), time.Time{})
+	assert.Equal(t, partial, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "repo1/README.md", docs[0].ID)
+
+	source.err = errors.New("boom")
+	docs, err = SourceDocuments(source).Collect(context.Background(), time.Time{})
+	assert.EqualError(t, err, "boom")
+	assert.Nil(t, docs)
+}
+
+func TestChunkDocuments(t *testing.T) {
+	updatedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	source := ContentSourceFunc(func(ctx context.Context, since time.Time) ([]document.Document, error) {
+		return []document.Document{
+			{ID: "repo1/README.md", Kind: document.DocumentKindFile, Repository: "repo1", Path: "README.md", Title: "README.md",
+				Body: "# Repo 1\nHello.\n## Usage\nRun it.", UpdatedAt: updatedAt, Metadata: map[string]string{"sha": "abc"}},
+			{ID: "repo1/empty.txt", Kind: document.DocumentKindFile, Repository: "repo1", Path: "empty.txt", Body: "\n\n"},
+		}, nil
+	})
+
+	docs, err := ChunkDocuments(source, document.Chunker{}).Collect(context.Background(), time.Time{})
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, document.Document{
+		ID:         "repo1/README.md#1",
+		Kind:       document.DocumentKindChunk,
+		Repository: "repo1",
+		Path:       "README.md",
+		Title:      "README.md > Repo 1 > Usage",
+		Body:       "## Usage\nRun it.",
+		UpdatedAt:  updatedAt,
+		Metadata: map[string]string{
+			"sha":         "abc",
+			"document_id": "repo1/README.md",
+			"chunk_index": "1",
+			"start_line":  "3",
+			"end_line":    "4",
+			"heading":     "Repo 1 > Usage",
+			"hash":        docs[1].Metadata["hash"],
+		},
+	}, docs[1])
+}
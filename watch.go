@@ -0,0 +1,80 @@
+package cocogh
+
+import (
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/watch"
+)
+
+// CheckpointStore is an alias of watch.CheckpointStore.
+//
+// Deprecated: use watch.CheckpointStore instead.
+type CheckpointStore = watch.CheckpointStore
+
+// CollectionResult is an alias of watch.CollectionResult.
+//
+// Deprecated: use watch.CollectionResult instead.
+type CollectionResult = watch.CollectionResult
+
+// Collector is an alias of watch.Collector.
+//
+// Deprecated: use watch.Collector instead.
+type Collector = watch.Collector
+
+// DocumentFilter is an alias of watch.DocumentFilter.
+//
+// Deprecated: use watch.DocumentFilter instead.
+type DocumentFilter = watch.DocumentFilter
+
+// FileCheckpointStore is an alias of watch.FileCheckpointStore.
+//
+// Deprecated: use watch.FileCheckpointStore instead.
+type FileCheckpointStore = watch.FileCheckpointStore
+
+// MemoryCheckpointStore is an alias of watch.MemoryCheckpointStore.
+//
+// Deprecated: use watch.MemoryCheckpointStore instead.
+type MemoryCheckpointStore = watch.MemoryCheckpointStore
+
+// Runner is an alias of watch.Runner.
+//
+// Deprecated: use watch.Runner instead.
+type Runner = watch.Runner
+
+// RunnerStatus is an alias of watch.RunnerStatus.
+//
+// Deprecated: use watch.RunnerStatus instead.
+type RunnerStatus = watch.RunnerStatus
+
+// Schedule is an alias of watch.Schedule.
+//
+// Deprecated: use watch.Schedule instead.
+type Schedule = watch.Schedule
+
+// Every calls watch.Every.
+//
+// Deprecated: use watch.Every instead.
+func Every(interval time.Duration) watch.Schedule {
+	return watch.Every(interval)
+}
+
+// NewFileCheckpointStore calls watch.NewFileCheckpointStore.
+//
+// Deprecated: use watch.NewFileCheckpointStore instead.
+func NewFileCheckpointStore(path string) *watch.FileCheckpointStore {
+	return watch.NewFileCheckpointStore(path)
+}
+
+// NewMemoryCheckpointStore calls watch.NewMemoryCheckpointStore.
+//
+// Deprecated: use watch.NewMemoryCheckpointStore instead.
+func NewMemoryCheckpointStore() *watch.MemoryCheckpointStore {
+	return watch.NewMemoryCheckpointStore()
+}
+
+// ParseSchedule calls watch.ParseSchedule.
+//
+// Deprecated: use watch.ParseSchedule instead.
+func ParseSchedule(spec string) (watch.Schedule, error) {
+	return watch.ParseSchedule(spec)
+}
@@ -0,0 +1,234 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/github"
+	"github.com/shaharia-lab/coco-gh/v2/internal/fileutil"
+	"github.com/shaharia-lab/coco-gh/v2/internal/timeutil"
+	"github.com/shaharia-lab/coco-gh/v2/sink"
+	"github.com/shaharia-lab/coco-gh/v2/source"
+)
+
+// DocumentFilter reports whether a collected document is kept.
+type DocumentFilter func(doc document.Document) bool
+
+// CheckpointStore remembers when a collection last ran, so the next run only collects what changed since.
+// Load returns the zero time if there is no checkpoint for the key.
+type CheckpointStore interface {
+	Load(ctx context.Context, key string) (time.Time, error)
+	Save(ctx context.Context, key string, checkpoint time.Time) error
+}
+
+// MemoryCheckpointStore is a CheckpointStore keeping checkpoints in memory. It is safe for concurrent use.
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]time.Time
+}
+
+// NewMemoryCheckpointStore creates an empty MemoryCheckpointStore.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: make(map[string]time.Time)}
+}
+
+// Load returns the checkpoint saved for the key, or the zero time.
+func (s *MemoryCheckpointStore) Load(_ context.Context, key string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkpoints[key], nil
+}
+
+// Save stores the checkpoint for the key.
+func (s *MemoryCheckpointStore) Save(_ context.Context, key string, checkpoint time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoints[key] = checkpoint
+	return nil
+}
+
+// FileCheckpointStore is a CheckpointStore keeping the checkpoints of all collections in a JSON file. A
+// missing file holds no checkpoints. The file is replaced atomically on every save. It is safe for
+// concurrent use within a process, but not across processes sharing the file.
+type FileCheckpointStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// NewFileCheckpointStore creates a FileCheckpointStore for the JSON file at path.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{Path: path}
+}
+
+// Load returns the checkpoint saved for the key, or the zero time.
+func (s *FileCheckpointStore) Load(_ context.Context, key string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return checkpoints[key], nil
+}
+
+// Save stores the checkpoint for the key, keeping the checkpoints of other keys.
+func (s *FileCheckpointStore) Save(_ context.Context, key string, checkpoint time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return err
+	}
+	checkpoints[key] = checkpoint
+
+	data, err := json.MarshalIndent(checkpoints, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := fileutil.WriteAtomic(s.Path, append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write checkpoints: %w", err)
+	}
+	return nil
+}
+
+// read reads all checkpoints from the file.
+func (s *FileCheckpointStore) read() (map[string]time.Time, error) {
+	checkpoints := make(map[string]time.Time)
+
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoints, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoints: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &checkpoints); err != nil {
+		return nil, fmt.Errorf("failed to read checkpoints %s: %w", s.Path, err)
+	}
+	return checkpoints, nil
+}
+
+// Collector runs a collection pipeline: it collects documents from the source, keeps those passing every
+// filter, applies the transformers in order and writes the result to the sink.
+//
+// Name identifies the collection in the checkpoint store. Without a checkpoint store, or before the first
+// successful run, Run performs a full collection; afterwards it collects incrementally from the time the
+// previous run started. Clock is the source of that time; nil uses the wall clock.
+//
+// Usage:
+//
+//	collector := &Collector{
+//	    Name:        "commits",
+//	    Source:      source.ContentSourceFunc(gh.GetCommitDocumentsSince),
+//	    Filters:     []DocumentFilter{func(doc document.Document) bool { return doc.Author != "dependabot[bot]" }},
+//	    Sink:        sink,
+//	    Checkpoints: NewMemoryCheckpointStore(),
+//	}
+//
+//	result, err := collector.Run(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(result.Written, "documents written")
+type Collector struct {
+	Name         string
+	Source       source.ContentSource
+	Filters      []DocumentFilter
+	Transformers []document.Transformer
+	Sink         sink.Sink
+	Checkpoints  CheckpointStore
+	Clock        github.Clock
+}
+
+// CollectionResult summarizes a run of a Collector.
+//
+// Since is the start of the collection, the zero time for a full collection. Collected is the number of
+// documents the source returned, Written the number written to the sink after filtering and dropping those
+// a Transformer skipped.
+type CollectionResult struct {
+	Since     time.Time
+	Started   time.Time
+	Collected int
+	Written   int
+}
+
+// Run performs one collection. The checkpoint is only advanced when every step succeeded, so a failed run
+// is retried from the same point.
+//
+// If the source fails with a *PartialError, e.g. a GitHub client with GitHubConfig.ContinueOnError, the
+// documents of the successful repositories are still written, the checkpoint is not advanced and Run
+// returns the partial error.
+func (c *Collector) Run(ctx context.Context) (CollectionResult, error) {
+	if c.Source == nil || c.Sink == nil {
+		return CollectionResult{}, fmt.Errorf("collector %q needs a source and a sink", c.Name)
+	}
+
+	result := CollectionResult{Started: timeutil.Now(c.Clock)}
+
+	if c.Checkpoints != nil {
+		since, err := c.Checkpoints.Load(ctx, c.Name)
+		if err != nil {
+			return result, fmt.Errorf("failed to load checkpoint of %q: %w", c.Name, err)
+		}
+		result.Since = since
+	}
+
+	docs, err := c.Source.Collect(ctx, result.Since)
+	var partial *github.PartialError
+	if err != nil && !errors.As(err, &partial) {
+		return result, fmt.Errorf("failed to collect documents for %q: %w", c.Name, err)
+	}
+	result.Collected = len(docs)
+
+	var kept []document.Document
+	for _, doc := range docs {
+		if !c.keep(doc) {
+			continue
+		}
+
+		transformed, err := document.ChainTransformers(c.Transformers...).Transform(ctx, doc)
+		if errors.Is(err, document.ErrSkipDocument) {
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("failed to transform document %s: %w", doc.ID, err)
+		}
+		kept = append(kept, transformed)
+	}
+
+	if err := c.Sink.Write(ctx, kept); err != nil {
+		return result, fmt.Errorf("failed to write documents for %q: %w", c.Name, err)
+	}
+	result.Written = len(kept)
+
+	if partial != nil {
+		return result, fmt.Errorf("failed to collect documents for %q: %w", c.Name, err)
+	}
+	if c.Checkpoints != nil {
+		if err := c.Checkpoints.Save(ctx, c.Name, result.Started); err != nil {
+			return result, fmt.Errorf("failed to save checkpoint of %q: %w", c.Name, err)
+		}
+	}
+
+	return result, nil
+}
+
+// keep reports whether the document passes every filter.
+func (c *Collector) keep(doc document.Document) bool {
+	for _, filter := range c.Filters {
+		if !filter(doc) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,198 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/github"
+	"github.com/shaharia-lab/coco-gh/v2/sink"
+	"github.com/shaharia-lab/coco-gh/v2/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sinkStub collects the documents written to it.
+type sinkStub struct {
+	docs []document.Document
+	err  error
+}
+
+func (s *sinkStub) Write(_ context.Context, docs []document.Document) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.docs = append(s.docs, docs...)
+	return nil
+}
+
+func TestCollector_Run(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	var sinces []time.Time
+	src := source.ContentSourceFunc(func(_ context.Context, since time.Time) ([]document.Document, error) {
+		sinces = append(sinces, since)
+		return []document.Document{
+			{ID: "o/r/commit/1", Title: "feat: add docs", Author: "alice"},
+			{ID: "o/r/commit/2", Title: "chore: bump deps", Author: "dependabot[bot]"},
+		}, nil
+	})
+	out := &sinkStub{}
+	checkpoints := NewMemoryCheckpointStore()
+
+	collector := &Collector{
+		Name:    "commits",
+		Source:  src,
+		Filters: []DocumentFilter{func(doc document.Document) bool { return doc.Author != "dependabot[bot]" }},
+		Transformers: []document.Transformer{document.TransformerFunc(func(_ context.Context, doc document.Document) (document.Document, error) {
+			doc.Title = strings.ToUpper(doc.Title)
+			return doc, nil
+		})},
+		Sink:        out,
+		Checkpoints: checkpoints,
+		Clock:       &testClock{now: now},
+	}
+
+	result, err := collector.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, CollectionResult{Started: now, Collected: 2, Written: 1}, result)
+	assert.Equal(t, []document.Document{{ID: "o/r/commit/1", Title: "FEAT: ADD DOCS", Author: "alice"}}, out.docs)
+
+	checkpoint, err := checkpoints.Load(context.Background(), "commits")
+	assert.NoError(t, err)
+	assert.Equal(t, now, checkpoint)
+
+	collector.Clock = &testClock{now: now.Add(time.Hour)}
+	result, err = collector.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, now, result.Since)
+	assert.Equal(t, []time.Time{{}, now}, sinces)
+}
+
+func TestCollector_Run_Errors(t *testing.T) {
+	boom := errors.New("boom")
+	docs := source.ContentSourceFunc(func(context.Context, time.Time) ([]document.Document, error) {
+		return []document.Document{{ID: "o/r/commit/1"}}, nil
+	})
+
+	tests := []struct {
+		name      string
+		collector *Collector
+		wantErr   string
+	}{
+		{
+			name:      "missing sink",
+			collector: &Collector{Name: "commits", Source: docs},
+			wantErr:   `collector "commits" needs a source and a sink`,
+		},
+		{
+			name: "source fails",
+			collector: &Collector{Name: "commits", Sink: &sinkStub{}, Source: source.ContentSourceFunc(func(context.Context, time.Time) ([]document.Document, error) {
+				return nil, boom
+			})},
+			wantErr: `failed to collect documents for "commits": boom`,
+		},
+		{
+			name: "transformer fails",
+			collector: &Collector{Name: "commits", Source: docs, Sink: &sinkStub{}, Transformers: []document.Transformer{
+				document.TransformerFunc(func(context.Context, document.Document) (document.Document, error) { return document.Document{}, boom }),
+			}},
+			wantErr: "failed to transform document o/r/commit/1: boom",
+		},
+		{
+			name:      "sink fails",
+			collector: &Collector{Name: "commits", Source: docs, Sink: &sinkStub{err: boom}},
+			wantErr:   `failed to write documents for "commits": boom`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkpoints := NewMemoryCheckpointStore()
+			tt.collector.Checkpoints = checkpoints
+
+			_, err := tt.collector.Run(context.Background())
+			assert.EqualError(t, err, tt.wantErr)
+
+			checkpoint, _ := checkpoints.Load(context.Background(), "commits")
+			assert.True(t, checkpoint.IsZero())
+		})
+	}
+}
+
+func TestCollector_Run_Partial(t *testing.T) {
+	partial := &github.PartialError{Succeeded: []string{"repo1"}, Failed: map[string]error{"archived": errors.New("boom")}}
+	out := &sinkStub{}
+	checkpoints := NewMemoryCheckpointStore()
+	collector := &Collector{
+		Name: "files",
+		Source: source.ContentSourceFunc(func(context.Context, time.Time) ([]document.Document, error) {
+			return []document.Document{{ID: "repo1/README.md"}}, partial
+		}),
+		Sink:        out,
+		Checkpoints: checkpoints,
+	}
+
+	// The documents of the successful repositories are written, the checkpoint stays.
+	result, err := collector.Run(context.Background())
+	assert.EqualError(t, err, `failed to collect documents for "files": 1 of 2 repositories failed: archived: boom`)
+	assert.ErrorIs(t, err, partial)
+	assert.Equal(t, 1, result.Written)
+	assert.Equal(t, []document.Document{{ID: "repo1/README.md"}}, out.docs)
+
+	checkpoint, _ := checkpoints.Load(context.Background(), "files")
+	assert.True(t, checkpoint.IsZero())
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "checkpoints.json")
+	store := NewFileCheckpointStore(path)
+
+	checkpoint, err := store.Load(ctx, "files")
+	require.NoError(t, err)
+	assert.True(t, checkpoint.IsZero())
+
+	first := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Save(ctx, "files", first))
+	require.NoError(t, store.Save(ctx, "issues", first.Add(time.Hour)))
+
+	checkpoint, err = NewFileCheckpointStore(path).Load(ctx, "files")
+	require.NoError(t, err)
+	assert.True(t, first.Equal(checkpoint))
+
+	checkpoint, err = store.Load(ctx, "issues")
+	require.NoError(t, err)
+	assert.True(t, first.Add(time.Hour).Equal(checkpoint))
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+	_, err = store.Load(ctx, "files")
+	assert.ErrorContains(t, err, "failed to read checkpoints "+path)
+}
+
+func TestCollector_Run_SkipDocument(t *testing.T) {
+	src := source.ContentSourceFunc(func(context.Context, time.Time) ([]document.Document, error) {
+		return []document.Document{
+			{ID: "repo1/README.md", Body: "# Repo 1"},
+			{ID: "repo1/.env", Body: "GITHUB_TOKEN=ghp_" + strings.Repeat("a1B2", 9)},
+		}, nil
+	})
+	out := &sinkStub{}
+	collector := &Collector{Name: "docs", Source: src, Transformers: []document.Transformer{document.SecretScanner{Action: document.SecretDrop}}, Sink: out}
+
+	result, err := collector.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Collected)
+	assert.Equal(t, 1, result.Written)
+	assert.Equal(t, []document.Document{{ID: "repo1/README.md", Body: "# Repo 1"}}, out.docs)
+
+	out = &sinkStub{}
+	docs, err := src.Collect(context.Background(), time.Time{})
+	require.NoError(t, err)
+	require.NoError(t, sink.TransformSink(out, document.SecretScanner{Action: document.SecretDrop}).Write(context.Background(), docs))
+	assert.Equal(t, []document.Document{{ID: "repo1/README.md", Body: "# Repo 1"}}, out.docs)
+}
@@ -0,0 +1,6 @@
+// Package watch runs collections continuously.
+//
+// A Collector runs a ContentSource through filters and Transformers into a Sink, keeping its progress in
+// a CheckpointStore. A Runner runs jobs such as collections on an interval or cron Schedule and serves their
+// health and status over HTTP.
+package watch
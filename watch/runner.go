@@ -0,0 +1,201 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/shaharia-lab/coco-gh/v2/github"
+	"github.com/shaharia-lab/coco-gh/v2/internal/timeutil"
+)
+
+// RunnerStatus is the state of a Runner as reported by Runner.Status and its status endpoint.
+//
+// Runs and Failures count the finished runs and the failed ones among them, ConsecutiveFailures the
+// failures since the last successful run. LastError is the error of the last run, empty if it succeeded.
+// NextRun is the time the next run is scheduled at while the runner waits.
+type RunnerStatus struct {
+	Name                string    `json:"name,omitempty"`
+	Started             bool      `json:"started"`
+	Running             bool      `json:"running"`
+	Runs                int       `json:"runs"`
+	Failures            int       `json:"failures"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastStart           time.Time `json:"last_start"`
+	LastEnd             time.Time `json:"last_end"`
+	LastError           string    `json:"last_error,omitempty"`
+	NextRun             time.Time `json:"next_run"`
+}
+
+// Runner runs a job, such as a sync, on a Schedule until its context is canceled, for long-running
+// deployments polling repositories instead of being triggered externally.
+//
+// The next run is scheduled once the previous one finished, so runs never overlap. RunAtStart runs the job
+// once before waiting for the schedule. A failed run is logged and counted, the runner keeps going. Canceling
+// the context passed to Run shuts the runner down gracefully: the running job sees the canceled context and
+// Run returns once it finished.
+//
+// Handler serves the health and status of the runner over HTTP. UnhealthyAfter is the number of consecutive
+// failed runs after which the runner reports itself unhealthy; zero never does.
+//
+// Usage:
+//
+//	runner := &Runner{
+//	    Name:       "docs",
+//	    Job:        func(ctx context.Context) error { _, err := collector.Run(ctx); return err },
+//	    Schedule:   Every(15 * time.Minute),
+//	    RunAtStart: true,
+//	}
+//	go http.ListenAndServe(":8080", runner.Handler())
+//
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+//	defer stop()
+//	err := runner.Run(ctx)
+type Runner struct {
+	Name           string
+	Job            func(ctx context.Context) error
+	Schedule       Schedule
+	RunAtStart     bool
+	UnhealthyAfter int
+	Clock          github.Clock
+	Logger         github.Logger
+
+	mu     sync.Mutex
+	status RunnerStatus
+
+	// sleep waits for d or until the context is done. Tests replace it to avoid waiting.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// Run runs the job on the schedule until the context is canceled, which is not an error. It fails if
+// the runner lacks a job or a schedule, or the schedule never runs again.
+func (r *Runner) Run(ctx context.Context) error {
+	if r.Job == nil || r.Schedule == nil {
+		return fmt.Errorf("runner %q needs a job and a schedule", r.Name)
+	}
+	r.update(func(s *RunnerStatus) {
+		s.Name = r.Name
+		s.Started = true
+	})
+	defer r.update(func(s *RunnerStatus) {
+		s.Started = false
+		s.NextRun = time.Time{}
+	})
+
+	if r.RunAtStart {
+		r.runJob(ctx)
+	}
+	for ctx.Err() == nil {
+		now := timeutil.Now(r.Clock)
+		next := r.Schedule.Next(now)
+		if next.IsZero() {
+			return fmt.Errorf("schedule of runner %q never runs again", r.Name)
+		}
+		r.update(func(s *RunnerStatus) { s.NextRun = next })
+
+		if err := r.wait(ctx, next.Sub(now)); err != nil {
+			break
+		}
+		r.runJob(ctx)
+	}
+	return nil
+}
+
+// runJob runs the job once, recording the outcome.
+func (r *Runner) runJob(ctx context.Context) {
+	start := timeutil.Now(r.Clock)
+	r.update(func(s *RunnerStatus) {
+		s.Running = true
+		s.LastStart = start
+		s.NextRun = time.Time{}
+	})
+	r.debug("runner job started", "runner", r.Name)
+
+	err := r.Job(ctx)
+	if errors.Is(err, context.Canceled) && ctx.Err() != nil {
+		// Shutting down is not a failure of the job.
+		err = nil
+	}
+
+	end := timeutil.Now(r.Clock)
+	r.update(func(s *RunnerStatus) {
+		s.Running = false
+		s.LastEnd = end
+		s.Runs++
+		if err != nil {
+			s.Failures++
+			s.ConsecutiveFailures++
+			s.LastError = err.Error()
+			return
+		}
+		s.ConsecutiveFailures = 0
+		s.LastError = ""
+	})
+	if err != nil {
+		r.debug("runner job failed", "runner", r.Name, "duration", end.Sub(start), "error", err)
+		return
+	}
+	r.debug("runner job finished", "runner", r.Name, "duration", end.Sub(start))
+}
+
+// debug logs to the runner's logger if it is set.
+func (r *Runner) debug(msg string, keysAndValues ...interface{}) {
+	if r.Logger != nil {
+		r.Logger.Debug(msg, keysAndValues...)
+	}
+}
+
+// Status returns the current state of the runner.
+func (r *Runner) Status() RunnerStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status
+}
+
+// Healthy reports whether the runner is running and has not failed UnhealthyAfter times in a row.
+func (r *Runner) Healthy() bool {
+	status := r.Status()
+	if !status.Started {
+		return false
+	}
+	return r.UnhealthyAfter <= 0 || status.ConsecutiveFailures < r.UnhealthyAfter
+}
+
+// Handler returns an http.Handler serving the health of the runner at /healthz, 200 OK if it is healthy and
+// 503 Service Unavailable otherwise, and its RunnerStatus as JSON at /status.
+func (r *Runner) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if !r.Healthy() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintln(w, "unhealthy")
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/status", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(r.Status())
+	})
+	return mux
+}
+
+// update changes the status under the lock.
+func (r *Runner) update(change func(s *RunnerStatus)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	change(&r.status)
+}
+
+// wait waits for d or until the context is done.
+func (r *Runner) wait(ctx context.Context, d time.Duration) error {
+	if r.sleep != nil {
+		return r.sleep(ctx, d)
+	}
+	return timeutil.Sleep(ctx, d)
+}
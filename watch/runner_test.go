@@ -0,0 +1,146 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestRunner_Run(t *testing.T) {
+	clock := &testClock{now: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var runs []time.Time
+	var waits []time.Duration
+	runner := &Runner{
+		Name:           "docs",
+		Schedule:       Every(15 * time.Minute),
+		RunAtStart:     true,
+		UnhealthyAfter: 2,
+		Clock:          clock,
+		Job: func(ctx context.Context) error {
+			runs = append(runs, clock.Now())
+			clock.Advance(time.Minute)
+			if len(runs) == 2 || len(runs) == 3 {
+				return errors.New("boom")
+			}
+			return nil
+		},
+	}
+	runner.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		status := runner.Status()
+		assert.Equal(t, clock.Now().Add(d), status.NextRun)
+		assert.Equal(t, len(runs) != 3, runner.Healthy(), "after %d runs", len(runs))
+		if len(runs) == 4 {
+			cancel()
+			return ctx.Err()
+		}
+		clock.Advance(d)
+		return nil
+	}
+
+	require.NoError(t, runner.Run(ctx))
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 1, 10, 16, 0, 0, time.UTC),
+		time.Date(2024, 3, 1, 10, 32, 0, 0, time.UTC),
+		time.Date(2024, 3, 1, 10, 48, 0, 0, time.UTC),
+	}, runs)
+	assert.Equal(t, []time.Duration{15 * time.Minute, 15 * time.Minute, 15 * time.Minute, 15 * time.Minute}, waits)
+
+	status := runner.Status()
+	assert.Equal(t, RunnerStatus{
+		Name:      "docs",
+		Runs:      4,
+		Failures:  2,
+		LastStart: time.Date(2024, 3, 1, 10, 48, 0, 0, time.UTC),
+		LastEnd:   time.Date(2024, 3, 1, 10, 49, 0, 0, time.UTC),
+	}, status)
+	assert.False(t, runner.Healthy())
+}
+
+func TestRunner_Run_Shutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	runner := &Runner{
+		Schedule:   Every(time.Hour),
+		RunAtStart: true,
+		Job: func(ctx context.Context) error {
+			cancel()
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+
+	require.NoError(t, runner.Run(ctx))
+	status := runner.Status()
+	assert.Equal(t, 1, status.Runs)
+	assert.Zero(t, status.Failures)
+
+	assert.EqualError(t, (&Runner{Name: "docs"}).Run(context.Background()), `runner "docs" needs a job and a schedule`)
+
+	never, err := ParseSchedule("0 0 30 2 *")
+	require.NoError(t, err)
+	err = (&Runner{Name: "docs", Schedule: never, Job: func(context.Context) error { return nil }}).Run(context.Background())
+	assert.EqualError(t, err, `schedule of runner "docs" never runs again`)
+}
+
+func TestRunner_Handler(t *testing.T) {
+	runner := &Runner{Name: "docs", UnhealthyAfter: 1}
+	handler := runner.Handler()
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := get("/healthz")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	runner.update(func(s *RunnerStatus) {
+		s.Name = "docs"
+		s.Started = true
+		s.Runs = 3
+		s.LastError = "boom"
+	})
+	rec = get("/healthz")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ok\n", rec.Body.String())
+
+	rec = get("/status")
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var status RunnerStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.Equal(t, 3, status.Runs)
+	assert.Equal(t, "boom", status.LastError)
+
+	runner.update(func(s *RunnerStatus) { s.ConsecutiveFailures = 1 })
+	assert.Equal(t, http.StatusServiceUnavailable, get("/healthz").Code)
+}
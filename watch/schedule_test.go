@@ -0,0 +1,59 @@
+package watch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	// Friday, 2024-03-01 10:07:30 UTC.
+	now := time.Date(2024, 3, 1, 10, 7, 30, 0, time.UTC)
+
+	for spec, want := range map[string]time.Time{
+		"15m":            now.Add(15 * time.Minute),
+		"@every 1h30m":   now.Add(90 * time.Minute),
+		"*/15 * * * *":   time.Date(2024, 3, 1, 10, 15, 0, 0, time.UTC),
+		"5 * * * *":      time.Date(2024, 3, 1, 11, 5, 0, 0, time.UTC),
+		"0 9-17/4 * * *": time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC),
+		"30 2 * * mon":   time.Date(2024, 3, 4, 2, 30, 0, 0, time.UTC),
+		"0 0 * * 7":      time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC),
+		"0 0 1,15 * *":   time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		"0 0 29 feb *":   time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		"0 0 15 * 1":     time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+		"@hourly":        time.Date(2024, 3, 1, 11, 0, 0, 0, time.UTC),
+		"@daily":         time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+		"@weekly":        time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC),
+		"@monthly":       time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		"@yearly":        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		schedule, err := ParseSchedule(spec)
+		require.NoError(t, err, spec)
+		assert.Equal(t, want, schedule.Next(now), spec)
+	}
+
+	schedule, err := ParseSchedule("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, schedule.Next(now).IsZero())
+
+	for spec, want := range map[string]string{
+		"0s":          `invalid schedule "0s": interval must be positive`,
+		"* * * *":     `invalid schedule "* * * *": want an interval or a cron expression with 5 fields`,
+		"60 * * * *":  `invalid schedule "60 * * * *": invalid value "60" in minute field, want 0-59`,
+		"*/0 * * * *": `invalid schedule "*/0 * * * *": invalid step "0" in minute field`,
+		"0 5-1 * * *": `invalid schedule "0 5-1 * * *": invalid range "5-1" in hour field`,
+		"0 0 * foo *": `invalid schedule "0 0 * foo *": invalid value "foo" in month field, want 1-12`,
+	} {
+		_, err := ParseSchedule(spec)
+		assert.EqualError(t, err, want)
+	}
+}
+
+func TestCronSchedule_Location(t *testing.T) {
+	berlin := time.FixedZone("CET", 3600)
+	schedule, err := ParseSchedule("0 9 * * *")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 2, 9, 0, 0, 0, berlin), schedule.Next(time.Date(2024, 3, 1, 9, 0, 0, 0, berlin)))
+}
@@ -0,0 +1,203 @@
+// Package webhook receives GitHub webhook deliveries and turns them into the changed file paths cocogh
+// collects by polling, so collection can react to pushes and pull requests as they happen.
+//
+// A Handler validates the HMAC signature of every delivery, converts push and pull_request events into an
+// Event carrying document.Paths and passes it to a callback:
+//
+//	handler := webhook.NewHandler([]byte(os.Getenv("WEBHOOK_SECRET")), func(ctx context.Context, event webhook.Event) error {
+//	    return reindex(ctx, event.Repository, event.Paths)
+//	})
+//	handler.PullRequestFiles = gh
+//
+//	http.Handle("/github/events", handler)
+//
+// Push events list the changed files of up to 20 commits. Pull requests do not carry their files at all,
+// they are fetched through PullRequestFiles if it is set.
+package webhook
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+)
+
+// The event types a Handler converts. Other event types are acknowledged and dropped.
+const (
+	EventPush        = "push"
+	EventPullRequest = "pull_request"
+)
+
+// Event is a webhook delivery converted into changed file paths.
+//
+// Type is EventPush or EventPullRequest, DeliveryID the unique ID GitHub assigned to the delivery.
+// Repository is the full name of the repository, "owner/name". Ref is the pushed ref for push events and
+// the head branch for pull request events, where PullRequest is the pull request number and Action the
+// performed action, e.g. "opened" or "synchronize".
+type Event struct {
+	Type        string
+	DeliveryID  string
+	Repository  string
+	Ref         string
+	PullRequest int
+	Action      string
+	Paths       document.Paths
+}
+
+// PullRequestFiles fetches the files changed by a pull request of the repository with the given full name,
+// "owner/name". The coco-gh github.GitHub implements it.
+type PullRequestFiles interface {
+	GetPullRequestFiles(ctx context.Context, repo string, number int) (document.Paths, error)
+}
+
+// Handler is an http.Handler receiving GitHub webhook deliveries.
+//
+// Filter only keeps the paths passing it, see filter.GitHubFilter.Match, with renames into or out of the
+// filtered paths turned into additions or removals like for polling. PullRequestFiles fetches the files of
+// pull request events; without it their Paths are empty. OnError receives the errors of deliveries answered
+// with 500 Internal Server Error, whose body does not reveal them; without it they are logged with the log
+// package.
+type Handler struct {
+	Filter           filter.GitHubFilter
+	PullRequestFiles PullRequestFiles
+	OnError          func(r *http.Request, err error)
+
+	secret   []byte
+	onChange func(ctx context.Context, event Event) error
+}
+
+// NewHandler creates a Handler validating deliveries with the webhook secret and passing every push and
+// pull request event to onChange. An empty secret disables the validation, which is only meant for local
+// development.
+func NewHandler(secret []byte, onChange func(ctx context.Context, event Event) error) *Handler {
+	return &Handler{secret: secret, onChange: onChange}
+}
+
+// ServeHTTP implements http.Handler. Deliveries with an invalid signature are rejected with 401
+// Unauthorized, payloads that cannot be parsed with 400 Bad Request. If onChange fails the delivery is
+// answered with 500 Internal Server Error, so it shows up as failed and can be redelivered.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := github.ValidatePayload(r, h.secret)
+	if err != nil {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	eventType := github.WebHookType(r)
+	if eventType != EventPush && eventType != EventPullRequest {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	parsed, err := github.ParseWebHook(eventType, payload)
+	if err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	var event Event
+	switch e := parsed.(type) {
+	case *github.PushEvent:
+		event = h.pushEvent(e)
+	case *github.PullRequestEvent:
+		event, err = h.pullRequestEvent(r.Context(), e)
+		if err != nil {
+			h.fail(w, r, err)
+			return
+		}
+	}
+	event.Type = eventType
+	event.DeliveryID = github.DeliveryID(r)
+
+	if err := h.onChange(r.Context(), event); err != nil {
+		h.fail(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// fail answers a delivery with 500 Internal Server Error and passes the error to OnError.
+func (h *Handler) fail(w http.ResponseWriter, r *http.Request, err error) {
+	if h.OnError != nil {
+		h.OnError(r, err)
+	} else {
+		log.Printf("webhook: failed to handle delivery %s: %v", github.DeliveryID(r), err)
+	}
+	http.Error(w, "internal server error", http.StatusInternalServerError)
+}
+
+// pushEvent converts a push event, reconciling the files of its commits into their net change. Push events
+// do not report renames, a renamed file is removed and added.
+func (h *Handler) pushEvent(e *github.PushEvent) Event {
+	var events []document.ChangeEvent
+	for i := len(e.Commits) - 1; i >= 0; i-- {
+		commit := e.Commits[i]
+		for _, file := range commit.Modified {
+			events = append(events, document.ChangeEvent{Path: file, Status: "modified"})
+		}
+		for _, file := range commit.Added {
+			events = append(events, document.ChangeEvent{Path: file, Status: "added"})
+		}
+		for _, file := range commit.Removed {
+			events = append(events, document.ChangeEvent{Path: file, Status: "removed"})
+		}
+	}
+
+	return Event{
+		Repository: e.GetRepo().GetFullName(),
+		Ref:        e.GetRef(),
+		Paths:      document.ReconcileChanges(events, h.Filter.Match),
+	}
+}
+
+// pullRequestEvent converts a pull request event, fetching its files if PullRequestFiles is set.
+func (h *Handler) pullRequestEvent(ctx context.Context, e *github.PullRequestEvent) (Event, error) {
+	event := Event{
+		Repository:  e.GetRepo().GetFullName(),
+		Ref:         e.GetPullRequest().GetHead().GetRef(),
+		PullRequest: e.GetNumber(),
+		Action:      e.GetAction(),
+	}
+	if h.PullRequestFiles == nil {
+		return event, nil
+	}
+
+	paths, err := h.PullRequestFiles.GetPullRequestFiles(ctx, e.GetRepo().GetFullName(), e.GetNumber())
+	if err != nil {
+		return Event{}, err
+	}
+	event.Paths = document.ReconcileChanges(pathEvents(paths), h.Filter.Match)
+	return event, nil
+}
+
+// pathEvents converts the changed paths of a pull request back into change events, so renames are
+// filtered like the changes of commits.
+func pathEvents(paths document.Paths) []document.ChangeEvent {
+	renamed := make(map[string]bool)
+	var events []document.ChangeEvent
+	for _, rename := range paths.Renamed {
+		renamed[rename.From], renamed[rename.To] = true, true
+		events = append(events, document.ChangeEvent{Path: rename.To, PreviousPath: rename.From, Status: "renamed"})
+	}
+
+	add := func(files []string, status string) {
+		for _, file := range files {
+			if !renamed[file] {
+				events = append(events, document.ChangeEvent{Path: file, Status: status})
+			}
+		}
+	}
+	add(paths.Added, "added")
+	add(paths.Removed, "removed")
+	add(paths.Modified, "modified")
+	return events
+}
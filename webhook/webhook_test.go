@@ -0,0 +1,181 @@
+package webhook_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shaharia-lab/coco-gh/v2/document"
+	"github.com/shaharia-lab/coco-gh/v2/filter"
+	"github.com/shaharia-lab/coco-gh/v2/github"
+	"github.com/shaharia-lab/coco-gh/v2/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ webhook.PullRequestFiles = (*github.GitHub)(nil)
+
+var secret = []byte("s3cret")
+
+const pushPayload = `{
+  "ref": "refs/heads/main",
+  "repository": {"name": "repo1", "full_name": "testowner/repo1"},
+  "commits": [
+    {"id": "c1", "added": ["docs/new.md"], "removed": ["docs/old.md"], "modified": ["README.md"]},
+    {"id": "c2", "added": [], "removed": [], "modified": ["docs/index.md"]}
+  ]
+}`
+
+const pullRequestPayload = `{
+  "action": "opened",
+  "number": 42,
+  "pull_request": {"number": 42, "head": {"ref": "feature"}},
+  "repository": {"name": "repo1", "full_name": "testowner/repo1"}
+}`
+
+func deliver(t *testing.T, handler http.Handler, eventType, payload string, key []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", eventType)
+	req.Header.Set("X-GitHub-Delivery", "delivery-1")
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+type pullRequestFiles func(ctx context.Context, repo string, number int) (document.Paths, error)
+
+func (f pullRequestFiles) GetPullRequestFiles(ctx context.Context, repo string, number int) (document.Paths, error) {
+	return f(ctx, repo, number)
+}
+
+func TestHandler_Push(t *testing.T) {
+	var events []webhook.Event
+	handler := webhook.NewHandler(secret, func(_ context.Context, event webhook.Event) error {
+		events = append(events, event)
+		return nil
+	})
+	handler.Filter = filter.GitHubFilter{FilePath: "docs"}
+
+	rec := deliver(t, handler, webhook.EventPush, pushPayload, secret)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, []webhook.Event{{
+		Type:       webhook.EventPush,
+		DeliveryID: "delivery-1",
+		Repository: "testowner/repo1",
+		Ref:        "refs/heads/main",
+		Paths: document.Paths{
+			Added:    []string{"docs/new.md"},
+			Removed:  []string{"docs/old.md"},
+			Modified: []string{"docs/index.md"},
+		},
+	}}, events)
+}
+
+func TestHandler_PushReconciled(t *testing.T) {
+	// A file added and modified, a file renamed out of docs, a sibling directory sharing the prefix and a
+	// file removed and added again.
+	const payload = `{
+  "ref": "refs/heads/main",
+  "repository": {"name": "repo1", "full_name": "testowner/repo1"},
+  "commits": [
+    {"id": "c1", "added": ["docs/new.md", "docs-old/page.md"], "removed": ["docs/index.md"], "modified": []},
+    {"id": "c2", "added": ["archive/faq.md", "docs/index.md"], "removed": ["docs/faq.md"], "modified": ["docs/new.md"]}
+  ]
+}`
+
+	var events []webhook.Event
+	handler := webhook.NewHandler(secret, func(_ context.Context, event webhook.Event) error {
+		events = append(events, event)
+		return nil
+	})
+	handler.Filter = filter.GitHubFilter{FilePath: "docs", FileTypes: []string{".md"}}
+
+	rec := deliver(t, handler, webhook.EventPush, payload, secret)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, document.Paths{
+		Added:    []string{"docs/new.md"},
+		Removed:  []string{"docs/faq.md"},
+		Modified: []string{"docs/index.md"},
+	}, events[0].Paths)
+}
+
+func TestHandler_PullRequest(t *testing.T) {
+	var events []webhook.Event
+	handler := webhook.NewHandler(secret, func(_ context.Context, event webhook.Event) error {
+		events = append(events, event)
+		return nil
+	})
+	handler.PullRequestFiles = pullRequestFiles(func(_ context.Context, repo string, number int) (document.Paths, error) {
+		assert.Equal(t, "testowner/repo1", repo)
+		assert.Equal(t, 42, number)
+		return document.Paths{
+			Added:    []string{"docs/b.md", "docs/c.md", "archive/d.md"},
+			Removed:  []string{"docs/a.md", "docs-old/c.md", "docs/d.md"},
+			Modified: []string{"docs/index.md"},
+			Renamed: []document.Rename{
+				{From: "docs/a.md", To: "docs/b.md"},
+				{From: "docs-old/c.md", To: "docs/c.md"},
+				{From: "docs/d.md", To: "archive/d.md"},
+			},
+		}, nil
+	})
+	handler.Filter = filter.GitHubFilter{FilePath: "docs"}
+
+	rec := deliver(t, handler, webhook.EventPullRequest, pullRequestPayload, secret)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, []webhook.Event{{
+		Type:        webhook.EventPullRequest,
+		DeliveryID:  "delivery-1",
+		Repository:  "testowner/repo1",
+		Ref:         "feature",
+		PullRequest: 42,
+		Action:      "opened",
+		Paths: document.Paths{
+			Added:    []string{"docs/b.md", "docs/c.md"},
+			Removed:  []string{"docs/a.md", "docs/d.md"},
+			Modified: []string{"docs/index.md"},
+			Renamed:  []document.Rename{{From: "docs/a.md", To: "docs/b.md"}},
+		},
+	}}, events)
+}
+
+func TestHandler_Rejected(t *testing.T) {
+	called := false
+	handler := webhook.NewHandler(secret, func(context.Context, webhook.Event) error {
+		called = true
+		return errors.New("boom")
+	})
+
+	rec := deliver(t, handler, webhook.EventPush, pushPayload, []byte("wrong"))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = deliver(t, handler, "issues", `{}`, secret)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	rec = deliver(t, handler, webhook.EventPush, `{"ref": 1}`, secret)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.False(t, called)
+
+	var handled error
+	handler.OnError = func(_ *http.Request, err error) { handled = err }
+	rec = deliver(t, handler, webhook.EventPush, pushPayload, secret)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "boom")
+	assert.EqualError(t, handled, "boom")
+	assert.True(t, called)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}